@@ -0,0 +1,124 @@
+// Command stream-consumer is a reference consumer for the event stream. It
+// rebuilds a small read model, users per role and currently suspended
+// users, from the user events and logs it as it changes. Downstream teams
+// can copy it as a starting point; it keeps state in memory only.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/app"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/streaming"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// readModel is the projection rebuilt from the stream
+type readModel struct {
+	mu        sync.Mutex
+	seen      map[uuid.UUID]struct{}
+	roles     map[models.UserRole]int
+	suspended map[uuid.UUID]struct{}
+}
+
+// apply updates the model for one envelope. Delivery is at least once, so
+// envelopes already applied are skipped by ID.
+func (m *readModel) apply(envelope *streaming.Envelope) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.seen[envelope.ID]; ok {
+		return false, nil
+	}
+
+	// Only the schema versions this consumer understands are applied
+	switch envelope.Schema {
+	case streaming.SchemaOf(events.NameUserCreated):
+		var e events.UserCreated
+		if err := json.Unmarshal(envelope.Data, &e); err != nil {
+			return false, err
+		}
+		m.roles[e.Role]++
+	case streaming.SchemaOf(events.NameUserSuspended):
+		var e events.UserSuspended
+		if err := json.Unmarshal(envelope.Data, &e); err != nil {
+			return false, err
+		}
+		m.suspended[e.UserID] = struct{}{}
+	case streaming.SchemaOf(events.NameUserReinstated):
+		var e events.UserReinstated
+		if err := json.Unmarshal(envelope.Data, &e); err != nil {
+			return false, err
+		}
+		delete(m.suspended, e.UserID)
+	default:
+		return false, nil
+	}
+
+	m.seen[envelope.ID] = struct{}{}
+	return true, nil
+}
+
+func (m *readModel) log(logger zerolog.Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	roles := zerolog.Dict()
+	for role, count := range m.roles {
+		roles.Int(string(role), count)
+	}
+	logger.Info().Dict("users_by_role", roles).Int("suspended_users", len(m.suspended)).Msg("read model updated")
+}
+
+func main() {
+	// Initialize logger
+	log := logger.New().With().Str("component", "stream_consumer").Logger()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+
+	opts := app.StreamingOptions(cfg.Streaming)
+	consumer, err := streaming.NewConsumer(opts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create consumer")
+	}
+	defer consumer.Close()
+
+	model := &readModel{
+		seen:      make(map[uuid.UUID]struct{}),
+		roles:     make(map[models.UserRole]int),
+		suspended: make(map[uuid.UUID]struct{}),
+	}
+
+	// Run until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().Str("broker", opts.Broker).Str("group", opts.Group).Msg("Starting stream consumer")
+	err = consumer.Consume(ctx, opts.Topics.All(), func(ctx context.Context, envelope *streaming.Envelope) error {
+		changed, err := model.apply(envelope)
+		if err != nil {
+			return err
+		}
+		if changed {
+			model.log(log)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Stream consumer failed")
+	}
+	log.Info().Msg("Stream consumer exited")
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/app"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/backup"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/dbmetrics"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/jobs"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/retention"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/logger"
+
+	_ "github.com/lib/pq"
+)
+
+// backupCheckInterval is how often the backup job looks at the newest
+// backup's age
+const backupCheckInterval = 10 * time.Minute
+
+func main() {
+	// Initialize logger
+	log := logger.New().With().Str("component", "worker").Logger()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+
+	// Connect to database
+	database, err := sql.Open("postgres", cfg.Database.DSN())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+
+	// Test database connection
+	if err := database.Ping(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to ping database")
+	}
+
+	log.Info().Msg("Database connection established")
+
+	// Initialize dependencies
+	queries := db.New(dbmetrics.NewRecorder(db.NewTxConn(database), cfg.Diagnostics.SlowQueryThreshold, log))
+	tx := repository.NewTransactor(database)
+
+	// Initialize repositories
+	userRepo := repository.NewUserRepository(queries)
+	suspensionRepo := repository.NewSuspensionRepository(queries)
+	auditRepo := repository.NewAuditRepository(queries)
+	outboxRepo := repository.NewOutboxRepository(queries)
+	retentionRepo := repository.NewRetentionRepository(queries)
+	partitionRepo := repository.NewPartitionRepository(queries)
+	notificationRepo := repository.NewNotificationRepository(queries)
+	phoneRepo := repository.NewPhoneRepository(queries)
+	var eventRepo repository.EventRepository
+	if cfg.Events.Persist {
+		eventRepo = repository.NewEventRepository(queries)
+	}
+
+	// Initialize services
+	suspensionService := service.NewSuspensionService(suspensionRepo, userRepo, auditRepo, tx, outbox.NewPublisher(outboxRepo))
+
+	// The scheduler stops first and waits for in-flight jobs before the
+	// stream producer and the database close
+	lifecycle := app.NewLifecycle(cfg.Server.ShutdownTimeout, log)
+	lifecycle.Append(app.Closer("database", database))
+
+	// Outbox messages go to the broker, when configured, then the event bus
+	var sink outbox.Sink
+	if cfg.Streaming.Broker != "" {
+		mirror, hook, err := app.NewStreamMirror(cfg.Streaming)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to configure event streaming")
+		}
+		sink = mirror
+		lifecycle.Append(hook)
+	}
+	bus := app.NewEventBus(eventRepo, app.NewNotifier(cfg.Push, notificationRepo, userRepo, phoneRepo, app.NewMailer(cfg.Mail), app.NewTexter(cfg.SMS), log), log)
+	relay := outbox.NewRelay(outboxRepo, tx, bus, sink, cfg.Worker.OutboxMaxAttempts, log)
+
+	// Register jobs
+	scheduler := jobs.NewScheduler(log)
+	scheduler.Every(cfg.Worker.SuspensionSweepInterval, jobs.NewSuspensionReinstatementJob(suspensionService, cfg.Worker.SuspensionBatchSize, log))
+	scheduler.Every(cfg.Worker.OutboxRelayInterval, jobs.NewOutboxRelayJob(relay, cfg.Worker.OutboxBatchSize, log))
+	if cfg.Backup.Dir != "" {
+		manager := backup.NewManager(cfg.Database, backup.NewDirStore(cfg.Backup.Dir), cfg.Backup.Retention, cfg.Backup.Prefix)
+		// Checked often so a backup missed while the worker was down is taken soon after it starts
+		scheduler.Every(min(cfg.Backup.Interval, backupCheckInterval), jobs.NewBackupJob(manager, cfg.Backup.Interval, log))
+	}
+	// Events past retention go a month at a time; audit logs are kept
+	partitioned := []jobs.PartitionedTable{
+		{Name: "audit_logs"},
+		{Name: "events", Retention: cfg.Retention.Events},
+	}
+	scheduler.Every(cfg.Partitions.Interval, jobs.NewPartitionMaintenanceJob(partitionRepo, partitioned, cfg.Partitions.MonthsAhead, log))
+	purger := retention.NewPurger(retentionRepo, cfg.Retention, log)
+	if len(purger.Policies()) > 0 {
+		scheduler.Every(cfg.Retention.Interval, jobs.NewRetentionJob(purger))
+	}
+	lifecycle.Append(app.Background("scheduler", scheduler.Start))
+	if cfg.Debug.Enabled {
+		// Serves the retention counters, among others
+		lifecycle.Append(app.DebugServer(cfg, log))
+		log.Info().Str("address", cfg.Debug.Addr).Msg("Debug endpoints enabled")
+	}
+
+	// Run until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().Msg("Starting worker")
+	if err := lifecycle.Run(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Worker forced to shutdown")
+	}
+	log.Info().Msg("Worker exited")
+}
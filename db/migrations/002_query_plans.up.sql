@@ -0,0 +1,13 @@
+-- Query plan snapshots captured by the staging plan guard
+CREATE TABLE query_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    query_name VARCHAR(255) NOT NULL,
+    deploy_version VARCHAR(100) NOT NULL,
+    plan JSONB NOT NULL,
+    scans JSONB NOT NULL,
+    regression BOOLEAN NOT NULL DEFAULT FALSE,
+    regression_detail TEXT,
+    captured_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_query_plans_name_captured_at ON query_plans (query_name, captured_at DESC);
@@ -0,0 +1,2 @@
+-- Supports signup analytics range scans and ListUsers ordering
+CREATE INDEX idx_users_created_at ON users (created_at);
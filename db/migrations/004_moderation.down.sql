@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS moderation_items;
+DROP TYPE IF EXISTS moderation_status;
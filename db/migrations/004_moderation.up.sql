@@ -0,0 +1,17 @@
+-- Moderation queue for user-submitted content
+CREATE TYPE moderation_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE moderation_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subject_type VARCHAR(50) NOT NULL,
+    subject_id UUID NOT NULL,
+    submitted_by UUID NOT NULL REFERENCES users(id),
+    content TEXT NOT NULL,
+    status moderation_status NOT NULL DEFAULT 'pending',
+    rejection_reason TEXT,
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_moderation_items_status_created_at ON moderation_items (status, created_at);
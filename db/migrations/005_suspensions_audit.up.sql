@@ -0,0 +1,28 @@
+-- Time-boxed suspensions; a NULL expires_at is a permanent ban
+CREATE TABLE suspensions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    issued_by UUID REFERENCES users(id),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    lifted_at TIMESTAMP WITH TIME ZONE,
+    lifted_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_suspensions_user_id ON suspensions (user_id, created_at DESC);
+CREATE INDEX idx_suspensions_open_expires_at ON suspensions (expires_at) WHERE lifted_at IS NULL;
+
+-- Audit trail of administrative actions
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID REFERENCES users(id),
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(45),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_target ON audit_logs (target_type, target_id, created_at DESC);
@@ -0,0 +1,42 @@
+-- Fine-grained permissions granted per role and per user
+CREATE TABLE permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+CREATE TABLE role_permissions (
+    role user_role NOT NULL,
+    permission VARCHAR(100) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE user_permissions (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    granted_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, permission)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'View user accounts and their history'),
+    ('users:suspend', 'Issue and lift user suspensions'),
+    ('moderation:review', 'Approve or reject moderated content'),
+    ('analytics:read', 'View platform analytics'),
+    ('diagnostics:read', 'View query plan diagnostics'),
+    ('permissions:manage', 'Grant and revoke permissions for other admins');
+
+-- Admins keep everything they could do before permissions existed; only
+-- super admins manage grants until they delegate that too
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:read'),
+    ('admin', 'users:suspend'),
+    ('admin', 'moderation:review'),
+    ('admin', 'analytics:read'),
+    ('admin', 'diagnostics:read'),
+    ('su-admin', 'users:read'),
+    ('su-admin', 'users:suspend'),
+    ('su-admin', 'moderation:review'),
+    ('su-admin', 'analytics:read'),
+    ('su-admin', 'diagnostics:read'),
+    ('su-admin', 'permissions:manage');
@@ -0,0 +1,9 @@
+-- Domain events, persisted when EVENTS_PERSIST is on so subscribers can be replayed
+CREATE TABLE events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_events_occurred_at ON events (occurred_at, id);
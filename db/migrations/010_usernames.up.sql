@@ -0,0 +1,5 @@
+-- Optional public handle. Uniqueness ignores case so "Neo" and "neo"
+-- cannot both be registered.
+ALTER TABLE users ADD COLUMN username VARCHAR(30);
+
+CREATE UNIQUE INDEX users_username_lower_key ON users (LOWER(username));
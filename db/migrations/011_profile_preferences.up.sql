@@ -0,0 +1,9 @@
+-- Which parts of a user's public profile others may see. Users without a
+-- row get the defaults: every optional section hidden.
+CREATE TABLE profile_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    show_library BOOLEAN NOT NULL DEFAULT FALSE,
+    show_wishlist BOOLEAN NOT NULL DEFAULT FALSE,
+    show_activity BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
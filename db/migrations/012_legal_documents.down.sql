@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'legal:publish';
+DROP TABLE IF EXISTS consent_acceptances;
+DROP TABLE IF EXISTS legal_documents;
@@ -0,0 +1,32 @@
+-- Published versions of the terms of service and privacy policy. Once a
+-- mandatory version is effective, users must accept it or a later version
+-- of the same kind before using the API again.
+CREATE TABLE legal_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('terms', 'privacy')),
+    version VARCHAR(50) NOT NULL,
+    url TEXT NOT NULL,
+    mandatory BOOLEAN NOT NULL DEFAULT TRUE,
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    published_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, version)
+);
+
+CREATE INDEX idx_legal_documents_kind_effective_at ON legal_documents (kind, effective_at DESC);
+
+-- Which versions each user accepted, and from where
+CREATE TABLE consent_acceptances (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES legal_documents(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45),
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, document_id)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('legal:publish', 'Publish terms of service and privacy policy versions');
+
+-- Publishing binds every user, so only super admins hold it by default
+INSERT INTO role_permissions (role, permission) VALUES
+    ('su-admin', 'legal:publish');
@@ -0,0 +1,16 @@
+-- Risk scores of signups. Every assessment is kept, blocked ones included,
+-- so velocity rules can count attempts per IP address.
+CREATE TABLE fraud_assessments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    check_type VARCHAR(20) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45),
+    score INTEGER NOT NULL,
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('pass', 'review', 'block')),
+    signals JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_fraud_assessments_ip_address ON fraud_assessments (check_type, ip_address, created_at DESC);
+CREATE INDEX idx_fraud_assessments_user_id ON fraud_assessments (user_id, created_at DESC);
@@ -0,0 +1,30 @@
+-- Where each successful login came from. Geo columns are NULL when the
+-- address could not be placed. Unverified rows are logins still waiting
+-- on a step-up code; they don't count as known locations.
+CREATE TABLE login_locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45) NOT NULL,
+    country_code VARCHAR(2),
+    country VARCHAR(100),
+    city VARCHAR(100),
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    anomaly VARCHAR(30) CHECK (anomaly IN ('new_country', 'impossible_travel')),
+    verified BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_locations_user_id ON login_locations (user_id, created_at DESC);
+
+-- One-time codes holding back a suspicious login until the user confirms it
+CREATE TABLE login_challenges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES login_locations(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,7 @@
+DELETE FROM login_locations WHERE anomaly = 'new_device';
+ALTER TABLE login_locations DROP CONSTRAINT login_locations_anomaly_check;
+ALTER TABLE login_locations ADD CONSTRAINT login_locations_anomaly_check
+    CHECK (anomaly IN ('new_country', 'impossible_travel'));
+
+ALTER TABLE login_challenges DROP COLUMN IF EXISTS device_id;
+DROP TABLE IF EXISTS devices;
@@ -0,0 +1,20 @@
+-- Devices users log in from, identified by a hash of the client's device
+-- ID or, failing that, its user agent. Trusted devices skip step-up
+-- verification.
+CREATE TABLE devices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    last_ip VARCHAR(45),
+    trusted_at TIMESTAMP WITH TIME ZONE,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, fingerprint)
+);
+
+ALTER TABLE login_challenges ADD COLUMN device_id UUID REFERENCES devices(id) ON DELETE SET NULL;
+
+ALTER TABLE login_locations DROP CONSTRAINT login_locations_anomaly_check;
+ALTER TABLE login_locations ADD CONSTRAINT login_locations_anomaly_check
+    CHECK (anomaly IN ('new_country', 'impossible_travel', 'new_device'));
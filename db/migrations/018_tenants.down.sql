@@ -0,0 +1,10 @@
+DELETE FROM permissions WHERE name = 'tenant:manage';
+
+DROP INDEX IF EXISTS users_tenant_username_lower_key;
+CREATE UNIQUE INDEX users_username_lower_key ON users (LOWER(username));
+DROP INDEX IF EXISTS users_tenant_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenant_hostnames;
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,40 @@
+-- Branded stores served by one deployment. Everything that existed before
+-- tenancy belongs to the seeded default tenant, which also serves any
+-- hostname no other tenant claims.
+CREATE TABLE tenants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    branding JSONB NOT NULL DEFAULT '{}',
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    -- Marketplace fee in basis points, 250 being 2.5%
+    fee_bps INTEGER NOT NULL DEFAULT 0 CHECK (fee_bps BETWEEN 0 AND 10000),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE tenant_hostnames (
+    hostname VARCHAR(255) PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_tenant_hostnames_tenant_id ON tenant_hostnames (tenant_id);
+
+INSERT INTO tenants (id, slug, name) VALUES
+    ('00000000-0000-0000-0000-000000000001', 'default', 'RealGaming Marketplace');
+
+ALTER TABLE users ADD COLUMN tenant_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id);
+
+-- Emails and usernames only need to be unique within one store
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+CREATE UNIQUE INDEX users_tenant_email_key ON users (tenant_id, email);
+DROP INDEX users_username_lower_key;
+CREATE UNIQUE INDEX users_tenant_username_lower_key ON users (tenant_id, LOWER(username));
+
+INSERT INTO permissions (name, description) VALUES
+    ('tenant:manage', 'Change the store''s branding, currency and fees');
+
+-- Fees and currency affect every seller, so only super admins hold it by default
+INSERT INTO role_permissions (role, permission) VALUES
+    ('su-admin', 'tenant:manage');
@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE name = 'oauth:manage';
+
+DROP TABLE IF EXISTS oauth_tokens;
+DROP TABLE IF EXISTS oauth_authorization_codes;
+DROP TABLE IF EXISTS oauth_grants;
+DROP TABLE IF EXISTS oauth_clients;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sso_identities;
+DROP TABLE IF EXISTS sso_login_states;
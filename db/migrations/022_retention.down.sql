@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_audit_logs_ip_created_at;
+DROP INDEX IF EXISTS idx_outbox_sent_at;
@@ -0,0 +1,3 @@
+-- Let the retention jobs find old rows without scanning whole tables
+CREATE INDEX idx_outbox_sent_at ON outbox (sent_at) WHERE sent_at IS NOT NULL;
+CREATE INDEX idx_audit_logs_ip_created_at ON audit_logs (created_at) WHERE ip_address IS NOT NULL;
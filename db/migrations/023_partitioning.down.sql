@@ -0,0 +1,36 @@
+ALTER TABLE audit_logs RENAME TO audit_logs_partitioned;
+ALTER INDEX idx_audit_logs_target RENAME TO idx_audit_logs_partitioned_target;
+ALTER INDEX idx_audit_logs_ip_created_at RENAME TO idx_audit_logs_partitioned_ip_created_at;
+
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID REFERENCES users(id),
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(45),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO audit_logs SELECT id, actor_id, action, target_type, target_id, metadata, ip_address, created_at FROM audit_logs_partitioned;
+DROP TABLE audit_logs_partitioned;
+CREATE INDEX idx_audit_logs_target ON audit_logs (target_type, target_id, created_at DESC);
+CREATE INDEX idx_audit_logs_ip_created_at ON audit_logs (created_at) WHERE ip_address IS NOT NULL;
+
+ALTER TABLE events RENAME TO events_partitioned;
+ALTER INDEX idx_events_occurred_at RENAME TO idx_events_partitioned_occurred_at;
+
+CREATE TABLE events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO events SELECT id, name, payload, occurred_at FROM events_partitioned;
+DROP TABLE events_partitioned;
+CREATE INDEX idx_events_occurred_at ON events (occurred_at, id);
+
+DROP FUNCTION drop_monthly_partitions_before(TEXT, DATE);
+DROP FUNCTION create_monthly_partition(TEXT, DATE);
@@ -0,0 +1,115 @@
+-- Audit logs and persisted events grow without bound, so they are
+-- partitioned by month. Queries bounded by time only touch the months they
+-- need, and old months of events are dropped whole once past retention.
+-- The worker keeps partitions for the coming months created; the default
+-- partitions catch rows outside every month, such as far backdated events.
+
+-- Creates parent's partition for the UTC month containing day, unless it
+-- exists, and reports whether it did
+CREATE FUNCTION create_monthly_partition(parent TEXT, day DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    month_start DATE := date_trunc('month', day)::date;
+    child TEXT := format('%s_y%sm%s', parent, to_char(month_start, 'YYYY'), to_char(month_start, 'MM'));
+BEGIN
+    IF to_regclass(child) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+    EXECUTE format(
+        'CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+        child, parent,
+        month_start::timestamp AT TIME ZONE 'UTC',
+        (month_start + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC'
+    );
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Detaches and drops parent's monthly partitions that end on or before
+-- cutoff, returning their names
+CREATE FUNCTION drop_monthly_partitions_before(parent TEXT, cutoff DATE) RETURNS SETOF TEXT AS $$
+DECLARE
+    child TEXT;
+BEGIN
+    FOR child IN
+        SELECT c.relname FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = parent::regclass
+        AND c.relname ~ '_y[0-9]{4}m[0-9]{2}$'
+        ORDER BY c.relname
+    LOOP
+        IF to_date(right(child, 7), 'YYYY"m"MM') + INTERVAL '1 month' <= cutoff THEN
+            EXECUTE format('ALTER TABLE %I DETACH PARTITION %I', parent, child);
+            EXECUTE format('DROP TABLE %I', child);
+            RETURN NEXT child;
+        END IF;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The primary keys gain the partition key, which PostgreSQL requires
+ALTER TABLE audit_logs RENAME TO audit_logs_unpartitioned;
+ALTER TABLE audit_logs_unpartitioned RENAME CONSTRAINT audit_logs_pkey TO audit_logs_unpartitioned_pkey;
+ALTER INDEX idx_audit_logs_target RENAME TO idx_audit_logs_unpartitioned_target;
+ALTER INDEX idx_audit_logs_ip_created_at RENAME TO idx_audit_logs_unpartitioned_ip_created_at;
+
+CREATE TABLE audit_logs (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    actor_id UUID REFERENCES users(id),
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(45),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX idx_audit_logs_target ON audit_logs (target_type, target_id, created_at DESC);
+CREATE INDEX idx_audit_logs_ip_created_at ON audit_logs (created_at) WHERE ip_address IS NOT NULL;
+CREATE TABLE audit_logs_default PARTITION OF audit_logs DEFAULT;
+
+ALTER TABLE events RENAME TO events_unpartitioned;
+ALTER TABLE events_unpartitioned RENAME CONSTRAINT events_pkey TO events_unpartitioned_pkey;
+ALTER INDEX idx_events_occurred_at RENAME TO idx_events_unpartitioned_occurred_at;
+
+CREATE TABLE events (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+CREATE INDEX idx_events_occurred_at ON events (occurred_at, id);
+CREATE TABLE events_default PARTITION OF events DEFAULT;
+
+-- Every month with existing rows gets its partition before the rows are
+-- copied, so none land in the default partitions, as do the next few
+-- months until the worker takes over
+DO $$
+DECLARE
+    month_start DATE;
+BEGIN
+    FOR month_start IN
+        SELECT DISTINCT date_trunc('month', created_at AT TIME ZONE 'UTC')::date FROM audit_logs_unpartitioned WHERE created_at IS NOT NULL
+        UNION SELECT (date_trunc('month', NOW() AT TIME ZONE 'UTC') + n * INTERVAL '1 month')::date FROM generate_series(0, 3) AS n
+    LOOP
+        PERFORM create_monthly_partition('audit_logs', month_start);
+    END LOOP;
+    FOR month_start IN
+        SELECT DISTINCT date_trunc('month', occurred_at AT TIME ZONE 'UTC')::date FROM events_unpartitioned
+        UNION SELECT (date_trunc('month', NOW() AT TIME ZONE 'UTC') + n * INTERVAL '1 month')::date FROM generate_series(0, 3) AS n
+    LOOP
+        PERFORM create_monthly_partition('events', month_start);
+    END LOOP;
+END;
+$$;
+
+INSERT INTO audit_logs (id, actor_id, action, target_type, target_id, metadata, ip_address, created_at)
+SELECT id, actor_id, action, target_type, target_id, metadata, ip_address, COALESCE(created_at, NOW())
+FROM audit_logs_unpartitioned;
+DROP TABLE audit_logs_unpartitioned;
+
+INSERT INTO events (id, name, payload, occurred_at)
+SELECT id, name, payload, occurred_at FROM events_unpartitioned;
+DROP TABLE events_unpartitioned;
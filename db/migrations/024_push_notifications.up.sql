@@ -0,0 +1,42 @@
+-- Tokens mobile apps register to receive push notifications through FCM
+-- or APNs. A token belongs to one app install, so registering it again,
+-- even as another user, moves it there.
+CREATE TABLE push_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(10) NOT NULL CHECK (platform IN ('fcm', 'apns')),
+    token TEXT NOT NULL UNIQUE,
+    device_name VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_push_tokens_user_id ON push_tokens(user_id, updated_at DESC);
+
+-- Which channels a user receives notifications on. Users without a row
+-- get every channel. Security notifications ignore these.
+CREATE TABLE notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    push BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT TRUE,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Every push sent to a token and what the provider answered. Rows
+-- outlive the token so failures stay visible after it is dropped.
+CREATE TABLE push_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    push_token_id UUID REFERENCES push_tokens(id) ON DELETE SET NULL,
+    platform VARCHAR(10) NOT NULL,
+    notification_type VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'failed', 'token_invalid')),
+    provider_message_id TEXT,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_push_deliveries_user_id_created_at ON push_deliveries(user_id, created_at DESC);
+CREATE INDEX idx_push_deliveries_created_at ON push_deliveries(created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS sms;
+DROP TABLE IF EXISTS verified_phones;
+DROP TABLE IF EXISTS phone_verifications;
@@ -0,0 +1,27 @@
+-- One-time codes texted to prove a user holds the phone number on their
+-- account. The number is kept with the code, so a code can't verify a
+-- number the user switched to after it was sent.
+CREATE TABLE phone_verifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phone VARCHAR(20) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_phone_verifications_user_id ON phone_verifications (user_id, created_at DESC);
+
+-- The number each user last proved they hold. It only counts while it is
+-- still the number on their account.
+CREATE TABLE verified_phones (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    phone VARCHAR(20) NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Texts cost money and reach the phone whether or not the app is
+-- installed, so they are opt-in
+ALTER TABLE notification_preferences ADD COLUMN sms BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outbox_dead_at;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox (occurred_at, id) WHERE sent_at IS NULL;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS dead_at;
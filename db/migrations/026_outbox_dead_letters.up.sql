@@ -0,0 +1,7 @@
+-- Messages that kept failing are parked with dead_at set so they stop
+-- blocking the messages behind them; requeueing revives them
+ALTER TABLE outbox ADD COLUMN dead_at TIMESTAMP WITH TIME ZONE;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox (occurred_at, id) WHERE sent_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_outbox_dead_at ON outbox (dead_at) WHERE dead_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_addresses_default_billing;
+DROP INDEX IF EXISTS idx_addresses_default_shipping;
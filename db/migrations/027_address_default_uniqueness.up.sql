@@ -0,0 +1,4 @@
+-- One default shipping and one default billing address per user, enforced
+-- by the database rather than only by the application
+CREATE UNIQUE INDEX idx_addresses_default_shipping ON addresses (user_id) WHERE is_default_shipping;
+CREATE UNIQUE INDEX idx_addresses_default_billing ON addresses (user_id) WHERE is_default_billing;
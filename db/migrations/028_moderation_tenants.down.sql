@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_moderation_items_tenant_status_created_at;
+CREATE INDEX idx_moderation_items_status_created_at ON moderation_items (status, created_at);
+
+ALTER TABLE moderation_items DROP COLUMN IF EXISTS tenant_id;
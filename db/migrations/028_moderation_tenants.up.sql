@@ -0,0 +1,11 @@
+-- Each store moderates its own users' submissions, so items carry the
+-- submitter's tenant and the queue is filtered by it
+ALTER TABLE moderation_items ADD COLUMN tenant_id UUID REFERENCES tenants(id);
+
+UPDATE moderation_items m SET tenant_id = u.tenant_id
+FROM users u WHERE u.id = m.submitted_by;
+
+ALTER TABLE moderation_items ALTER COLUMN tenant_id SET NOT NULL;
+
+DROP INDEX idx_moderation_items_status_created_at;
+CREATE INDEX idx_moderation_items_tenant_status_created_at ON moderation_items (tenant_id, status, created_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_oauth_tokens_expires_at;
+DROP INDEX IF EXISTS idx_oauth_authorization_codes_expires_at;
+DROP INDEX IF EXISTS idx_phone_verifications_expires_at;
+DROP INDEX IF EXISTS idx_magic_links_expires_at;
+DROP INDEX IF EXISTS idx_login_challenges_expires_at;
+DROP INDEX IF EXISTS idx_login_locations_created_at;
@@ -0,0 +1,8 @@
+-- Let the retention jobs find old sign-in records and expired codes,
+-- links and tokens without scanning whole tables
+CREATE INDEX idx_login_locations_created_at ON login_locations (created_at);
+CREATE INDEX idx_login_challenges_expires_at ON login_challenges (expires_at);
+CREATE INDEX idx_magic_links_expires_at ON magic_links (expires_at);
+CREATE INDEX idx_phone_verifications_expires_at ON phone_verifications (expires_at);
+CREATE INDEX idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes (expires_at);
+CREATE INDEX idx_oauth_tokens_expires_at ON oauth_tokens (expires_at);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_login_challenges_user_id;
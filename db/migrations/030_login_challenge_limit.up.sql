@@ -0,0 +1,2 @@
+-- Let step-up count the challenges raised for a user within the window
+CREATE INDEX idx_login_challenges_user_id ON login_challenges (user_id, created_at DESC);
@@ -0,0 +1,45 @@
+-- name: CreateAddress :one
+INSERT INTO addresses (
+    user_id, label, recipient_name, line1, line2, city, region,
+    postal_code, country_code, phone, is_default_shipping, is_default_billing
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetAddressForUser :one
+SELECT * FROM addresses
+WHERE id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: ListAddressesByUser :many
+SELECT * FROM addresses
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: CountAddressesByUser :one
+SELECT COUNT(*) FROM addresses WHERE user_id = $1;
+
+-- name: UpdateAddress :one
+UPDATE addresses
+SET label = $3, recipient_name = $4, line1 = $5, line2 = $6, city = $7,
+    region = $8, postal_code = $9, country_code = $10, phone = $11,
+    is_default_shipping = $12, is_default_billing = $13, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteAddress :execrows
+DELETE FROM addresses WHERE id = $1 AND user_id = $2;
+
+-- name: ClearOtherDefaultShipping :exec
+UPDATE addresses SET is_default_shipping = FALSE, updated_at = NOW()
+WHERE user_id = $1 AND id <> $2 AND is_default_shipping;
+
+-- name: ClearOtherDefaultBilling :exec
+UPDATE addresses SET is_default_billing = FALSE, updated_at = NOW()
+WHERE user_id = $1 AND id <> $2 AND is_default_billing;
+
+-- name: LockAddressBook :one
+-- Serializes changes to one user's addresses until the transaction ends
+SELECT id FROM users
+WHERE id = $1
+FOR NO KEY UPDATE;
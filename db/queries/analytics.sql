@@ -0,0 +1,6 @@
+-- name: CountSignupsByBucket :many
+SELECT date_trunc($1::text, created_at)::timestamptz AS bucket, COUNT(*)::bigint AS signups
+FROM users
+WHERE tenant_id = $4 AND created_at >= $2 AND created_at < $3
+GROUP BY bucket
+ORDER BY bucket;
@@ -0,0 +1,6 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_logs (
+    actor_id, action, target_type, target_id, metadata, ip_address
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
@@ -0,0 +1,28 @@
+-- name: UpsertDevice :one
+INSERT INTO devices (
+    user_id, fingerprint, name, last_ip
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id, fingerprint) DO UPDATE
+SET name = EXCLUDED.name,
+    last_ip = EXCLUDED.last_ip,
+    last_seen_at = NOW()
+RETURNING *, (xmax = 0) AS created;
+
+-- name: CountDevicesByUser :one
+SELECT COUNT(*) FROM devices
+WHERE user_id = $1;
+
+-- name: ListDevicesByUser :many
+SELECT * FROM devices
+WHERE user_id = $1
+ORDER BY last_seen_at DESC;
+
+-- name: TrustDevice :exec
+UPDATE devices SET trusted_at = NOW()
+WHERE id = $1 AND trusted_at IS NULL;
+
+-- name: DeleteDevice :execrows
+DELETE FROM devices
+WHERE id = $1 AND user_id = $2;
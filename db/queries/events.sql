@@ -0,0 +1,12 @@
+-- name: CreateEvent :one
+INSERT INTO events (
+    name, payload, occurred_at
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListEventsAfter :many
+SELECT * FROM events
+WHERE (occurred_at, id) > ($1, $2)
+ORDER BY occurred_at, id
+LIMIT $3;
@@ -0,0 +1,15 @@
+-- name: CreateFraudAssessment :one
+INSERT INTO fraud_assessments (
+    check_type, user_id, email, ip_address, score, decision, signals
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: CountRecentFraudAssessmentsByIP :one
+SELECT COUNT(*) FROM fraud_assessments
+WHERE check_type = $1 AND ip_address = $2 AND created_at >= $3;
+
+-- name: ListFraudAssessmentsByUser :many
+SELECT * FROM fraud_assessments
+WHERE user_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,53 @@
+-- name: CreateLegalDocument :one
+INSERT INTO legal_documents (
+    kind, version, url, mandatory, effective_at, published_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetLegalDocument :one
+SELECT * FROM legal_documents WHERE id = $1 LIMIT 1;
+
+-- name: ListLegalDocuments :many
+SELECT * FROM legal_documents
+ORDER BY kind, effective_at DESC;
+
+-- name: ListCurrentLegalDocuments :many
+SELECT DISTINCT ON (kind) * FROM legal_documents
+WHERE effective_at <= NOW()
+ORDER BY kind, effective_at DESC;
+
+-- name: ListPendingLegalDocuments :many
+-- The latest effective mandatory version of each kind, unless the user
+-- accepted it or any version of that kind effective no earlier
+SELECT d.* FROM legal_documents d
+WHERE d.mandatory AND d.effective_at <= NOW()
+AND NOT EXISTS (
+    SELECT 1 FROM legal_documents n
+    WHERE n.kind = d.kind AND n.mandatory AND n.effective_at <= NOW()
+    AND n.effective_at > d.effective_at
+)
+AND NOT EXISTS (
+    SELECT 1 FROM consent_acceptances a
+    JOIN legal_documents ad ON ad.id = a.document_id
+    WHERE a.user_id = $1 AND ad.kind = d.kind AND ad.effective_at >= d.effective_at
+)
+ORDER BY d.kind;
+
+-- name: AcceptLegalDocument :one
+-- Accepting twice keeps the first acceptance
+INSERT INTO consent_acceptances (
+    user_id, document_id, ip_address
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, document_id) DO UPDATE
+SET accepted_at = consent_acceptances.accepted_at
+RETURNING *;
+
+-- name: ListConsentAcceptancesByUser :many
+SELECT a.user_id, a.document_id, d.kind, d.version, a.ip_address, a.accepted_at
+FROM consent_acceptances a
+JOIN legal_documents d ON d.id = a.document_id
+WHERE a.user_id = $1
+ORDER BY a.accepted_at DESC;
@@ -0,0 +1,51 @@
+-- name: CreateLoginLocation :one
+INSERT INTO login_locations (
+    user_id, ip_address, country_code, country, city, latitude, longitude, anomaly, verified
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetLastLoginLocation :one
+SELECT * FROM login_locations
+WHERE user_id = $1 AND verified AND country_code IS NOT NULL
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CountLoginLocationsInCountry :one
+SELECT COUNT(*) FROM login_locations
+WHERE user_id = $1 AND verified AND country_code = $2;
+
+-- name: VerifyLoginLocation :exec
+UPDATE login_locations SET verified = TRUE
+WHERE id = $1;
+
+-- name: ListLoginLocationsByUser :many
+SELECT * FROM login_locations
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: CreateLoginChallenge :one
+INSERT INTO login_challenges (
+    user_id, location_id, code_hash, expires_at, device_id
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: CountLoginChallengesSince :one
+SELECT COUNT(*) FROM login_challenges
+WHERE user_id = $1 AND created_at > $2;
+
+-- name: GetLoginChallenge :one
+SELECT * FROM login_challenges
+WHERE id = $1;
+
+-- name: IncrementLoginChallengeAttempts :one
+UPDATE login_challenges SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts;
+
+-- name: CompleteLoginChallenge :one
+UPDATE login_challenges SET verified_at = NOW()
+WHERE id = $1 AND verified_at IS NULL AND expires_at > NOW() AND attempts < $2
+RETURNING *;
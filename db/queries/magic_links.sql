@@ -0,0 +1,15 @@
+-- name: CreateMagicLink :one
+INSERT INTO magic_links (
+    user_id, token_hash, ip_address, expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: CountMagicLinksSince :one
+SELECT COUNT(*) FROM magic_links
+WHERE user_id = $1 AND created_at > $2;
+
+-- name: RedeemMagicLink :one
+UPDATE magic_links SET used_at = NOW()
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING *;
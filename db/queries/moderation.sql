@@ -0,0 +1,33 @@
+-- name: CreateModerationItem :one
+INSERT INTO moderation_items (
+    tenant_id, subject_type, subject_id, submitted_by, content, status, rejection_reason
+) VALUES (
+    (SELECT tenant_id FROM users WHERE id = $3), $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetModerationItem :one
+SELECT * FROM moderation_items
+WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+LIMIT 1;
+
+-- name: GetLatestModerationItem :one
+SELECT * FROM moderation_items
+WHERE subject_type = $1 AND subject_id = $2
+AND ($3::uuid IS NULL OR tenant_id = $3)
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListModerationItems :many
+SELECT * FROM moderation_items
+WHERE ($1::moderation_status IS NULL OR status = $1)
+AND ($2::text IS NULL OR subject_type = $2)
+AND ($5::uuid IS NULL OR tenant_id = $5)
+ORDER BY created_at ASC
+LIMIT $3 OFFSET $4;
+
+-- name: ReviewModerationItem :one
+UPDATE moderation_items
+SET status = $2, rejection_reason = $3, reviewed_by = $4, reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+AND ($5::uuid IS NULL OR tenant_id = $5)
+RETURNING *;
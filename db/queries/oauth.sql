@@ -0,0 +1,84 @@
+-- name: CreateOAuthClient :one
+INSERT INTO oauth_clients (
+    tenant_id, name, secret_hash, redirect_uris, scopes, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetOAuthClient :one
+SELECT * FROM oauth_clients
+WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+LIMIT 1;
+
+-- name: ListOAuthClients :many
+SELECT * FROM oauth_clients
+WHERE tenant_id = $1
+ORDER BY created_at;
+
+-- name: DeleteOAuthClient :execrows
+DELETE FROM oauth_clients
+WHERE id = $1 AND tenant_id = $2;
+
+-- name: UpsertOAuthGrant :one
+INSERT INTO oauth_grants (
+    user_id, client_id, scopes
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, client_id) DO UPDATE SET
+    scopes = EXCLUDED.scopes,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetOAuthGrant :one
+SELECT * FROM oauth_grants
+WHERE user_id = $1 AND client_id = $2
+LIMIT 1;
+
+-- name: ListOAuthGrantsByUser :many
+SELECT g.user_id, g.client_id, g.scopes, g.created_at, g.updated_at, c.name AS client_name
+FROM oauth_grants g
+JOIN oauth_clients c ON c.id = g.client_id
+WHERE g.user_id = $1
+ORDER BY g.created_at;
+
+-- name: DeleteOAuthGrant :execrows
+DELETE FROM oauth_grants
+WHERE user_id = $1 AND client_id = $2;
+
+-- name: CreateOAuthAuthorizationCode :exec
+INSERT INTO oauth_authorization_codes (
+    code_hash, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: RedeemOAuthAuthorizationCode :one
+UPDATE oauth_authorization_codes SET used_at = NOW()
+WHERE code_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING *;
+
+-- name: CreateOAuthToken :one
+INSERT INTO oauth_tokens (
+    token_hash, kind, client_id, user_id, scopes, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetOAuthToken :one
+SELECT * FROM oauth_tokens
+WHERE token_hash = $1
+LIMIT 1;
+
+-- name: RedeemOAuthRefreshToken :one
+UPDATE oauth_tokens SET revoked_at = NOW()
+WHERE token_hash = $1 AND kind = 'refresh' AND revoked_at IS NULL AND expires_at > NOW()
+RETURNING *;
+
+-- name: RevokeOAuthToken :exec
+UPDATE oauth_tokens SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeOAuthTokensForGrant :exec
+UPDATE oauth_tokens SET revoked_at = NOW()
+WHERE user_id = $1 AND client_id = $2 AND revoked_at IS NULL;
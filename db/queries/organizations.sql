@@ -0,0 +1,53 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (
+    tenant_id, name, created_by
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+LIMIT 1;
+
+-- name: LockOrganization :one
+SELECT id FROM organizations
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListOrganizationsByMember :many
+SELECT o.id, o.tenant_id, o.name, o.created_by, o.created_at, o.updated_at, m.role
+FROM organizations o
+JOIN organization_members m ON m.organization_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.created_at;
+
+-- name: AddOrganizationMember :one
+INSERT INTO organization_members (
+    organization_id, user_id, role, added_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: ListOrganizationMembers :many
+SELECT * FROM organization_members
+WHERE organization_id = $1
+ORDER BY created_at;
+
+-- name: CountOrganizationOwners :one
+SELECT COUNT(*) FROM organization_members
+WHERE organization_id = $1 AND role = 'owner';
+
+-- name: UpdateOrganizationMemberRole :one
+UPDATE organization_members SET role = $3
+WHERE organization_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
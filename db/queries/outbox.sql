@@ -0,0 +1,37 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+    event_name, payload, occurred_at
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: LockOutboxRelay :one
+-- Holds the relay lock until the transaction ends; false when another relay has it
+SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay'))::boolean AS locked;
+
+-- name: ClaimOutboxMessages :many
+-- Locks the oldest pending rows; concurrent relays skip them
+SELECT * FROM outbox
+WHERE sent_at IS NULL AND dead_at IS NULL
+ORDER BY occurred_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxMessageSent :exec
+UPDATE outbox SET sent_at = NOW(), last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox SET attempts = attempts + 1, last_error = $2
+WHERE id = $1;
+
+-- name: MarkOutboxMessageDead :exec
+UPDATE outbox SET attempts = attempts + 1, last_error = $2, dead_at = NOW()
+WHERE id = $1;
+
+-- name: RequeueOutboxMessages :execrows
+-- Marks delivered and dead messages pending again so the relay sends them once more
+UPDATE outbox SET sent_at = NULL, dead_at = NULL, attempts = 0, last_error = NULL
+WHERE (sent_at IS NOT NULL OR dead_at IS NOT NULL)
+AND occurred_at >= $1
+AND ($2::varchar IS NULL OR event_name = $2);
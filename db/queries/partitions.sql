@@ -0,0 +1,5 @@
+-- name: CreateMonthlyPartition :one
+SELECT create_monthly_partition($1::text, $2::date)::boolean AS created;
+
+-- name: DropMonthlyPartitionsBefore :many
+SELECT drop_monthly_partitions_before($1::text, $2::date)::text AS partition;
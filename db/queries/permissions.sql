@@ -0,0 +1,22 @@
+-- name: ListPermissions :many
+SELECT * FROM permissions ORDER BY name;
+
+-- name: GetPermission :one
+SELECT * FROM permissions WHERE name = $1 LIMIT 1;
+
+-- name: ListEffectivePermissions :many
+SELECT permission FROM role_permissions WHERE role = $1
+UNION
+SELECT permission FROM user_permissions WHERE user_id = $2
+ORDER BY permission;
+
+-- name: ListUserPermissionGrants :many
+SELECT * FROM user_permissions WHERE user_id = $1 ORDER BY permission;
+
+-- name: GrantUserPermission :execrows
+INSERT INTO user_permissions (user_id, permission, granted_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, permission) DO NOTHING;
+
+-- name: RevokeUserPermission :execrows
+DELETE FROM user_permissions WHERE user_id = $1 AND permission = $2;
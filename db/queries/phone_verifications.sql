@@ -0,0 +1,41 @@
+-- name: CreatePhoneVerification :one
+INSERT INTO phone_verifications (
+    user_id, phone, code_hash, expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: CountPhoneVerificationsSince :one
+SELECT COUNT(*) FROM phone_verifications
+WHERE user_id = $1 AND created_at > $2;
+
+-- name: GetLatestPhoneVerification :one
+SELECT * FROM phone_verifications
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: IncrementPhoneVerificationAttempts :one
+UPDATE phone_verifications SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts;
+
+-- name: CompletePhoneVerification :one
+UPDATE phone_verifications SET verified_at = NOW()
+WHERE id = $1 AND verified_at IS NULL AND expires_at > NOW() AND attempts < $2
+RETURNING *;
+
+-- name: UpsertVerifiedPhone :exec
+INSERT INTO verified_phones (
+    user_id, phone
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET phone = EXCLUDED.phone,
+    verified_at = NOW();
+
+-- name: GetVerifiedPhone :one
+SELECT verified_phones.* FROM verified_phones
+JOIN users ON users.id = verified_phones.user_id AND users.phone = verified_phones.phone
+WHERE verified_phones.user_id = $1;
@@ -0,0 +1,15 @@
+-- name: GetProfilePreferences :one
+SELECT * FROM profile_preferences WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertProfilePreferences :one
+INSERT INTO profile_preferences (
+    user_id, show_library, show_wishlist, show_activity
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id) DO UPDATE
+SET show_library = EXCLUDED.show_library,
+    show_wishlist = EXCLUDED.show_wishlist,
+    show_activity = EXCLUDED.show_activity,
+    updated_at = NOW()
+RETURNING *;
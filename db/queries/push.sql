@@ -0,0 +1,64 @@
+-- name: UpsertPushToken :one
+INSERT INTO push_tokens (
+    user_id, platform, token, device_name
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (token) DO UPDATE
+SET user_id = EXCLUDED.user_id,
+    platform = EXCLUDED.platform,
+    device_name = EXCLUDED.device_name,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: TrimPushTokens :execrows
+DELETE FROM push_tokens
+WHERE user_id = $1 AND id NOT IN (
+    SELECT id FROM push_tokens WHERE user_id = $1 ORDER BY updated_at DESC LIMIT $2
+);
+
+-- name: ListPushTokensByUser :many
+SELECT * FROM push_tokens
+WHERE user_id = $1
+ORDER BY updated_at DESC;
+
+-- name: DeletePushToken :execrows
+DELETE FROM push_tokens
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeletePushTokenByID :exec
+DELETE FROM push_tokens WHERE id = $1;
+
+-- name: TouchPushToken :exec
+UPDATE push_tokens SET last_used_at = NOW()
+WHERE id = $1;
+
+-- name: GetNotificationPreferences :one
+SELECT * FROM notification_preferences WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertNotificationPreferences :one
+INSERT INTO notification_preferences (
+    user_id, push, email, in_app, sms
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (user_id) DO UPDATE
+SET push = EXCLUDED.push,
+    email = EXCLUDED.email,
+    in_app = EXCLUDED.in_app,
+    sms = EXCLUDED.sms,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: CreatePushDelivery :exec
+INSERT INTO push_deliveries (
+    user_id, push_token_id, platform, notification_type, status, provider_message_id, error
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListPushDeliveriesByUser :many
+SELECT * FROM push_deliveries
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
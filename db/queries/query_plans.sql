@@ -0,0 +1,22 @@
+-- name: CountQueryPlans :one
+SELECT COUNT(*) FROM query_plans
+WHERE ($1::boolean IS NULL OR regression = $1);
+
+-- name: CreateQueryPlan :one
+INSERT INTO query_plans (
+    query_name, deploy_version, plan, scans, regression, regression_detail
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetBaselineQueryPlan :one
+SELECT * FROM query_plans
+WHERE query_name = $1 AND deploy_version <> $2
+ORDER BY captured_at DESC
+LIMIT 1;
+
+-- name: ListQueryPlans :many
+SELECT * FROM query_plans
+WHERE ($1::boolean IS NULL OR regression = $1)
+ORDER BY captured_at DESC
+LIMIT $2 OFFSET $3;
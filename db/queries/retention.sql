@@ -0,0 +1,63 @@
+-- Each statement handles at most $2 rows, so the retention jobs hold locks
+-- briefly and can pause between batches. The outer time bound on the
+-- partitioned tables lets PostgreSQL skip the months it can't match.
+
+-- name: DeleteEventsBefore :execrows
+DELETE FROM events
+WHERE occurred_at < $1 AND (id, occurred_at) IN (
+    SELECT id, occurred_at FROM events WHERE occurred_at < $1 ORDER BY occurred_at LIMIT $2
+);
+
+-- name: DeleteSentOutboxBefore :execrows
+DELETE FROM outbox
+WHERE id IN (
+    SELECT id FROM outbox WHERE sent_at IS NOT NULL AND sent_at < $1 ORDER BY sent_at LIMIT $2
+);
+
+-- name: AnonymizeAuditLogIPsBefore :execrows
+UPDATE audit_logs SET ip_address = NULL
+WHERE created_at < $1 AND (id, created_at) IN (
+    SELECT id, created_at FROM audit_logs WHERE ip_address IS NOT NULL AND created_at < $1 ORDER BY created_at LIMIT $2
+);
+
+-- name: DeletePushDeliveriesBefore :execrows
+DELETE FROM push_deliveries
+WHERE id IN (
+    SELECT id FROM push_deliveries WHERE created_at < $1 ORDER BY created_at LIMIT $2
+);
+
+-- name: DeleteLoginLocationsBefore :execrows
+DELETE FROM login_locations
+WHERE id IN (
+    SELECT id FROM login_locations WHERE created_at < $1 ORDER BY created_at LIMIT $2
+);
+
+-- name: DeleteExpiredLoginChallengesBefore :execrows
+DELETE FROM login_challenges
+WHERE id IN (
+    SELECT id FROM login_challenges WHERE expires_at < $1 ORDER BY expires_at LIMIT $2
+);
+
+-- name: DeleteExpiredMagicLinksBefore :execrows
+DELETE FROM magic_links
+WHERE id IN (
+    SELECT id FROM magic_links WHERE expires_at < $1 ORDER BY expires_at LIMIT $2
+);
+
+-- name: DeleteExpiredPhoneVerificationsBefore :execrows
+DELETE FROM phone_verifications
+WHERE id IN (
+    SELECT id FROM phone_verifications WHERE expires_at < $1 ORDER BY expires_at LIMIT $2
+);
+
+-- name: DeleteExpiredOAuthCodesBefore :execrows
+DELETE FROM oauth_authorization_codes
+WHERE code_hash IN (
+    SELECT code_hash FROM oauth_authorization_codes WHERE expires_at < $1 ORDER BY expires_at LIMIT $2
+);
+
+-- name: DeleteExpiredOAuthTokensBefore :execrows
+DELETE FROM oauth_tokens
+WHERE id IN (
+    SELECT id FROM oauth_tokens WHERE expires_at < $1 ORDER BY expires_at LIMIT $2
+);
@@ -0,0 +1,27 @@
+-- name: CreateSSOLoginState :exec
+INSERT INTO sso_login_states (
+    state_hash, nonce, code_verifier, expires_at
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: ConsumeSSOLoginState :one
+DELETE FROM sso_login_states
+WHERE state_hash = $1 AND expires_at > NOW()
+RETURNING *;
+
+-- name: GetSSOIdentity :one
+SELECT * FROM sso_identities
+WHERE issuer = $1 AND subject = $2
+LIMIT 1;
+
+-- name: CreateSSOIdentity :one
+INSERT INTO sso_identities (
+    issuer, subject, user_id, email
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: TouchSSOIdentity :exec
+UPDATE sso_identities SET email = $3, last_login_at = NOW()
+WHERE issuer = $1 AND subject = $2;
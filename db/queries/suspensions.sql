@@ -0,0 +1,32 @@
+-- name: CreateSuspension :one
+INSERT INTO suspensions (
+    user_id, reason, issued_by, expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetActiveSuspensionByUser :one
+SELECT * FROM suspensions
+WHERE user_id = $1 AND lifted_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListSuspensionsByUser :many
+SELECT * FROM suspensions
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListExpiredSuspensions :many
+SELECT * FROM suspensions
+WHERE lifted_at IS NULL AND expires_at <= NOW()
+ORDER BY expires_at ASC
+LIMIT $1;
+
+-- name: LiftSuspension :execrows
+UPDATE suspensions SET lifted_at = NOW(), lifted_by = $2
+WHERE id = $1 AND lifted_at IS NULL;
+
+-- name: LiftSuspensionsByUser :execrows
+UPDATE suspensions SET lifted_at = NOW(), lifted_by = $2
+WHERE user_id = $1 AND lifted_at IS NULL;
@@ -0,0 +1,17 @@
+-- name: GetTenant :one
+SELECT * FROM tenants WHERE id = $1 LIMIT 1;
+
+-- name: GetTenantByHostname :one
+SELECT t.* FROM tenants t
+JOIN tenant_hostnames h ON h.tenant_id = t.id
+WHERE h.hostname = LOWER($1)
+LIMIT 1;
+
+-- name: GetTenantBySlug :one
+SELECT * FROM tenants WHERE slug = $1 LIMIT 1;
+
+-- name: UpdateTenant :one
+UPDATE tenants
+SET name = $2, branding = $3, currency = $4, fee_bps = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
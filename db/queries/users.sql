@@ -1,19 +1,24 @@
 -- name: CreateUser :one
 INSERT INTO users (
-    email, password_hash, first_name, last_name, role, phone
+    email, password_hash, first_name, last_name, role, phone, username, status, tenant_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetUserByEmail :one
-SELECT * FROM users WHERE email = $1 LIMIT 1;
+SELECT * FROM users WHERE tenant_id = $1 AND email = $2 LIMIT 1;
 
 -- name: GetUserByID :one
-SELECT * FROM users WHERE id = $1 LIMIT 1;
+SELECT * FROM users
+WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+LIMIT 1;
+
+-- name: GetUserByUsername :one
+SELECT * FROM users WHERE tenant_id = $1 AND LOWER(username) = LOWER($2) LIMIT 1;
 
 -- name: UpdateUser :one
 UPDATE users 
-SET first_name = $2, last_name = $3, phone = $4, avatar_url = $5
+SET first_name = $2, last_name = $3, phone = $4, avatar_url = $5, username = $6
 WHERE id = $1 
 RETURNING *;
 
@@ -22,7 +27,21 @@ UPDATE users SET status = $2 WHERE id = $1;
 
 -- name: ListUsers :many
 SELECT * FROM users 
-WHERE ($1::user_role IS NULL OR role = $1)
+WHERE tenant_id = $5
+AND ($1::user_role IS NULL OR role = $1)
 AND ($2::user_status IS NULL OR status = $2)
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4;
+
+-- name: ListUsersByIDs :many
+SELECT * FROM users
+WHERE id = ANY($1::uuid[]) AND ($2::uuid IS NULL OR tenant_id = $2);
+
+-- name: UpdateUserAvatar :exec
+UPDATE users SET avatar_url = $2 WHERE id = $1;
+
+-- name: UpdateUserRole :exec
+UPDATE users SET role = $2 WHERE id = $1;
+
+-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $2 WHERE id = $1;
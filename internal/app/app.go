@@ -0,0 +1,324 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/captcha"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/dbmetrics"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/handler"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/probe"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/queryplan"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/rs/zerolog"
+)
+
+// App is the assembled API: its router, the services behind it and the
+// components whose lifetime it manages
+type App struct {
+	// Handler is Router wrapped in request ID and access log middleware; it
+	// is what the server serves
+	Handler   http.Handler
+	Router    *mux.Router
+	Services  *Services
+	Lifecycle *Lifecycle
+}
+
+// Repositories is every data store the services depend on, and the
+// transactor that makes their writes atomic. QueryPlan is only set when the
+// query plan guard is enabled.
+type Repositories struct {
+	User         repository.UserRepository
+	Analytics    repository.AnalyticsRepository
+	Moderation   repository.ModerationRepository
+	Suspension   repository.SuspensionRepository
+	Audit        repository.AuditRepository
+	Permission   repository.PermissionRepository
+	Address      repository.AddressRepository
+	Profile      repository.ProfileRepository
+	Consent      repository.ConsentRepository
+	Fraud        repository.FraudRepository
+	Login        repository.LoginRepository
+	Device       repository.DeviceRepository
+	MagicLink    repository.MagicLinkRepository
+	Passkey      repository.PasskeyRepository
+	SSO          repository.SSORepository
+	Tenant       repository.TenantRepository
+	Organization repository.OrganizationRepository
+	OAuth        repository.OAuthRepository
+	Notification repository.NotificationRepository
+	Phone        repository.PhoneRepository
+	Outbox       repository.OutboxRepository
+	QueryPlan    repository.QueryPlanRepository
+	Tx           repository.Transactor
+}
+
+type Services struct {
+	User         service.UserService
+	Analytics    service.AnalyticsService
+	Moderation   service.ModerationService
+	Suspension   service.SuspensionService
+	Permission   service.PermissionService
+	Address      service.AddressService
+	Profile      service.ProfileService
+	Consent      service.ConsentService
+	Fraud        service.FraudService
+	Login        service.LoginSecurityService
+	MagicLink    service.MagicLinkService // nil unless email is configured
+	Passkey      service.PasskeyService   // nil unless passkeys are configured
+	SSO          service.SSOService       // nil unless an identity provider is configured
+	Tenant       service.TenantService
+	Organization service.OrganizationService
+	OAuth        service.OAuthService
+	Notification service.NotificationService
+	Phone        service.PhoneService // nil unless SMS is configured
+	Tokens       *auth.TokenManager
+}
+
+// New assembles the API on top of database. The returned lifecycle owns the
+// database handle and closes it last on shutdown.
+func New(cfg *config.Config, database *sql.DB, logger zerolog.Logger) *App {
+	var conn db.DBTX = database
+	repos := &Repositories{}
+	if cfg.Diagnostics.QueryPlanGuard {
+		// Plans are recorded through the raw connection so the guard doesn't observe itself
+		repos.QueryPlan = repository.NewQueryPlanRepository(db.New(database))
+		conn = queryplan.NewGuard(database, repos.QueryPlan, cfg.Diagnostics.DeployVersion, cfg.Diagnostics.HotQueryThreshold, logger)
+
+		logger.Info().Str("deploy_version", cfg.Diagnostics.DeployVersion).Msg("Query plan guard enabled")
+	}
+
+	// TxConn sends queries inside a transaction to it, bypassing the guard;
+	// the recorder wraps everything so those are still timed
+	queries := db.New(dbmetrics.NewRecorder(db.NewTxConn(conn), cfg.Diagnostics.SlowQueryThreshold, logger))
+	repos.Tx = repository.NewTransactor(database)
+	repos.User = repository.NewUserRepository(queries)
+	repos.Analytics = repository.NewAnalyticsRepository(queries)
+	repos.Moderation = repository.NewModerationRepository(queries)
+	repos.Suspension = repository.NewSuspensionRepository(queries)
+	repos.Audit = repository.NewAuditRepository(queries)
+	repos.Permission = repository.NewPermissionRepository(queries)
+	repos.Address = repository.NewAddressRepository(queries)
+	repos.Profile = repository.NewProfileRepository(queries)
+	repos.Consent = repository.NewConsentRepository(queries)
+	repos.Fraud = repository.NewFraudRepository(queries)
+	repos.Login = repository.NewLoginRepository(queries)
+	repos.Device = repository.NewDeviceRepository(queries)
+	repos.MagicLink = repository.NewMagicLinkRepository(queries)
+	repos.Passkey = repository.NewPasskeyRepository(queries)
+	repos.SSO = repository.NewSSORepository(queries)
+	repos.Tenant = repository.NewTenantRepository(queries)
+	repos.Organization = repository.NewOrganizationRepository(queries)
+	repos.OAuth = repository.NewOAuthRepository(queries)
+	repos.Notification = repository.NewNotificationRepository(queries)
+	repos.Phone = repository.NewPhoneRepository(queries)
+	repos.Outbox = repository.NewOutboxRepository(queries)
+
+	application := NewWithRepositories(cfg, repos, logger)
+
+	// Prepended so it stops after everything that might still query it
+	application.Lifecycle.Prepend(Closer("database", database))
+
+	return application
+}
+
+// NewWithRepositories assembles the API on top of the given repositories, so
+// tests can run the full stack against fakes or a test database
+func NewWithRepositories(cfg *config.Config, repos *Repositories, logger zerolog.Logger) *App {
+	validator := NewValidator()
+
+	// Domain events go to the outbox and are relayed by the worker
+	publisher := outbox.NewPublisher(repos.Outbox)
+
+	// Initialize services
+	mailer := NewMailer(cfg.Mail)
+	texter := NewTexter(cfg.SMS)
+	notifier := NewNotifier(cfg.Push, repos.Notification, repos.User, repos.Phone, mailer, texter, logger)
+	moderationService := service.NewModerationService(repos.Moderation, repos.User, notifier, moderationScreeners(cfg)...)
+	fraudService := service.NewFraudService(repos.Fraud, repos.User, fraudPipeline(cfg, repos.Fraud))
+	loginPolicy := service.LoginPolicy{
+		MaxTravelSpeed:         cfg.Login.MaxTravelSpeed,
+		StepUp:                 cfg.Login.StepUp,
+		ChallengeTTL:           cfg.Login.ChallengeTTL,
+		MaxChallengesPerWindow: cfg.Login.MaxChallengesPerWindow,
+		ChallengeWindow:        cfg.Login.ChallengeWindow,
+	}
+	magicLinkPolicy := service.MagicLinkPolicy{
+		URL:          cfg.MagicLink.URL,
+		TTL:          cfg.MagicLink.TTL,
+		MaxPerWindow: cfg.MagicLink.MaxPerWindow,
+		Window:       cfg.MagicLink.Window,
+	}
+	oauthPolicy := service.OAuthPolicy{
+		CodeTTL:         cfg.OAuth.CodeTTL,
+		AccessTokenTTL:  cfg.OAuth.AccessTokenTTL,
+		RefreshTokenTTL: cfg.OAuth.RefreshTokenTTL,
+	}
+	services := &Services{
+		User:         service.NewUserService(repos.User, moderationService, fraudService, repos.Tx, publisher),
+		Analytics:    service.NewAnalyticsService(repos.Analytics),
+		Moderation:   moderationService,
+		Suspension:   service.NewSuspensionService(repos.Suspension, repos.User, repos.Audit, repos.Tx, publisher),
+		Permission:   service.NewPermissionService(repos.Permission, repos.User, repos.Audit, repos.Tx),
+		Address:      service.NewAddressService(repos.Address, repos.Tx),
+		Profile:      service.NewProfileService(repos.Profile, repos.User),
+		Consent:      service.NewConsentService(repos.Consent, repos.Audit, repos.Tx),
+		Fraud:        fraudService,
+		Login:        service.NewLoginSecurityService(repos.Login, repos.Device, geoLocator(cfg), notifier, repos.Tx, loginPolicy),
+		Tenant:       service.NewTenantService(repos.Tenant, repos.Audit, repos.Tx, cfg.Tenancy.CacheTTL),
+		Organization: service.NewOrganizationService(repos.Organization, repos.User, repos.Audit, repos.Tx),
+		OAuth:        service.NewOAuthService(repos.OAuth, repos.Audit, repos.Tx, oauthPolicy),
+		Notification: service.NewNotificationService(repos.Notification, repos.User, repos.Tx, cfg.Push.MaxTokens),
+		Tokens:       auth.NewTokenManager(cfg.JWT.Secret, cfg.JWT.Expiration),
+	}
+	if mailer != nil {
+		services.MagicLink = service.NewMagicLinkService(repos.MagicLink, repos.User, notifier, magicLinkPolicy)
+	}
+	if rp := relyingParty(cfg, logger); rp != nil {
+		services.Passkey = service.NewPasskeyService(repos.Passkey, repos.User, rp)
+	}
+	if texter != nil {
+		services.Phone = service.NewPhoneService(repos.Phone, repos.User, texter, repos.Tx, service.PhonePolicy{
+			CodeTTL:      cfg.SMS.CodeTTL,
+			MaxPerWindow: cfg.SMS.CodeMaxPerWindow,
+			Window:       cfg.SMS.CodeWindow,
+		})
+	}
+	if cfg.SSO.Issuer != "" {
+		services.SSO = service.NewSSOService(identityProvider(cfg), repos.SSO, repos.User, repos.Audit, repos.Tx, ssoPolicy(cfg))
+	}
+
+	// Initialize auth
+	authenticator := middleware.NewAuthenticator(services.Tokens, services.User, services.Suspension, services.Permission, services.Consent, services.OAuth, logger)
+
+	// Initialize handlers
+	handlers := &routeHandlers{
+		user:         handler.NewUserHandler(services.User, services.Login, services.MagicLink, services.Passkey, services.SSO, services.Tokens, validator, logger),
+		analytics:    handler.NewAnalyticsHandler(services.Analytics, logger),
+		moderation:   handler.NewModerationHandler(services.Moderation, validator, logger),
+		suspension:   handler.NewSuspensionHandler(services.Suspension, validator, logger),
+		permission:   handler.NewPermissionHandler(services.Permission, validator, logger),
+		address:      handler.NewAddressHandler(services.Address, validator, logger),
+		profile:      handler.NewProfileHandler(services.Profile, validator, logger),
+		consent:      handler.NewConsentHandler(services.Consent, validator, logger),
+		fraud:        handler.NewFraudHandler(services.Fraud, logger),
+		device:       handler.NewDeviceHandler(services.Login, logger),
+		tenant:       handler.NewTenantHandler(services.Tenant, validator, logger),
+		organization: handler.NewOrganizationHandler(services.Organization, validator, logger),
+		oauth:        handler.NewOAuthHandler(services.OAuth, validator, logger),
+		notification: handler.NewNotificationHandler(services.Notification, validator, logger),
+		ssoEnabled:   services.SSO != nil,
+		magicLinks:   services.MagicLink != nil,
+	}
+	if cfg.Captcha.Provider != "" {
+		verifier := captcha.NewSiteVerifier(cfg.Captcha.Provider, cfg.Captcha.Secret, cfg.Captcha.VerifyURL, cfg.Captcha.Timeout)
+		handlers.captcha = middleware.NewCaptcha(verifier, middleware.CaptchaOptions{
+			Signup:        cfg.Captcha.Signup,
+			LoginFailures: cfg.Captcha.LoginFailures,
+			FailureWindow: cfg.Captcha.FailureWindow,
+		}, logger)
+	}
+	if services.Passkey != nil {
+		handlers.passkey = handler.NewPasskeyHandler(services.Passkey, validator, logger)
+	}
+	if services.Phone != nil {
+		handlers.phone = handler.NewPhoneHandler(services.Phone, validator, logger)
+	}
+	if repos.QueryPlan != nil {
+		handlers.diagnostics = handler.NewDiagnosticsHandler(service.NewDiagnosticsService(repos.QueryPlan), logger)
+	}
+
+	// Setup routes
+	timeouts := middleware.NewTimeout(logger, middleware.TimeoutOptions{
+		Default: cfg.Server.RequestTimeout,
+		Routes:  cfg.Server.RouteTimeouts,
+	})
+	extra := groupMiddleware{}
+	if len(cfg.Compression.Groups) > 0 {
+		compressor := middleware.NewCompressor(middleware.CompressOptions{
+			MinSize:      cfg.Compression.MinSize,
+			ContentTypes: cfg.Compression.ContentTypes,
+		})
+		for _, group := range cfg.Compression.Groups {
+			extra[group] = append(extra[group], compressor.Middleware)
+		}
+	}
+	tenants := middleware.NewTenantResolver(services.Tenant, logger)
+	router := setupRoutes(handlers, authenticator, tenants, timeouts, extra)
+
+	// Wrapped outside the router so unmatched routes are logged too
+	accessLog := middleware.NewAccessLog(logger, middleware.AccessLogOptions{
+		SuccessSampleRate: cfg.AccessLog.SuccessSampleRate,
+		LogBodies:         cfg.AccessLog.LogBodies,
+		MaxBodyBytes:      cfg.AccessLog.MaxBodyBytes,
+	})
+	// Recovery sits inside the access log so recovered panics are logged as 500s
+	recovery := middleware.NewRecovery(logger)
+	rootHandler := middleware.RequestID(accessLog.Middleware(recovery.Middleware(router)))
+	// Outermost, so everything inside sees the client behind any proxies
+	rootHandler = middleware.NewClientIPResolver(cfg.Server.TrustedProxies).Middleware(rootHandler)
+
+	// Setup server
+	tlsConfig, tlsHook, challenges := serverTLS(cfg.TLS)
+	server := &http.Server{
+		Addr:         fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		Handler:      rootHandler,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		TLSConfig:    tlsConfig,
+	}
+
+	// Components stop in reverse order: probes, then the servers
+	lifecycle := NewLifecycle(cfg.Server.ShutdownTimeout, logger)
+	if tlsHook != nil {
+		lifecycle.Append(*tlsHook)
+	}
+	lifecycle.Append(Server("http", server, logger))
+	if cfg.TLS.RedirectAddr != "" {
+		var redirect http.Handler = httpsRedirect(cfg.Server.Port)
+		if challenges != nil {
+			redirect = challenges(redirect)
+		}
+		redirectServer := &http.Server{
+			Addr:         cfg.TLS.RedirectAddr,
+			Handler:      redirect,
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+		}
+		lifecycle.Append(Server("https_redirect", redirectServer, logger))
+	}
+	if cfg.Debug.Enabled {
+		lifecycle.Append(DebugServer(cfg, logger))
+
+		logger.Info().Str("address", cfg.Debug.Addr).Msg("Debug endpoints enabled")
+	}
+	if cfg.Probe.Enabled {
+		// Synthetic probes run against the server once it is listening
+		account := probe.Account{
+			Tenant:   cfg.Probe.Tenant,
+			Email:    cfg.Probe.Email,
+			Password: cfg.Probe.Password,
+			// A challenged signup can't pass, so the account must already exist
+			Provisioned: handlers.captcha != nil && cfg.Captcha.Signup,
+		}
+		runner := probe.NewRunner(cfg.Probe.BaseURL, account, cfg.Probe.Interval, cfg.Probe.Timeout, logger)
+		lifecycle.Append(Background("synthetic_probes", runner.Start))
+
+		logger.Info().Str("base_url", cfg.Probe.BaseURL).Str("tenant", cfg.Probe.Tenant).Dur("interval", cfg.Probe.Interval).Msg("Synthetic probes enabled")
+	}
+
+	return &App{
+		Handler:   rootHandler,
+		Router:    router,
+		Services:  services,
+		Lifecycle: lifecycle,
+	}
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/buildinfo"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/rs/zerolog"
+)
+
+// DebugServer serves the debug endpoints on cfg.Debug.Addr, for any
+// process that wants its profiles and expvar metrics reachable
+func DebugServer(cfg *config.Config, logger zerolog.Logger) Hook {
+	// No write timeout: CPU profiles and traces stream for as long as requested
+	server := &http.Server{
+		Addr:        cfg.Debug.Addr,
+		Handler:     debugHandler(),
+		ReadTimeout: cfg.Server.ReadTimeout,
+	}
+	return Server("debug", server, logger)
+}
+
+// debugHandler serves profiling and runtime endpoints. It is mounted on its
+// own listener so it can be bound to a private interface, never the public port.
+func debugHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	// Includes the synthetic probe counters when probes are enabled, and
+	// the retention counters in the worker
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	mux.HandleFunc("GET /debug/buildinfo", func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, response.Success(buildinfo.Get()))
+	})
+
+	return mux
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/streaming"
+	"github.com/rs/zerolog"
+)
+
+// NewEventBus creates the domain event bus with the built-in subscribers.
+// A nil store leaves persistence off. The outbox relay publishes to it.
+func NewEventBus(store repository.EventRepository, notifier notification.Notifier, logger zerolog.Logger) *events.Bus {
+	// A nil interface, not a typed nil, is what disables persistence
+	var eventStore events.Store
+	if store != nil {
+		eventStore = store
+	}
+
+	bus := events.NewBus(eventStore, logger)
+	subscribeNotifications(bus, notifier)
+	return bus
+}
+
+// subscribeNotifications tells users when their account is suspended or reinstated
+func subscribeNotifications(bus *events.Bus, notifier notification.Notifier) {
+	events.On(bus, "notifications", func(ctx context.Context, e events.UserSuspended) error {
+		n := notification.Notification{
+			Type:  "account_suspended",
+			Title: "Your account has been suspended",
+			Body:  e.Reason,
+			Data:  map[string]string{"suspension_id": e.SuspensionID.String()},
+		}
+		if e.ExpiresAt != nil {
+			n.Data["expires_at"] = e.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		return notifier.Notify(ctx, e.UserID, n)
+	})
+
+	events.On(bus, "notifications", func(ctx context.Context, e events.UserReinstated) error {
+		return notifier.Notify(ctx, e.UserID, notification.Notification{
+			Type:  "account_reinstated",
+			Title: "Your account has been reinstated",
+		})
+	})
+}
+
+// NewStreamMirror builds the mirror that forwards events to the configured
+// broker. The returned hook connects the producer on start and closes it on
+// stop; append it before anything that sends through the mirror.
+func NewStreamMirror(cfg config.StreamingConfig) (*streaming.Mirror, Hook, error) {
+	opts := StreamingOptions(cfg)
+	producer, err := streaming.NewProducer(opts)
+	if err != nil {
+		return nil, Hook{}, err
+	}
+
+	return streaming.NewMirror(producer, opts.Topics), Hook{
+		Name:    "stream_producer",
+		OnStart: producer.Connect,
+		OnStop: func(ctx context.Context) error {
+			return producer.Close()
+		},
+	}, nil
+}
+
+// StreamingOptions converts config to broker options
+func StreamingOptions(cfg config.StreamingConfig) streaming.Options {
+	return streaming.Options{
+		Broker: cfg.Broker,
+		URLs:   cfg.URLs,
+		Topics: streaming.Topics{
+			Prefix:    cfg.TopicPrefix,
+			Overrides: cfg.Topics,
+		},
+		Stream: cfg.NATSStream,
+		Group:  cfg.ConsumerGroup,
+	}
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Hook is a component with a managed lifetime. OnStart must not block;
+// long-running work belongs in a goroutine, see Background.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+	// StopTimeout bounds OnStop; zero uses the lifecycle's default
+	StopTimeout time.Duration
+}
+
+// Lifecycle starts hooks in the order they were appended and stops them in
+// reverse, so a component is always stopped before the ones it depends on
+type Lifecycle struct {
+	hooks       []Hook
+	started     int
+	stopTimeout time.Duration
+	logger      zerolog.Logger
+}
+
+func NewLifecycle(stopTimeout time.Duration, logger zerolog.Logger) *Lifecycle {
+	return &Lifecycle{
+		stopTimeout: stopTimeout,
+		logger:      logger.With().Str("component", "lifecycle").Logger(),
+	}
+}
+
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Prepend registers hook to start before and stop after every other hook
+func (l *Lifecycle) Prepend(hook Hook) {
+	l.hooks = append([]Hook{hook}, l.hooks...)
+}
+
+// Start runs every OnStart in order. If one fails, the hooks already started
+// are stopped and the error is returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for _, hook := range l.hooks {
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("error starting %s: %w", hook.Name, err)
+				if stopErr := l.Stop(context.Background()); stopErr != nil {
+					return errors.Join(startErr, stopErr)
+				}
+				return startErr
+			}
+		}
+		l.started++
+		l.logger.Debug().Str("hook", hook.Name).Msg("started")
+	}
+	return nil
+}
+
+// Stop runs OnStop for every started hook in reverse order, each under its
+// own timeout. A failing or slow hook doesn't prevent the rest from stopping.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		timeout := hook.StopTimeout
+		if timeout == 0 {
+			timeout = l.stopTimeout
+		}
+		stopCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		err := hook.OnStop(stopCtx)
+		cancel()
+
+		if err != nil {
+			l.logger.Error().Err(err).Str("hook", hook.Name).Dur("duration", time.Since(start)).Msg("failed to stop")
+			errs = append(errs, fmt.Errorf("error stopping %s: %w", hook.Name, err))
+			continue
+		}
+		l.logger.Debug().Str("hook", hook.Name).Dur("duration", time.Since(start)).Msg("stopped")
+	}
+	return errors.Join(errs...)
+}
+
+// Run starts every hook, waits for ctx to be cancelled, typically by a
+// signal, then stops them
+func (l *Lifecycle) Run(ctx context.Context) error {
+	if err := l.Start(ctx); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	l.logger.Info().Msg("Shutting down...")
+
+	// ctx is already cancelled; stopping needs a fresh context
+	return l.Stop(context.Background())
+}
+
+// Background adapts a blocking function into a hook. run receives a context
+// that is cancelled on stop, and stopping waits for run to return.
+func Background(name string, run func(ctx context.Context)) Hook {
+	var (
+		cancel context.CancelFunc
+		done   = make(chan struct{})
+	)
+
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			// Detached from the start context so only OnStop ends it
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+			go func() {
+				defer close(done)
+				run(runCtx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// Server adapts an HTTP server into a hook. The listener is bound during
+// start so address errors fail startup instead of surfacing later. A server
+// with a TLSConfig serves HTTPS, negotiating HTTP/2.
+func Server(name string, server *http.Server, logger zerolog.Logger) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+
+			go func() {
+				logger.Info().Str("address", server.Addr).Bool("tls", server.TLSConfig != nil).Msg("Starting server")
+				var err error
+				if server.TLSConfig != nil {
+					// Certificates come from the TLSConfig
+					err = server.ServeTLS(listener, "", "")
+				} else {
+					err = server.Serve(listener)
+				}
+				if err != nil && err != http.ErrServerClosed {
+					logger.Error().Err(err).Str("address", server.Addr).Msg("Server stopped unexpectedly")
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	}
+}
+
+// Closer adapts a resource such as a database handle into a hook that only
+// needs closing
+func Closer(name string, closer interface{ Close() error }) Hook {
+	return Hook{
+		Name: name,
+		OnStop: func(ctx context.Context) error {
+			return closer.Close()
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"os"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// NewNotifier routes notifications over the channels each user keeps on:
+// email through mailer, or the log in its place when mailer is nil, push to
+// the platforms configured, and texts when texter is not nil
+func NewNotifier(cfg config.PushConfig, repo repository.NotificationRepository, users repository.UserRepository, phones repository.PhoneRepository, mailer notification.MailSender, texter *notification.Texter, logger zerolog.Logger) notification.Notifier {
+	channels := map[notification.Channel]notification.Notifier{
+		notification.ChannelEmail: notification.NewLogNotifier(logger),
+	}
+	if mailer != nil {
+		channels[notification.ChannelEmail] = notification.NewEmailNotifier(users, mailer, logger)
+	}
+	if pushers := pushers(cfg, logger); len(pushers) > 0 {
+		channels[notification.ChannelPush] = notification.NewPushNotifier(repo, pushers, logger)
+	}
+	if texter != nil {
+		channels[notification.ChannelSMS] = notification.NewSMSNotifier(phones, texter, logger)
+	}
+	return notification.NewRouter(repo, channels)
+}
+
+// NewMailer sends email through the configured SMTP relay; it returns nil
+// unless one is configured
+func NewMailer(cfg config.MailConfig) notification.MailSender {
+	if cfg.SMTPHost == "" {
+		return nil
+	}
+	return notification.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From, cfg.Timeout)
+}
+
+// NewTexter sends texts through Twilio within the configured sending
+// rules; it returns nil unless a Twilio account is configured
+func NewTexter(cfg config.SMSConfig) *notification.Texter {
+	if cfg.TwilioAccountSID == "" {
+		return nil
+	}
+	return notification.NewTexter(notification.NewTwilio(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.Timeout), notification.SMSRules{
+		Allowed: cfg.AllowedPrefixes,
+		Senders: cfg.Senders,
+		From:    cfg.From,
+	})
+}
+
+// pushers builds a Pusher for each platform with credentials. A platform
+// whose credentials are rejected is left out, so the rest still work.
+func pushers(cfg config.PushConfig, logger zerolog.Logger) map[models.PushPlatform]notification.Pusher {
+	pushers := map[models.PushPlatform]notification.Pusher{}
+	if cfg.FCMCredentialsFile != "" {
+		if fcm, err := newFCM(cfg); err != nil {
+			logger.Error().Err(err).Str("file", cfg.FCMCredentialsFile).Msg("invalid FCM credentials, FCM push disabled")
+		} else {
+			pushers[models.PushFCM] = fcm
+		}
+	}
+	if cfg.APNSKeyFile != "" {
+		if apns, err := newAPNs(cfg); err != nil {
+			logger.Error().Err(err).Str("file", cfg.APNSKeyFile).Msg("invalid APNs key, APNs push disabled")
+		} else {
+			pushers[models.PushAPNs] = apns
+		}
+	}
+	return pushers
+}
+
+func newFCM(cfg config.PushConfig) (*notification.FCM, error) {
+	credentials, err := os.ReadFile(cfg.FCMCredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	return notification.NewFCM(credentials, cfg.Timeout)
+}
+
+func newAPNs(cfg config.PushConfig) (*notification.APNs, error) {
+	key, err := os.ReadFile(cfg.APNSKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return notification.NewAPNs(key, cfg.APNSKeyID, cfg.APNSTeamID, cfg.APNSTopic, cfg.APNSSandbox, cfg.Timeout)
+}
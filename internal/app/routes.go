@@ -0,0 +1,343 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/fraud"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/geoip"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/handler"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/moderation"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/oidc"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/rs/zerolog"
+)
+
+// routeHandlers groups the HTTP handlers mounted by setupRoutes
+type routeHandlers struct {
+	user         *handler.UserHandler
+	analytics    *handler.AnalyticsHandler
+	moderation   *handler.ModerationHandler
+	suspension   *handler.SuspensionHandler
+	permission   *handler.PermissionHandler
+	address      *handler.AddressHandler
+	profile      *handler.ProfileHandler
+	consent      *handler.ConsentHandler
+	fraud        *handler.FraudHandler
+	device       *handler.DeviceHandler
+	tenant       *handler.TenantHandler
+	organization *handler.OrganizationHandler
+	oauth        *handler.OAuthHandler
+	notification *handler.NotificationHandler
+	passkey      *handler.PasskeyHandler     // nil unless passkeys are configured
+	phone        *handler.PhoneHandler       // nil unless SMS is configured
+	ssoEnabled   bool                        // whether an identity provider is configured
+	magicLinks   bool                        // whether email is configured to deliver sign-in links
+	diagnostics  *handler.DiagnosticsHandler // nil unless the query plan guard is enabled
+	captcha      *middleware.Captcha         // nil unless a CAPTCHA provider is configured
+}
+
+func setupRoutes(h *routeHandlers, authenticator *middleware.Authenticator, tenants *middleware.TenantResolver, timeouts *middleware.Timeout, extra groupMiddleware) *mux.Router {
+	router := mux.NewRouter()
+
+	// API versioning: each version lists the modules it mounts. v2 only
+	// carries routes whose DTOs changed; everything else stays on v1.
+	mountVersion(router, "/api/v1", authenticator, extra, h,
+		userRoutesV1,
+		passkeyRoutesV1,
+		ssoRoutesV1,
+		magicLinkRoutesV1,
+		addressRoutesV1,
+		profileRoutesV1,
+		notificationRoutesV1,
+		phoneRoutesV1,
+		consentRoutesV1,
+		tenantRoutesV1,
+		organizationRoutesV1,
+		oauthRoutesV1,
+		analyticsRoutesV1,
+		moderationRoutesV1,
+		suspensionRoutesV1,
+		fraudRoutesV1,
+		permissionRoutesV1,
+		diagnosticsRoutesV1,
+	)
+	mountVersion(router, "/api/v2", authenticator, extra, h,
+		userRoutesV2,
+	)
+
+	// Add CORS middleware
+	router.Use(corsMiddleware)
+
+	// Negotiate response language from Accept-Language
+	router.Use(i18n.Middleware)
+
+	// Bound each handler so slow queries are cancelled with a 504 before
+	// the server's WriteTimeout drops the connection
+	router.Use(timeouts.Middleware)
+
+	// Resolve the store before anything reads or writes tenant-scoped
+	// data, authentication included
+	router.Use(tenants.Middleware)
+
+	return router
+}
+
+func userRoutesV1(h *routeHandlers, v *versionRoutes) {
+	// User routes
+	v.Public.Handle("/users", h.captcha.Signup(h.user.CreateUser)).Methods("POST")
+	v.Public.HandleFunc("/users", h.user.ListUsers).Methods("GET")
+	v.Public.HandleFunc("/users/{id}", h.user.GetUser).Methods("GET")
+	v.Public.Handle("/users/{id}", v.Authenticated(h.user.UpdateUser)).Methods("PUT")
+	v.Public.Handle("/users/{id}", v.Authenticated(h.user.DeleteUser)).Methods("DELETE")
+	v.Public.HandleFunc("/users/by-username/{username}", h.user.GetUserByUsername).Methods("GET")
+	v.Public.HandleFunc("/usernames/{name}/availability", h.user.UsernameAvailability).Methods("GET")
+
+	// Auth routes
+	v.Public.Handle("/auth/login", h.captcha.Login(h.user.Login)).Methods("POST")
+	v.Public.HandleFunc("/auth/login/verify", h.user.VerifyLogin).Methods("POST")
+	v.Me.HandleFunc("/login-locations", h.user.ListLoginLocations).Methods("GET")
+	v.Me.HandleFunc("/devices", h.device.ListDevices).Methods("GET")
+	v.Me.HandleFunc("/devices/{id}", h.device.RevokeDevice).Methods("DELETE")
+}
+
+// passkeyRoutesV1 is a no-op unless a WebAuthn relying party is configured.
+// Registering is an /auth route but needs a signed-in caller.
+func passkeyRoutesV1(h *routeHandlers, v *versionRoutes) {
+	if h.passkey == nil {
+		return
+	}
+	v.Public.Handle("/auth/webauthn/register/begin", v.Authenticated(h.passkey.BeginRegistration)).Methods("POST")
+	v.Public.Handle("/auth/webauthn/register/finish", v.Authenticated(h.passkey.FinishRegistration)).Methods("POST")
+	v.Public.HandleFunc("/auth/webauthn/login/begin", h.user.BeginPasskeyLogin).Methods("POST")
+	v.Public.HandleFunc("/auth/webauthn/login/finish", h.user.FinishPasskeyLogin).Methods("POST")
+	v.Me.HandleFunc("/passkeys", h.passkey.ListPasskeys).Methods("GET")
+	v.Me.HandleFunc("/passkeys/{id}", h.passkey.DeletePasskey).Methods("DELETE")
+}
+
+// magicLinkRoutesV1 is a no-op unless email is configured, since a link
+// that is only logged never reaches the user
+func magicLinkRoutesV1(h *routeHandlers, v *versionRoutes) {
+	if !h.magicLinks {
+		return
+	}
+	v.Public.HandleFunc("/auth/magic-link", h.user.SendMagicLink).Methods("POST")
+	v.Public.HandleFunc("/auth/magic-link/verify", h.user.VerifyMagicLink).Methods("GET")
+}
+
+// ssoRoutesV1 is a no-op unless an identity provider is configured
+func ssoRoutesV1(h *routeHandlers, v *versionRoutes) {
+	if !h.ssoEnabled {
+		return
+	}
+	v.Public.HandleFunc("/auth/sso/begin", h.user.BeginSSOLogin).Methods("POST")
+	v.Public.HandleFunc("/auth/sso/callback", h.user.FinishSSOLogin).Methods("GET")
+}
+
+func userRoutesV2(h *routeHandlers, v *versionRoutes) {
+	v.Public.HandleFunc("/users", h.user.ListUsersV2).Methods("GET")
+}
+
+func addressRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Delegated.Handle("/addresses", v.Scoped(models.ScopeAddressesRead, h.address.ListAddresses)).Methods("GET")
+	v.Me.HandleFunc("/addresses", h.address.CreateAddress).Methods("POST")
+	v.Delegated.Handle("/addresses/{id}", v.Scoped(models.ScopeAddressesRead, h.address.GetAddress)).Methods("GET")
+	v.Me.HandleFunc("/addresses/{id}", h.address.UpdateAddress).Methods("PUT")
+	v.Me.HandleFunc("/addresses/{id}", h.address.DeleteAddress).Methods("DELETE")
+}
+
+func profileRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Public.HandleFunc("/profiles/{username}", h.profile.GetProfile).Methods("GET")
+	v.Delegated.Handle("/profile/preferences", v.Scoped(models.ScopeProfileRead, h.profile.GetPreferences)).Methods("GET")
+	v.Me.HandleFunc("/profile/preferences", h.profile.UpdatePreferences).Methods("PUT")
+}
+
+func notificationRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Me.HandleFunc("/push-tokens", h.notification.ListPushTokens).Methods("GET")
+	v.Me.HandleFunc("/push-tokens", h.notification.RegisterPushToken).Methods("POST")
+	v.Me.HandleFunc("/push-tokens/{id}", h.notification.DeletePushToken).Methods("DELETE")
+	v.Me.HandleFunc("/notification-preferences", h.notification.GetPreferences).Methods("GET")
+	v.Me.HandleFunc("/notification-preferences", h.notification.UpdatePreferences).Methods("PUT")
+	v.Admin.Handle("/users/{id}/push-deliveries", v.Requires(models.PermUsersRead, h.notification.ListDeliveries)).Methods("GET")
+}
+
+// phoneRoutesV1 is a no-op unless SMS is configured
+func phoneRoutesV1(h *routeHandlers, v *versionRoutes) {
+	if h.phone == nil {
+		return
+	}
+	v.Me.HandleFunc("/phone", h.phone.GetStatus).Methods("GET")
+	v.Me.HandleFunc("/phone/verification", h.phone.SendCode).Methods("POST")
+	v.Me.HandleFunc("/phone/verification/confirm", h.phone.Confirm).Methods("POST")
+}
+
+func consentRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Public.HandleFunc("/legal-documents", h.consent.ListCurrent).Methods("GET")
+	v.Consent.HandleFunc("/consents", h.consent.GetStatus).Methods("GET")
+	v.Consent.HandleFunc("/consents", h.consent.Accept).Methods("POST")
+	// Legal documents apply to every store, so only operators manage them
+	v.Admin.Handle("/legal-documents", v.Operator(v.Requires(models.PermLegalPublish, h.consent.ListDocuments))).Methods("GET")
+	v.Admin.Handle("/legal-documents", v.Operator(v.Requires(models.PermLegalPublish, h.consent.Publish))).Methods("POST")
+}
+
+func tenantRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Public.HandleFunc("/tenant", h.tenant.GetTenant).Methods("GET")
+	v.Admin.Handle("/tenant", v.Requires(models.PermTenantManage, h.tenant.UpdateTenant)).Methods("PUT")
+}
+
+func organizationRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Me.HandleFunc("/organizations", h.organization.ListOrganizations).Methods("GET")
+	v.Me.HandleFunc("/organizations", h.organization.CreateOrganization).Methods("POST")
+	v.Me.HandleFunc("/organizations/{id}", h.organization.GetOrganization).Methods("GET")
+	v.Me.HandleFunc("/organizations/{id}/members", h.organization.ListMembers).Methods("GET")
+	v.Me.HandleFunc("/organizations/{id}/members", h.organization.AddMember).Methods("POST")
+	v.Me.HandleFunc("/organizations/{id}/members/{user_id}", h.organization.UpdateMember).Methods("PUT")
+	v.Me.HandleFunc("/organizations/{id}/members/{user_id}", h.organization.RemoveMember).Methods("DELETE")
+}
+
+func oauthRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Public.Handle("/oauth/authorize", v.Authenticated(h.oauth.Authorize)).Methods("GET")
+	v.Public.Handle("/oauth/authorize", v.Authenticated(h.oauth.Decide)).Methods("POST")
+	v.Public.HandleFunc("/oauth/token", h.oauth.Token).Methods("POST")
+	v.Public.HandleFunc("/oauth/introspect", h.oauth.Introspect).Methods("POST")
+	v.Public.HandleFunc("/oauth/revoke", h.oauth.Revoke).Methods("POST")
+	v.Me.HandleFunc("/oauth/grants", h.oauth.ListGrants).Methods("GET")
+	v.Me.HandleFunc("/oauth/grants/{client_id}", h.oauth.RevokeGrant).Methods("DELETE")
+	v.Admin.Handle("/oauth/clients", v.Requires(models.PermOAuthManage, h.oauth.ListClients)).Methods("GET")
+	v.Admin.Handle("/oauth/clients", v.Requires(models.PermOAuthManage, h.oauth.RegisterClient)).Methods("POST")
+	v.Admin.Handle("/oauth/clients/{id}", v.Requires(models.PermOAuthManage, h.oauth.DeleteClient)).Methods("DELETE")
+}
+
+func analyticsRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Admin.Handle("/analytics/signups", v.Requires(models.PermAnalyticsRead, h.analytics.Signups)).Methods("GET")
+}
+
+func moderationRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Admin.Handle("/moderation", v.Requires(models.PermModerationReview, h.moderation.ListItems)).Methods("GET")
+	v.Admin.Handle("/moderation/{id}/approve", v.Requires(models.PermModerationReview, h.moderation.ApproveItem)).Methods("POST")
+	v.Admin.Handle("/moderation/{id}/reject", v.Requires(models.PermModerationReview, h.moderation.RejectItem)).Methods("POST")
+}
+
+func suspensionRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Admin.Handle("/users/bulk-status", v.Requires(models.PermUsersSuspend, h.suspension.BulkUpdateStatus)).Methods("POST")
+	v.Admin.Handle("/users/{id}/suspensions", v.Requires(models.PermUsersSuspend, h.suspension.IssueSuspension)).Methods("POST")
+	v.Admin.Handle("/users/{id}/suspensions", v.Requires(models.PermUsersSuspend, h.suspension.LiftSuspension)).Methods("DELETE")
+	v.Admin.Handle("/users/{id}/suspensions", v.Requires(models.PermUsersRead, h.suspension.ListSuspensions)).Methods("GET")
+}
+
+func fraudRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Admin.Handle("/users/{id}/fraud-assessments", v.Requires(models.PermUsersRead, h.fraud.ListForUser)).Methods("GET")
+}
+
+func permissionRoutesV1(h *routeHandlers, v *versionRoutes) {
+	v.Admin.Handle("/permissions", v.Requires(models.PermPermissionsManage, h.permission.ListPermissions)).Methods("GET")
+	v.Admin.Handle("/users/{id}/permissions", v.Requires(models.PermPermissionsManage, h.permission.GetUserPermissions)).Methods("GET")
+	v.Admin.Handle("/users/{id}/permissions", v.Requires(models.PermPermissionsManage, h.permission.GrantPermission)).Methods("POST")
+	v.Admin.Handle("/users/{id}/permissions/{permission}", v.Requires(models.PermPermissionsManage, h.permission.RevokePermission)).Methods("DELETE")
+}
+
+// diagnosticsRoutesV1 is a no-op unless the query plan guard is enabled
+func diagnosticsRoutesV1(h *routeHandlers, v *versionRoutes) {
+	if h.diagnostics != nil {
+		v.Admin.Handle("/diagnostics/query-plans", v.Requires(models.PermDiagnosticsRead, h.diagnostics.ListQueryPlans)).Methods("GET")
+	}
+}
+
+// moderationScreeners builds the automated pre-screening hooks from config
+func moderationScreeners(cfg *config.Config) []moderation.Screener {
+	var screeners []moderation.Screener
+	if len(cfg.Moderation.BannedWords) > 0 {
+		screeners = append(screeners, moderation.NewWordListScreener(cfg.Moderation.BannedWords...))
+	}
+	return screeners
+}
+
+// fraudPipeline builds the signup risk checks from config, or nil when
+// they are disabled
+func fraudPipeline(cfg *config.Config, counter fraud.AttemptCounter) *fraud.Pipeline {
+	if !cfg.Fraud.Enabled {
+		return nil
+	}
+	thresholds := fraud.Thresholds{Review: cfg.Fraud.ReviewScore, Block: cfg.Fraud.BlockScore}
+	return fraud.NewPipeline(thresholds,
+		fraud.NewDisposableEmailRule(cfg.Fraud.DisposableDomains...),
+		fraud.NewVelocityRule(counter, cfg.Fraud.VelocityLimit, cfg.Fraud.VelocityWindow),
+	)
+}
+
+// relyingParty builds the WebAuthn relying party, or nil when passkeys
+// are not configured or the configuration is rejected
+func relyingParty(cfg *config.Config, logger zerolog.Logger) *webauthn.WebAuthn {
+	if cfg.WebAuthn.RPID == "" {
+		return nil
+	}
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: cfg.WebAuthn.SessionTTL, TimeoutUVD: cfg.WebAuthn.SessionTTL}
+	rp, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthn.RPID,
+		RPDisplayName: cfg.WebAuthn.RPName,
+		RPOrigins:     cfg.WebAuthn.Origins,
+		Timeouts:      webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("rp_id", cfg.WebAuthn.RPID).Msg("invalid WebAuthn configuration, passkeys disabled")
+		return nil
+	}
+	return rp
+}
+
+// identityProvider builds the OpenID Connect client admins sign in through
+func identityProvider(cfg *config.Config) oidc.Provider {
+	return oidc.NewClient(oidc.Config{
+		Issuer:       cfg.SSO.Issuer,
+		ClientID:     cfg.SSO.ClientID,
+		ClientSecret: cfg.SSO.ClientSecret,
+		RedirectURL:  cfg.SSO.RedirectURL,
+		Scopes:       cfg.SSO.Scopes,
+		GroupsClaim:  cfg.SSO.GroupsClaim,
+		Timeout:      cfg.SSO.Timeout,
+	})
+}
+
+func ssoPolicy(cfg *config.Config) service.SSOPolicy {
+	groupRoles := make(map[string]models.UserRole, len(cfg.SSO.GroupRoles))
+	for group, role := range cfg.SSO.GroupRoles {
+		groupRoles[group] = models.UserRole(role)
+	}
+	return service.SSOPolicy{
+		GroupRoles: groupRoles,
+		Required:   cfg.SSO.Required,
+		StateTTL:   cfg.SSO.StateTTL,
+	}
+}
+
+// geoLocator builds the IP geolocation client, or nil when none is configured
+func geoLocator(cfg *config.Config) geoip.Locator {
+	switch cfg.GeoIP.Provider {
+	case "ip-api":
+		return geoip.NewIPAPILocator(cfg.GeoIP.URL, cfg.GeoIP.Timeout)
+	default:
+		return nil
+	}
+}
+
+// CORS middleware
+func corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept-Language, X-Request-ID, "+middleware.CaptchaTokenHeader+", "+handler.DeviceIDHeader+", "+middleware.TenantHeader)
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, "+middleware.CaptchaRequiredHeader)
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// serverTLS returns the API server's TLS config, or nil when TLS is off.
+// The hook, if any, must start before the server; challenges answers ACME
+// HTTP-01 challenges on the redirect listener, or is nil.
+func serverTLS(cfg config.TLSConfig) (tlsConfig *tls.Config, hook *Hook, challenges func(http.Handler) http.Handler) {
+	if len(cfg.AutocertDomains) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+			Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+			Email:      cfg.AutocertEmail,
+		}
+		// Includes h2 and the TLS-ALPN-01 challenge protocol
+		tlsConfig = manager.TLSConfig()
+		tlsConfig.MinVersion = tls.VersionTLS12
+		return tlsConfig, nil, manager.HTTPHandler
+	}
+
+	if cfg.CertFile != "" {
+		certificate := &certificateFile{certFile: cfg.CertFile, keyFile: cfg.KeyFile}
+		tlsConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			NextProtos:     []string{"h2", "http/1.1"},
+			GetCertificate: certificate.get,
+		}
+		hook := Hook{Name: "tls_certificate", OnStart: certificate.load}
+		return tlsConfig, &hook, nil
+	}
+
+	return nil, nil, nil
+}
+
+// certificateFile serves a key pair read from disk. It is read when the
+// lifecycle starts, so a bad path or key fails startup rather than every
+// handshake.
+type certificateFile struct {
+	certFile    string
+	keyFile     string
+	certificate *tls.Certificate
+}
+
+func (c *certificateFile) load(ctx context.Context) error {
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.certificate = &certificate
+	return nil
+}
+
+func (c *certificateFile) get(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.certificate, nil
+}
+
+// httpsRedirect sends every request to the same URL over HTTPS on the API
+// server's port. GET and HEAD get a 301; other methods a 308 so clients
+// keep the method and body.
+func httpsRedirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		status := http.StatusPermanentRedirect
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), status)
+	})
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSRedirect(t *testing.T) {
+	for _, tc := range []struct {
+		name, method, host, target, port string
+		status                           int
+		location                         string
+	}{
+		{"get", http.MethodGet, "shop.example.com", "/games?page=2", "443", http.StatusMovedPermanently, "https://shop.example.com/games?page=2"},
+		{"head", http.MethodHead, "shop.example.com", "/", "443", http.StatusMovedPermanently, "https://shop.example.com/"},
+		{"post keeps the method", http.MethodPost, "shop.example.com", "/orders", "443", http.StatusPermanentRedirect, "https://shop.example.com/orders"},
+		{"http port dropped", http.MethodGet, "shop.example.com:80", "/", "443", http.StatusMovedPermanently, "https://shop.example.com/"},
+		{"other https port", http.MethodGet, "localhost:8080", "/health", "8443", http.StatusMovedPermanently, "https://localhost:8443/health"},
+		{"ipv6 host", http.MethodGet, "[::1]:8080", "/", "8443", http.StatusMovedPermanently, "https://[::1]:8443/"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.target, nil)
+			r.Host = tc.host
+			w := httptest.NewRecorder()
+
+			httpsRedirect(tc.port).ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Fatalf("status = %d, want %d", w.Code, tc.status)
+			}
+			if got := w.Header().Get("Location"); got != tc.location {
+				t.Fatalf("Location = %q, want %q", got, tc.location)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+)
+
+// NewValidator validates requests against the marketplace's roles,
+// statuses and username rules
+func NewValidator() *validator.Validator {
+	return validator.New(validator.Domain{
+		Roles:    []string{string(models.RoleGamer), string(models.RoleAdmin), string(models.RoleSuperAdmin)},
+		Statuses: []string{string(models.StatusActive), string(models.StatusInactive), string(models.StatusSuspended)},
+		Username: func(name string) bool {
+			return models.ValidUsername(name) && !models.ReservedUsername(name)
+		},
+	})
+}
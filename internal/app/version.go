@@ -0,0 +1,117 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// versionRoutes is the set of subrouters one API version mounts its modules
+// on. Every version gets the same middleware chains, so a module only
+// decides which group a route belongs to.
+type versionRoutes struct {
+	// Public routes need no authentication
+	Public *mux.Router
+	// Me routes act on the authenticated caller's own resources
+	Me *mux.Router
+	// Consent routes are Me routes reachable before the caller accepts
+	// pending legal documents; keep them to what accepting needs
+	Consent *mux.Router
+	// Delegated routes are Me routes third-party apps may also call with an
+	// OAuth access token; wrap each handler with Scoped for the scope it needs
+	Delegated *mux.Router
+	// Admin routes require an authenticated admin; wrap each handler with
+	// Requires for the permission its action needs
+	Admin *mux.Router
+
+	authenticator *middleware.Authenticator
+}
+
+// routeModule registers one module's routes for an API version
+type routeModule func(h *routeHandlers, v *versionRoutes)
+
+// Route group names, as used in configuration
+const (
+	groupPublic = "public"
+	groupMe     = "me"
+	groupAdmin  = "admin"
+)
+
+// groupMiddleware is extra middleware per route group, run before the
+// group's own authentication
+type groupMiddleware map[string][]mux.MiddlewareFunc
+
+func newVersionRoutes(router *mux.Router, prefix string, authenticator *middleware.Authenticator, extra groupMiddleware) *versionRoutes {
+	api := router.PathPrefix(prefix).Subrouter()
+
+	// Registered before consent and me, which it shares a prefix with, so
+	// its routes match first and accept OAuth access tokens
+	delegated := api.PathPrefix("/me").Subrouter()
+	delegated.Use(extra[groupMe]...)
+	delegated.Use(authenticator.AuthenticateDelegated)
+	delegated.Use(authenticator.RequireConsent)
+
+	// Registered before me, which it shares a prefix with, so its routes
+	// match first and skip the consent check
+	consent := api.PathPrefix("/me").Subrouter()
+	consent.Use(extra[groupMe]...)
+	consent.Use(authenticator.Authenticate)
+
+	me := api.PathPrefix("/me").Subrouter()
+	me.Use(extra[groupMe]...)
+	me.Use(authenticator.Authenticate)
+	me.Use(authenticator.RequireConsent)
+
+	admin := api.PathPrefix("/admin").Subrouter()
+	admin.Use(extra[groupAdmin]...)
+	admin.Use(authenticator.Authenticate)
+	admin.Use(middleware.RequireRole(models.RoleAdmin, models.RoleSuperAdmin))
+	admin.Use(authenticator.RequireConsent)
+
+	// A matcher-less subrouter registered last, so public middleware
+	// doesn't leak into /me and /admin the way middleware on api would
+	public := api.NewRoute().Subrouter()
+	public.Use(extra[groupPublic]...)
+
+	return &versionRoutes{
+		Public:        public,
+		Me:            me,
+		Consent:       consent,
+		Delegated:     delegated,
+		Admin:         admin,
+		authenticator: authenticator,
+	}
+}
+
+// Requires wraps fn so it only runs when the caller holds permission
+func (v *versionRoutes) Requires(permission models.Permission, fn http.HandlerFunc) http.Handler {
+	return v.authenticator.RequirePermission(permission)(fn)
+}
+
+// Operator wraps next so only the deployment's operators reach it, for
+// Admin routes that act on every store at once
+func (v *versionRoutes) Operator(next http.Handler) http.Handler {
+	return middleware.RequireOperator(next)
+}
+
+// Scoped wraps fn so a third-party app's token only reaches it when it was
+// granted scope; the user's own sessions always do
+func (v *versionRoutes) Scoped(scope models.OAuthScope, fn http.HandlerFunc) http.Handler {
+	return middleware.RequireScope(scope)(fn)
+}
+
+// mountVersion registers modules under prefix, e.g. "/api/v1"
+func mountVersion(router *mux.Router, prefix string, authenticator *middleware.Authenticator, extra groupMiddleware, h *routeHandlers, modules ...routeModule) {
+	v := newVersionRoutes(router, prefix, authenticator, extra)
+	for _, module := range modules {
+		module(h, v)
+	}
+}
+
+// Authenticated wraps fn so it gets the protections of a Me route while
+// living outside /me
+func (v *versionRoutes) Authenticated(fn http.HandlerFunc) http.Handler {
+	return v.authenticator.Authenticate(v.authenticator.RequireConsent(fn))
+}
@@ -0,0 +1,122 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeAddressRepository is an in-memory repository.AddressRepository
+type FakeAddressRepository struct {
+	mu        sync.Mutex
+	addresses map[uuid.UUID]*models.Address
+}
+
+func NewFakeAddressRepository() *FakeAddressRepository {
+	return &FakeAddressRepository{addresses: make(map[uuid.UUID]*models.Address)}
+}
+
+// Lock is a no-op; the fake serializes every call anyway
+func (f *FakeAddressRepository) Lock(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (f *FakeAddressRepository) Create(ctx context.Context, address *models.Address) (*models.Address, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *address
+	created.ID = uuid.New()
+	created.CreatedAt = time.Now()
+	created.UpdatedAt = created.CreatedAt
+	f.addresses[created.ID] = &created
+	copied := created
+	return &copied, nil
+}
+
+func (f *FakeAddressRepository) GetForUser(ctx context.Context, id, userID uuid.UUID) (*models.Address, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address, ok := f.addresses[id]
+	if !ok || address.UserID != userID {
+		return nil, nil
+	}
+	copied := *address
+	return &copied, nil
+}
+
+func (f *FakeAddressRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Address, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	addresses := make([]*models.Address, 0, len(f.addresses))
+	for _, address := range f.addresses {
+		if address.UserID == userID {
+			copied := *address
+			addresses = append(addresses, &copied)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].CreatedAt.Before(addresses[j].CreatedAt)
+	})
+	return addresses, nil
+}
+
+func (f *FakeAddressRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	addresses, err := f.ListByUser(ctx, userID)
+	return int64(len(addresses)), err
+}
+
+func (f *FakeAddressRepository) Update(ctx context.Context, address *models.Address) (*models.Address, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.addresses[address.ID]
+	if !ok || existing.UserID != address.UserID {
+		return nil, nil
+	}
+	updated := *address
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now()
+	f.addresses[address.ID] = &updated
+	copied := updated
+	return &copied, nil
+}
+
+func (f *FakeAddressRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address, ok := f.addresses[id]
+	if !ok || address.UserID != userID {
+		return false, nil
+	}
+	delete(f.addresses, id)
+	return true, nil
+}
+
+func (f *FakeAddressRepository) ClearOtherDefaultShipping(ctx context.Context, userID, keepID uuid.UUID) error {
+	f.clearOther(userID, keepID, func(address *models.Address) { address.IsDefaultShipping = false })
+	return nil
+}
+
+func (f *FakeAddressRepository) ClearOtherDefaultBilling(ctx context.Context, userID, keepID uuid.UUID) error {
+	f.clearOther(userID, keepID, func(address *models.Address) { address.IsDefaultBilling = false })
+	return nil
+}
+
+func (f *FakeAddressRepository) clearOther(userID, keepID uuid.UUID, clear func(*models.Address)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, address := range f.addresses {
+		if address.UserID == userID && id != keepID {
+			clear(address)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package apptest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeAnalyticsRepository is a repository.AnalyticsRepository that returns
+// preset buckets, so tests control what the endpoints report
+type FakeAnalyticsRepository struct {
+	mu      sync.Mutex
+	buckets []*models.SignupBucket
+}
+
+func NewFakeAnalyticsRepository() *FakeAnalyticsRepository {
+	return &FakeAnalyticsRepository{}
+}
+
+// SetSignups sets the buckets returned for any range
+func (f *FakeAnalyticsRepository) SetSignups(buckets ...*models.SignupBucket) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.buckets = buckets
+}
+
+func (f *FakeAnalyticsRepository) SignupsOverTime(ctx context.Context, rng models.AnalyticsRange) ([]*models.SignupBucket, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var buckets []*models.SignupBucket
+	for _, bucket := range f.buckets {
+		if !bucket.Bucket.Before(rng.From) && bucket.Bucket.Before(rng.To) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets, nil
+}
@@ -0,0 +1,43 @@
+package apptest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeAuditRepository is an in-memory repository.AuditRepository that keeps
+// every entry for assertions
+type FakeAuditRepository struct {
+	mu      sync.Mutex
+	entries []*models.AuditLog
+}
+
+func NewFakeAuditRepository() *FakeAuditRepository {
+	return &FakeAuditRepository{}
+}
+
+func (f *FakeAuditRepository) Create(ctx context.Context, entry *models.AuditLog) (*models.AuditLog, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *entry
+	created.ID = uuid.New()
+	created.CreatedAt = time.Now()
+	f.entries = append(f.entries, &created)
+	copied := created
+	return &copied, nil
+}
+
+// Entries returns every audit entry written so far, oldest first
+func (f *FakeAuditRepository) Entries() []*models.AuditLog {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries := make([]*models.AuditLog, len(f.entries))
+	copy(entries, f.entries)
+	return entries
+}
@@ -0,0 +1,168 @@
+package apptest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// FakeConsentRepository is an in-memory repository.ConsentRepository
+type FakeConsentRepository struct {
+	mu          sync.Mutex
+	documents   map[uuid.UUID]*models.LegalDocument
+	acceptances map[uuid.UUID]map[uuid.UUID]*models.ConsentAcceptance
+}
+
+func NewFakeConsentRepository() *FakeConsentRepository {
+	return &FakeConsentRepository{
+		documents:   make(map[uuid.UUID]*models.LegalDocument),
+		acceptances: make(map[uuid.UUID]map[uuid.UUID]*models.ConsentAcceptance),
+	}
+}
+
+func (f *FakeConsentRepository) CreateDocument(ctx context.Context, doc *models.LegalDocument) (*models.LegalDocument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, existing := range f.documents {
+		if existing.Kind == doc.Kind && existing.Version == doc.Version {
+			return nil, fmt.Errorf("%w: legal_documents_kind_version_key", repository.ErrConflict)
+		}
+	}
+
+	stored := *doc
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.documents[stored.ID] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeConsentRepository) GetDocument(ctx context.Context, id uuid.UUID) (*models.LegalDocument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	doc, ok := f.documents[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *doc
+	return &copied, nil
+}
+
+func (f *FakeConsentRepository) ListDocuments(ctx context.Context) ([]*models.LegalDocument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.sorted(func(*models.LegalDocument) bool { return true }), nil
+}
+
+func (f *FakeConsentRepository) ListCurrent(ctx context.Context) ([]*models.LegalDocument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	current := make(map[models.LegalDocumentKind]*models.LegalDocument)
+	for _, doc := range f.sorted(func(doc *models.LegalDocument) bool { return !doc.EffectiveAt.After(now) }) {
+		if _, ok := current[doc.Kind]; !ok {
+			current[doc.Kind] = doc
+		}
+	}
+	return sortedByKind(current), nil
+}
+
+func (f *FakeConsentRepository) ListPending(ctx context.Context, userID uuid.UUID) ([]*models.LegalDocument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	required := make(map[models.LegalDocumentKind]*models.LegalDocument)
+	for _, doc := range f.sorted(func(doc *models.LegalDocument) bool { return doc.Mandatory && !doc.EffectiveAt.After(now) }) {
+		if _, ok := required[doc.Kind]; !ok {
+			required[doc.Kind] = doc
+		}
+	}
+
+	// Accepting any version of a kind effective no earlier satisfies it
+	for documentID := range f.acceptances[userID] {
+		accepted := f.documents[documentID]
+		if doc, ok := required[accepted.Kind]; ok && !accepted.EffectiveAt.Before(doc.EffectiveAt) {
+			delete(required, accepted.Kind)
+		}
+	}
+	return sortedByKind(required), nil
+}
+
+func (f *FakeConsentRepository) Accept(ctx context.Context, userID, documentID uuid.UUID, ipAddress *string) (time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	doc, ok := f.documents[documentID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("legal document %s does not exist", documentID)
+	}
+	if f.acceptances[userID] == nil {
+		f.acceptances[userID] = make(map[uuid.UUID]*models.ConsentAcceptance)
+	}
+	if existing, ok := f.acceptances[userID][documentID]; ok {
+		return existing.AcceptedAt, nil
+	}
+
+	f.acceptances[userID][documentID] = &models.ConsentAcceptance{
+		DocumentID: documentID,
+		Kind:       doc.Kind,
+		Version:    doc.Version,
+		IPAddress:  ipAddress,
+		AcceptedAt: time.Now(),
+	}
+	return f.acceptances[userID][documentID].AcceptedAt, nil
+}
+
+func (f *FakeConsentRepository) ListAcceptances(ctx context.Context, userID uuid.UUID) ([]*models.ConsentAcceptance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	acceptances := make([]*models.ConsentAcceptance, 0, len(f.acceptances[userID]))
+	for _, acceptance := range f.acceptances[userID] {
+		copied := *acceptance
+		acceptances = append(acceptances, &copied)
+	}
+	sort.Slice(acceptances, func(i, j int) bool {
+		return acceptances[i].AcceptedAt.After(acceptances[j].AcceptedAt)
+	})
+	return acceptances, nil
+}
+
+// sorted returns copies of the documents matching keep, by kind and newest
+// effective date first. Callers hold the lock.
+func (f *FakeConsentRepository) sorted(keep func(*models.LegalDocument) bool) []*models.LegalDocument {
+	docs := make([]*models.LegalDocument, 0, len(f.documents))
+	for _, doc := range f.documents {
+		if keep(doc) {
+			copied := *doc
+			docs = append(docs, &copied)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Kind != docs[j].Kind {
+			return docs[i].Kind < docs[j].Kind
+		}
+		return docs[i].EffectiveAt.After(docs[j].EffectiveAt)
+	})
+	return docs
+}
+
+func sortedByKind(byKind map[models.LegalDocumentKind]*models.LegalDocument) []*models.LegalDocument {
+	docs := make([]*models.LegalDocument, 0, len(byKind))
+	for _, doc := range byKind {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Kind < docs[j].Kind })
+	return docs
+}
@@ -0,0 +1,97 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeDeviceRepository is an in-memory repository.DeviceRepository
+type FakeDeviceRepository struct {
+	mu      sync.Mutex
+	devices map[uuid.UUID]*models.Device
+}
+
+func NewFakeDeviceRepository() *FakeDeviceRepository {
+	return &FakeDeviceRepository{devices: make(map[uuid.UUID]*models.Device)}
+}
+
+func (f *FakeDeviceRepository) Touch(ctx context.Context, device *models.Device) (*models.Device, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	for _, d := range f.devices {
+		if d.UserID == device.UserID && d.Fingerprint == device.Fingerprint {
+			d.Name = device.Name
+			d.LastIP = device.LastIP
+			d.LastSeenAt = now
+			copied := *d
+			return &copied, false, nil
+		}
+	}
+
+	stored := *device
+	stored.ID = uuid.New()
+	stored.FirstSeenAt = now
+	stored.LastSeenAt = now
+	f.devices[stored.ID] = &stored
+	copied := stored
+	return &copied, true, nil
+}
+
+func (f *FakeDeviceRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, d := range f.devices {
+		if d.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeDeviceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Device, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	devices := []*models.Device{}
+	for _, d := range f.devices {
+		if d.UserID == userID {
+			copied := *d
+			devices = append(devices, &copied)
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].LastSeenAt.After(devices[j].LastSeenAt) })
+	return devices, nil
+}
+
+func (f *FakeDeviceRepository) Trust(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if d, ok := f.devices[id]; ok && d.TrustedAt == nil {
+		now := time.Now()
+		d.Trusted = true
+		d.TrustedAt = &now
+	}
+	return nil
+}
+
+func (f *FakeDeviceRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	d, ok := f.devices[id]
+	if !ok || d.UserID != userID {
+		return false, nil
+	}
+	delete(f.devices, id)
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeFraudRepository is an in-memory repository.FraudRepository
+type FakeFraudRepository struct {
+	mu          sync.Mutex
+	assessments []*models.FraudAssessment
+}
+
+func NewFakeFraudRepository() *FakeFraudRepository {
+	return &FakeFraudRepository{}
+}
+
+func (f *FakeFraudRepository) Create(ctx context.Context, assessment *models.FraudAssessment) (*models.FraudAssessment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *assessment
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	stored.Signals = append([]models.FraudSignal(nil), assessment.Signals...)
+	f.assessments = append(f.assessments, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeFraudRepository) CountRecentByIP(ctx context.Context, check models.FraudCheck, ipAddress string, since time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, a := range f.assessments {
+		if a.Check == check && a.IPAddress != nil && *a.IPAddress == ipAddress && !a.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeFraudRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.FraudAssessment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var assessments []*models.FraudAssessment
+	for _, a := range f.assessments {
+		if a.UserID != nil && *a.UserID == userID {
+			copied := *a
+			assessments = append(assessments, &copied)
+		}
+	}
+	sort.Slice(assessments, func(i, j int) bool {
+		return assessments[i].CreatedAt.After(assessments[j].CreatedAt)
+	})
+	return assessments, nil
+}
@@ -0,0 +1,300 @@
+// Package apptest boots the full API on in-memory fakes for handler and
+// service tests that don't need a database.
+//
+//	h := apptest.New(t)
+//	admin := h.CreateUser(t, models.RoleAdmin)
+//	resp := h.Do(t, http.MethodGet, "/api/v1/admin/permissions", nil, h.TokenFor(t, admin))
+package apptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/app"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password is the plain-text password of every user created by CreateUser
+const Password = "Corr3ct-Horse!"
+
+// Repositories holds the fakes behind a Harness so tests can seed and inspect them
+type Repositories struct {
+	User         *FakeUserRepository
+	Analytics    *FakeAnalyticsRepository
+	Moderation   *FakeModerationRepository
+	Suspension   *FakeSuspensionRepository
+	Audit        *FakeAuditRepository
+	Permission   *FakePermissionRepository
+	Address      *FakeAddressRepository
+	Profile      *FakeProfileRepository
+	Consent      *FakeConsentRepository
+	Fraud        *FakeFraudRepository
+	Login        *FakeLoginRepository
+	Device       *FakeDeviceRepository
+	MagicLink    *FakeMagicLinkRepository
+	Passkey      *FakePasskeyRepository
+	SSO          *FakeSSORepository
+	Tenant       *FakeTenantRepository
+	Organization *FakeOrganizationRepository
+	OAuth        *FakeOAuthRepository
+	Notification *FakeNotificationRepository
+	Phone        *FakePhoneRepository
+	Outbox       *FakeOutboxRepository
+}
+
+func NewRepositories() *Repositories {
+	users := NewFakeUserRepository()
+	return &Repositories{
+		User:         users,
+		Analytics:    NewFakeAnalyticsRepository(),
+		Moderation:   NewFakeModerationRepository(),
+		Suspension:   NewFakeSuspensionRepository(),
+		Audit:        NewFakeAuditRepository(),
+		Permission:   NewFakePermissionRepository(),
+		Address:      NewFakeAddressRepository(),
+		Profile:      NewFakeProfileRepository(),
+		Consent:      NewFakeConsentRepository(),
+		Fraud:        NewFakeFraudRepository(),
+		Login:        NewFakeLoginRepository(),
+		Device:       NewFakeDeviceRepository(),
+		MagicLink:    NewFakeMagicLinkRepository(),
+		Passkey:      NewFakePasskeyRepository(),
+		SSO:          NewFakeSSORepository(),
+		Tenant:       NewFakeTenantRepository(),
+		Organization: NewFakeOrganizationRepository(),
+		OAuth:        NewFakeOAuthRepository(),
+		Notification: NewFakeNotificationRepository(),
+		Phone:        NewFakePhoneRepository(users),
+		Outbox:       NewFakeOutboxRepository(),
+	}
+}
+
+// App converts the fakes into the set app.NewWithRepositories expects
+func (r *Repositories) App() *app.Repositories {
+	return &app.Repositories{
+		User:         r.User,
+		Analytics:    r.Analytics,
+		Moderation:   r.Moderation,
+		Suspension:   r.Suspension,
+		Audit:        r.Audit,
+		Permission:   r.Permission,
+		Address:      r.Address,
+		Profile:      r.Profile,
+		Consent:      r.Consent,
+		Fraud:        r.Fraud,
+		Login:        r.Login,
+		Device:       r.Device,
+		MagicLink:    r.MagicLink,
+		Passkey:      r.Passkey,
+		SSO:          r.SSO,
+		Tenant:       r.Tenant,
+		Organization: r.Organization,
+		OAuth:        r.OAuth,
+		Notification: r.Notification,
+		Phone:        r.Phone,
+		Outbox:       r.Outbox,
+		Tx:           FakeTransactor{},
+	}
+}
+
+// Config returns a configuration suitable for tests: fixed JWT secret,
+// probes and the query plan guard off
+func Config() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{
+			Host:            "127.0.0.1",
+			Port:            "0",
+			ReadTimeout:     5 * time.Second,
+			WriteTimeout:    5 * time.Second,
+			ShutdownTimeout: time.Second,
+			RequestTimeout:  4 * time.Second,
+		},
+		JWT: config.JWTConfig{
+			Secret:     "apptest-secret",
+			Expiration: time.Hour,
+		},
+		AccessLog: config.AccessLogConfig{
+			SuccessSampleRate: 1,
+			MaxBodyBytes:      4096,
+		},
+		Worker: config.WorkerConfig{
+			SuspensionSweepInterval: time.Minute,
+			SuspensionBatchSize:     100,
+		},
+		MagicLink: config.MagicLinkConfig{
+			URL:          "https://marketplace.test/sign-in",
+			TTL:          15 * time.Minute,
+			MaxPerWindow: 3,
+			Window:       time.Hour,
+		},
+		WebAuthn: config.WebAuthnConfig{
+			RPID:       "marketplace.test",
+			RPName:     "RealGaming Marketplace",
+			Origins:    []string{"https://marketplace.test"},
+			SessionTTL: 5 * time.Minute,
+		},
+		OAuth: config.OAuthConfig{
+			CodeTTL:         time.Minute,
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: 24 * time.Hour,
+		},
+		Push: config.PushConfig{
+			MaxTokens: 10,
+		},
+	}
+}
+
+// Harness is the API served by an httptest server over fakes
+type Harness struct {
+	App    *app.App
+	Repos  *Repositories
+	Server *httptest.Server
+}
+
+// New boots the router with fresh fakes; the server is closed when the test ends
+func New(t testing.TB) *Harness {
+	return NewWithConfig(t, Config())
+}
+
+func NewWithConfig(t testing.TB, cfg *config.Config) *Harness {
+	t.Helper()
+
+	repos := NewRepositories()
+	application := app.NewWithRepositories(cfg, repos.App(), zerolog.Nop())
+	server := httptest.NewServer(application.Handler)
+	t.Cleanup(server.Close)
+
+	return &Harness{
+		App:    application,
+		Repos:  repos,
+		Server: server,
+	}
+}
+
+// CreateUser seeds an active user with role whose password is Password
+func (h *Harness) CreateUser(t testing.TB, role models.UserRole) *models.User {
+	t.Helper()
+
+	// MinCost keeps tests fast; login still goes through bcrypt
+	hash, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hashing password: %v", err)
+	}
+
+	return h.Repos.User.Add(&models.User{
+		Email:        uuid.NewString() + "@example.com",
+		PasswordHash: string(hash),
+		FirstName:    "Test",
+		LastName:     "User",
+		Role:         role,
+		Status:       models.StatusActive,
+	})
+}
+
+// TokenFor issues a bearer token for user without going through login
+func (h *Harness) TokenFor(t testing.TB, user *models.User) string {
+	t.Helper()
+
+	token, _, err := h.App.Services.Tokens.Issue(user.ID, user.Role)
+	if err != nil {
+		t.Fatalf("issuing token: %v", err)
+	}
+	return token
+}
+
+// Response is a decoded API response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Envelope is the standard response body written by pkg/response
+type Envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Error   json.RawMessage `json:"error"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Envelope decodes the body as a standard response envelope
+func (r *Response) Envelope(t testing.TB) Envelope {
+	t.Helper()
+
+	var envelope Envelope
+	if err := json.Unmarshal(r.Body, &envelope); err != nil {
+		t.Fatalf("decoding response envelope: %v\nbody: %s", err, r.Body)
+	}
+	return envelope
+}
+
+// Data decodes the envelope's data field into v
+func (r *Response) Data(t testing.TB, v interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal(r.Envelope(t).Data, v); err != nil {
+		t.Fatalf("decoding response data: %v\nbody: %s", err, r.Body)
+	}
+}
+
+// Do sends a JSON request to the harness server. body is marshalled unless
+// nil, and token is sent as a bearer token unless empty.
+func (h *Harness) Do(t testing.TB, method, path string, body interface{}, token string) *Response {
+	t.Helper()
+
+	return h.DoInTenant(t, "", method, path, body, token)
+}
+
+// DoInTenant is Do for the store whose slug is tenant, or the default
+// store when it is empty
+func (h *Harness) DoInTenant(t testing.TB, tenant, method, path string, body interface{}, token string) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encoding request body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, h.Server.URL+path, reader)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tenant != "" {
+		req.Header.Set(middleware.TenantHeader, tenant)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %v", err)
+	}
+
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+	}
+}
@@ -0,0 +1,156 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeLoginRepository is an in-memory repository.LoginRepository
+type FakeLoginRepository struct {
+	mu         sync.Mutex
+	locations  []*models.LoginLocation
+	challenges map[uuid.UUID]*models.LoginChallenge
+}
+
+func NewFakeLoginRepository() *FakeLoginRepository {
+	return &FakeLoginRepository{challenges: make(map[uuid.UUID]*models.LoginChallenge)}
+}
+
+func (f *FakeLoginRepository) CreateLocation(ctx context.Context, location *models.LoginLocation) (*models.LoginLocation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *location
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.locations = append(f.locations, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeLoginRepository) LastLocation(ctx context.Context, userID uuid.UUID) (*models.LoginLocation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := len(f.locations) - 1; i >= 0; i-- {
+		l := f.locations[i]
+		if l.UserID == userID && l.Verified && l.CountryCode != nil {
+			copied := *l
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeLoginRepository) CountInCountry(ctx context.Context, userID uuid.UUID, countryCode string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, l := range f.locations {
+		if l.UserID == userID && l.Verified && l.CountryCode != nil && *l.CountryCode == countryCode {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeLoginRepository) VerifyLocation(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, l := range f.locations {
+		if l.ID == id {
+			l.Verified = true
+		}
+	}
+	return nil
+}
+
+func (f *FakeLoginRepository) ListLocations(ctx context.Context, userID uuid.UUID, limit int) ([]*models.LoginLocation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	locations := []*models.LoginLocation{}
+	for _, l := range f.locations {
+		if l.UserID == userID {
+			copied := *l
+			locations = append(locations, &copied)
+		}
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].CreatedAt.After(locations[j].CreatedAt)
+	})
+	if len(locations) > limit {
+		locations = locations[:limit]
+	}
+	return locations, nil
+}
+
+func (f *FakeLoginRepository) CreateChallenge(ctx context.Context, challenge *models.LoginChallenge) (*models.LoginChallenge, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *challenge
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.challenges[stored.ID] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeLoginRepository) CountChallengesSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, c := range f.challenges {
+		if c.UserID == userID && c.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeLoginRepository) GetChallenge(ctx context.Context, id uuid.UUID) (*models.LoginChallenge, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	challenge, ok := f.challenges[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *challenge
+	return &copied, nil
+}
+
+func (f *FakeLoginRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	challenge, ok := f.challenges[id]
+	if !ok {
+		return 0, nil
+	}
+	challenge.Attempts++
+	return challenge.Attempts, nil
+}
+
+func (f *FakeLoginRepository) CompleteChallenge(ctx context.Context, id uuid.UUID, maxAttempts int) (*models.LoginChallenge, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	challenge, ok := f.challenges[id]
+	if !ok || challenge.VerifiedAt != nil || !time.Now().Before(challenge.ExpiresAt) || challenge.Attempts >= maxAttempts {
+		return nil, nil
+	}
+	now := time.Now()
+	challenge.VerifiedAt = &now
+	copied := *challenge
+	return &copied, nil
+}
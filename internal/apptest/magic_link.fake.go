@@ -0,0 +1,60 @@
+package apptest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeMagicLinkRepository is an in-memory repository.MagicLinkRepository
+type FakeMagicLinkRepository struct {
+	mu    sync.Mutex
+	links []*models.MagicLink
+}
+
+func NewFakeMagicLinkRepository() *FakeMagicLinkRepository {
+	return &FakeMagicLinkRepository{}
+}
+
+func (f *FakeMagicLinkRepository) Create(ctx context.Context, link *models.MagicLink) (*models.MagicLink, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *link
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.links = append(f.links, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeMagicLinkRepository) CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, l := range f.links {
+		if l.UserID == userID && l.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeMagicLinkRepository) Redeem(ctx context.Context, tokenHash string) (*models.MagicLink, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, l := range f.links {
+		if l.TokenHash == tokenHash && l.UsedAt == nil && time.Now().Before(l.ExpiresAt) {
+			now := time.Now()
+			l.UsedAt = &now
+			copied := *l
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
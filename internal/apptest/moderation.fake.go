@@ -0,0 +1,117 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// FakeModerationRepository is an in-memory repository.ModerationRepository.
+// Items belong to the tenant they were created in and are scoped to the
+// tenant in the context like users are.
+type FakeModerationRepository struct {
+	mu    sync.Mutex
+	items map[uuid.UUID]*models.ModerationItem
+}
+
+func NewFakeModerationRepository() *FakeModerationRepository {
+	return &FakeModerationRepository{items: make(map[uuid.UUID]*models.ModerationItem)}
+}
+
+func (f *FakeModerationRepository) Create(ctx context.Context, item *models.ModerationItem) (*models.ModerationItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *item
+	created.ID = uuid.New()
+	created.TenantID = tenancy.ID(ctx)
+	created.CreatedAt = time.Now()
+	f.items[created.ID] = &created
+	copied := created
+	return &copied, nil
+}
+
+func (f *FakeModerationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ModerationItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, ok := f.items[id]
+	if !ok || !itemInScope(ctx, item) {
+		return nil, nil
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (f *FakeModerationRepository) GetLatest(ctx context.Context, subject models.ModerationSubject, subjectID uuid.UUID) (*models.ModerationItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var latest *models.ModerationItem
+	for _, item := range f.items {
+		if item.SubjectType != subject || item.SubjectID != subjectID || !itemInScope(ctx, item) {
+			continue
+		}
+		if latest == nil || item.CreatedAt.After(latest.CreatedAt) {
+			latest = item
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	copied := *latest
+	return &copied, nil
+}
+
+func (f *FakeModerationRepository) List(ctx context.Context, status *models.ModerationStatus, subject *models.ModerationSubject, limit, offset int) ([]*models.ModerationItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var items []*models.ModerationItem
+	for _, item := range f.items {
+		if status != nil && item.Status != *status {
+			continue
+		}
+		if subject != nil && item.SubjectType != *subject {
+			continue
+		}
+		if !itemInScope(ctx, item) {
+			continue
+		}
+		copied := *item
+		items = append(items, &copied)
+	}
+
+	// Oldest first, matching ListModerationItems
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	return page(items, limit, offset), nil
+}
+
+func (f *FakeModerationRepository) Review(ctx context.Context, id uuid.UUID, status models.ModerationStatus, reason *string, reviewedBy *uuid.UUID) (*models.ModerationItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, ok := f.items[id]
+	if !ok || item.Status != models.ModerationPending || !itemInScope(ctx, item) {
+		return nil, nil
+	}
+	now := time.Now()
+	item.Status = status
+	item.RejectionReason = reason
+	item.ReviewedBy = reviewedBy
+	item.ReviewedAt = &now
+	copied := *item
+	return &copied, nil
+}
+
+func itemInScope(ctx context.Context, item *models.ModerationItem) bool {
+	scope := tenancy.ScopeID(ctx)
+	return scope == nil || *scope == item.TenantID
+}
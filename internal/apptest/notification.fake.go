@@ -0,0 +1,170 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeNotificationRepository is an in-memory repository.NotificationRepository
+type FakeNotificationRepository struct {
+	mu          sync.Mutex
+	tokens      map[uuid.UUID]*models.PushToken
+	preferences map[uuid.UUID]*models.NotificationPreferences
+	deliveries  []*models.PushDelivery
+}
+
+func NewFakeNotificationRepository() *FakeNotificationRepository {
+	return &FakeNotificationRepository{
+		tokens:      make(map[uuid.UUID]*models.PushToken),
+		preferences: make(map[uuid.UUID]*models.NotificationPreferences),
+	}
+}
+
+func (f *FakeNotificationRepository) UpsertPushToken(ctx context.Context, token *models.PushToken) (*models.PushToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range f.tokens {
+		if t.Token == token.Token {
+			t.UserID = token.UserID
+			t.Platform = token.Platform
+			t.DeviceName = token.DeviceName
+			t.UpdatedAt = now
+			copied := *t
+			return &copied, nil
+		}
+	}
+
+	stored := *token
+	stored.ID = uuid.New()
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	f.tokens[stored.ID] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeNotificationRepository) TrimPushTokens(ctx context.Context, userID uuid.UUID, keep int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens := f.userTokens(userID)
+	var trimmed int64
+	for i := keep; i < len(tokens); i++ {
+		delete(f.tokens, tokens[i].ID)
+		trimmed++
+	}
+	return trimmed, nil
+}
+
+func (f *FakeNotificationRepository) ListPushTokens(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens := []*models.PushToken{}
+	for _, t := range f.userTokens(userID) {
+		copied := *t
+		tokens = append(tokens, &copied)
+	}
+	return tokens, nil
+}
+
+func (f *FakeNotificationRepository) DeletePushToken(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t, ok := f.tokens[id]
+	if !ok || t.UserID != userID {
+		return false, nil
+	}
+	delete(f.tokens, id)
+	return true, nil
+}
+
+func (f *FakeNotificationRepository) DropPushToken(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.tokens, id)
+	return nil
+}
+
+func (f *FakeNotificationRepository) TouchPushToken(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if t, ok := f.tokens[id]; ok {
+		now := time.Now()
+		t.LastUsedAt = &now
+	}
+	return nil
+}
+
+func (f *FakeNotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prefs, ok := f.preferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *prefs
+	return &copied, nil
+}
+
+func (f *FakeNotificationRepository) UpsertPreferences(ctx context.Context, prefs *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *prefs
+	now := time.Now()
+	stored.UpdatedAt = &now
+	f.preferences[prefs.UserID] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeNotificationRepository) CreateDelivery(ctx context.Context, delivery *models.PushDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *delivery
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.deliveries = append(f.deliveries, &stored)
+	return nil
+}
+
+func (f *FakeNotificationRepository) ListDeliveries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.PushDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	deliveries := []*models.PushDelivery{}
+	// Appended oldest first, so walk backwards for newest first
+	for i := len(f.deliveries) - 1; i >= 0; i-- {
+		if f.deliveries[i].UserID == userID {
+			copied := *f.deliveries[i]
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	return page(deliveries, limit, offset), nil
+}
+
+// userTokens returns the user's tokens, most recently registered first;
+// the caller holds the lock
+func (f *FakeNotificationRepository) userTokens(userID uuid.UUID) []*models.PushToken {
+	var tokens []*models.PushToken
+	for _, t := range f.tokens {
+		if t.UserID == userID {
+			tokens = append(tokens, t)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].UpdatedAt.After(tokens[j].UpdatedAt) })
+	return tokens
+}
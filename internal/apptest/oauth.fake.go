@@ -0,0 +1,255 @@
+package apptest
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// FakeOAuthRepository is an in-memory repository.OAuthRepository
+type FakeOAuthRepository struct {
+	mu      sync.Mutex
+	clients map[uuid.UUID]*models.OAuthClient
+	grants  []*models.OAuthGrant
+	codes   map[string]*models.OAuthAuthorizationCode
+	tokens  []*models.OAuthToken
+}
+
+func NewFakeOAuthRepository() *FakeOAuthRepository {
+	return &FakeOAuthRepository{
+		clients: make(map[uuid.UUID]*models.OAuthClient),
+		codes:   make(map[string]*models.OAuthAuthorizationCode),
+	}
+}
+
+func (f *FakeOAuthRepository) CreateClient(ctx context.Context, client *models.OAuthClient) (*models.OAuthClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *client
+	stored.ID = uuid.New()
+	stored.TenantID = tenancy.ID(ctx)
+	stored.Confidential = stored.SecretHash != nil
+	stored.CreatedAt = time.Now()
+	f.clients[stored.ID] = &stored
+
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeOAuthRepository) GetClient(ctx context.Context, id uuid.UUID) (*models.OAuthClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	client, ok := f.clients[id]
+	if !ok {
+		return nil, nil
+	}
+	if scope := tenancy.ScopeID(ctx); scope != nil && *scope != client.TenantID {
+		return nil, nil
+	}
+	copied := *client
+	return &copied, nil
+}
+
+func (f *FakeOAuthRepository) ListClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenantID := tenancy.ID(ctx)
+	var clients []*models.OAuthClient
+	for _, c := range f.clients {
+		if c.TenantID == tenantID {
+			copied := *c
+			clients = append(clients, &copied)
+		}
+	}
+
+	// Oldest first, matching ListOAuthClients
+	sort.Slice(clients, func(i, j int) bool { return clients[i].CreatedAt.Before(clients[j].CreatedAt) })
+	return clients, nil
+}
+
+func (f *FakeOAuthRepository) DeleteClient(ctx context.Context, id uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	client, ok := f.clients[id]
+	if !ok || client.TenantID != tenancy.ID(ctx) {
+		return false, nil
+	}
+	delete(f.clients, id)
+
+	// The foreign keys cascade
+	f.grants = slices.DeleteFunc(f.grants, func(g *models.OAuthGrant) bool { return g.ClientID == id })
+	f.tokens = slices.DeleteFunc(f.tokens, func(t *models.OAuthToken) bool { return t.ClientID == id })
+	for hash, code := range f.codes {
+		if code.ClientID == id {
+			delete(f.codes, hash)
+		}
+	}
+	return true, nil
+}
+
+func (f *FakeOAuthRepository) GetGrant(ctx context.Context, userID, clientID uuid.UUID) (*models.OAuthGrant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if g := f.findGrant(userID, clientID); g != nil {
+		copied := *g
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *FakeOAuthRepository) SaveGrant(ctx context.Context, grant *models.OAuthGrant) (*models.OAuthGrant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if g := f.findGrant(grant.UserID, grant.ClientID); g != nil {
+		g.Scopes = grant.Scopes
+		g.UpdatedAt = now
+		copied := *g
+		return &copied, nil
+	}
+
+	stored := *grant
+	stored.ClientName = ""
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	f.grants = append(f.grants, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeOAuthRepository) ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.OAuthGrant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var grants []*models.OAuthGrant
+	for _, g := range f.grants {
+		if g.UserID == userID {
+			copied := *g
+			if client, ok := f.clients[g.ClientID]; ok {
+				copied.ClientName = client.Name
+			}
+			grants = append(grants, &copied)
+		}
+	}
+	return grants, nil
+}
+
+func (f *FakeOAuthRepository) DeleteGrant(ctx context.Context, userID, clientID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	before := len(f.grants)
+	f.grants = slices.DeleteFunc(f.grants, func(g *models.OAuthGrant) bool { return g.UserID == userID && g.ClientID == clientID })
+	return len(f.grants) < before, nil
+}
+
+func (f *FakeOAuthRepository) CreateCode(ctx context.Context, code *models.OAuthAuthorizationCode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *code
+	stored.CreatedAt = time.Now()
+	f.codes[stored.CodeHash] = &stored
+	return nil
+}
+
+func (f *FakeOAuthRepository) RedeemCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	code, ok := f.codes[codeHash]
+	if !ok || code.UsedAt != nil || !time.Now().Before(code.ExpiresAt) {
+		return nil, nil
+	}
+	now := time.Now()
+	code.UsedAt = &now
+	copied := *code
+	return &copied, nil
+}
+
+func (f *FakeOAuthRepository) CreateToken(ctx context.Context, token *models.OAuthToken) (*models.OAuthToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *token
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.tokens = append(f.tokens, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeOAuthRepository) GetToken(ctx context.Context, tokenHash string) (*models.OAuthToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, t := range f.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeOAuthRepository) RedeemRefreshToken(ctx context.Context, tokenHash string) (*models.OAuthToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, t := range f.tokens {
+		if t.TokenHash == tokenHash && t.Kind == models.OAuthRefreshToken && t.RevokedAt == nil && time.Now().Before(t.ExpiresAt) {
+			now := time.Now()
+			t.RevokedAt = &now
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeOAuthRepository) RevokeToken(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, t := range f.tokens {
+		if t.ID == id && t.RevokedAt == nil {
+			now := time.Now()
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *FakeOAuthRepository) RevokeGrantTokens(ctx context.Context, userID, clientID uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range f.tokens {
+		if t.UserID == userID && t.ClientID == clientID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *FakeOAuthRepository) findGrant(userID, clientID uuid.UUID) *models.OAuthGrant {
+	for _, g := range f.grants {
+		if g.UserID == userID && g.ClientID == clientID {
+			return g
+		}
+	}
+	return nil
+}
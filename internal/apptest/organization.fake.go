@@ -0,0 +1,170 @@
+package apptest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// FakeOrganizationRepository is an in-memory repository.OrganizationRepository.
+// Lock is a no-op; the mutex already serializes every call.
+type FakeOrganizationRepository struct {
+	mu      sync.Mutex
+	orgs    map[uuid.UUID]*models.Organization
+	members map[uuid.UUID]map[uuid.UUID]*models.OrganizationMember
+}
+
+func NewFakeOrganizationRepository() *FakeOrganizationRepository {
+	return &FakeOrganizationRepository{
+		orgs:    make(map[uuid.UUID]*models.Organization),
+		members: make(map[uuid.UUID]map[uuid.UUID]*models.OrganizationMember),
+	}
+}
+
+func (f *FakeOrganizationRepository) Create(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *org
+	stored.ID = uuid.New()
+	stored.TenantID = tenancy.ID(ctx)
+	stored.Role = ""
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	f.orgs[stored.ID] = &stored
+	f.members[stored.ID] = make(map[uuid.UUID]*models.OrganizationMember)
+
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	org, ok := f.orgs[id]
+	if !ok {
+		return nil, nil
+	}
+	if scope := tenancy.ScopeID(ctx); scope != nil && *scope != org.TenantID {
+		return nil, nil
+	}
+	copied := *org
+	return &copied, nil
+}
+
+func (f *FakeOrganizationRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (f *FakeOrganizationRepository) ListForMember(ctx context.Context, userID uuid.UUID) ([]*models.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var orgs []*models.Organization
+	for id, members := range f.members {
+		if member, ok := members[userID]; ok {
+			org := *f.orgs[id]
+			org.Role = member.Role
+			orgs = append(orgs, &org)
+		}
+	}
+
+	// Oldest first, matching ListOrganizationsByMember
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].CreatedAt.Before(orgs[j].CreatedAt)
+	})
+	return orgs, nil
+}
+
+func (f *FakeOrganizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) (*models.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	members, ok := f.members[member.OrganizationID]
+	if !ok {
+		return nil, fmt.Errorf("organization %s does not exist", member.OrganizationID)
+	}
+	if _, ok := members[member.UserID]; ok {
+		return nil, fmt.Errorf("%w: organization_members_pkey", repository.ErrConflict)
+	}
+
+	stored := *member
+	stored.CreatedAt = time.Now()
+	members[stored.UserID] = &stored
+
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeOrganizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if member, ok := f.members[orgID][userID]; ok {
+		copied := *member
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *FakeOrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	members := make([]*models.OrganizationMember, 0, len(f.members[orgID]))
+	for _, member := range f.members[orgID] {
+		copied := *member
+		members = append(members, &copied)
+	}
+
+	// Oldest first, matching ListOrganizationMembers
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+	return members, nil
+}
+
+func (f *FakeOrganizationRepository) CountOwners(ctx context.Context, orgID uuid.UUID) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	owners := 0
+	for _, member := range f.members[orgID] {
+		if member.Role == models.OrgRoleOwner {
+			owners++
+		}
+	}
+	return owners, nil
+}
+
+func (f *FakeOrganizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role models.OrganizationRole) (*models.OrganizationMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	member, ok := f.members[orgID][userID]
+	if !ok {
+		return nil, nil
+	}
+	member.Role = role
+	copied := *member
+	return &copied, nil
+}
+
+func (f *FakeOrganizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.members[orgID][userID]; !ok {
+		return false, nil
+	}
+	delete(f.members[orgID], userID)
+	return true, nil
+}
@@ -0,0 +1,138 @@
+package apptest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeOutboxRepository is an in-memory repository.OutboxRepository. Lock
+// always succeeds and Claim does not lock, so only one relay should drain
+// it at a time.
+type FakeOutboxRepository struct {
+	mu       sync.Mutex
+	messages []*models.OutboxMessage
+}
+
+func NewFakeOutboxRepository() *FakeOutboxRepository {
+	return &FakeOutboxRepository{}
+}
+
+func (f *FakeOutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) (*models.OutboxMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *message
+	created.ID = uuid.New()
+	if created.OccurredAt.IsZero() {
+		created.OccurredAt = time.Now()
+	}
+	f.messages = append(f.messages, &created)
+	copied := created
+	return &copied, nil
+}
+
+func (f *FakeOutboxRepository) Lock(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (f *FakeOutboxRepository) Claim(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var pending []*models.OutboxMessage
+	for _, message := range f.messages {
+		if message.SentAt == nil && message.DeadAt == nil {
+			copied := *message
+			pending = append(pending, &copied)
+		}
+	}
+	return page(pending, limit, 0), nil
+}
+
+func (f *FakeOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, message := range f.messages {
+		if message.ID == id {
+			now := time.Now()
+			message.SentAt = &now
+			message.LastError = nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, message := range f.messages {
+		if message.ID == id {
+			message.Attempts++
+			message.LastError = &reason
+		}
+	}
+	return nil
+}
+
+func (f *FakeOutboxRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, message := range f.messages {
+		if message.ID == id {
+			now := time.Now()
+			message.Attempts++
+			message.LastError = &reason
+			message.DeadAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *FakeOutboxRepository) Requeue(ctx context.Context, since time.Time, eventName *string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var requeued int64
+	for _, message := range f.messages {
+		if (message.SentAt == nil && message.DeadAt == nil) || message.OccurredAt.Before(since) {
+			continue
+		}
+		if eventName != nil && message.EventName != *eventName {
+			continue
+		}
+		message.SentAt = nil
+		message.DeadAt = nil
+		message.Attempts = 0
+		message.LastError = nil
+		requeued++
+	}
+	return requeued, nil
+}
+
+// Messages returns every message written so far, oldest first
+func (f *FakeOutboxRepository) Messages() []*models.OutboxMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	messages := make([]*models.OutboxMessage, len(f.messages))
+	for i, message := range f.messages {
+		copied := *message
+		messages[i] = &copied
+	}
+	return messages
+}
+
+// FakeTransactor runs fn directly. Fakes have no rollback, so writes made
+// before an error inside fn are kept.
+type FakeTransactor struct{}
+
+func (FakeTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,124 @@
+package apptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// FakePasskeyRepository is an in-memory repository.PasskeyRepository
+type FakePasskeyRepository struct {
+	mu       sync.Mutex
+	passkeys []*models.Passkey
+	sessions map[uuid.UUID]*models.WebAuthnSession
+}
+
+func NewFakePasskeyRepository() *FakePasskeyRepository {
+	return &FakePasskeyRepository{sessions: make(map[uuid.UUID]*models.WebAuthnSession)}
+}
+
+func (f *FakePasskeyRepository) Create(ctx context.Context, passkey *models.Passkey) (*models.Passkey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range f.passkeys {
+		if bytes.Equal(p.CredentialID, passkey.CredentialID) {
+			return nil, fmt.Errorf("%w: webauthn_credentials_credential_id_key", repository.ErrConflict)
+		}
+	}
+
+	stored := *passkey
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.passkeys = append(f.passkeys, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakePasskeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Passkey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	passkeys := []*models.Passkey{}
+	for _, p := range f.passkeys {
+		if p.UserID == userID {
+			copied := *p
+			passkeys = append(passkeys, &copied)
+		}
+	}
+	return passkeys, nil
+}
+
+func (f *FakePasskeyRepository) RecordUse(ctx context.Context, credentialID []byte, credential json.RawMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range f.passkeys {
+		if bytes.Equal(p.CredentialID, credentialID) {
+			now := time.Now()
+			p.Credential = credential
+			p.LastUsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *FakePasskeyRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, p := range f.passkeys {
+		if p.ID == id && p.UserID == userID {
+			f.passkeys = append(f.passkeys[:i], f.passkeys[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakePasskeyRepository) CreateSession(ctx context.Context, session *models.WebAuthnSession) (*models.WebAuthnSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *session
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.sessions[stored.ID] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakePasskeyRepository) ConsumeSession(ctx context.Context, id uuid.UUID, ceremony models.WebAuthnCeremony) (*models.WebAuthnSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	session, ok := f.sessions[id]
+	if !ok || session.Ceremony != ceremony {
+		return nil, nil
+	}
+	delete(f.sessions, id)
+	if !time.Now().Before(session.ExpiresAt) {
+		return nil, nil
+	}
+	return session, nil
+}
+
+func (f *FakePasskeyRepository) DeleteExpiredSessions(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	for id, session := range f.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(f.sessions, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakePermissionRepository is an in-memory repository.PermissionRepository
+// seeded with the same permissions and role defaults as the migrations
+type FakePermissionRepository struct {
+	mu          sync.Mutex
+	definitions map[models.Permission]string
+	roles       map[models.UserRole][]models.Permission
+	grants      map[uuid.UUID]map[models.Permission]*models.PermissionGrant
+}
+
+func NewFakePermissionRepository() *FakePermissionRepository {
+	return &FakePermissionRepository{
+		definitions: map[models.Permission]string{
+			models.PermUsersRead:         "View user accounts and their history",
+			models.PermUsersSuspend:      "Issue and lift user suspensions",
+			models.PermModerationReview:  "Approve or reject moderated content",
+			models.PermAnalyticsRead:     "View platform analytics",
+			models.PermDiagnosticsRead:   "View query plan diagnostics",
+			models.PermPermissionsManage: "Grant and revoke permissions for other admins",
+			models.PermLegalPublish:      "Publish terms of service and privacy policy versions",
+			models.PermTenantManage:      "Change the store's branding, currency and fees",
+			models.PermOAuthManage:       "Register and remove third-party OAuth apps",
+		},
+		roles: map[models.UserRole][]models.Permission{
+			models.RoleAdmin: {
+				models.PermUsersRead,
+				models.PermUsersSuspend,
+				models.PermModerationReview,
+				models.PermAnalyticsRead,
+				models.PermDiagnosticsRead,
+			},
+			models.RoleSuperAdmin: {
+				models.PermUsersRead,
+				models.PermUsersSuspend,
+				models.PermModerationReview,
+				models.PermAnalyticsRead,
+				models.PermDiagnosticsRead,
+				models.PermPermissionsManage,
+				models.PermLegalPublish,
+				models.PermTenantManage,
+				models.PermOAuthManage,
+			},
+		},
+		grants: make(map[uuid.UUID]map[models.Permission]*models.PermissionGrant),
+	}
+}
+
+// SetRolePermissions replaces the permissions role carries
+func (f *FakePermissionRepository) SetRolePermissions(role models.UserRole, permissions ...models.Permission) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.roles[role] = permissions
+}
+
+func (f *FakePermissionRepository) List(ctx context.Context) ([]*models.PermissionDefinition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	definitions := make([]*models.PermissionDefinition, 0, len(f.definitions))
+	for name, description := range f.definitions {
+		definitions = append(definitions, &models.PermissionDefinition{Name: name, Description: description})
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions, nil
+}
+
+func (f *FakePermissionRepository) Get(ctx context.Context, name models.Permission) (*models.PermissionDefinition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	description, ok := f.definitions[name]
+	if !ok {
+		return nil, nil
+	}
+	return &models.PermissionDefinition{Name: name, Description: description}, nil
+}
+
+func (f *FakePermissionRepository) ListEffective(ctx context.Context, userID uuid.UUID, role models.UserRole) ([]models.Permission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	set := make(map[models.Permission]struct{})
+	for _, permission := range f.roles[role] {
+		set[permission] = struct{}{}
+	}
+	for permission := range f.grants[userID] {
+		set[permission] = struct{}{}
+	}
+
+	permissions := make([]models.Permission, 0, len(set))
+	for permission := range set {
+		permissions = append(permissions, permission)
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i] < permissions[j]
+	})
+	return permissions, nil
+}
+
+func (f *FakePermissionRepository) ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.PermissionGrant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	grants := make([]*models.PermissionGrant, 0, len(f.grants[userID]))
+	for _, grant := range f.grants[userID] {
+		copied := *grant
+		grants = append(grants, &copied)
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		return grants[i].Permission < grants[j].Permission
+	})
+	return grants, nil
+}
+
+func (f *FakePermissionRepository) Grant(ctx context.Context, userID uuid.UUID, permission models.Permission, grantedBy *uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.grants[userID][permission]; ok {
+		return false, nil
+	}
+	if f.grants[userID] == nil {
+		f.grants[userID] = make(map[models.Permission]*models.PermissionGrant)
+	}
+	f.grants[userID][permission] = &models.PermissionGrant{
+		UserID:     userID,
+		Permission: permission,
+		GrantedBy:  grantedBy,
+		CreatedAt:  time.Now(),
+	}
+	return true, nil
+}
+
+func (f *FakePermissionRepository) Revoke(ctx context.Context, userID uuid.UUID, permission models.Permission) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.grants[userID][permission]; !ok {
+		return false, nil
+	}
+	delete(f.grants[userID], permission)
+	return true, nil
+}
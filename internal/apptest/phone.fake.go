@@ -0,0 +1,130 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakePhoneRepository is an in-memory repository.PhoneRepository. Like the
+// real one it only reports a verified phone while it matches the number
+// on the user, which it reads from users.
+type FakePhoneRepository struct {
+	mu            sync.Mutex
+	users         *FakeUserRepository
+	verifications []*models.PhoneVerification
+	verified      map[uuid.UUID]*models.VerifiedPhone
+}
+
+func NewFakePhoneRepository(users *FakeUserRepository) *FakePhoneRepository {
+	return &FakePhoneRepository{
+		users:    users,
+		verified: make(map[uuid.UUID]*models.VerifiedPhone),
+	}
+}
+
+func (f *FakePhoneRepository) CreateVerification(ctx context.Context, verification *models.PhoneVerification) (*models.PhoneVerification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *verification
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	f.verifications = append(f.verifications, &stored)
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakePhoneRepository) CountVerificationsSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, v := range f.verifications {
+		if v.UserID == userID && v.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakePhoneRepository) GetLatestVerification(ctx context.Context, userID uuid.UUID) (*models.PhoneVerification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var latest []*models.PhoneVerification
+	for _, v := range f.verifications {
+		if v.UserID == userID {
+			latest = append(latest, v)
+		}
+	}
+	if len(latest) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(latest, func(i, j int) bool { return latest[i].CreatedAt.After(latest[j].CreatedAt) })
+	copied := *latest[0]
+	return &copied, nil
+}
+
+func (f *FakePhoneRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, v := range f.verifications {
+		if v.ID == id {
+			v.Attempts++
+			return v.Attempts, nil
+		}
+	}
+	return 0, nil
+}
+
+func (f *FakePhoneRepository) CompleteVerification(ctx context.Context, id uuid.UUID, maxAttempts int) (*models.PhoneVerification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, v := range f.verifications {
+		if v.ID == id && v.VerifiedAt == nil && time.Now().Before(v.ExpiresAt) && v.Attempts < maxAttempts {
+			now := time.Now()
+			v.VerifiedAt = &now
+			copied := *v
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakePhoneRepository) SetVerified(ctx context.Context, userID uuid.UUID, phone string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.verified[userID] = &models.VerifiedPhone{
+		UserID:     userID,
+		Phone:      phone,
+		VerifiedAt: time.Now(),
+	}
+	return nil
+}
+
+func (f *FakePhoneRepository) GetVerified(ctx context.Context, userID uuid.UUID) (*models.VerifiedPhone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	verified, ok := f.verified[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	f.users.mu.Lock()
+	defer f.users.mu.Unlock()
+	user, ok := f.users.users[userID]
+	if !ok || user.Phone == nil || *user.Phone != verified.Phone {
+		return nil, nil
+	}
+	copied := *verified
+	return &copied, nil
+}
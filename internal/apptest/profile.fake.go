@@ -0,0 +1,44 @@
+package apptest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeProfileRepository is an in-memory repository.ProfileRepository
+type FakeProfileRepository struct {
+	mu          sync.Mutex
+	preferences map[uuid.UUID]*models.ProfilePreferences
+}
+
+func NewFakeProfileRepository() *FakeProfileRepository {
+	return &FakeProfileRepository{preferences: make(map[uuid.UUID]*models.ProfilePreferences)}
+}
+
+func (f *FakeProfileRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.ProfilePreferences, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prefs, ok := f.preferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *prefs
+	return &copied, nil
+}
+
+func (f *FakeProfileRepository) UpsertPreferences(ctx context.Context, prefs *models.ProfilePreferences) (*models.ProfilePreferences, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *prefs
+	now := time.Now()
+	stored.UpdatedAt = &now
+	f.preferences[stored.UserID] = &stored
+	copied := stored
+	return &copied, nil
+}
@@ -0,0 +1,90 @@
+package apptest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// FakeSSORepository is an in-memory repository.SSORepository
+type FakeSSORepository struct {
+	mu         sync.Mutex
+	states     map[string]*models.SSOLoginState
+	identities map[[2]string]*models.SSOIdentity
+}
+
+func NewFakeSSORepository() *FakeSSORepository {
+	return &FakeSSORepository{
+		states:     make(map[string]*models.SSOLoginState),
+		identities: make(map[[2]string]*models.SSOIdentity),
+	}
+}
+
+func (f *FakeSSORepository) CreateState(ctx context.Context, state *models.SSOLoginState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *state
+	stored.CreatedAt = time.Now()
+	f.states[state.StateHash] = &stored
+	return nil
+}
+
+func (f *FakeSSORepository) ConsumeState(ctx context.Context, stateHash string) (*models.SSOLoginState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, ok := f.states[stateHash]
+	if !ok || !time.Now().Before(state.ExpiresAt) {
+		return nil, nil
+	}
+	delete(f.states, stateHash)
+	return state, nil
+}
+
+func (f *FakeSSORepository) GetIdentity(ctx context.Context, issuer, subject string) (*models.SSOIdentity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if identity, ok := f.identities[[2]string{issuer, subject}]; ok {
+		copied := *identity
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *FakeSSORepository) CreateIdentity(ctx context.Context, identity *models.SSOIdentity) (*models.SSOIdentity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := [2]string{identity.Issuer, identity.Subject}
+	if _, ok := f.identities[key]; ok {
+		return nil, fmt.Errorf("%w: sso_identities_pkey", repository.ErrConflict)
+	}
+	for _, existing := range f.identities {
+		if existing.UserID == identity.UserID {
+			return nil, fmt.Errorf("%w: sso_identities_user_id_key", repository.ErrConflict)
+		}
+	}
+	stored := *identity
+	stored.CreatedAt = time.Now()
+	stored.LastLoginAt = stored.CreatedAt
+	f.identities[key] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (f *FakeSSORepository) TouchIdentity(ctx context.Context, issuer, subject, email string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if identity, ok := f.identities[[2]string{issuer, subject}]; ok {
+		identity.Email = email
+		identity.LastLoginAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package apptest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeSuspensionRepository is an in-memory repository.SuspensionRepository
+type FakeSuspensionRepository struct {
+	mu          sync.Mutex
+	suspensions []*models.Suspension
+}
+
+func NewFakeSuspensionRepository() *FakeSuspensionRepository {
+	return &FakeSuspensionRepository{}
+}
+
+func (f *FakeSuspensionRepository) Create(ctx context.Context, userID uuid.UUID, reason string, issuedBy *uuid.UUID, expiresAt *time.Time) (*models.Suspension, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	suspension := &models.Suspension{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Reason:    reason,
+		IssuedBy:  issuedBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	f.suspensions = append(f.suspensions, suspension)
+	copied := *suspension
+	return &copied, nil
+}
+
+func (f *FakeSuspensionRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) (*models.Suspension, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	var active *models.Suspension
+	for _, suspension := range f.suspensions {
+		if suspension.UserID != userID || suspension.LiftedAt != nil {
+			continue
+		}
+		if suspension.ExpiresAt != nil && !suspension.ExpiresAt.After(now) {
+			continue
+		}
+		if active == nil || suspension.CreatedAt.After(active.CreatedAt) {
+			active = suspension
+		}
+	}
+	if active == nil {
+		return nil, nil
+	}
+	copied := *active
+	return &copied, nil
+}
+
+func (f *FakeSuspensionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Suspension, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var suspensions []*models.Suspension
+	for _, suspension := range f.suspensions {
+		if suspension.UserID == userID {
+			copied := *suspension
+			suspensions = append(suspensions, &copied)
+		}
+	}
+	sort.Slice(suspensions, func(i, j int) bool {
+		return suspensions[i].CreatedAt.After(suspensions[j].CreatedAt)
+	})
+	return suspensions, nil
+}
+
+func (f *FakeSuspensionRepository) ListExpired(ctx context.Context, limit int) ([]*models.Suspension, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	var expired []*models.Suspension
+	for _, suspension := range f.suspensions {
+		if suspension.LiftedAt == nil && suspension.ExpiresAt != nil && !suspension.ExpiresAt.After(now) {
+			copied := *suspension
+			expired = append(expired, &copied)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ExpiresAt.Before(*expired[j].ExpiresAt)
+	})
+	return page(expired, limit, 0), nil
+}
+
+func (f *FakeSuspensionRepository) Lift(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, suspension := range f.suspensions {
+		if suspension.ID == id && suspension.LiftedAt == nil {
+			now := time.Now()
+			suspension.LiftedAt = &now
+			suspension.LiftedBy = liftedBy
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakeSuspensionRepository) LiftAllForUser(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	var lifted int64
+	for _, suspension := range f.suspensions {
+		if suspension.UserID != userID || suspension.LiftedAt != nil {
+			continue
+		}
+		suspension.LiftedAt = &now
+		suspension.LiftedBy = liftedBy
+		lifted++
+	}
+	return lifted, nil
+}
@@ -0,0 +1,105 @@
+package apptest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// FakeTenantRepository is an in-memory repository.TenantRepository seeded,
+// like the migration, with the default tenant
+type FakeTenantRepository struct {
+	mu        sync.Mutex
+	tenants   map[uuid.UUID]*models.Tenant
+	hostnames map[string]uuid.UUID
+}
+
+func NewFakeTenantRepository() *FakeTenantRepository {
+	f := &FakeTenantRepository{
+		tenants:   make(map[uuid.UUID]*models.Tenant),
+		hostnames: make(map[string]uuid.UUID),
+	}
+	f.Add(&models.Tenant{ID: models.DefaultTenantID, Slug: "default", Name: "RealGaming Marketplace", Currency: "USD"})
+	return f
+}
+
+// Add stores tenant, filling in an ID and timestamps when unset, and maps
+// each of hostnames to it
+func (f *FakeTenantRepository) Add(tenant *models.Tenant, hostnames ...string) *models.Tenant {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *tenant
+	if stored.ID == uuid.Nil {
+		stored.ID = uuid.New()
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = time.Now()
+		stored.UpdatedAt = stored.CreatedAt
+	}
+	f.tenants[stored.ID] = &stored
+	for _, hostname := range hostnames {
+		f.hostnames[strings.ToLower(hostname)] = stored.ID
+	}
+	copied := stored
+	return &copied
+}
+
+func (f *FakeTenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Tenant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.get(id), nil
+}
+
+func (f *FakeTenantRepository) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, tenant := range f.tenants {
+		if tenant.Slug == slug {
+			return f.get(tenant.ID), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeTenantRepository) GetByHostname(ctx context.Context, hostname string) (*models.Tenant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, ok := f.hostnames[strings.ToLower(hostname)]; ok {
+		return f.get(id), nil
+	}
+	return nil, nil
+}
+
+func (f *FakeTenantRepository) Update(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.tenants[tenant.ID]
+	if !ok {
+		return nil, nil
+	}
+	existing.Name = tenant.Name
+	existing.Branding = tenant.Branding
+	existing.Currency = tenant.Currency
+	existing.FeeBps = tenant.FeeBps
+	existing.UpdatedAt = time.Now()
+	return f.get(existing.ID), nil
+}
+
+// get copies the tenant with id, or returns nil, for callers holding f.mu
+func (f *FakeTenantRepository) get(id uuid.UUID) *models.Tenant {
+	tenant, ok := f.tenants[id]
+	if !ok {
+		return nil
+	}
+	copied := *tenant
+	return &copied
+}
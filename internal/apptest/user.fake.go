@@ -0,0 +1,253 @@
+package apptest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// FakeUserRepository is an in-memory repository.UserRepository. Like the
+// real one it returns nil, nil for missing users on reads and
+// sql.ErrNoRows when updating a user that doesn't exist, and it scopes
+// lookups to the tenant in the context the same way.
+type FakeUserRepository struct {
+	mu    sync.Mutex
+	users map[uuid.UUID]*models.User
+}
+
+func NewFakeUserRepository() *FakeUserRepository {
+	return &FakeUserRepository{users: make(map[uuid.UUID]*models.User)}
+}
+
+// Add stores user as-is, filling in an ID, tenant and timestamps when unset
+func (f *FakeUserRepository) Add(user *models.User) *models.User {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.add(user)
+}
+
+// add is Add for callers already holding f.mu
+func (f *FakeUserRepository) add(user *models.User) *models.User {
+	stored := *user
+	if stored.ID == uuid.Nil {
+		stored.ID = uuid.New()
+	}
+	if stored.Status == "" {
+		stored.Status = models.StatusActive
+	}
+	if stored.TenantID == uuid.Nil {
+		stored.TenantID = models.DefaultTenantID
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = time.Now()
+		stored.UpdatedAt = stored.CreatedAt
+	}
+	f.users[stored.ID] = &stored
+	return copyUser(&stored)
+}
+
+func (f *FakeUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenantID := tenancy.ID(ctx)
+	if err := f.conflict(user, tenantID); err != nil {
+		return nil, err
+	}
+
+	created := *user
+	created.ID = uuid.Nil
+	created.TenantID = tenantID
+	if created.Status == "" {
+		created.Status = models.StatusActive
+	}
+	return f.add(&created), nil
+}
+
+func (f *FakeUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenantID := tenancy.ID(ctx)
+	for _, user := range f.users {
+		if user.TenantID == tenantID && user.Email == email {
+			return copyUser(user), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeUserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenantID := tenancy.ID(ctx)
+	for _, user := range f.users {
+		if user.TenantID == tenantID && user.Username != nil && strings.EqualFold(*user.Username, username) {
+			return copyUser(user), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, ok := f.users[id]; ok && inScope(ctx, user) {
+		return copyUser(user), nil
+	}
+	return nil, nil
+}
+
+func (f *FakeUserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var users []*models.User
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range ids {
+		if user, ok := f.users[id]; ok && inScope(ctx, user) && !seen[id] {
+			seen[id] = true
+			users = append(users, copyUser(user))
+		}
+	}
+	return users, nil
+}
+
+func (f *FakeUserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.users[user.ID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if err := f.conflict(user, existing.TenantID); err != nil {
+		return nil, err
+	}
+	existing.FirstName = user.FirstName
+	existing.LastName = user.LastName
+	existing.Phone = user.Phone
+	existing.AvatarURL = user.AvatarURL
+	existing.Username = user.Username
+	existing.UpdatedAt = time.Now()
+	return copyUser(existing), nil
+}
+
+func (f *FakeUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.UserStatus) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, ok := f.users[id]; ok {
+		user.Status = status
+	}
+	return nil
+}
+
+func (f *FakeUserRepository) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, ok := f.users[id]; ok {
+		user.AvatarURL = avatarURL
+	}
+	return nil
+}
+
+func (f *FakeUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, ok := f.users[id]; ok {
+		user.Role = role
+	}
+	return nil
+}
+
+func (f *FakeUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, ok := f.users[id]; ok {
+		user.PasswordHash = passwordHash
+	}
+	return nil
+}
+
+func (f *FakeUserRepository) List(ctx context.Context, role *models.UserRole, status *models.UserStatus, limit, offset int) ([]*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenantID := tenancy.ID(ctx)
+	var users []*models.User
+	for _, user := range f.users {
+		if user.TenantID != tenantID {
+			continue
+		}
+		if role != nil && user.Role != *role {
+			continue
+		}
+		if status != nil && user.Status != *status {
+			continue
+		}
+		users = append(users, copyUser(user))
+	}
+
+	// Newest first, matching ListUsers
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].CreatedAt.After(users[j].CreatedAt)
+	})
+	return page(users, limit, offset), nil
+}
+
+// conflict mirrors the unique indexes on email and LOWER(username) within
+// tenantID for callers holding f.mu
+func (f *FakeUserRepository) conflict(user *models.User, tenantID uuid.UUID) error {
+	for _, existing := range f.users {
+		if existing.ID == user.ID || existing.TenantID != tenantID {
+			continue
+		}
+		if existing.Email == user.Email {
+			return fmt.Errorf("%w: users_tenant_email_key", repository.ErrConflict)
+		}
+		if existing.Username != nil && user.Username != nil && strings.EqualFold(*existing.Username, *user.Username) {
+			return fmt.Errorf("%w: users_tenant_username_lower_key", repository.ErrConflict)
+		}
+	}
+	return nil
+}
+
+// inScope reports whether a lookup by ID may see user: any tenant outside
+// a request, only the request's own inside one
+func inScope(ctx context.Context, user *models.User) bool {
+	scope := tenancy.ScopeID(ctx)
+	return scope == nil || *scope == user.TenantID
+}
+
+func copyUser(user *models.User) *models.User {
+	copied := *user
+	return &copied
+}
+
+// page applies LIMIT/OFFSET semantics to an already ordered slice
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
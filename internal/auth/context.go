@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// Principal is the authenticated caller attached to the request context.
+// ClientID is set when a third-party app calls for the user with an OAuth
+// access token, which only reaches the routes its Scopes cover.
+type Principal struct {
+	UserID   uuid.UUID
+	Role     models.UserRole
+	ClientID *uuid.UUID
+	Scopes   []models.OAuthScope
+}
+
+// HasScope reports whether the caller may use scope. The user's own
+// sessions hold every scope.
+func (p *Principal) HasScope(scope models.OAuthScope) bool {
+	return p.ClientID == nil || slices.Contains(p.Scopes, scope)
+}
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, or nil on public routes
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
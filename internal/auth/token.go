@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the JWT claims issued on login
+type Claims struct {
+	Role models.UserRole `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// UserID returns the authenticated user's ID from the subject claim
+func (c *Claims) UserID() (uuid.UUID, error) {
+	return uuid.Parse(c.Subject)
+}
+
+// TokenManager issues and verifies HMAC-signed access tokens
+type TokenManager struct {
+	secret     []byte
+	expiration time.Duration
+}
+
+func NewTokenManager(secret string, expiration time.Duration) *TokenManager {
+	return &TokenManager{
+		secret:     []byte(secret),
+		expiration: expiration,
+	}
+}
+
+// Issue signs a token for the user and returns it with its expiry
+func (m *TokenManager) Issue(userID uuid.UUID, role models.UserRole) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.expiration)
+
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error signing token: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
+
+// Parse verifies the signature and expiry of an access token
+func (m *TokenManager) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,177 @@
+// Package backup takes full database dumps with pg_dump, keeps them in a
+// Store and restores them with pg_restore
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+)
+
+// fileSuffix marks pg_dump custom-format archives; Store entries without it
+// are left alone
+const fileSuffix = ".dump"
+
+// nameLayout is the UTC time in a backup's name, so names sort by age
+const nameLayout = "20060102T150405Z"
+
+// ErrNotFound is returned by Store.Open for a backup that doesn't exist
+var ErrNotFound = errors.New("backup not found")
+
+// Object is a stored backup
+type Object struct {
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// Store keeps backups somewhere other than the database they came from
+type Store interface {
+	Put(ctx context.Context, name string, r io.Reader) error
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns everything stored, in any order
+	List(ctx context.Context) ([]Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// Manager dumps the database into a Store and prunes what is past retention
+type Manager struct {
+	db        config.DatabaseConfig
+	store     Store
+	retention time.Duration
+	prefix    string
+}
+
+// NewManager keeps backups of database in store for retention. prefix
+// starts every name, so stores can be shared between databases.
+func NewManager(database config.DatabaseConfig, store Store, retention time.Duration, prefix string) *Manager {
+	return &Manager{
+		db:        database,
+		store:     store,
+		retention: retention,
+		prefix:    prefix,
+	}
+}
+
+// Backup dumps the database and stores it, returning its name
+func (m *Manager) Backup(ctx context.Context) (string, error) {
+	name := m.prefix + time.Now().UTC().Format(nameLayout) + fileSuffix
+
+	// pg_dump writes into the pipe while the store reads from it, so the
+	// dump is never held in memory or on local disk
+	reader, writer := io.Pipe()
+	cmd := m.command(ctx, "pg_dump", "--format=custom", "--no-owner", "--no-privileges")
+	cmd.Stdout = writer
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("starting pg_dump: %w", err)
+	}
+	go func() {
+		err := cmd.Wait()
+		if err != nil {
+			err = fmt.Errorf("pg_dump: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		writer.CloseWithError(err)
+	}()
+
+	if err := m.store.Put(ctx, name, reader); err != nil {
+		// Unblocks pg_dump if the store gave up part way
+		reader.CloseWithError(err)
+		return "", fmt.Errorf("storing backup: %w", err)
+	}
+	return name, nil
+}
+
+// Latest returns the newest backup, or nil when there is none
+func (m *Manager) Latest(ctx context.Context) (*Object, error) {
+	backups, err := m.List(ctx)
+	if err != nil || len(backups) == 0 {
+		return nil, err
+	}
+	return &backups[len(backups)-1], nil
+}
+
+// List returns this database's backups, oldest first
+func (m *Manager) List(ctx context.Context) ([]Object, error) {
+	objects, err := m.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing backups: %w", err)
+	}
+
+	var backups []Object
+	for _, object := range objects {
+		if strings.HasPrefix(object.Name, m.prefix) && strings.HasSuffix(object.Name, fileSuffix) {
+			backups = append(backups, object)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name < backups[j].Name })
+	return backups, nil
+}
+
+// Prune deletes backups older than the retention period and returns their
+// names. The newest backup is always kept, however old.
+func (m *Manager) Prune(ctx context.Context) ([]string, error) {
+	backups, err := m.List(ctx)
+	if err != nil || len(backups) == 0 {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-m.retention)
+	var deleted []string
+	for _, object := range backups[:len(backups)-1] {
+		if object.CreatedAt.After(cutoff) {
+			continue
+		}
+		if err := m.store.Delete(ctx, object.Name); err != nil {
+			return deleted, fmt.Errorf("deleting backup %s: %w", object.Name, err)
+		}
+		deleted = append(deleted, object.Name)
+	}
+	return deleted, nil
+}
+
+// Restore replaces the database's contents with the backup name. Objects
+// in the backup are dropped and recreated; anything created since is left.
+func (m *Manager) Restore(ctx context.Context, name string) error {
+	if !strings.HasSuffix(name, fileSuffix) {
+		return fmt.Errorf("%s is not a backup", name)
+	}
+	archive, err := m.store.Open(ctx, name)
+	if err != nil {
+		return fmt.Errorf("opening backup: %w", err)
+	}
+	defer archive.Close()
+
+	cmd := m.command(ctx, "pg_restore", "--clean", "--if-exists", "--no-owner", "--no-privileges", "--single-transaction", "--dbname="+m.db.DBName)
+	cmd.Stdin = archive
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_restore: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// command runs a PostgreSQL client tool against the database. Connection
+// settings go in the environment so the password stays off the process list.
+func (m *Manager) command(ctx context.Context, tool string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, tool, args...)
+	cmd.Env = append(os.Environ(),
+		"PGHOST="+m.db.Host,
+		"PGPORT="+m.db.Port,
+		"PGUSER="+m.db.User,
+		"PGPASSWORD="+m.db.Password,
+		"PGDATABASE="+m.db.DBName,
+		"PGSSLMODE="+m.db.SSLMode,
+	)
+	return cmd
+}
@@ -0,0 +1,55 @@
+// Package buildinfo reports what binary is running. GitSHA and BuildTime are
+// injected at build time:
+//
+//	go build -ldflags "-X .../internal/buildinfo.GitSHA=$(git rev-parse HEAD) -X .../internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	GitSHA    = ""
+	BuildTime = ""
+)
+
+type Info struct {
+	GitSHA    string `json:"git_sha"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	// Modified is set when the binary was built from a dirty work tree
+	Modified bool `json:"modified"`
+}
+
+// Get returns the injected values, falling back to the VCS stamp the Go
+// toolchain embeds when ldflags weren't set
+func Get() Info {
+	info := Info{
+		GitSHA:    GitSHA,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.GitSHA == "" {
+					info.GitSHA = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if info.GitSHA == "" {
+		info.GitSHA = "unknown"
+	}
+	return info
+}
@@ -2,14 +2,42 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	Diagnostics DiagnosticsConfig
+	Probe       ProbeConfig
+	Moderation  ModerationConfig
+	Worker      WorkerConfig
+	Debug       DebugConfig
+	AccessLog   AccessLogConfig
+	Events      EventsConfig
+	Streaming   StreamingConfig
+	Compression CompressionConfig
+	TLS         TLSConfig
+	Fraud       FraudConfig
+	GeoIP       GeoIPConfig
+	Login       LoginConfig
+	MagicLink   MagicLinkConfig
+	WebAuthn    WebAuthnConfig
+	Captcha     CaptchaConfig
+	Tenancy     TenancyConfig
+	OAuth       OAuthConfig
+	SSO         SSOConfig
+	Backup      BackupConfig
+	Retention   RetentionConfig
+	Partitions  PartitionConfig
+	Push        PushConfig
+	SMS         SMSConfig
+	Mail        MailConfig
 }
 
 type ServerConfig struct {
@@ -17,6 +45,15 @@ type ServerConfig struct {
 	Host         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// ShutdownTimeout is how long each component gets to stop on shutdown
+	ShutdownTimeout time.Duration
+	// RequestTimeout bounds each handler, answering 504 when it is reached;
+	// RouteTimeouts overrides it by "METHOD /path/template" or path template
+	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration
+	// TrustedProxies are the load balancers and proxies in front of the
+	// server, whose X-Forwarded-For is believed when finding a client's IP
+	TrustedProxies []*net.IPNet
 }
 
 type DatabaseConfig struct {
@@ -33,13 +70,329 @@ type JWTConfig struct {
 	Expiration time.Duration
 }
 
+type DiagnosticsConfig struct {
+	// QueryPlanGuard EXPLAINs hot queries and flags plan regressions; meant for staging
+	QueryPlanGuard    bool
+	HotQueryThreshold int
+	DeployVersion     string
+	// SlowQueryThreshold logs statements at or above it; zero disables
+	SlowQueryThreshold time.Duration
+}
+
+type AccessLogConfig struct {
+	// SuccessSampleRate is the fraction of non-error requests logged
+	SuccessSampleRate float64
+	// LogBodies logs redacted headers and JSON bodies; meant for debugging
+	LogBodies    bool
+	MaxBodyBytes int
+}
+
+// CompressionConfig compresses responses in the listed route groups
+// (public, me, admin) once they reach MinSize bytes
+type CompressionConfig struct {
+	Groups       []string
+	MinSize      int
+	ContentTypes []string
+}
+
+// TLSConfig terminates TLS in the server itself, serving HTTP/2, for
+// deployments without a fronting proxy. Either a certificate and key or
+// autocert domains enable it.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// AutocertDomains obtains and renews certificates for these hosts from
+	// Let's Encrypt, cached in AutocertCacheDir
+	AutocertDomains  []string
+	AutocertCacheDir string
+	AutocertEmail    string
+	// RedirectAddr, when set, listens for plain HTTP and redirects it to
+	// HTTPS; it also answers ACME HTTP-01 challenges
+	RedirectAddr string
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || len(c.AutocertDomains) > 0
+}
+
+type EventsConfig struct {
+	// Persist stores every published domain event so subscribers can be replayed
+	Persist bool
+}
+
+// StreamingConfig mirrors domain events to Kafka or NATS JetStream when
+// Broker is set
+type StreamingConfig struct {
+	Broker string
+	// URLs are NATS server URLs or Kafka bootstrap brokers
+	URLs        []string
+	TopicPrefix string
+	// Topics overrides the topic of individual events
+	Topics map[string]string
+	// NATSStream is the JetStream stream that captures every topic
+	NATSStream    string
+	ConsumerGroup string
+}
+
+// DebugConfig gates pprof, expvar and build info on a separate listener
+type DebugConfig struct {
+	Enabled bool
+	// Addr should stay on a private interface; profiles expose internals
+	Addr string
+}
+
+type WorkerConfig struct {
+	SuspensionSweepInterval time.Duration
+	SuspensionBatchSize     int
+	// OutboxRelayInterval bounds how long a committed event waits for delivery
+	OutboxRelayInterval time.Duration
+	OutboxBatchSize     int
+	// OutboxMaxAttempts parks a message after that many failed deliveries
+	// so it stops holding up the rest; 0 retries forever
+	OutboxMaxAttempts int
+}
+
+// BackupConfig schedules pg_dump backups in the worker
+type BackupConfig struct {
+	// Dir enables backups: the directory they are written to, ideally a
+	// volume replicated off the host
+	Dir string
+	// Interval is the time between backups; the worker skips a run when
+	// the newest backup is younger, so restarts don't take extra ones
+	Interval time.Duration
+	// Retention is how long backups are kept. The newest is kept anyway.
+	Retention time.Duration
+	// Prefix starts every backup's name, so databases can share Dir
+	Prefix string
+}
+
+// RetentionConfig is how long the worker keeps each kind of data; a zero
+// age keeps it forever
+type RetentionConfig struct {
+	Interval time.Duration
+	// BatchSize rows are purged per statement, with BatchPause between
+	// statements so a backlog doesn't hog the database
+	BatchSize  int
+	BatchPause time.Duration
+	// Events is how long persisted domain events are kept
+	Events time.Duration
+	// Outbox is how long delivered outbox messages are kept
+	Outbox time.Duration
+	// AuditIPs is how long audit log entries keep the actor's IP address
+	AuditIPs time.Duration
+	// PushDeliveries is how long the record of each push is kept
+	PushDeliveries time.Duration
+	// LoginLocations is how long sign-in locations, and the challenges
+	// raised for them, are kept. New-country checks only see what is kept.
+	LoginLocations time.Duration
+	// ExpiredCodes is how long magic links, login challenges, phone
+	// verification codes and OAuth authorization codes are kept once they
+	// expire; at least the magic link, SMS code and login challenge
+	// windows, which count them
+	ExpiredCodes time.Duration
+	// ExpiredOAuthTokens is how long OAuth tokens are kept once they expire
+	ExpiredOAuthTokens time.Duration
+}
+
+// PartitionConfig schedules upkeep of the tables partitioned by month
+type PartitionConfig struct {
+	Interval time.Duration
+	// MonthsAhead is how many months after the current one have their
+	// partitions created in advance
+	MonthsAhead int
+}
+
+// PushConfig sends notifications to the mobile apps. Each platform is
+// enabled by its credentials; tokens for a disabled platform are still
+// registered but nothing is pushed to them.
+type PushConfig struct {
+	// FCMCredentialsFile is a Firebase service account key, as JSON
+	FCMCredentialsFile string
+	// APNSKeyFile is an APNs authentication key (.p8) from the Apple
+	// developer account, with its KeyID and the account's TeamID. Topic
+	// is the app's bundle ID.
+	APNSKeyFile string
+	APNSKeyID   string
+	APNSTeamID  string
+	APNSTopic   string
+	// APNSSandbox pushes to development builds of the app
+	APNSSandbox bool
+	Timeout     time.Duration
+	// MaxTokens is how many app installs one user may register; the
+	// least recently registered go first
+	MaxTokens int
+}
+
+// SMSConfig texts phone verification codes and urgent notifications
+// through Twilio
+type SMSConfig struct {
+	// TwilioAccountSID enables SMS
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	// From is the Twilio number or sender ID texts come from;
+	// Senders overrides it by number prefix, such as "+44"
+	From    string
+	Senders map[string]string
+	// AllowedPrefixes limits texts to numbers starting with one of them,
+	// such as "+1" or "+44"; empty allows any
+	AllowedPrefixes []string
+	CodeTTL         time.Duration
+	// CodeMaxPerWindow verification codes go to one account per
+	// CodeWindow; 0 means no limit
+	CodeMaxPerWindow int
+	CodeWindow       time.Duration
+	Timeout          time.Duration
+}
+
+// MailConfig emails notifications through an SMTP relay. Without one they
+// are only logged, so nothing that must reach the user's inbox, like
+// sign-in links and codes, is offered.
+type MailConfig struct {
+	// SMTPHost enables email
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	Timeout      time.Duration
+}
+
+type ModerationConfig struct {
+	// BannedWords enables the word-list pre-screen when non-empty
+	BannedWords []string
+}
+
+type FraudConfig struct {
+	// Enabled runs the signup risk checks
+	Enabled bool
+	// Signups scoring ReviewScore or more wait for an admin; BlockScore or
+	// more are refused
+	ReviewScore int
+	BlockScore  int
+	// VelocityLimit is how many signups one IP may attempt per VelocityWindow
+	VelocityLimit  int
+	VelocityWindow time.Duration
+	// DisposableDomains extends the built-in list of throwaway email domains
+	DisposableDomains []string
+}
+
+type GeoIPConfig struct {
+	// Provider enables IP geolocation; only "ip-api" is supported
+	Provider string
+	URL      string
+	Timeout  time.Duration
+}
+
+type LoginConfig struct {
+	// MaxTravelSpeed, in km/h, flags logins too far from the previous one
+	MaxTravelSpeed float64
+	// StepUp sends suspicious logins a one-time code instead of a token,
+	// except from devices the user chose to trust. The code is emailed, so
+	// it needs SMTP.
+	StepUp       bool
+	ChallengeTTL time.Duration
+	// MaxChallengesPerWindow step-up codes go to one account per
+	// ChallengeWindow; 0 means no limit
+	MaxChallengesPerWindow int
+	ChallengeWindow        time.Duration
+}
+
+type MagicLinkConfig struct {
+	// URL is where sign-in links point, usually a frontend page that hands
+	// the token to the verify endpoint; defaults to that endpoint itself
+	URL string
+	TTL time.Duration
+	// MaxPerWindow sign-in links go to one account per Window; 0 means no limit
+	MaxPerWindow int
+	Window       time.Duration
+}
+
+type WebAuthnConfig struct {
+	// RPID enables passkeys: the domain credentials are bound to, which
+	// every origin must be on or under
+	RPID   string
+	RPName string
+	// Origins lists the exact origins, such as https://app.example.com,
+	// that browsers may run the ceremonies from
+	Origins []string
+	// SessionTTL is how long a begin step waits for its finish step
+	SessionTTL time.Duration
+}
+
+type CaptchaConfig struct {
+	// Provider enables CAPTCHA checks: hcaptcha, recaptcha or turnstile
+	Provider string
+	Secret   string
+	// VerifyURL overrides the provider's siteverify endpoint
+	VerifyURL string
+	Timeout   time.Duration
+	// Signup challenges every signup; the synthetic probe skips its signup
+	// flow while it is on, so its account must be created beforehand
+	Signup bool
+	// LoginFailures is how many failed logins a client may have within
+	// FailureWindow before login is challenged; 0 never challenges it
+	LoginFailures int
+	FailureWindow time.Duration
+}
+
+type TenancyConfig struct {
+	// CacheTTL is how long a resolved hostname or slug is remembered,
+	// including misses; 0 looks every request up
+	CacheTTL time.Duration
+}
+
+type OAuthConfig struct {
+	// CodeTTL is how long an authorization code can be exchanged
+	CodeTTL time.Duration
+	// AccessTokenTTL and RefreshTokenTTL bound what third-party apps are
+	// issued; refresh tokens rotate on every use
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// SSOConfig signs admin staff in through an OpenID Connect provider
+type SSOConfig struct {
+	// Issuer enables SSO: the provider's issuer URL, which discovery runs against
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is registered with the provider; it is the callback
+	// endpoint or an admin console page that hands the query to it
+	RedirectURL string
+	Scopes      []string
+	// GroupsClaim names the ID token claim listing the user's groups, and
+	// GroupRoles maps those groups to admin or su-admin
+	GroupsClaim string
+	GroupRoles  map[string]string
+	// Required stops admin accounts logging in with a password, magic
+	// link or passkey
+	Required bool
+	StateTTL time.Duration
+	Timeout  time.Duration
+}
+
+type ProbeConfig struct {
+	Enabled  bool
+	BaseURL  string
+	Interval time.Duration
+	Timeout  time.Duration
+	// Tenant is the slug of the store the probe account lives in, kept
+	// apart from real stores so it never shows up in their users, reports
+	// or moderation queues
+	Tenant   string
+	Email    string
+	Password string
+}
+
 func Load() (*Config, error) {
 	cfg := &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "8080"),
-			Host:         getEnv("SERVER_HOST", "localhost"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", "30s"),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", "30s"),
+			Port:            getEnv("SERVER_PORT", "8080"),
+			Host:            getEnv("SERVER_HOST", "localhost"),
+			ReadTimeout:     getDurationEnv("SERVER_READ_TIMEOUT", "30s"),
+			WriteTimeout:    getDurationEnv("SERVER_WRITE_TIMEOUT", "30s"),
+			ShutdownTimeout: getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", "10s"),
+			RequestTimeout:  getDurationEnv("SERVER_REQUEST_TIMEOUT", "25s"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -53,6 +406,417 @@ func Load() (*Config, error) {
 			Secret:     getEnv("JWT_SECRET", "your-secret-key"),
 			Expiration: getDurationEnv("JWT_EXPIRATION", "24h"),
 		},
+		Diagnostics: DiagnosticsConfig{
+			QueryPlanGuard:     getBoolEnv("QUERY_PLAN_GUARD_ENABLED", false),
+			HotQueryThreshold:  getIntEnv("QUERY_PLAN_HOT_THRESHOLD", 100),
+			DeployVersion:      getEnv("APP_VERSION", "dev"),
+			SlowQueryThreshold: getDurationEnv("DB_SLOW_QUERY_THRESHOLD", "200ms"),
+		},
+		Probe: ProbeConfig{
+			Enabled:  getBoolEnv("PROBE_ENABLED", false),
+			BaseURL:  getEnv("PROBE_BASE_URL", ""),
+			Interval: getDurationEnv("PROBE_INTERVAL", "1m"),
+			Timeout:  getDurationEnv("PROBE_TIMEOUT", "10s"),
+			Tenant:   getEnv("PROBE_TENANT", ""),
+			Email:    getEnv("PROBE_EMAIL", ""),
+			Password: getEnv("PROBE_PASSWORD", ""),
+		},
+		Moderation: ModerationConfig{
+			BannedWords: getListEnv("MODERATION_BANNED_WORDS"),
+		},
+		Worker: WorkerConfig{
+			SuspensionSweepInterval: getDurationEnv("WORKER_SUSPENSION_SWEEP_INTERVAL", "1m"),
+			SuspensionBatchSize:     getIntEnv("WORKER_SUSPENSION_BATCH_SIZE", 100),
+			OutboxRelayInterval:     getDurationEnv("WORKER_OUTBOX_RELAY_INTERVAL", "1s"),
+			OutboxBatchSize:         getIntEnv("WORKER_OUTBOX_BATCH_SIZE", 100),
+			OutboxMaxAttempts:       getIntEnv("WORKER_OUTBOX_MAX_ATTEMPTS", 20),
+		},
+		AccessLog: AccessLogConfig{
+			SuccessSampleRate: getFloatEnv("ACCESS_LOG_SAMPLE_RATE", 1),
+			LogBodies:         getBoolEnv("ACCESS_LOG_BODIES", false),
+			MaxBodyBytes:      getIntEnv("ACCESS_LOG_MAX_BODY_BYTES", 4096),
+		},
+		Debug: DebugConfig{
+			Enabled: getBoolEnv("DEBUG_ENABLED", false),
+			Addr:    getEnv("DEBUG_ADDR", "localhost:6060"),
+		},
+		Events: EventsConfig{
+			Persist: getBoolEnv("EVENTS_PERSIST", false),
+		},
+		Compression: CompressionConfig{
+			Groups:       getListEnv("COMPRESSION_GROUPS"),
+			MinSize:      getIntEnv("COMPRESSION_MIN_SIZE", 1024),
+			ContentTypes: getListEnv("COMPRESSION_CONTENT_TYPES"),
+		},
+		TLS: TLSConfig{
+			CertFile:         getEnv("TLS_CERT_FILE", ""),
+			KeyFile:          getEnv("TLS_KEY_FILE", ""),
+			AutocertDomains:  getListEnv("TLS_AUTOCERT_DOMAINS"),
+			AutocertCacheDir: getEnv("TLS_AUTOCERT_CACHE_DIR", "autocert"),
+			AutocertEmail:    getEnv("TLS_AUTOCERT_EMAIL", ""),
+			RedirectAddr:     getEnv("TLS_REDIRECT_ADDR", ""),
+		},
+		Fraud: FraudConfig{
+			Enabled:           getBoolEnv("FRAUD_ENABLED", false),
+			ReviewScore:       getIntEnv("FRAUD_REVIEW_SCORE", 50),
+			BlockScore:        getIntEnv("FRAUD_BLOCK_SCORE", 80),
+			VelocityLimit:     getIntEnv("FRAUD_SIGNUP_VELOCITY_LIMIT", 3),
+			VelocityWindow:    getDurationEnv("FRAUD_SIGNUP_VELOCITY_WINDOW", "1h"),
+			DisposableDomains: getListEnv("FRAUD_DISPOSABLE_DOMAINS"),
+		},
+		GeoIP: GeoIPConfig{
+			Provider: getEnv("GEOIP_PROVIDER", ""),
+			URL:      getEnv("GEOIP_URL", "http://ip-api.com"),
+			Timeout:  getDurationEnv("GEOIP_TIMEOUT", "2s"),
+		},
+		Login: LoginConfig{
+			MaxTravelSpeed:         getFloatEnv("LOGIN_MAX_TRAVEL_SPEED", 1000),
+			StepUp:                 getBoolEnv("LOGIN_STEP_UP", false),
+			ChallengeTTL:           getDurationEnv("LOGIN_CHALLENGE_TTL", "10m"),
+			MaxChallengesPerWindow: getIntEnv("LOGIN_MAX_CHALLENGES_PER_WINDOW", 5),
+			ChallengeWindow:        getDurationEnv("LOGIN_CHALLENGE_WINDOW", "1h"),
+		},
+		MagicLink: MagicLinkConfig{
+			URL:          getEnv("MAGIC_LINK_URL", ""),
+			TTL:          getDurationEnv("MAGIC_LINK_TTL", "15m"),
+			MaxPerWindow: getIntEnv("MAGIC_LINK_MAX_PER_WINDOW", 3),
+			Window:       getDurationEnv("MAGIC_LINK_WINDOW", "1h"),
+		},
+		WebAuthn: WebAuthnConfig{
+			RPID:       getEnv("WEBAUTHN_RP_ID", ""),
+			RPName:     getEnv("WEBAUTHN_RP_NAME", "RealGaming Marketplace"),
+			Origins:    getListEnv("WEBAUTHN_RP_ORIGINS"),
+			SessionTTL: getDurationEnv("WEBAUTHN_SESSION_TTL", "5m"),
+		},
+		Captcha: CaptchaConfig{
+			Provider:      getEnv("CAPTCHA_PROVIDER", ""),
+			Secret:        getEnv("CAPTCHA_SECRET", ""),
+			VerifyURL:     getEnv("CAPTCHA_VERIFY_URL", ""),
+			Timeout:       getDurationEnv("CAPTCHA_TIMEOUT", "5s"),
+			Signup:        getBoolEnv("CAPTCHA_SIGNUP", true),
+			LoginFailures: getIntEnv("CAPTCHA_LOGIN_FAILURES", 3),
+			FailureWindow: getDurationEnv("CAPTCHA_FAILURE_WINDOW", "15m"),
+		},
+		Tenancy: TenancyConfig{
+			CacheTTL: getDurationEnv("TENANT_CACHE_TTL", "1m"),
+		},
+		OAuth: OAuthConfig{
+			CodeTTL:         getDurationEnv("OAUTH_CODE_TTL", "1m"),
+			AccessTokenTTL:  getDurationEnv("OAUTH_ACCESS_TOKEN_TTL", "1h"),
+			RefreshTokenTTL: getDurationEnv("OAUTH_REFRESH_TOKEN_TTL", "720h"),
+		},
+		SSO: SSOConfig{
+			Issuer:       getEnv("SSO_ISSUER", ""),
+			ClientID:     getEnv("SSO_CLIENT_ID", ""),
+			ClientSecret: getEnv("SSO_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("SSO_REDIRECT_URL", ""),
+			Scopes:       getListEnv("SSO_SCOPES"),
+			GroupsClaim:  getEnv("SSO_GROUPS_CLAIM", "groups"),
+			Required:     getBoolEnv("SSO_REQUIRED", false),
+			StateTTL:     getDurationEnv("SSO_STATE_TTL", "10m"),
+			Timeout:      getDurationEnv("SSO_TIMEOUT", "10s"),
+		},
+		Backup: BackupConfig{
+			Dir:       getEnv("BACKUP_DIR", ""),
+			Interval:  getDurationEnv("BACKUP_INTERVAL", "24h"),
+			Retention: getDurationEnv("BACKUP_RETENTION", "720h"),
+			Prefix:    getEnv("BACKUP_PREFIX", "marketplace-"),
+		},
+		Retention: RetentionConfig{
+			Interval:   getDurationEnv("RETENTION_INTERVAL", "1h"),
+			BatchSize:  getIntEnv("RETENTION_BATCH_SIZE", 1000),
+			BatchPause: getDurationEnv("RETENTION_BATCH_PAUSE", "100ms"),
+			Events:     getDurationEnv("RETENTION_EVENTS", "2160h"),
+			Outbox:     getDurationEnv("RETENTION_OUTBOX", "168h"),
+			AuditIPs:   getDurationEnv("RETENTION_AUDIT_IPS", "8760h"),
+			// Long enough to look into a delivery complaint
+			PushDeliveries:     getDurationEnv("RETENTION_PUSH_DELIVERIES", "720h"),
+			LoginLocations:     getDurationEnv("RETENTION_LOGIN_LOCATIONS", "8760h"),
+			ExpiredCodes:       getDurationEnv("RETENTION_EXPIRED_CODES", "24h"),
+			ExpiredOAuthTokens: getDurationEnv("RETENTION_EXPIRED_OAUTH_TOKENS", "720h"),
+		},
+		Partitions: PartitionConfig{
+			Interval:    getDurationEnv("PARTITION_MAINTENANCE_INTERVAL", "24h"),
+			MonthsAhead: getIntEnv("PARTITION_MONTHS_AHEAD", 3),
+		},
+		Push: PushConfig{
+			FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+			APNSKeyFile:        getEnv("APNS_KEY_FILE", ""),
+			APNSKeyID:          getEnv("APNS_KEY_ID", ""),
+			APNSTeamID:         getEnv("APNS_TEAM_ID", ""),
+			APNSTopic:          getEnv("APNS_TOPIC", ""),
+			APNSSandbox:        getBoolEnv("APNS_SANDBOX", false),
+			Timeout:            getDurationEnv("PUSH_TIMEOUT", "10s"),
+			MaxTokens:          getIntEnv("PUSH_MAX_TOKENS", 10),
+		},
+		SMS: SMSConfig{
+			TwilioAccountSID: getEnv("TWILIO_ACCOUNT_SID", ""),
+			TwilioAuthToken:  getEnv("TWILIO_AUTH_TOKEN", ""),
+			From:             getEnv("SMS_FROM", ""),
+			AllowedPrefixes:  getListEnv("SMS_ALLOWED_PREFIXES"),
+			CodeTTL:          getDurationEnv("SMS_CODE_TTL", "10m"),
+			CodeMaxPerWindow: getIntEnv("SMS_CODE_MAX_PER_WINDOW", 3),
+			CodeWindow:       getDurationEnv("SMS_CODE_WINDOW", "1h"),
+			Timeout:          getDurationEnv("SMS_TIMEOUT", "10s"),
+		},
+		Mail: MailConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getIntEnv("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("MAIL_FROM", ""),
+			Timeout:      getDurationEnv("MAIL_TIMEOUT", "10s"),
+		},
+		Streaming: StreamingConfig{
+			Broker:        getEnv("STREAM_BROKER", ""),
+			URLs:          getListEnv("STREAM_URLS"),
+			TopicPrefix:   getEnv("STREAM_TOPIC_PREFIX", "marketplace."),
+			NATSStream:    getEnv("STREAM_NATS_STREAM", "MARKETPLACE"),
+			ConsumerGroup: getEnv("STREAM_CONSUMER_GROUP", "marketplace-read-models"),
+		},
+	}
+
+	topics, err := getMapEnv("STREAM_TOPICS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Streaming.Topics = topics
+
+	groupRoles, err := getMapEnv("SSO_GROUP_ROLES")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SSO.GroupRoles = groupRoles
+	if len(cfg.SSO.Scopes) == 0 {
+		cfg.SSO.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	smsSenders, err := getMapEnv("SMS_SENDERS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SMS.Senders = smsSenders
+
+	routeTimeouts, err := getMapEnv("SERVER_ROUTE_TIMEOUTS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Server.RouteTimeouts = make(map[string]time.Duration, len(routeTimeouts))
+	for route, value := range routeTimeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("SERVER_ROUTE_TIMEOUTS: invalid duration %q for %s", value, route)
+		}
+		cfg.Server.RouteTimeouts[route] = timeout
+	}
+
+	for _, proxy := range getListEnv("SERVER_TRUSTED_PROXIES") {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("SERVER_TRUSTED_PROXIES: invalid address or CIDR %q", proxy)
+		}
+		cfg.Server.TrustedProxies = append(cfg.Server.TrustedProxies, network)
+	}
+
+	// A handler outliving WriteTimeout loses its connection before the 504 is written
+	if cfg.Server.WriteTimeout > 0 {
+		if cfg.Server.RequestTimeout >= cfg.Server.WriteTimeout {
+			return nil, fmt.Errorf("SERVER_REQUEST_TIMEOUT (%s) must be shorter than SERVER_WRITE_TIMEOUT (%s)", cfg.Server.RequestTimeout, cfg.Server.WriteTimeout)
+		}
+		for route, timeout := range cfg.Server.RouteTimeouts {
+			if timeout >= cfg.Server.WriteTimeout {
+				return nil, fmt.Errorf("SERVER_ROUTE_TIMEOUTS: %s timeout (%s) must be shorter than SERVER_WRITE_TIMEOUT (%s)", route, timeout, cfg.Server.WriteTimeout)
+			}
+		}
+	}
+
+	for _, group := range cfg.Compression.Groups {
+		switch group {
+		case "public", "me", "admin":
+		default:
+			return nil, fmt.Errorf("COMPRESSION_GROUPS: unknown route group %q, want public, me or admin", group)
+		}
+	}
+
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.TLS.CertFile != "" && len(cfg.TLS.AutocertDomains) > 0 {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_AUTOCERT_DOMAINS are mutually exclusive")
+	}
+	if cfg.TLS.RedirectAddr != "" && !cfg.TLS.Enabled() {
+		return nil, fmt.Errorf("TLS_REDIRECT_ADDR requires TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS")
+	}
+
+	if cfg.Fraud.Enabled && (cfg.Fraud.ReviewScore <= 0 || cfg.Fraud.ReviewScore > cfg.Fraud.BlockScore) {
+		return nil, fmt.Errorf("FRAUD_REVIEW_SCORE (%d) must be positive and at most FRAUD_BLOCK_SCORE (%d)", cfg.Fraud.ReviewScore, cfg.Fraud.BlockScore)
+	}
+
+	switch cfg.GeoIP.Provider {
+	case "", "ip-api":
+	default:
+		return nil, fmt.Errorf("GEOIP_PROVIDER must be ip-api, got %q", cfg.GeoIP.Provider)
+	}
+
+	if cfg.MagicLink.TTL <= 0 {
+		return nil, fmt.Errorf("MAGIC_LINK_TTL must be positive")
+	}
+
+	if cfg.OAuth.CodeTTL <= 0 {
+		return nil, fmt.Errorf("OAUTH_CODE_TTL must be positive")
+	}
+	if cfg.OAuth.AccessTokenTTL <= 0 {
+		return nil, fmt.Errorf("OAUTH_ACCESS_TOKEN_TTL must be positive")
+	}
+	if cfg.OAuth.RefreshTokenTTL <= 0 {
+		return nil, fmt.Errorf("OAUTH_REFRESH_TOKEN_TTL must be positive")
+	}
+
+	if cfg.WebAuthn.RPID != "" {
+		if len(cfg.WebAuthn.Origins) == 0 {
+			return nil, fmt.Errorf("WEBAUTHN_RP_ORIGINS is required when WEBAUTHN_RP_ID is set")
+		}
+		if cfg.WebAuthn.SessionTTL <= 0 {
+			return nil, fmt.Errorf("WEBAUTHN_SESSION_TTL must be positive")
+		}
+	}
+
+	if cfg.SSO.Issuer != "" {
+		if cfg.SSO.ClientID == "" || cfg.SSO.RedirectURL == "" {
+			return nil, fmt.Errorf("SSO_CLIENT_ID and SSO_REDIRECT_URL are required when SSO_ISSUER is set")
+		}
+		if len(cfg.SSO.GroupRoles) == 0 {
+			return nil, fmt.Errorf("SSO_GROUP_ROLES is required when SSO_ISSUER is set")
+		}
+		for group, role := range cfg.SSO.GroupRoles {
+			if role != "admin" && role != "su-admin" {
+				return nil, fmt.Errorf("SSO_GROUP_ROLES: group %s maps to %q, want admin or su-admin", group, role)
+			}
+		}
+		if cfg.SSO.StateTTL <= 0 {
+			return nil, fmt.Errorf("SSO_STATE_TTL must be positive")
+		}
+	} else if cfg.SSO.Required {
+		return nil, fmt.Errorf("SSO_REQUIRED requires SSO_ISSUER")
+	}
+
+	switch cfg.Captcha.Provider {
+	case "":
+	case "hcaptcha", "recaptcha", "turnstile":
+		if cfg.Captcha.Secret == "" {
+			return nil, fmt.Errorf("CAPTCHA_SECRET is required when CAPTCHA_PROVIDER is set")
+		}
+	default:
+		return nil, fmt.Errorf("CAPTCHA_PROVIDER must be hcaptcha, recaptcha or turnstile, got %q", cfg.Captcha.Provider)
+	}
+
+	if cfg.Backup.Dir != "" {
+		if cfg.Backup.Interval <= 0 {
+			return nil, fmt.Errorf("BACKUP_INTERVAL must be positive")
+		}
+		if cfg.Backup.Retention < cfg.Backup.Interval {
+			return nil, fmt.Errorf("BACKUP_RETENTION (%s) must be at least BACKUP_INTERVAL (%s)", cfg.Backup.Retention, cfg.Backup.Interval)
+		}
+	}
+
+	if cfg.Retention.Interval <= 0 {
+		return nil, fmt.Errorf("RETENTION_INTERVAL must be positive")
+	}
+	if cfg.Retention.BatchSize <= 0 {
+		return nil, fmt.Errorf("RETENTION_BATCH_SIZE must be positive")
+	}
+	for _, retention := range []struct {
+		env string
+		age time.Duration
+	}{
+		{"RETENTION_EVENTS", cfg.Retention.Events},
+		{"RETENTION_OUTBOX", cfg.Retention.Outbox},
+		{"RETENTION_AUDIT_IPS", cfg.Retention.AuditIPs},
+		{"RETENTION_PUSH_DELIVERIES", cfg.Retention.PushDeliveries},
+		{"RETENTION_LOGIN_LOCATIONS", cfg.Retention.LoginLocations},
+		{"RETENTION_EXPIRED_CODES", cfg.Retention.ExpiredCodes},
+		{"RETENTION_EXPIRED_OAUTH_TOKENS", cfg.Retention.ExpiredOAuthTokens},
+	} {
+		if retention.age < 0 {
+			return nil, fmt.Errorf("%s must not be negative", retention.env)
+		}
+	}
+	// Purging codes still inside a rate limit window would reset the limit
+	if cfg.Retention.ExpiredCodes > 0 && (cfg.Retention.ExpiredCodes < cfg.MagicLink.Window || cfg.Retention.ExpiredCodes < cfg.SMS.CodeWindow || cfg.Retention.ExpiredCodes < cfg.Login.ChallengeWindow) {
+		return nil, fmt.Errorf("RETENTION_EXPIRED_CODES must be at least MAGIC_LINK_WINDOW, SMS_CODE_WINDOW and LOGIN_CHALLENGE_WINDOW")
+	}
+
+	if cfg.Partitions.Interval <= 0 {
+		return nil, fmt.Errorf("PARTITION_MAINTENANCE_INTERVAL must be positive")
+	}
+	if cfg.Partitions.MonthsAhead < 1 {
+		return nil, fmt.Errorf("PARTITION_MONTHS_AHEAD must be at least 1")
+	}
+
+	if cfg.Push.APNSKeyFile != "" && (cfg.Push.APNSKeyID == "" || cfg.Push.APNSTeamID == "" || cfg.Push.APNSTopic == "") {
+		return nil, fmt.Errorf("APNS_KEY_ID, APNS_TEAM_ID and APNS_TOPIC are required when APNS_KEY_FILE is set")
+	}
+	if cfg.Push.MaxTokens < 1 {
+		return nil, fmt.Errorf("PUSH_MAX_TOKENS must be at least 1")
+	}
+
+	if cfg.SMS.TwilioAccountSID != "" {
+		if cfg.SMS.TwilioAuthToken == "" || cfg.SMS.From == "" {
+			return nil, fmt.Errorf("TWILIO_AUTH_TOKEN and SMS_FROM are required when TWILIO_ACCOUNT_SID is set")
+		}
+		if cfg.SMS.CodeTTL <= 0 {
+			return nil, fmt.Errorf("SMS_CODE_TTL must be positive")
+		}
+	}
+	for _, prefix := range cfg.SMS.AllowedPrefixes {
+		if !strings.HasPrefix(prefix, "+") {
+			return nil, fmt.Errorf("SMS_ALLOWED_PREFIXES: %q must start with +", prefix)
+		}
+	}
+	for prefix := range cfg.SMS.Senders {
+		if !strings.HasPrefix(prefix, "+") {
+			return nil, fmt.Errorf("SMS_SENDERS: %q must start with +", prefix)
+		}
+	}
+
+	if cfg.Mail.SMTPHost != "" && cfg.Mail.From == "" {
+		return nil, fmt.Errorf("MAIL_FROM is required when SMTP_HOST is set")
+	}
+
+	if cfg.Login.StepUp && cfg.Mail.SMTPHost == "" {
+		return nil, fmt.Errorf("LOGIN_STEP_UP needs SMTP_HOST to email sign-in codes")
+	}
+
+	switch cfg.Streaming.Broker {
+	case "":
+	case "nats", "kafka":
+		if len(cfg.Streaming.URLs) == 0 {
+			return nil, fmt.Errorf("STREAM_URLS is required when STREAM_BROKER is set")
+		}
+	default:
+		return nil, fmt.Errorf("STREAM_BROKER must be nats or kafka, got %q", cfg.Streaming.Broker)
+	}
+
+	if cfg.Probe.Enabled && (cfg.Probe.Tenant == "" || cfg.Probe.Email == "" || cfg.Probe.Password == "") {
+		return nil, fmt.Errorf("PROBE_TENANT, PROBE_EMAIL and PROBE_PASSWORD are required when PROBE_ENABLED is set")
+	}
+
+	scheme := "http"
+	if cfg.TLS.Enabled() {
+		scheme = "https"
+	}
+	if cfg.Probe.BaseURL == "" {
+		cfg.Probe.BaseURL = fmt.Sprintf("%s://%s:%s", scheme, cfg.Server.Host, cfg.Server.Port)
+	}
+	if cfg.MagicLink.URL == "" {
+		cfg.MagicLink.URL = fmt.Sprintf("%s://localhost:%s/api/v1/auth/magic-link/verify", scheme, cfg.Server.Port)
 	}
 
 	if cfg.Database.Password == "" {
@@ -85,3 +849,54 @@ func getDurationEnv(key, defaultValue string) time.Duration {
 	duration, _ := time.ParseDuration(defaultValue)
 	return duration
 }
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getMapEnv reads comma-separated key=value pairs
+func getMapEnv(key string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range getListEnv(key) {
+		k, v, ok := strings.Cut(pair, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("%s: invalid pair %q, want key=value", key, pair)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
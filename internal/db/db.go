@@ -7,6 +7,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -50,6 +51,14 @@ const (
 	UserStatusSuspended UserStatus = "suspended"
 )
 
+type ModerationStatus string
+
+const (
+	ModerationStatusPending  ModerationStatus = "pending"
+	ModerationStatusApproved ModerationStatus = "approved"
+	ModerationStatusRejected ModerationStatus = "rejected"
+)
+
 type User struct {
 	ID           uuid.UUID  `json:"id"`
 	Email        string     `json:"email"`
@@ -62,4 +71,347 @@ type User struct {
 	Phone        *string    `json:"phone"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
+	Username     *string    `json:"username"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+}
+
+type QueryPlan struct {
+	ID               uuid.UUID       `json:"id"`
+	QueryName        string          `json:"query_name"`
+	DeployVersion    string          `json:"deploy_version"`
+	Plan             json.RawMessage `json:"plan"`
+	Scans            json.RawMessage `json:"scans"`
+	Regression       bool            `json:"regression"`
+	RegressionDetail *string         `json:"regression_detail"`
+	CapturedAt       time.Time       `json:"captured_at"`
+}
+
+type ModerationItem struct {
+	ID              uuid.UUID        `json:"id"`
+	SubjectType     string           `json:"subject_type"`
+	SubjectID       uuid.UUID        `json:"subject_id"`
+	SubmittedBy     uuid.UUID        `json:"submitted_by"`
+	Content         string           `json:"content"`
+	Status          ModerationStatus `json:"status"`
+	RejectionReason *string          `json:"rejection_reason"`
+	ReviewedBy      *uuid.UUID       `json:"reviewed_by"`
+	ReviewedAt      *time.Time       `json:"reviewed_at"`
+	CreatedAt       time.Time        `json:"created_at"`
+	TenantID        uuid.UUID        `json:"tenant_id"`
+}
+
+type Suspension struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Reason    string     `json:"reason"`
+	IssuedBy  *uuid.UUID `json:"issued_by"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	LiftedAt  *time.Time `json:"lifted_at"`
+	LiftedBy  *uuid.UUID `json:"lifted_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   uuid.UUID       `json:"target_id"`
+	Metadata   json.RawMessage `json:"metadata"`
+	IpAddress  *string         `json:"ip_address"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UserPermission struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Permission string     `json:"permission"`
+	GrantedBy  *uuid.UUID `json:"granted_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type Address struct {
+	ID                uuid.UUID `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	Label             *string   `json:"label"`
+	RecipientName     string    `json:"recipient_name"`
+	Line1             string    `json:"line1"`
+	Line2             *string   `json:"line2"`
+	City              string    `json:"city"`
+	Region            *string   `json:"region"`
+	PostalCode        string    `json:"postal_code"`
+	CountryCode       string    `json:"country_code"`
+	Phone             *string   `json:"phone"`
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Name       string          `json:"name"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+type Outbox struct {
+	ID         uuid.UUID       `json:"id"`
+	EventName  string          `json:"event_name"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Attempts   int32           `json:"attempts"`
+	LastError  *string         `json:"last_error"`
+	SentAt     *time.Time      `json:"sent_at"`
+	DeadAt     *time.Time      `json:"dead_at"`
+}
+
+type ProfilePreference struct {
+	UserID       uuid.UUID `json:"user_id"`
+	ShowLibrary  bool      `json:"show_library"`
+	ShowWishlist bool      `json:"show_wishlist"`
+	ShowActivity bool      `json:"show_activity"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type LegalDocument struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	Version     string     `json:"version"`
+	Url         string     `json:"url"`
+	Mandatory   bool       `json:"mandatory"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	PublishedBy *uuid.UUID `json:"published_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type ConsentAcceptance struct {
+	UserID     uuid.UUID `json:"user_id"`
+	DocumentID uuid.UUID `json:"document_id"`
+	IpAddress  *string   `json:"ip_address"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+type FraudAssessment struct {
+	ID        uuid.UUID       `json:"id"`
+	CheckType string          `json:"check_type"`
+	UserID    *uuid.UUID      `json:"user_id"`
+	Email     string          `json:"email"`
+	IpAddress *string         `json:"ip_address"`
+	Score     int32           `json:"score"`
+	Decision  string          `json:"decision"`
+	Signals   json.RawMessage `json:"signals"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type LoginLocation struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	IpAddress   string    `json:"ip_address"`
+	CountryCode *string   `json:"country_code"`
+	Country     *string   `json:"country"`
+	City        *string   `json:"city"`
+	Latitude    *float64  `json:"latitude"`
+	Longitude   *float64  `json:"longitude"`
+	Anomaly     *string   `json:"anomaly"`
+	Verified    bool      `json:"verified"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type LoginChallenge struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	LocationID uuid.UUID  `json:"location_id"`
+	CodeHash   string     `json:"code_hash"`
+	Attempts   int32      `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DeviceID   *uuid.UUID `json:"device_id"`
+}
+
+type Device struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Fingerprint string     `json:"fingerprint"`
+	Name        string     `json:"name"`
+	LastIp      *string    `json:"last_ip"`
+	TrustedAt   *time.Time `json:"trusted_at"`
+	FirstSeenAt time.Time  `json:"first_seen_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at"`
+}
+
+type MagicLink struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	TokenHash string     `json:"token_hash"`
+	IpAddress *string    `json:"ip_address"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type WebauthnCredential struct {
+	ID           uuid.UUID       `json:"id"`
+	UserID       uuid.UUID       `json:"user_id"`
+	CredentialID []byte          `json:"credential_id"`
+	Name         string          `json:"name"`
+	Data         json.RawMessage `json:"data"`
+	CreatedAt    time.Time       `json:"created_at"`
+	LastUsedAt   *time.Time      `json:"last_used_at"`
+}
+
+type WebauthnSession struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    *uuid.UUID      `json:"user_id"`
+	Ceremony  string          `json:"ceremony"`
+	Data      json.RawMessage `json:"data"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type Tenant struct {
+	ID        uuid.UUID       `json:"id"`
+	Slug      string          `json:"slug"`
+	Name      string          `json:"name"`
+	Branding  json.RawMessage `json:"branding"`
+	Currency  string          `json:"currency"`
+	FeeBps    int32           `json:"fee_bps"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type TenantHostname struct {
+	Hostname string    `json:"hostname"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type Organization struct {
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	Name      string     `json:"name"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Role           string     `json:"role"`
+	AddedBy        *uuid.UUID `json:"added_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type OauthAuthorizationCode struct {
+	CodeHash      string     `json:"code_hash"`
+	ClientID      uuid.UUID  `json:"client_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	RedirectUri   string     `json:"redirect_uri"`
+	Scopes        []string   `json:"scopes"`
+	CodeChallenge string     `json:"code_challenge"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	UsedAt        *time.Time `json:"used_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type OauthClient struct {
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	Name         string     `json:"name"`
+	SecretHash   *string    `json:"secret_hash"`
+	RedirectUris []string   `json:"redirect_uris"`
+	Scopes       []string   `json:"scopes"`
+	CreatedBy    *uuid.UUID `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type OauthGrant struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ClientID  uuid.UUID `json:"client_id"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OauthToken struct {
+	ID        uuid.UUID  `json:"id"`
+	TokenHash string     `json:"token_hash"`
+	Kind      string     `json:"kind"`
+	ClientID  uuid.UUID  `json:"client_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type SsoIdentity struct {
+	Issuer      string    `json:"issuer"`
+	Subject     string    `json:"subject"`
+	UserID      uuid.UUID `json:"user_id"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+type SsoLoginState struct {
+	StateHash    string    `json:"state_hash"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PushToken struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Platform   string     `json:"platform"`
+	Token      string     `json:"token"`
+	DeviceName *string    `json:"device_name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type NotificationPreference struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Push      bool      `json:"push"`
+	Email     bool      `json:"email"`
+	InApp     bool      `json:"in_app"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Sms       bool      `json:"sms"`
+}
+
+type PushDelivery struct {
+	ID                uuid.UUID  `json:"id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	PushTokenID       *uuid.UUID `json:"push_token_id"`
+	Platform          string     `json:"platform"`
+	NotificationType  string     `json:"notification_type"`
+	Status            string     `json:"status"`
+	ProviderMessageID *string    `json:"provider_message_id"`
+	Error             *string    `json:"error"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+type PhoneVerification struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Phone      string     `json:"phone"`
+	CodeHash   string     `json:"code_hash"`
+	Attempts   int32      `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type VerifiedPhone struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Phone      string    `json:"phone"`
+	VerifiedAt time.Time `json:"verified_at"`
 }
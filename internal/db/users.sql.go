@@ -8,14 +8,15 @@ package db
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
-    email, password_hash, first_name, last_name, role, phone
+    email, password_hash, first_name, last_name, role, phone, username, status, tenant_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id
 `
 
 type CreateUserParams struct {
@@ -25,6 +26,9 @@ type CreateUserParams struct {
 	LastName     string    `json:"last_name"`
 	Role         UserRole  `json:"role"`
 	Phone        *string   `json:"phone"`
+	Username     *string   `json:"username"`
+	Status       UserStatus `json:"status"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -35,6 +39,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.LastName,
 		arg.Role,
 		arg.Phone,
+		arg.Username,
+		arg.Status,
+		arg.TenantID,
 	)
 	var i User
 	err := row.Scan(
@@ -49,16 +56,23 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Phone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Username,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at FROM users WHERE email = $1 LIMIT 1
+SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id FROM users WHERE tenant_id = $1 AND email = $2 LIMIT 1
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Email    string    `json:"email"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, arg.TenantID, arg.Email)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -72,16 +86,55 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Phone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Username,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at FROM users WHERE id = $1 LIMIT 1
+SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id FROM users
+WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+LIMIT 1
+`
+
+type GetUserByIDParams struct {
+	ID       uuid.UUID  `json:"id"`
+	TenantID *uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, arg.ID, arg.TenantID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.FirstName,
+		&i.LastName,
+		&i.Role,
+		&i.Status,
+		&i.AvatarUrl,
+		&i.Phone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const getUserByUsername = `-- name: GetUserByUsername :one
+SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id FROM users WHERE tenant_id = $1 AND LOWER(username) = LOWER($2) LIMIT 1
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByID, id)
+type GetUserByUsernameParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Lower    string    `json:"lower"`
+}
+
+func (q *Queries) GetUserByUsername(ctx context.Context, arg GetUserByUsernameParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByUsername, arg.TenantID, arg.Lower)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -95,13 +148,16 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Phone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Username,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at FROM users 
-WHERE ($1::user_role IS NULL OR role = $1)
+SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id FROM users 
+WHERE tenant_id = $5
+AND ($1::user_role IS NULL OR role = $1)
 AND ($2::user_status IS NULL OR status = $2)
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4
@@ -111,7 +167,8 @@ type ListUsersParams struct {
 	Role   *UserRole   `json:"role"`
 	Status *UserStatus `json:"status"`
 	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
+	Offset   int32       `json:"offset"`
+	TenantID uuid.UUID   `json:"tenant_id"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
@@ -120,6 +177,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 		arg.Status,
 		arg.Limit,
 		arg.Offset,
+		arg.TenantID,
 	)
 	if err != nil {
 		return nil, err
@@ -140,6 +198,55 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.Phone,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Username,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByIDs = `-- name: ListUsersByIDs :many
+SELECT id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id FROM users
+WHERE id = ANY($1::uuid[]) AND ($2::uuid IS NULL OR tenant_id = $2)
+`
+
+type ListUsersByIDsParams struct {
+	Dollar1  []uuid.UUID `json:"dollar_1"`
+	TenantID *uuid.UUID  `json:"tenant_id"`
+}
+
+func (q *Queries) ListUsersByIDs(ctx context.Context, arg ListUsersByIDsParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByIDs, pq.Array(arg.Dollar1), arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FirstName,
+			&i.LastName,
+			&i.Role,
+			&i.Status,
+			&i.AvatarUrl,
+			&i.Phone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Username,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -156,9 +263,9 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users 
-SET first_name = $2, last_name = $3, phone = $4, avatar_url = $5
+SET first_name = $2, last_name = $3, phone = $4, avatar_url = $5, username = $6
 WHERE id = $1 
-RETURNING id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at
+RETURNING id, email, password_hash, first_name, last_name, role, status, avatar_url, phone, created_at, updated_at, username, tenant_id
 `
 
 type UpdateUserParams struct {
@@ -167,6 +274,7 @@ type UpdateUserParams struct {
 	LastName  string    `json:"last_name"`
 	Phone     *string   `json:"phone"`
 	AvatarUrl *string   `json:"avatar_url"`
+	Username  *string   `json:"username"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -176,6 +284,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.LastName,
 		arg.Phone,
 		arg.AvatarUrl,
+		arg.Username,
 	)
 	var i User
 	err := row.Scan(
@@ -190,6 +299,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Phone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Username,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -207,3 +318,45 @@ func (q *Queries) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusPara
 	_, err := q.db.ExecContext(ctx, updateUserStatus, arg.ID, arg.Status)
 	return err
 }
+
+const updateUserAvatar = `-- name: UpdateUserAvatar :exec
+UPDATE users SET avatar_url = $2 WHERE id = $1
+`
+
+type UpdateUserAvatarParams struct {
+	ID        uuid.UUID `json:"id"`
+	AvatarUrl *string   `json:"avatar_url"`
+}
+
+func (q *Queries) UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserAvatar, arg.ID, arg.AvatarUrl)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :exec
+UPDATE users SET role = $2 WHERE id = $1
+`
+
+type UpdateUserRoleParams struct {
+	ID   uuid.UUID `json:"id"`
+	Role UserRole  `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserRole, arg.ID, arg.Role)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $2 WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID           uuid.UUID `json:"id"`
+	PasswordHash string    `json:"password_hash"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
@@ -0,0 +1,198 @@
+// Package dbmetrics records per-query latency and errors for the sqlc query
+// layer and logs slow statements. Metrics are published at /debug/vars as
+// "db_queries", keyed by sqlc query name.
+package dbmetrics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/rs/zerolog"
+)
+
+// metrics is shared by every recorder in the process
+var metrics = expvar.NewMap("db_queries")
+
+// bucketBounds are the histogram upper bounds in milliseconds; slower
+// queries land in the final, unbounded bucket
+var bucketBounds = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500}
+
+// sqlc prefixes every statement with "-- name: <QueryName> :<kind>"
+var queryNamePattern = regexp.MustCompile(`^-- name: (\w+)`)
+
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// unnamedQuery groups statements that don't come from sqlc
+const unnamedQuery = "unnamed"
+
+// queryStats is one query's counters, rendered as JSON by expvar
+type queryStats struct {
+	mu      sync.Mutex
+	count   int64
+	errors  int64
+	totalMs float64
+	maxMs   float64
+	buckets []int64
+}
+
+func newQueryStats() *queryStats {
+	return &queryStats{buckets: make([]int64, len(bucketBounds)+1)}
+}
+
+func (s *queryStats) record(ms float64, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+	if failed {
+		s.errors++
+	}
+	s.totalMs += ms
+	if ms > s.maxMs {
+		s.maxMs = ms
+	}
+
+	bucket := len(bucketBounds)
+	for i, bound := range bucketBounds {
+		if ms <= bound {
+			bucket = i
+			break
+		}
+	}
+	s.buckets[bucket]++
+}
+
+func (s *queryStats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	histogram := make(map[string]int64, len(s.buckets))
+	for i, count := range s.buckets {
+		if i < len(bucketBounds) {
+			histogram["le_"+formatMs(bucketBounds[i])] = count
+		} else {
+			histogram["gt_"+formatMs(bucketBounds[i-1])] = count
+		}
+	}
+
+	mean := 0.0
+	if s.count > 0 {
+		mean = s.totalMs / float64(s.count)
+	}
+
+	out, _ := json.Marshal(map[string]interface{}{
+		"count":     s.count,
+		"errors":    s.errors,
+		"mean_ms":   mean,
+		"max_ms":    s.maxMs,
+		"histogram": histogram,
+	})
+	return string(out)
+}
+
+var statsMu sync.Mutex
+
+// statsFor returns the counters for name, creating them on first use
+func statsFor(name string) *queryStats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	if stats, ok := metrics.Get(name).(*queryStats); ok {
+		return stats
+	}
+	stats := newQueryStats()
+	metrics.Set(name, stats)
+	return stats
+}
+
+func formatMs(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', -1, 64) + "ms"
+}
+
+// Recorder wraps a db.DBTX, timing every statement
+type Recorder struct {
+	db            db.DBTX
+	slowThreshold time.Duration
+	logger        zerolog.Logger
+}
+
+// NewRecorder wraps conn. Statements slower than slowThreshold are logged
+// with their normalized SQL; zero disables slow query logging.
+func NewRecorder(conn db.DBTX, slowThreshold time.Duration, logger zerolog.Logger) *Recorder {
+	return &Recorder{
+		db:            conn,
+		slowThreshold: slowThreshold,
+		logger:        logger.With().Str("component", "db_metrics").Logger(),
+	}
+}
+
+func (r *Recorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := r.db.ExecContext(ctx, query, args...)
+	r.observe(query, time.Since(start), err)
+	return result, err
+}
+
+func (r *Recorder) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.db.PrepareContext(ctx, query)
+}
+
+func (r *Recorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	r.observe(query, time.Since(start), err)
+	return rows, err
+}
+
+// QueryRowContext times the round trip; database/sql runs the query before
+// returning the row, and Err reports its failure before Scan
+func (r *Recorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := r.db.QueryRowContext(ctx, query, args...)
+	r.observe(query, time.Since(start), row.Err())
+	return row
+}
+
+func (r *Recorder) observe(query string, duration time.Duration, err error) {
+	name := unnamedQuery
+	if match := queryNamePattern.FindStringSubmatch(query); match != nil {
+		name = match[1]
+	}
+
+	// Cancelled requests say nothing about the query's health
+	failed := err != nil && !errors.Is(err, context.Canceled)
+
+	statsFor(name).record(float64(duration.Microseconds())/1000, failed)
+
+	if r.slowThreshold > 0 && duration >= r.slowThreshold {
+		event := r.logger.Warn().
+			Str("alert", "slow_query").
+			Str("query", name).
+			Dur("duration", duration).
+			Str("sql", Normalize(query))
+		if failed {
+			event = event.Err(err)
+		}
+		event.Msg("slow query")
+	}
+}
+
+// Normalize strips the sqlc name comment and collapses whitespace. sqlc
+// statements already use $n placeholders, so no literal values remain.
+func Normalize(query string) string {
+	if match := queryNamePattern.FindStringIndex(query); match != nil {
+		if newline := strings.IndexByte(query, '\n'); newline >= 0 {
+			query = query[newline+1:]
+		}
+	}
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(query, " "))
+}
@@ -0,0 +1,35 @@
+// Package v2 holds the response DTOs for /api/v2. Types here may change
+// shape relative to v1 without affecting v1 clients; handlers for v2 routes
+// encode these instead of the pkg/response envelopes.
+package v2
+
+// ListResponse is the v2 list envelope. Unlike v1 it reports no total,
+// which v1 could only approximate from the current page.
+type ListResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+	Page    Page        `json:"page"`
+}
+
+// Page describes the returned page; NextPage is nil on the last page
+type Page struct {
+	Number   int  `json:"number"`
+	Size     int  `json:"size"`
+	NextPage *int `json:"next_page"`
+}
+
+// NewList builds a list response for page number of the given size, where
+// count is how many items data holds. A full page implies another may follow.
+func NewList(data interface{}, number, size, count int) ListResponse {
+	page := Page{Number: number, Size: size}
+	if count >= size {
+		next := number + 1
+		page.NextPage = &next
+	}
+
+	return ListResponse{
+		Success: true,
+		Data:    data,
+		Page:    page,
+	}
+}
@@ -0,0 +1,190 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// Publisher is what services depend on to emit events. An error means the
+// event was not recorded and the caller's change should be rolled back.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Handler reacts to one event. Handlers run synchronously on the
+// publisher's goroutine, so slow work belongs in a background job.
+type Handler func(ctx context.Context, event Event) error
+
+// Store persists published events for replay
+type Store interface {
+	Create(ctx context.Context, event *models.StoredEvent) (*models.StoredEvent, error)
+	ListAfter(ctx context.Context, occurredAt time.Time, id uuid.UUID, limit int) ([]*models.StoredEvent, error)
+}
+
+type subscription struct {
+	name    string
+	handler Handler
+}
+
+// Bus dispatches events in-process to the subscribers registered for them
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]subscription
+	store       Store
+	logger      zerolog.Logger
+}
+
+// NewBus creates a bus; a nil store disables persistence and replay
+func NewBus(store Store, logger zerolog.Logger) *Bus {
+	return &Bus{
+		subscribers: make(map[string][]subscription),
+		store:       store,
+		logger:      logger.With().Str("component", "event_bus").Logger(),
+	}
+}
+
+// Subscribe registers handler, identified by name in logs, for eventName
+func (b *Bus) Subscribe(eventName, name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventName] = append(b.subscribers[eventName], subscription{name: name, handler: handler})
+}
+
+// On subscribes a handler for a single event type, e.g.
+// events.On(bus, "notify", func(ctx context.Context, e events.UserSuspended) error {...})
+func On[T Event](b *Bus, name string, handler func(ctx context.Context, event T) error) {
+	var zero T
+	b.Subscribe(zero.EventName(), name, func(ctx context.Context, event Event) error {
+		typed, ok := event.(T)
+		if !ok {
+			return fmt.Errorf("unexpected event type %T", event)
+		}
+		return handler(ctx, typed)
+	})
+}
+
+// Publish persists event when a store is configured, then dispatches it.
+// A persistence failure is returned before any subscriber runs; subscriber
+// failures are logged, since the change the event describes already happened.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	if b.store != nil {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("error encoding %s: %w", event.EventName(), err)
+		}
+		_, err = b.store.Create(ctx, &models.StoredEvent{
+			Name:       event.EventName(),
+			Payload:    payload,
+			OccurredAt: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("error persisting %s: %w", event.EventName(), err)
+		}
+	}
+
+	b.dispatch(ctx, event)
+	return nil
+}
+
+// Replay re-dispatches persisted events that occurred at or after since, in
+// order, and returns how many were dispatched. Subscribers see replayed
+// events exactly as live ones, so they must tolerate duplicates.
+func (b *Bus) Replay(ctx context.Context, since time.Time, batchSize int) (int, error) {
+	if b.store == nil {
+		return 0, fmt.Errorf("event persistence is disabled")
+	}
+
+	// Every real ID orders after uuid.Nil, so events at exactly since are included
+	cursorTime, cursorID := since, uuid.Nil
+	replayed := 0
+	for {
+		stored, err := b.store.ListAfter(ctx, cursorTime, cursorID, batchSize)
+		if err != nil {
+			return replayed, fmt.Errorf("error listing events: %w", err)
+		}
+
+		for _, s := range stored {
+			cursorTime, cursorID = s.OccurredAt, s.ID
+
+			event, err := decode(s)
+			if err != nil {
+				b.logger.Warn().Err(err).Str("event_id", s.ID.String()).Msg("skipping undecodable event")
+				continue
+			}
+			b.dispatch(ctx, event)
+			replayed++
+		}
+
+		if len(stored) < batchSize {
+			return replayed, nil
+		}
+	}
+}
+
+func (b *Bus) dispatch(ctx context.Context, event Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers[event.EventName()]
+	b.mu.RUnlock()
+
+	for _, sub := range subscribers {
+		if err := b.call(ctx, sub, event); err != nil {
+			b.logger.Error().Err(err).Str("event", event.EventName()).Str("subscriber", sub.name).Msg("event subscriber failed")
+		}
+	}
+}
+
+// call runs one subscriber, turning a panic into an error so one broken
+// subscriber can't take down the publisher
+func (b *Bus) call(ctx context.Context, sub subscription, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return sub.handler(ctx, event)
+}
+
+// decoders maps event name to a function rebuilding the typed event
+var decoders = map[string]func(payload json.RawMessage) (Event, error){}
+
+func register[T Event]() {
+	var zero T
+	decoders[zero.EventName()] = func(payload json.RawMessage) (Event, error) {
+		var event T
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// Names returns every registered event name, sorted
+func Names() []string {
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decode rebuilds the typed event called name from its JSON payload
+func Decode(name string, payload json.RawMessage) (Event, error) {
+	decoder, ok := decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+	return decoder(payload)
+}
+
+func decode(stored *models.StoredEvent) (Event, error) {
+	return Decode(stored.Name, stored.Payload)
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// Event is a domain fact services publish after a change is committed.
+// Name is stable across releases: it keys subscriptions and persisted rows.
+type Event interface {
+	EventName() string
+}
+
+// Keyed events name the aggregate they belong to. Brokers partition on the
+// key so one aggregate's events stay in order.
+type Keyed interface {
+	EventKey() string
+}
+
+// Event names
+const (
+	NameUserCreated    = "user.created"
+	NameUserSuspended  = "user.suspended"
+	NameUserReinstated = "user.reinstated"
+)
+
+type UserCreated struct {
+	UserID uuid.UUID       `json:"user_id"`
+	Email  string          `json:"email"`
+	Role   models.UserRole `json:"role"`
+}
+
+func (UserCreated) EventName() string  { return NameUserCreated }
+func (e UserCreated) EventKey() string { return e.UserID.String() }
+
+type UserSuspended struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	SuspensionID uuid.UUID  `json:"suspension_id"`
+	Reason       string     `json:"reason"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+func (UserSuspended) EventName() string  { return NameUserSuspended }
+func (e UserSuspended) EventKey() string { return e.UserID.String() }
+
+// UserReinstated is published when a user's last open suspension ends,
+// either lifted by an admin or expired
+type UserReinstated struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Expired bool      `json:"expired"`
+}
+
+func (UserReinstated) EventName() string  { return NameUserReinstated }
+func (e UserReinstated) EventKey() string { return e.UserID.String() }
+
+func init() {
+	register[UserCreated]()
+	register[UserSuspended]()
+	register[UserReinstated]()
+}
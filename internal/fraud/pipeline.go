@@ -0,0 +1,72 @@
+package fraud
+
+import (
+	"context"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// MaxScore caps an assessment's total, whatever the rules add up to
+const MaxScore = 100
+
+// Signup is what signup rules look at
+type Signup struct {
+	Email     string
+	IPAddress string
+}
+
+// SignupRule is one check run on every signup. It returns nil when it
+// finds nothing suspicious.
+type SignupRule interface {
+	CheckSignup(ctx context.Context, signup Signup) (*models.FraudSignal, error)
+}
+
+// Thresholds turn a total score into a decision: at or above Block the
+// signup is refused, at or above Review it is held for an admin
+type Thresholds struct {
+	Review int
+	Block  int
+}
+
+// Pipeline runs every rule and sums their scores
+type Pipeline struct {
+	thresholds Thresholds
+	rules      []SignupRule
+}
+
+func NewPipeline(thresholds Thresholds, rules ...SignupRule) *Pipeline {
+	return &Pipeline{thresholds: thresholds, rules: rules}
+}
+
+// AssessSignup scores a signup; the assessment is not stored
+func (p *Pipeline) AssessSignup(ctx context.Context, signup Signup) (*models.FraudAssessment, error) {
+	assessment := &models.FraudAssessment{
+		Check:    models.FraudCheckSignup,
+		Email:    signup.Email,
+		Decision: models.FraudPass,
+		Signals:  []models.FraudSignal{},
+	}
+	if signup.IPAddress != "" {
+		assessment.IPAddress = &signup.IPAddress
+	}
+
+	for _, rule := range p.rules {
+		signal, err := rule.CheckSignup(ctx, signup)
+		if err != nil {
+			return nil, err
+		}
+		if signal != nil {
+			assessment.Signals = append(assessment.Signals, *signal)
+			assessment.Score += signal.Score
+		}
+	}
+	assessment.Score = min(assessment.Score, MaxScore)
+
+	switch {
+	case assessment.Score >= p.thresholds.Block:
+		assessment.Decision = models.FraudBlock
+	case assessment.Score >= p.thresholds.Review:
+		assessment.Decision = models.FraudReview
+	}
+	return assessment, nil
+}
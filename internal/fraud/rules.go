@@ -0,0 +1,101 @@
+package fraud
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainList string
+
+// Rule scores; a disposable address alone is held for review, and so is
+// exceeding the signup velocity limit, while doing both is blocked
+const (
+	disposableEmailScore = 60
+	velocityScore        = 50
+	// velocityFloodScore applies at twice the limit, blocking on its own
+	velocityFloodScore = MaxScore
+)
+
+// DisposableEmailRule flags addresses at throwaway email providers
+type DisposableEmailRule struct {
+	domains map[string]struct{}
+}
+
+// NewDisposableEmailRule flags the built-in domains and any extra ones
+func NewDisposableEmailRule(extra ...string) *DisposableEmailRule {
+	domains := make(map[string]struct{})
+	for _, domain := range append(strings.Split(disposableDomainList, "\n"), extra...) {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains[domain] = struct{}{}
+		}
+	}
+	return &DisposableEmailRule{domains: domains}
+}
+
+func (r *DisposableEmailRule) CheckSignup(ctx context.Context, signup Signup) (*models.FraudSignal, error) {
+	at := strings.LastIndexByte(signup.Email, '@')
+	if at < 0 {
+		return nil, nil
+	}
+	domain := strings.ToLower(signup.Email[at+1:])
+
+	// Subdomains of a disposable provider count too
+	for candidate := domain; candidate != ""; {
+		if _, ok := r.domains[candidate]; ok {
+			return &models.FraudSignal{
+				Rule:   "disposable_email",
+				Score:  disposableEmailScore,
+				Detail: fmt.Sprintf("email domain %s is a disposable provider", domain),
+			}, nil
+		}
+		_, candidate, _ = strings.Cut(candidate, ".")
+	}
+	return nil, nil
+}
+
+// AttemptCounter counts recent assessments of a check from one IP address
+type AttemptCounter interface {
+	CountRecentByIP(ctx context.Context, check models.FraudCheck, ipAddress string, since time.Time) (int, error)
+}
+
+// VelocityRule flags IP addresses that already signed up, or tried to,
+// limit times within window
+type VelocityRule struct {
+	counter AttemptCounter
+	limit   int
+	window  time.Duration
+}
+
+func NewVelocityRule(counter AttemptCounter, limit int, window time.Duration) *VelocityRule {
+	return &VelocityRule{counter: counter, limit: limit, window: window}
+}
+
+func (r *VelocityRule) CheckSignup(ctx context.Context, signup Signup) (*models.FraudSignal, error) {
+	if signup.IPAddress == "" {
+		return nil, nil
+	}
+
+	attempts, err := r.counter.CountRecentByIP(ctx, models.FraudCheckSignup, signup.IPAddress, time.Now().Add(-r.window))
+	if err != nil {
+		return nil, fmt.Errorf("error counting recent signups: %w", err)
+	}
+	if attempts < r.limit {
+		return nil, nil
+	}
+
+	score := velocityScore
+	if attempts >= 2*r.limit {
+		score = velocityFloodScore
+	}
+	return &models.FraudSignal{
+		Rule:   "signup_velocity",
+		Score:  score,
+		Detail: fmt.Sprintf("%d signups from %s in the last %s", attempts, signup.IPAddress, r.window),
+	}, nil
+}
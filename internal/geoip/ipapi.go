@@ -0,0 +1,77 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultIPAPIURL is ip-api.com's free endpoint; it is HTTP only and rate
+// limited, so production deployments should point at the paid one
+const DefaultIPAPIURL = "http://ip-api.com"
+
+// IPAPILocator looks addresses up with the ip-api.com JSON API
+type IPAPILocator struct {
+	baseURL string
+	http    *http.Client
+}
+
+func NewIPAPILocator(baseURL string, timeout time.Duration) *IPAPILocator {
+	return &IPAPILocator{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: timeout},
+	}
+}
+
+type ipAPIResponse struct {
+	Status      string  `json:"status"`
+	Message     string  `json:"message"`
+	CountryCode string  `json:"countryCode"`
+	Country     string  `json:"country"`
+	City        string  `json:"city"`
+	Lat         float64 `json:"lat"`
+	Lon         float64 `json:"lon"`
+}
+
+func (l *IPAPILocator) Locate(ctx context.Context, ip string) (*Location, error) {
+	if !Routable(ip) {
+		return nil, nil
+	}
+
+	endpoint := l.baseURL + "/json/" + url.PathEscape(ip) + "?fields=status,message,countryCode,country,city,lat,lon"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("geoip lookup: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geoip lookup: unexpected status %d", resp.StatusCode)
+	}
+
+	var body ipAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("geoip lookup: decoding response: %w", err)
+	}
+	// "fail" covers reserved ranges and unknown addresses alike
+	if body.Status != "success" || body.CountryCode == "" {
+		return nil, nil
+	}
+
+	return &Location{
+		CountryCode: body.CountryCode,
+		Country:     body.Country,
+		City:        body.City,
+		Latitude:    body.Lat,
+		Longitude:   body.Lon,
+	}, nil
+}
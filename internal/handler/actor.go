@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// actorFromRequest attributes an action to the authenticated caller
+func actorFromRequest(r *http.Request) models.Actor {
+	actor := models.Actor{IPAddress: middleware.ClientIP(r)}
+	if principal := auth.PrincipalFromContext(r.Context()); principal != nil {
+		actor.UserID = &principal.UserID
+		actor.Role = principal.Role
+	}
+	return actor
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// AddressHandler serves the caller's own address book; every route must run
+// behind Authenticate
+type AddressHandler struct {
+	addressService service.AddressService
+	validator      *validator.Validator
+	logger         zerolog.Logger
+}
+
+func NewAddressHandler(addressService service.AddressService, validator *validator.Validator, logger zerolog.Logger) *AddressHandler {
+	return &AddressHandler{
+		addressService: addressService,
+		validator:      validator,
+		logger:         logger,
+	}
+}
+
+// ListAddresses lists the caller's addresses, oldest first
+// GET /api/v1/me/addresses
+func (h *AddressHandler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	addresses, err := h.addressService.List(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list addresses")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, addresses)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// GetAddress returns one of the caller's addresses
+// GET /api/v1/me/addresses/{id}
+func (h *AddressHandler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_address_id")
+		return
+	}
+
+	address, err := h.addressService.Get(r.Context(), userID, id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("address_id", id.String()).Msg("failed to get address")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, address)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// CreateAddress adds an address to the caller's address book
+// POST /api/v1/me/addresses
+func (h *AddressHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.AddressRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	address, err := h.addressService.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to create address")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(address, "Address created successfully"))
+}
+
+// UpdateAddress replaces one of the caller's addresses
+// PUT /api/v1/me/addresses/{id}
+func (h *AddressHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_address_id")
+		return
+	}
+
+	var req models.AddressRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	address, err := h.addressService.Update(r.Context(), userID, id, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("address_id", id.String()).Msg("failed to update address")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(address, "Address updated successfully"))
+}
+
+// DeleteAddress removes one of the caller's addresses
+// DELETE /api/v1/me/addresses/{id}
+func (h *AddressHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_address_id")
+		return
+	}
+
+	if err := h.addressService.Delete(r.Context(), userID, id); err != nil {
+		h.logger.Error().Err(err).Str("address_id", id.String()).Msg("failed to delete address")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "Address deleted successfully"))
+}
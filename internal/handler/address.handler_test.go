@@ -0,0 +1,22 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+func TestListAddressesWithNoneSaved(t *testing.T) {
+	h := apptest.New(t)
+	token := h.TokenFor(t, h.CreateUser(t, models.RoleGamer))
+
+	resp := h.Do(t, http.MethodGet, "/api/v1/me/addresses", nil, token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", resp.StatusCode, http.StatusOK, resp.Body)
+	}
+	if data := string(resp.Envelope(t).Data); data != "[]" {
+		t.Fatalf("data = %s, want []", data)
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/rs/zerolog"
+)
+
+// defaultAnalyticsWindow is used when the request has no from parameter
+const defaultAnalyticsWindow = 30 * 24 * time.Hour
+
+type AnalyticsHandler struct {
+	analyticsService service.AnalyticsService
+	logger           zerolog.Logger
+}
+
+func NewAnalyticsHandler(analyticsService service.AnalyticsService, logger zerolog.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+// Signups reports new users per time bucket, as JSON or CSV
+// GET /api/v1/admin/analytics/signups
+func (h *AnalyticsHandler) Signups(w http.ResponseWriter, r *http.Request) {
+	rng, ok := h.parseRange(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_format")
+		return
+	}
+
+	buckets, err := h.analyticsService.SignupsOverTime(r.Context(), rng)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to compute signup analytics")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	if format == "csv" {
+		rows := make([][]string, len(buckets))
+		for i, bucket := range buckets {
+			rows[i] = []string{bucket.Bucket.Format(time.RFC3339), strconv.FormatInt(bucket.Signups, 10)}
+		}
+		response.CSV(w, "signups.csv", []string{"bucket", "signups"}, rows)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(buckets))
+}
+
+// parseRange reads from, to and granularity query parameters, writing a 400
+// and returning false when any of them is malformed
+func (h *AnalyticsHandler) parseRange(w http.ResponseWriter, r *http.Request) (models.AnalyticsRange, bool) {
+	query := r.URL.Query()
+
+	rng := models.AnalyticsRange{
+		To:          time.Now().UTC(),
+		Granularity: models.GranularityDay,
+	}
+
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := parseAnalyticsTime(toStr)
+		if err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_date")
+			return rng, false
+		}
+		rng.To = to
+	}
+
+	rng.From = rng.To.Add(-defaultAnalyticsWindow)
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := parseAnalyticsTime(fromStr)
+		if err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_date")
+			return rng, false
+		}
+		rng.From = from
+	}
+
+	if granularity := query.Get("granularity"); granularity != "" {
+		switch models.AnalyticsGranularity(granularity) {
+		case models.GranularityDay, models.GranularityWeek, models.GranularityMonth:
+			rng.Granularity = models.AnalyticsGranularity(granularity)
+		default:
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_granularity")
+			return rng, false
+		}
+	}
+
+	return rng, true
+}
+
+// parseAnalyticsTime accepts a plain date or a full RFC 3339 timestamp
+func parseAnalyticsTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
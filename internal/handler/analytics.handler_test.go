@@ -0,0 +1,32 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+func TestSignupAnalyticsNeedAnAdmin(t *testing.T) {
+	h := apptest.New(t)
+	gamer := h.CreateUser(t, models.RoleGamer)
+	admin := h.CreateUser(t, models.RoleAdmin)
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"anonymous", "", http.StatusUnauthorized},
+		{"gamer", h.TokenFor(t, gamer), http.StatusForbidden},
+		{"admin", h.TokenFor(t, admin), http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := h.Do(t, http.MethodGet, "/api/v1/admin/analytics/signups", nil, tc.token)
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tc.want, resp.Body)
+			}
+		})
+	}
+}
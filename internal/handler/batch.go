@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+)
+
+// parseBatchIDs reads a comma-separated list of UUIDs from the query
+// parameter param. Blank entries are skipped and repeats kept, so results
+// can line up with the request. Bad or too many IDs are answered with a
+// 400 in the validation error shape and false.
+func parseBatchIDs(w http.ResponseWriter, r *http.Request, param string, max int) ([]uuid.UUID, bool) {
+	lang := i18n.FromContext(r.Context())
+
+	var ids []uuid.UUID
+	var invalid []validator.ValidationError
+	for _, value := range strings.Split(r.URL.Query().Get(param), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			invalid = append(invalid, validator.ValidationError{
+				Field:   param,
+				Tag:     "uuid",
+				Value:   value,
+				Message: i18n.T(lang, "validation.uuid", param),
+			})
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	count := len(ids) + len(invalid)
+	switch {
+	case count == 0:
+		invalid = append(invalid, validator.ValidationError{
+			Field:   param,
+			Tag:     "required",
+			Message: i18n.T(lang, "validation.required", param),
+		})
+	case count > max:
+		invalid = append(invalid, validator.ValidationError{
+			Field:   param,
+			Tag:     "max",
+			Value:   strconv.Itoa(count),
+			Message: i18n.T(lang, "validation.max_items", param, strconv.Itoa(max)),
+		})
+	}
+	if len(invalid) > 0 {
+		validationErrorResponse(w, r, validator.ValidationErrors{Errors: invalid})
+		return nil, false
+	}
+	return ids, true
+}
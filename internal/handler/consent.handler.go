@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// ConsentHandler serves the terms of service and privacy policy versions
+// and the caller's acceptance of them
+type ConsentHandler struct {
+	consentService service.ConsentService
+	validator      *validator.Validator
+	logger         zerolog.Logger
+}
+
+func NewConsentHandler(consentService service.ConsentService, validator *validator.Validator, logger zerolog.Logger) *ConsentHandler {
+	return &ConsentHandler{
+		consentService: consentService,
+		validator:      validator,
+		logger:         logger,
+	}
+}
+
+// ListCurrent returns the version of each legal document in force
+// GET /api/v1/legal-documents
+func (h *ConsentHandler) ListCurrent(w http.ResponseWriter, r *http.Request) {
+	docs, err := h.consentService.ListCurrent(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list current legal documents")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, docs)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// GetStatus returns what the caller accepted and what is still pending
+// GET /api/v1/me/consents
+func (h *ConsentHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	status, err := h.consentService.Status(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to get consent status")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(status))
+}
+
+// Accept records the caller accepting a legal document version
+// POST /api/v1/me/consents
+func (h *ConsentHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.AcceptConsentRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	acceptance, err := h.consentService.Accept(r.Context(), userID, req.DocumentID, middleware.ClientIP(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Str("document_id", req.DocumentID.String()).Msg("failed to accept legal document")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(acceptance, "Legal document accepted"))
+}
+
+// ListDocuments lists every published version, including upcoming ones
+// GET /api/v1/admin/legal-documents
+func (h *ConsentHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	docs, err := h.consentService.ListDocuments(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list legal documents")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, docs)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// Publish adds a new legal document version
+// POST /api/v1/admin/legal-documents
+func (h *ConsentHandler) Publish(w http.ResponseWriter, r *http.Request) {
+	var req models.PublishLegalDocumentRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	doc, err := h.consentService.Publish(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("kind", string(req.Kind)).Str("version", req.Version).Msg("failed to publish legal document")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(doc, "Legal document published"))
+}
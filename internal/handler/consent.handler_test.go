@@ -0,0 +1,33 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+func TestLegalDocumentsAreManagedByOperators(t *testing.T) {
+	h := apptest.New(t)
+	store := h.Repos.Tenant.Add(&models.Tenant{Slug: "other-store", Name: "Other Store", Currency: "EUR"})
+	storeAdmin := h.Repos.User.Add(&models.User{Email: "owner@example.com", Role: models.RoleSuperAdmin, Status: models.StatusActive, TenantID: store.ID})
+
+	for _, tc := range []struct {
+		name   string
+		tenant string
+		token  string
+		want   int
+	}{
+		{"admin", "", h.TokenFor(t, h.CreateUser(t, models.RoleAdmin)), http.StatusForbidden},
+		{"another store's super admin", store.Slug, h.TokenFor(t, storeAdmin), http.StatusForbidden},
+		{"operator", "", h.TokenFor(t, h.CreateUser(t, models.RoleSuperAdmin)), http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := h.DoInTenant(t, tc.tenant, http.MethodGet, "/api/v1/admin/legal-documents", nil, tc.token)
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tc.want, resp.Body)
+			}
+		})
+	}
+}
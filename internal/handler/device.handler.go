@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/rs/zerolog"
+)
+
+// DeviceIDHeader carries a stable ID the client generates once and keeps,
+// which identifies a device far better than its user agent
+const DeviceIDHeader = "X-Device-ID"
+
+// DeviceHandler serves the devices the caller has logged in from
+type DeviceHandler struct {
+	loginSecurity service.LoginSecurityService
+	logger        zerolog.Logger
+}
+
+func NewDeviceHandler(loginSecurity service.LoginSecurityService, logger zerolog.Logger) *DeviceHandler {
+	return &DeviceHandler{
+		loginSecurity: loginSecurity,
+		logger:        logger,
+	}
+}
+
+// ListDevices lists the caller's devices, most recently used first
+// GET /api/v1/me/devices
+func (h *DeviceHandler) ListDevices(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	devices, err := h.loginSecurity.ListDevices(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list devices")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, devices)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// RevokeDevice forgets one of the caller's devices, withdrawing its trust
+// DELETE /api/v1/me/devices/{id}
+func (h *DeviceHandler) RevokeDevice(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_device_id")
+		return
+	}
+
+	if err := h.loginSecurity.RevokeDevice(r.Context(), userID, id); err != nil {
+		h.logger.Error().Err(err).Str("device_id", id.String()).Msg("failed to revoke device")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "Device revoked"))
+}
+
+// loginAttempt describes a login that passed the password check. Clients
+// that send no device ID are told apart by user agent alone.
+func loginAttempt(r *http.Request, userID uuid.UUID) models.LoginAttempt {
+	userAgent := r.UserAgent()
+	deviceID := r.Header.Get(DeviceIDHeader)
+	if deviceID == "" && userAgent != "" {
+		deviceID = "ua:" + userAgent
+	}
+	return models.LoginAttempt{
+		UserID:     userID,
+		IPAddress:  middleware.ClientIP(r),
+		DeviceID:   deviceID,
+		DeviceName: userAgent,
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/rs/zerolog"
+)
+
+type DiagnosticsHandler struct {
+	diagnosticsService service.DiagnosticsService
+	logger             zerolog.Logger
+}
+
+func NewDiagnosticsHandler(diagnosticsService service.DiagnosticsService, logger zerolog.Logger) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		diagnosticsService: diagnosticsService,
+		logger:             logger,
+	}
+}
+
+// ListQueryPlans lists recorded query plans, optionally only regressions
+// GET /api/v1/admin/diagnostics/query-plans
+func (h *DiagnosticsHandler) ListQueryPlans(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Pagination
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// Filters
+	var regression *bool
+	if regressionStr := query.Get("regression"); regressionStr != "" {
+		regressionVal, err := strconv.ParseBool(regressionStr)
+		if err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_regression_filter")
+			return
+		}
+		regression = &regressionVal
+	}
+
+	plans, total, err := h.diagnosticsService.ListQueryPlans(r.Context(), regression, page, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list query plans")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Paginated(plans, page, limit, total))
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+)
+
+// errorResponse writes the message for key in the request's language, as
+// problem+json when the client accepts it and the standard envelope otherwise
+func errorResponse(w http.ResponseWriter, r *http.Request, statusCode int, key string) {
+	msg := i18n.T(i18n.FromContext(r.Context()), key)
+	if response.WantsProblem(r) {
+		response.WriteProblem(w, response.NewProblem(statusCode, key, msg, r, middleware.RequestIDFromContext(r.Context())))
+		return
+	}
+	response.JSON(w, statusCode, response.Error(msg))
+}
+
+// validationErrorResponse writes field-level validation errors, or a generic
+// invalid body error when the JSON could not be decoded
+func validationErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	lang := i18n.FromContext(r.Context())
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_json")
+		return
+	}
+
+	msg := i18n.T(lang, "validation.failed")
+	if response.WantsProblem(r) {
+		problem := response.NewProblem(http.StatusBadRequest, "validation.failed", msg, r, middleware.RequestIDFromContext(r.Context()))
+		problem.Errors = validationErrs.Errors
+		response.WriteProblem(w, problem)
+		return
+	}
+	response.JSON(w, http.StatusBadRequest, response.ErrorWithMessage(validationErrs.Errors, msg))
+}
+
+// serviceErrorResponse maps a service error onto a status code and a
+// localized message; anything unrecognised is reported as a 500
+func serviceErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode, key := mapServiceError(err)
+	errorResponse(w, r, statusCode, key)
+}
+
+func mapServiceError(err error) (int, string) {
+	msg := err.Error()
+	switch {
+	// Passkey and SSO errors come first: they wrap library text that could
+	// match a more general case below
+	case strings.Contains(msg, "passkey session expired or already used"):
+		return http.StatusBadRequest, "error.passkey_session_invalid"
+	case strings.Contains(msg, "passkey registration failed"):
+		return http.StatusBadRequest, "error.passkey_registration_failed"
+	case strings.Contains(msg, "passkey login failed"):
+		return http.StatusUnauthorized, "error.passkey_login_failed"
+	case strings.Contains(msg, "passkey already registered"):
+		return http.StatusConflict, "error.passkey_exists"
+	case strings.Contains(msg, "passkey not found"):
+		return http.StatusNotFound, "error.passkey_not_found"
+	case strings.Contains(msg, "sso session expired or already used"):
+		return http.StatusBadRequest, "error.sso_session_invalid"
+	case strings.Contains(msg, "sso login failed"):
+		return http.StatusUnauthorized, "error.sso_login_failed"
+	case strings.Contains(msg, "sso account not authorized"):
+		return http.StatusForbidden, "error.sso_not_authorized"
+	case strings.Contains(msg, "user not found"):
+		return http.StatusNotFound, "error.user_not_found"
+	case strings.Contains(msg, "moderation item not found"):
+		return http.StatusNotFound, "error.moderation_item_not_found"
+	case strings.Contains(msg, "profile not found"):
+		return http.StatusNotFound, "error.profile_not_found"
+	case strings.Contains(msg, "address not found"):
+		return http.StatusNotFound, "error.address_not_found"
+	case strings.Contains(msg, "login challenge not found"):
+		return http.StatusNotFound, "error.login_challenge_not_found"
+	case strings.Contains(msg, "device not found"):
+		return http.StatusNotFound, "error.device_not_found"
+	case strings.Contains(msg, "push token not found"):
+		return http.StatusNotFound, "error.push_token_not_found"
+	case strings.Contains(msg, "legal document not found"):
+		return http.StatusNotFound, "error.legal_document_not_found"
+	case strings.Contains(msg, "tenant not found"):
+		return http.StatusNotFound, "error.tenant_not_found"
+	case strings.Contains(msg, "organization not found"):
+		return http.StatusNotFound, "error.organization_not_found"
+	case strings.Contains(msg, "organization member not found"):
+		return http.StatusNotFound, "error.organization_member_not_found"
+	case strings.Contains(msg, "oauth client not found"):
+		return http.StatusNotFound, "error.oauth_client_not_found"
+	case strings.Contains(msg, "oauth grant not found"):
+		return http.StatusNotFound, "error.oauth_grant_not_found"
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound, "error.not_found"
+	case strings.Contains(msg, "already reviewed"):
+		return http.StatusConflict, "error.moderation_already_reviewed"
+	case strings.Contains(msg, "superseded by a newer submission"):
+		return http.StatusConflict, "error.moderation_superseded"
+	case strings.Contains(msg, "username is already taken"):
+		return http.StatusConflict, "error.username_taken"
+	case strings.Contains(msg, "legal document version already published"):
+		return http.StatusConflict, "error.legal_document_exists"
+	case strings.Contains(msg, "already a member of this organization"):
+		return http.StatusConflict, "error.organization_member_exists"
+	case strings.Contains(msg, "organization must keep an owner"):
+		return http.StatusConflict, "error.organization_owner_required"
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict, "error.user_exists"
+	case strings.Contains(msg, "credentials"):
+		return http.StatusUnauthorized, "error.invalid_credentials"
+	case strings.Contains(msg, "inactive"):
+		return http.StatusUnauthorized, "error.account_inactive"
+	case strings.Contains(msg, "suspended"):
+		return http.StatusForbidden, "error.account_suspended"
+	case strings.Contains(msg, "cannot suspend yourself"):
+		return http.StatusBadRequest, "error.cannot_suspend_self"
+	case strings.Contains(msg, "cannot change your own status"):
+		return http.StatusBadRequest, "error.cannot_change_own_status"
+	case strings.Contains(msg, "cannot change your own permissions"):
+		return http.StatusBadRequest, "error.cannot_change_own_permissions"
+	case strings.Contains(msg, "only be granted to admins"):
+		return http.StatusBadRequest, "error.permission_requires_admin"
+	case strings.Contains(msg, "insufficient privileges"):
+		return http.StatusForbidden, "error.forbidden"
+	case strings.Contains(msg, "no active suspension"):
+		return http.StatusNotFound, "error.no_active_suspension"
+	case strings.Contains(msg, "invalid expiry"):
+		return http.StatusBadRequest, "error.invalid_expiry"
+	case strings.Contains(msg, "address limit reached"):
+		return http.StatusConflict, "error.address_limit"
+	case strings.Contains(msg, "invalid effective date"):
+		return http.StatusBadRequest, "error.invalid_effective_date"
+	case strings.Contains(msg, "invalid redirect URI"):
+		return http.StatusBadRequest, "error.oauth_invalid_redirect_uri"
+	case strings.Contains(msg, "invalid range"):
+		return http.StatusBadRequest, "error.invalid_range"
+	case strings.Contains(msg, "login challenge expired"):
+		return http.StatusUnauthorized, "error.login_challenge_expired"
+	case strings.Contains(msg, "invalid verification code"):
+		return http.StatusUnauthorized, "error.invalid_verification_code"
+	case strings.Contains(msg, "too many login challenges"):
+		return http.StatusTooManyRequests, "error.login_challenge_limit"
+	case strings.Contains(msg, "magic link invalid"):
+		return http.StatusUnauthorized, "error.magic_link_invalid"
+	case strings.Contains(msg, "phone number not set"):
+		return http.StatusBadRequest, "error.phone_not_set"
+	case strings.Contains(msg, "phone number already verified"):
+		return http.StatusConflict, "error.phone_already_verified"
+	case strings.Contains(msg, "phone number not supported"):
+		return http.StatusBadRequest, "error.phone_not_supported"
+	case strings.Contains(msg, "too many verification codes"):
+		return http.StatusTooManyRequests, "error.phone_code_limit"
+	case strings.Contains(msg, "phone verification expired or already used"):
+		return http.StatusBadRequest, "error.phone_verification_invalid"
+	case strings.Contains(msg, "phone verification code is incorrect"):
+		return http.StatusBadRequest, "error.invalid_verification_code"
+	case strings.Contains(msg, "signup rejected"):
+		return http.StatusForbidden, "error.signup_rejected"
+	case errors.Is(err, repository.ErrConflict):
+		return http.StatusConflict, "error.conflict"
+	default:
+		return http.StatusInternalServerError, "error.internal"
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+)
+
+// fieldsParam is the query parameter clients trim a response with, as
+// in ?fields=id,email,role
+const fieldsParam = "fields"
+
+// selectFields trims data to the fields the client asked for. An unknown
+// field is answered with a 400 in the validation error shape and false;
+// without the parameter data is returned untouched.
+func selectFields(w http.ResponseWriter, r *http.Request, data interface{}) (interface{}, bool) {
+	fields := response.ParseFields(r.URL.Query().Get(fieldsParam))
+	if len(fields) == 0 {
+		return data, true
+	}
+
+	available := response.FieldNames(data)
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[name] = true
+	}
+
+	var unknown []validator.ValidationError
+	lang := i18n.FromContext(r.Context())
+	allowed := strings.Join(available, " ")
+	for _, field := range fields {
+		if !known[field] {
+			unknown = append(unknown, validator.ValidationError{
+				Field:   fieldsParam,
+				Tag:     "oneof",
+				Value:   field,
+				Message: i18n.T(lang, "validation.oneof", fieldsParam, allowed),
+			})
+		}
+	}
+	if len(unknown) > 0 {
+		validationErrorResponse(w, r, validator.ValidationErrors{Errors: unknown})
+		return nil, false
+	}
+
+	projected, err := response.Project(data, fields)
+	if err != nil {
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return nil, false
+	}
+	return projected, true
+}
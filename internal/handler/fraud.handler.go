@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/rs/zerolog"
+)
+
+type FraudHandler struct {
+	fraudService service.FraudService
+	logger       zerolog.Logger
+}
+
+func NewFraudHandler(fraudService service.FraudService, logger zerolog.Logger) *FraudHandler {
+	return &FraudHandler{
+		fraudService: fraudService,
+		logger:       logger,
+	}
+}
+
+// ListForUser lists the risk assessments recorded for a user, newest first
+// GET /api/v1/admin/users/{id}/fraud-assessments
+func (h *FraudHandler) ListForUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	assessments, err := h.fraudService.ListForUser(r.Context(), id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to list fraud assessments")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, assessments)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+type ModerationHandler struct {
+	moderationService service.ModerationService
+	validator         *validator.Validator
+	logger            zerolog.Logger
+}
+
+func NewModerationHandler(moderationService service.ModerationService, validator *validator.Validator, logger zerolog.Logger) *ModerationHandler {
+	return &ModerationHandler{
+		moderationService: moderationService,
+		validator:         validator,
+		logger:            logger,
+	}
+}
+
+// ListItems lists moderation items, pending ones by default
+// GET /api/v1/admin/moderation
+func (h *ModerationHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Pagination
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// Filters
+	status := models.ModerationPending
+	if statusStr := query.Get("status"); statusStr != "" {
+		if err := h.validator.ValidateVar(statusStr, "oneof=pending approved rejected"); err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_moderation_status_filter")
+			return
+		}
+		status = models.ModerationStatus(statusStr)
+	}
+
+	var subject *models.ModerationSubject
+	if subjectStr := query.Get("type"); subjectStr != "" {
+		if err := h.validator.ValidateVar(subjectStr, "oneof=avatar signup"); err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_subject_filter")
+			return
+		}
+		subjectVal := models.ModerationSubject(subjectStr)
+		subject = &subjectVal
+	}
+
+	items, err := h.moderationService.List(r.Context(), &status, subject, page, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list moderation items")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, items)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Paginated(data, page, limit, len(items)))
+}
+
+// ApproveItem approves a pending item and publishes its content
+// POST /api/v1/admin/moderation/{id}/approve
+func (h *ModerationHandler) ApproveItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_moderation_id")
+		return
+	}
+
+	item, err := h.moderationService.Approve(r.Context(), id, actorFromRequest(r).UserID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("moderation_item_id", id.String()).Msg("failed to approve moderation item")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	h.logger.Info().Str("moderation_item_id", id.String()).Msg("moderation item approved")
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(item, "Item approved"))
+}
+
+// RejectItem rejects a pending item and notifies the submitter
+// POST /api/v1/admin/moderation/{id}/reject
+func (h *ModerationHandler) RejectItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_moderation_id")
+		return
+	}
+
+	var req models.RejectModerationRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	item, err := h.moderationService.Reject(r.Context(), id, actorFromRequest(r).UserID, req.Reason)
+	if err != nil {
+		h.logger.Error().Err(err).Str("moderation_item_id", id.String()).Msg("failed to reject moderation item")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	h.logger.Info().Str("moderation_item_id", id.String()).Msg("moderation item rejected")
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(item, "Item rejected"))
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+func TestModerationNeedsAnAdmin(t *testing.T) {
+	h := apptest.New(t)
+	gamer := h.CreateUser(t, models.RoleGamer)
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"anonymous", "", http.StatusUnauthorized},
+		{"gamer", h.TokenFor(t, gamer), http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := h.Do(t, http.MethodGet, "/api/v1/admin/moderation", nil, tc.token)
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tc.want, resp.Body)
+			}
+		})
+	}
+}
+
+func TestApprovingAReplacedAvatarIsRefused(t *testing.T) {
+	h := apptest.New(t)
+	gamer := h.CreateUser(t, models.RoleGamer)
+	token := h.TokenFor(t, h.CreateUser(t, models.RoleAdmin))
+
+	submit := func(content string) *models.ModerationItem {
+		item, err := h.Repos.Moderation.Create(context.Background(), &models.ModerationItem{
+			SubjectType: models.SubjectAvatar,
+			SubjectID:   gamer.ID,
+			SubmittedBy: gamer.ID,
+			Content:     content,
+			Status:      models.ModerationPending,
+		})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return item
+	}
+	older := submit("https://cdn.example.com/old.png")
+	newer := submit("https://cdn.example.com/new.png")
+
+	if resp := h.Do(t, http.MethodPost, "/api/v1/admin/moderation/"+newer.ID.String()+"/approve", nil, token); resp.StatusCode != http.StatusOK {
+		t.Fatalf("approving the newer avatar: status %d: %s", resp.StatusCode, resp.Body)
+	}
+	if resp := h.Do(t, http.MethodPost, "/api/v1/admin/moderation/"+older.ID.String()+"/approve", nil, token); resp.StatusCode != http.StatusConflict {
+		t.Fatalf("approving the older avatar: status %d, want %d: %s", resp.StatusCode, http.StatusConflict, resp.Body)
+	}
+	if resp := h.Do(t, http.MethodPost, "/api/v1/admin/moderation/"+older.ID.String()+"/reject", map[string]string{"reason": "replaced"}, token); resp.StatusCode != http.StatusOK {
+		t.Fatalf("rejecting the older avatar: status %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	user, err := h.Repos.User.GetByID(context.Background(), gamer.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if user.AvatarURL == nil || *user.AvatarURL != newer.Content {
+		t.Fatalf("avatar = %v, want the newer submission", user.AvatarURL)
+	}
+}
+
+func TestModerationQueueIsPerStore(t *testing.T) {
+	h := apptest.New(t)
+	store := h.Repos.Tenant.Add(&models.Tenant{Slug: "other-store", Name: "Other Store", Currency: "EUR"})
+	token := h.TokenFor(t, h.CreateUser(t, models.RoleAdmin))
+
+	// Submitted by another store's user, so it carries that store
+	gamer := h.Repos.User.Add(&models.User{Email: "gamer@example.com", Role: models.RoleGamer, Status: models.StatusActive, TenantID: store.ID})
+	item, err := h.Repos.Moderation.Create(tenancy.WithTenant(context.Background(), store), &models.ModerationItem{
+		SubjectType: models.SubjectAvatar,
+		SubjectID:   gamer.ID,
+		SubmittedBy: gamer.ID,
+		Content:     "https://cdn.example.com/avatar.png",
+		Status:      models.ModerationPending,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	resp := h.Do(t, http.MethodGet, "/api/v1/admin/moderation", nil, token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("listing: status %d: %s", resp.StatusCode, resp.Body)
+	}
+	var items []models.ModerationItem
+	resp.Data(t, &items)
+	if len(items) != 0 {
+		t.Fatalf("listed %d items from another store", len(items))
+	}
+
+	if resp := h.Do(t, http.MethodPost, "/api/v1/admin/moderation/"+item.ID.String()+"/approve", nil, token); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("approving another store's item: status %d, want %d: %s", resp.StatusCode, http.StatusNotFound, resp.Body)
+	}
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// NotificationHandler serves the caller's push tokens and notification
+// preferences, and the push history admins look into
+type NotificationHandler struct {
+	notificationService service.NotificationService
+	validator           *validator.Validator
+	logger              zerolog.Logger
+}
+
+func NewNotificationHandler(notificationService service.NotificationService, validator *validator.Validator, logger zerolog.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		validator:           validator,
+		logger:              logger,
+	}
+}
+
+// RegisterPushToken registers the calling app install for push
+// notifications; registering a token again refreshes it
+// POST /api/v1/me/push-tokens
+func (h *NotificationHandler) RegisterPushToken(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.RegisterPushTokenRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	token, err := h.notificationService.RegisterPushToken(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to register push token")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(token, "Push token registered"))
+}
+
+// ListPushTokens lists the caller's registered app installs, most
+// recently registered first
+// GET /api/v1/me/push-tokens
+func (h *NotificationHandler) ListPushTokens(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	tokens, err := h.notificationService.ListPushTokens(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list push tokens")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, tokens)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// DeletePushToken stops pushes to one of the caller's app installs, as on
+// sign-out
+// DELETE /api/v1/me/push-tokens/{id}
+func (h *NotificationHandler) DeletePushToken(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_push_token_id")
+		return
+	}
+
+	if err := h.notificationService.DeletePushToken(r.Context(), userID, id); err != nil {
+		h.logger.Error().Err(err).Str("push_token_id", id.String()).Msg("failed to delete push token")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "Push token deleted"))
+}
+
+// GetPreferences returns the channels the caller receives notifications on
+// GET /api/v1/me/notification-preferences
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	prefs, err := h.notificationService.GetPreferences(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to get notification preferences")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, prefs)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// UpdatePreferences replaces the caller's notification preferences
+// PUT /api/v1/me/notification-preferences
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.UpdateNotificationPreferencesRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	prefs, err := h.notificationService.UpdatePreferences(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to update notification preferences")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(prefs, "Notification preferences updated"))
+}
+
+// ListDeliveries lists the pushes sent to a user and how each went,
+// newest first
+// GET /api/v1/admin/users/{id}/push-deliveries
+func (h *NotificationHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	deliveries, err := h.notificationService.ListDeliveries(r.Context(), id, page, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to list push deliveries")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, deliveries)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// OAuthHandler serves the OAuth2 authorization server: client registration
+// for admins, the consent screen and app management for users, and the
+// token, introspection and revocation endpoints for clients. The client
+// endpoints speak RFC 6749 form posts and JSON, not the standard envelope.
+type OAuthHandler struct {
+	oauthService service.OAuthService
+	validator    *validator.Validator
+	logger       zerolog.Logger
+}
+
+func NewOAuthHandler(oauthService service.OAuthService, validator *validator.Validator, logger zerolog.Logger) *OAuthHandler {
+	return &OAuthHandler{
+		oauthService: oauthService,
+		validator:    validator,
+		logger:       logger,
+	}
+}
+
+// RegisterClient registers a third-party app. A confidential client's
+// secret is in the response and can't be retrieved again.
+// POST /api/v1/admin/oauth/clients
+func (h *OAuthHandler) RegisterClient(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateOAuthClientRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	client, err := h.oauthService.RegisterClient(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("failed to register oauth client")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(client, "OAuth client registered"))
+}
+
+// ListClients lists the store's registered apps
+// GET /api/v1/admin/oauth/clients
+func (h *OAuthHandler) ListClients(w http.ResponseWriter, r *http.Request) {
+	clients, err := h.oauthService.ListClients(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list oauth clients")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, clients)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// DeleteClient removes an app, revoking everything issued to it
+// DELETE /api/v1/admin/oauth/clients/{id}
+func (h *OAuthHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := oauthClientID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.oauthService.DeleteClient(r.Context(), clientID, actorFromRequest(r)); err != nil {
+		h.logger.Error().Err(err).Str("client_id", clientID.String()).Msg("failed to delete oauth client")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "OAuth client deleted"))
+}
+
+// Authorize describes the consent screen for an authorization request. The
+// frontend's authorization page passes its query string through and either
+// shows the screen or, when redirect_uri is all that comes back, sends the
+// browser there.
+// GET /api/v1/oauth/authorize
+func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	query := r.URL.Query()
+	req := models.OAuthAuthorizeRequest{
+		ResponseType:        query.Get("response_type"),
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	}
+
+	authorization, err := h.oauthService.Authorize(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("client_id", req.ClientID).Msg("failed to check authorization request")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(authorization))
+}
+
+// Decide records the caller's answer on the consent screen and returns
+// where to send the browser
+// POST /api/v1/oauth/authorize
+func (h *OAuthHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.OAuthDecisionRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	authorization, err := h.oauthService.Decide(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("client_id", req.ClientID).Msg("failed to decide authorization request")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(authorization))
+}
+
+// Token exchanges an authorization code or refresh token for tokens
+// POST /api/v1/oauth/token
+func (h *OAuthHandler) Token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.oauthErrorResponse(w, &service.OAuthError{Code: "invalid_request", Description: "the body must be form-encoded"})
+		return
+	}
+
+	tokens, err := h.oauthService.Exchange(r.Context(), clientCredentials(r), &models.OAuthTokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("grant_type", r.PostForm.Get("grant_type")).Msg("failed to issue oauth tokens")
+		h.oauthErrorResponse(w, err)
+		return
+	}
+
+	noStore(w)
+	response.JSON(w, http.StatusOK, tokens)
+}
+
+// Introspect reports whether a token is active (RFC 7662)
+// POST /api/v1/oauth/introspect
+func (h *OAuthHandler) Introspect(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("token") == "" {
+		h.oauthErrorResponse(w, &service.OAuthError{Code: "invalid_request", Description: "token is required"})
+		return
+	}
+
+	introspection, err := h.oauthService.Introspect(r.Context(), clientCredentials(r), r.PostForm.Get("token"))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to introspect oauth token")
+		h.oauthErrorResponse(w, err)
+		return
+	}
+
+	noStore(w)
+	response.JSON(w, http.StatusOK, introspection)
+}
+
+// Revoke revokes a token (RFC 7009). It succeeds for unknown tokens too,
+// so there is nothing to learn from trying.
+// POST /api/v1/oauth/revoke
+func (h *OAuthHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("token") == "" {
+		h.oauthErrorResponse(w, &service.OAuthError{Code: "invalid_request", Description: "token is required"})
+		return
+	}
+
+	if err := h.oauthService.Revoke(r.Context(), clientCredentials(r), r.PostForm.Get("token")); err != nil {
+		h.logger.Error().Err(err).Msg("failed to revoke oauth token")
+		h.oauthErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListGrants lists the apps the caller has given access to
+// GET /api/v1/me/oauth/grants
+func (h *OAuthHandler) ListGrants(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	grants, err := h.oauthService.ListGrants(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list oauth grants")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, grants)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// RevokeGrant takes away an app's access to the caller's account
+// DELETE /api/v1/me/oauth/grants/{client_id}
+func (h *OAuthHandler) RevokeGrant(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	clientID, ok := oauthClientID(w, r, "client_id")
+	if !ok {
+		return
+	}
+
+	if err := h.oauthService.RevokeGrant(r.Context(), userID, clientID); err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Str("client_id", clientID.String()).Msg("failed to revoke oauth grant")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "App access revoked"))
+}
+
+// oauthErrorResponse writes a client endpoint error in the RFC 6749 5.2
+// shape, which OAuth libraries parse instead of the standard envelope
+func (h *OAuthHandler) oauthErrorResponse(w http.ResponseWriter, err error) {
+	statusCode := http.StatusBadRequest
+	var oauthErr *service.OAuthError
+	switch {
+	case !errors.As(err, &oauthErr):
+		statusCode = http.StatusInternalServerError
+		oauthErr = &service.OAuthError{Code: "server_error", Description: "internal error"}
+	case oauthErr.Code == "invalid_client":
+		statusCode = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+
+	noStore(w)
+	response.JSON(w, statusCode, models.OAuthErrorResponse{Error: oauthErr.Code, Description: oauthErr.Description})
+}
+
+// clientCredentials reads HTTP Basic client authentication, falling back
+// to client_id and client_secret in the form. It must run after ParseForm.
+func clientCredentials(r *http.Request) models.OAuthClientCredentials {
+	if id, secret, ok := r.BasicAuth(); ok {
+		// RFC 6749 2.3.1: both are form-encoded before going in the header
+		if unescaped, err := url.QueryUnescape(id); err == nil {
+			id = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(secret); err == nil {
+			secret = unescaped
+		}
+		return models.OAuthClientCredentials{ClientID: id, ClientSecret: secret}
+	}
+	return models.OAuthClientCredentials{
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+	}
+}
+
+// noStore keeps tokens out of caches (RFC 6749 5.1)
+func noStore(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+}
+
+// oauthClientID parses a client ID path variable, writing a 400 when it is
+// not a UUID
+func oauthClientID(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)[name])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_oauth_client_id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
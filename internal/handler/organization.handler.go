@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// OrganizationHandler serves the publisher teams the caller belongs to;
+// every route must run behind Authenticate
+type OrganizationHandler struct {
+	orgService service.OrganizationService
+	validator  *validator.Validator
+	logger     zerolog.Logger
+}
+
+func NewOrganizationHandler(orgService service.OrganizationService, validator *validator.Validator, logger zerolog.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+		validator:  validator,
+		logger:     logger,
+	}
+}
+
+// CreateOrganization creates an organization owned by the caller
+// POST /api/v1/me/organizations
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateOrganizationRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	org, err := h.orgService.Create(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to create organization")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(org, "Organization created"))
+}
+
+// ListOrganizations lists the caller's organizations with their role in each
+// GET /api/v1/me/organizations
+func (h *OrganizationHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	orgs, err := h.orgService.List(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list organizations")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, orgs)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// GetOrganization returns one of the caller's organizations
+// GET /api/v1/me/organizations/{id}
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	orgID, ok := organizationID(w, r)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.Get(r.Context(), userID, orgID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("organization_id", orgID.String()).Msg("failed to get organization")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, org)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// ListMembers lists an organization's members, in the order they joined
+// GET /api/v1/me/organizations/{id}/members
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	orgID, ok := organizationID(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.orgService.ListMembers(r.Context(), userID, orgID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("organization_id", orgID.String()).Msg("failed to list organization members")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, members)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// AddMember adds a user to an organization with a role
+// POST /api/v1/me/organizations/{id}/members
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := organizationID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AddOrganizationMemberRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	member, err := h.orgService.AddMember(r.Context(), orgID, &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("organization_id", orgID.String()).Str("user_id", req.UserID.String()).Msg("failed to add organization member")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(member, "Member added"))
+}
+
+// UpdateMember changes a member's role
+// PUT /api/v1/me/organizations/{id}/members/{user_id}
+func (h *OrganizationHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := organizationID(w, r)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	var req models.UpdateOrganizationMemberRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	member, err := h.orgService.UpdateMember(r.Context(), orgID, userID, &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("organization_id", orgID.String()).Str("user_id", userID.String()).Msg("failed to update organization member")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(member, "Member updated"))
+}
+
+// RemoveMember removes a member; members may remove themselves to leave
+// DELETE /api/v1/me/organizations/{id}/members/{user_id}
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := organizationID(w, r)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	if err := h.orgService.RemoveMember(r.Context(), orgID, userID, actorFromRequest(r)); err != nil {
+		h.logger.Error().Err(err).Str("organization_id", orgID.String()).Str("user_id", userID.String()).Msg("failed to remove organization member")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "Member removed"))
+}
+
+// organizationID parses the {id} path variable, writing a 400 when it is
+// not a UUID
+func organizationID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_organization_id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// PasskeyHandler registers and manages the caller's passkeys; signing in
+// with one is on UserHandler with the other logins
+type PasskeyHandler struct {
+	passkeys  service.PasskeyService
+	validator *validator.Validator
+	logger    zerolog.Logger
+}
+
+func NewPasskeyHandler(passkeys service.PasskeyService, validator *validator.Validator, logger zerolog.Logger) *PasskeyHandler {
+	return &PasskeyHandler{
+		passkeys:  passkeys,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// BeginRegistration returns the options for navigator.credentials.create
+// POST /api/v1/auth/webauthn/register/begin
+func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	begin, err := h.passkeys.BeginRegistration(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to begin passkey registration")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(begin))
+}
+
+// FinishRegistration verifies the new credential and stores it
+// POST /api/v1/auth/webauthn/register/finish
+func (h *PasskeyHandler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.FinishPasskeyRegistrationRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	passkey, err := h.passkeys.FinishRegistration(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Str("session_id", req.SessionID.String()).Msg("passkey registration failed")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(passkey, "Passkey registered"))
+}
+
+// ListPasskeys lists the caller's passkeys in the order they were added
+// GET /api/v1/me/passkeys
+func (h *PasskeyHandler) ListPasskeys(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	passkeys, err := h.passkeys.List(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list passkeys")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, passkeys)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// DeletePasskey removes one of the caller's passkeys
+// DELETE /api/v1/me/passkeys/{id}
+func (h *PasskeyHandler) DeletePasskey(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_passkey_id")
+		return
+	}
+
+	if err := h.passkeys.Delete(r.Context(), userID, id); err != nil {
+		h.logger.Error().Err(err).Str("passkey_id", id.String()).Msg("failed to delete passkey")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "Passkey deleted"))
+}
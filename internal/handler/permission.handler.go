@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+type PermissionHandler struct {
+	permissionService service.PermissionService
+	validator         *validator.Validator
+	logger            zerolog.Logger
+}
+
+func NewPermissionHandler(permissionService service.PermissionService, validator *validator.Validator, logger zerolog.Logger) *PermissionHandler {
+	return &PermissionHandler{
+		permissionService: permissionService,
+		validator:         validator,
+		logger:            logger,
+	}
+}
+
+// ListPermissions lists every permission that can be granted
+// GET /api/v1/admin/permissions
+func (h *PermissionHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.permissionService.List(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list permissions")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(permissions))
+}
+
+// GetUserPermissions returns a user's effective permissions and direct grants
+// GET /api/v1/admin/users/{id}/permissions
+func (h *PermissionHandler) GetUserPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	permissions, err := h.permissionService.GetForUser(r.Context(), id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to get user permissions")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(permissions))
+}
+
+// GrantPermission grants a permission directly to an admin
+// POST /api/v1/admin/users/{id}/permissions
+func (h *PermissionHandler) GrantPermission(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	var req models.GrantPermissionRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	permissions, err := h.permissionService.Grant(r.Context(), id, req.Permission, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Str("permission", string(req.Permission)).Msg("failed to grant permission")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	h.logger.Info().Str("user_id", id.String()).Str("permission", string(req.Permission)).Msg("permission granted")
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(permissions, "Permission granted"))
+}
+
+// RevokePermission removes a directly granted permission; permissions that
+// come from the user's role cannot be revoked here
+// DELETE /api/v1/admin/users/{id}/permissions/{permission}
+func (h *PermissionHandler) RevokePermission(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+	permission := models.Permission(vars["permission"])
+
+	permissions, err := h.permissionService.Revoke(r.Context(), id, permission, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Str("permission", string(permission)).Msg("failed to revoke permission")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	h.logger.Info().Str("user_id", id.String()).Str("permission", string(permission)).Msg("permission revoked")
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(permissions, "Permission revoked"))
+}
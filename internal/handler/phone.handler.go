@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// PhoneHandler verifies the phone number on the caller's account by text
+type PhoneHandler struct {
+	phoneService service.PhoneService
+	validator    *validator.Validator
+	logger       zerolog.Logger
+}
+
+func NewPhoneHandler(phoneService service.PhoneService, validator *validator.Validator, logger zerolog.Logger) *PhoneHandler {
+	return &PhoneHandler{
+		phoneService: phoneService,
+		validator:    validator,
+		logger:       logger,
+	}
+}
+
+// GetStatus returns the caller's phone number and whether it is verified
+// GET /api/v1/me/phone
+func (h *PhoneHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	status, err := h.phoneService.GetStatus(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to get phone status")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(status))
+}
+
+// SendCode texts a verification code to the caller's phone number
+// POST /api/v1/me/phone/verification
+func (h *PhoneHandler) SendCode(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	sent, err := h.phoneService.SendCode(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to send phone verification code")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, response.SuccessWithMessage(sent, "Verification code sent"))
+}
+
+// Confirm verifies the caller's phone number with the code texted to it
+// POST /api/v1/me/phone/verification/confirm
+func (h *PhoneHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.ConfirmPhoneRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	status, err := h.phoneService.Confirm(r.Context(), userID, req.Code)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to confirm phone")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(status, "Phone number verified"))
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// ProfileHandler serves public profiles and the caller's profile
+// preferences; the preference routes must run behind Authenticate
+type ProfileHandler struct {
+	profileService service.ProfileService
+	validator      *validator.Validator
+	logger         zerolog.Logger
+}
+
+func NewProfileHandler(profileService service.ProfileService, validator *validator.Validator, logger zerolog.Logger) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+		validator:      validator,
+		logger:         logger,
+	}
+}
+
+// GetProfile returns a user's public profile
+// GET /api/v1/profiles/{username}
+func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	profile, err := h.profileService.GetPublic(r.Context(), username)
+	if err != nil {
+		h.logger.Error().Err(err).Str("username", username).Msg("failed to get profile")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, profile)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// GetPreferences returns the caller's profile preferences
+// GET /api/v1/me/profile/preferences
+func (h *ProfileHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	prefs, err := h.profileService.GetPreferences(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to get profile preferences")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, prefs)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// UpdatePreferences replaces the caller's profile preferences
+// PUT /api/v1/me/profile/preferences
+func (h *ProfileHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	var req models.UpdateProfilePreferencesRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	prefs, err := h.profileService.UpdatePreferences(r.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to update profile preferences")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(prefs, "Profile preferences updated"))
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+type SuspensionHandler struct {
+	suspensionService service.SuspensionService
+	validator         *validator.Validator
+	logger            zerolog.Logger
+}
+
+func NewSuspensionHandler(suspensionService service.SuspensionService, validator *validator.Validator, logger zerolog.Logger) *SuspensionHandler {
+	return &SuspensionHandler{
+		suspensionService: suspensionService,
+		validator:         validator,
+		logger:            logger,
+	}
+}
+
+// IssueSuspension suspends a user, until expires_at or permanently
+// POST /api/v1/admin/users/{id}/suspensions
+func (h *SuspensionHandler) IssueSuspension(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	var req models.IssueSuspensionRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	suspension, err := h.suspensionService.Issue(r.Context(), id, &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to suspend user")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	h.logger.Info().Str("user_id", id.String()).Str("suspension_id", suspension.ID.String()).Msg("user suspended")
+	response.JSON(w, http.StatusCreated, response.SuccessWithMessage(suspension, "User suspended"))
+}
+
+// LiftSuspension lifts every active suspension on a user
+// DELETE /api/v1/admin/users/{id}/suspensions
+func (h *SuspensionHandler) LiftSuspension(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	if err := h.suspensionService.Lift(r.Context(), id, actorFromRequest(r)); err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to lift suspension")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	h.logger.Info().Str("user_id", id.String()).Msg("suspension lifted")
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "Suspension lifted"))
+}
+
+// BulkUpdateStatus moves a batch of users to one status in a single
+// transaction, reporting the outcome for each user
+// POST /api/v1/admin/users/bulk-status
+func (h *SuspensionHandler) BulkUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	var req models.BulkStatusRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	results, err := h.suspensionService.BulkUpdateStatus(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Int("count", len(req.UserIDs)).Msg("failed to bulk update user status")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	lang := i18n.FromContext(r.Context())
+	updated := 0
+	for i := range results {
+		if results[i].Err != nil {
+			_, key := mapServiceError(results[i].Err)
+			results[i].Error = i18n.T(lang, key)
+		}
+		if results[i].Result == models.BulkStatusUpdated {
+			updated++
+		}
+	}
+
+	h.logger.Info().Str("status", string(req.Status)).Int("count", len(req.UserIDs)).Int("updated", updated).Msg("bulk user status change applied")
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(results, "Bulk status change applied"))
+}
+
+// ListSuspensions lists a user's suspension history
+// GET /api/v1/admin/users/{id}/suspensions
+func (h *SuspensionHandler) ListSuspensions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return
+	}
+
+	suspensions, err := h.suspensionService.ListForUser(r.Context(), id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to list suspensions")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, suspensions)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
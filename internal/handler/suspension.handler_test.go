@@ -0,0 +1,35 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+func TestAdminsCantReachAnotherStoresUsers(t *testing.T) {
+	h := apptest.New(t)
+	store := h.Repos.Tenant.Add(&models.Tenant{Slug: "other-store", Name: "Other Store", Currency: "EUR"})
+	gamer := h.Repos.User.Add(&models.User{Email: "gamer@example.com", Role: models.RoleGamer, Status: models.StatusActive, TenantID: store.ID})
+	token := h.TokenFor(t, h.CreateUser(t, models.RoleAdmin))
+	path := "/api/v1/admin/users/" + gamer.ID.String()
+
+	for _, tc := range []struct {
+		method, path string
+		body         interface{}
+	}{
+		{http.MethodGet, path + "/suspensions", nil},
+		{http.MethodPost, path + "/suspensions", map[string]string{"reason": "spamming the forums"}},
+		{http.MethodDelete, path + "/suspensions", nil},
+		{http.MethodGet, path + "/fraud-assessments", nil},
+		{http.MethodGet, path + "/push-deliveries", nil},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			resp := h.Do(t, tc.method, tc.path, tc.body, token)
+			if resp.StatusCode != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, http.StatusNotFound, resp.Body)
+			}
+		})
+	}
+}
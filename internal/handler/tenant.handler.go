@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/validator"
+	"github.com/rs/zerolog"
+)
+
+// TenantHandler serves the store the request was resolved to: its
+// branding for storefronts, and its settings for admins
+type TenantHandler struct {
+	tenantService service.TenantService
+	validator     *validator.Validator
+	logger        zerolog.Logger
+}
+
+func NewTenantHandler(tenantService service.TenantService, validator *validator.Validator, logger zerolog.Logger) *TenantHandler {
+	return &TenantHandler{
+		tenantService: tenantService,
+		validator:     validator,
+		logger:        logger,
+	}
+}
+
+// GetTenant returns the current store's name, branding, currency and fee
+// GET /api/v1/tenant
+func (h *TenantHandler) GetTenant(w http.ResponseWriter, r *http.Request) {
+	tenant, err := h.tenantService.Current(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to get tenant")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, tenant)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// UpdateTenant changes the current store's branding, currency and fee
+// PUT /api/v1/admin/tenant
+func (h *TenantHandler) UpdateTenant(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateTenantRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	tenant, err := h.tenantService.Update(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to update tenant")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(tenant, "Tenant updated"))
+}
@@ -3,10 +3,11 @@ package handler
 import (
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
@@ -15,16 +16,26 @@ import (
 )
 
 type UserHandler struct {
-	userService service.UserService
-	validator   *validator.Validator
-	logger      zerolog.Logger
+	userService   service.UserService
+	loginSecurity service.LoginSecurityService
+	magicLinks    service.MagicLinkService
+	passkeys      service.PasskeyService
+	sso           service.SSOService // nil unless SSO is configured
+	tokens        *auth.TokenManager
+	validator     *validator.Validator
+	logger        zerolog.Logger
 }
 
-func NewUserHandler(userService service.UserService, validator *validator.Validator, logger zerolog.Logger) *UserHandler {
+func NewUserHandler(userService service.UserService, loginSecurity service.LoginSecurityService, magicLinks service.MagicLinkService, passkeys service.PasskeyService, sso service.SSOService, tokens *auth.TokenManager, validator *validator.Validator, logger zerolog.Logger) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		validator:   validator,
-		logger:      logger,
+		userService:   userService,
+		loginSecurity: loginSecurity,
+		magicLinks:    magicLinks,
+		passkeys:      passkeys,
+		sso:           sso,
+		tokens:        tokens,
+		validator:     validator,
+		logger:        logger,
 	}
 }
 
@@ -36,19 +47,15 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Validate and parse JSON
 	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
 		h.logger.Error().Err(err).Msg("validation failed")
-		response.JSON(w, http.StatusBadRequest, response.Error(err.Error()))
+		validationErrorResponse(w, r, err)
 		return
 	}
 
 	// Create user
-	user, err := h.userService.CreateUser(r.Context(), &req)
+	user, err := h.userService.CreateUser(r.Context(), &req, middleware.ClientIP(r))
 	if err != nil {
 		h.logger.Error().Err(err).Msg("failed to create user")
-		if strings.Contains(err.Error(), "already exists") {
-			response.JSON(w, http.StatusConflict, response.Error(err.Error()))
-			return
-		}
-		response.JSON(w, http.StatusInternalServerError, response.Error("Internal server error"))
+		serviceErrorResponse(w, r, err)
 		return
 	}
 
@@ -64,33 +71,65 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		response.JSON(w, http.StatusBadRequest, response.Error("invalid user ID"))
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
 		return
 	}
 
 	user, err := h.userService.GetUserByID(r.Context(), id)
 	if err != nil {
 		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to get user")
-		if strings.Contains(err.Error(), "not found") {
-			response.JSON(w, http.StatusNotFound, response.Error("User not found"))
-			return
-		}
-		response.JSON(w, http.StatusInternalServerError, response.Error("Internal server error"))
+		serviceErrorResponse(w, r, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Success(user))
+	data, ok := selectFields(w, r, user)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
 }
 
-// UpdateUser updates a user
-// PUT /api/v1/users/{id}
-func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	idStr := vars["id"]
+// GetUserByUsername gets a user by their username, ignoring case
+// GET /api/v1/users/by-username/{username}
+func (h *UserHandler) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
 
-	id, err := uuid.Parse(idStr)
+	user, err := h.userService.GetUserByUsername(r.Context(), username)
 	if err != nil {
-		response.JSON(w, http.StatusBadRequest, response.Error("invalid user ID"))
+		h.logger.Error().Err(err).Str("username", username).Msg("failed to get user by username")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	data, ok := selectFields(w, r, user)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// UsernameAvailability reports whether a username is free to register
+// GET /api/v1/usernames/{name}/availability
+func (h *UserHandler) UsernameAvailability(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	availability, err := h.userService.UsernameAvailability(r.Context(), name)
+	if err != nil {
+		h.logger.Error().Err(err).Str("username", name).Msg("failed to check username availability")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(availability))
+}
+
+// UpdateUser updates the caller's own account
+// PUT /api/v1/users/{id}
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.ownAccount(w, r)
+	if !ok {
 		return
 	}
 
@@ -99,18 +138,14 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Validate and parse JSON
 	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
 		h.logger.Error().Err(err).Msg("validation failed")
-		response.JSON(w, http.StatusBadRequest, response.Error(err.Error()))
+		validationErrorResponse(w, r, err)
 		return
 	}
 
 	user, err := h.userService.UpdateUser(r.Context(), id, &req)
 	if err != nil {
 		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to update user")
-		if strings.Contains(err.Error(), "not found") {
-			response.JSON(w, http.StatusNotFound, response.Error("User not found"))
-			return
-		}
-		response.JSON(w, http.StatusInternalServerError, response.Error("Internal server error"))
+		serviceErrorResponse(w, r, err)
 		return
 	}
 
@@ -118,26 +153,19 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, response.SuccessWithMessage(user, "User updated successfully"))
 }
 
-// DeleteUser (actually updates status to inactive)
+// DeleteUser deactivates the caller's own account (actually updates
+// status to inactive); admins deactivate others through bulk-status
 // DELETE /api/v1/users/{id}
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	idStr := vars["id"]
-
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		response.JSON(w, http.StatusBadRequest, response.Error("invalid user ID"))
+	id, ok := h.ownAccount(w, r)
+	if !ok {
 		return
 	}
 
-	err = h.userService.UpdateUserStatus(r.Context(), id, models.StatusInactive)
+	err := h.userService.UpdateUserStatus(r.Context(), id, models.StatusInactive)
 	if err != nil {
 		h.logger.Error().Err(err).Str("user_id", id.String()).Msg("failed to delete user")
-		if strings.Contains(err.Error(), "not found") {
-			response.JSON(w, http.StatusNotFound, response.Error("User not found"))
-			return
-		}
-		response.JSON(w, http.StatusInternalServerError, response.Error("Internal server error"))
+		serviceErrorResponse(w, r, err)
 		return
 	}
 
@@ -145,9 +173,89 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, response.SuccessWithMessage(nil, "User deleted successfully"))
 }
 
-// ListUsers lists users with optional filters
+// ownAccount parses the {id} route variable, writing a 400 when it is
+// malformed and a 403 when it isn't the caller's own account
+func (h *UserHandler) ownAccount(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponse(w, r, http.StatusBadRequest, "error.invalid_user_id")
+		return uuid.Nil, false
+	}
+	if id != auth.PrincipalFromContext(r.Context()).UserID {
+		errorResponse(w, r, http.StatusForbidden, "error.forbidden")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// ListUsers lists users with optional filters, or with ?ids= looks up
+// the listed users instead
 // GET /api/v1/users
 func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("ids") {
+		h.batchGetUsers(w, r)
+		return
+	}
+
+	query, ok := h.parseListUsersQuery(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := h.userService.ListUsers(r.Context(), query.role, query.status, query.page, query.limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list users")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	// For pagination, you might want to get total count as well
+	// This is simplified - you'd typically need a separate count query
+	total := len(users)
+
+	data, ok := selectFields(w, r, users)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Paginated(data, query.page, query.limit, total))
+}
+
+// batchGetUsers resolves up to MaxBatchUserIDs comma-separated IDs in one
+// lookup, so clients don't fan out a request per user
+// GET /api/v1/users?ids=a,b,c
+func (h *UserHandler) batchGetUsers(w http.ResponseWriter, r *http.Request) {
+	ids, ok := parseBatchIDs(w, r, "ids", models.MaxBatchUserIDs)
+	if !ok {
+		return
+	}
+
+	results, err := h.userService.GetUsersByIDs(r.Context(), ids)
+	if err != nil {
+		h.logger.Error().Err(err).Int("count", len(ids)).Msg("failed to batch get users")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, results)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
+}
+
+// listUsersQuery is the pagination and filters shared by every API version
+type listUsersQuery struct {
+	page   int
+	limit  int
+	role   *models.UserRole
+	status *models.UserStatus
+}
+
+// parseListUsersQuery reads pagination and filters, writing a 400 and
+// returning false when a filter is invalid
+func (h *UserHandler) parseListUsersQuery(w http.ResponseWriter, r *http.Request) (listUsersQuery, bool) {
 	// Parse query parameters
 	query := r.URL.Query()
 
@@ -165,32 +273,28 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		limit = 100
 	}
 
-	// Filters
-	var role *models.UserRole
-	var status *models.UserStatus
+	result := listUsersQuery{page: page, limit: limit}
 
+	// Filters
 	if roleStr := query.Get("role"); roleStr != "" {
+		if err := h.validator.ValidateVar(roleStr, "user_role"); err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_role_filter")
+			return result, false
+		}
 		roleVal := models.UserRole(roleStr)
-		role = &roleVal
+		result.role = &roleVal
 	}
 
 	if statusStr := query.Get("status"); statusStr != "" {
+		if err := h.validator.ValidateVar(statusStr, "user_status"); err != nil {
+			errorResponse(w, r, http.StatusBadRequest, "error.invalid_status_filter")
+			return result, false
+		}
 		statusVal := models.UserStatus(statusStr)
-		status = &statusVal
-	}
-
-	users, err := h.userService.ListUsers(r.Context(), role, status, page, limit)
-	if err != nil {
-		h.logger.Error().Err(err).Msg("failed to list users")
-		response.JSON(w, http.StatusInternalServerError, response.Error("Internal server error"))
-		return
+		result.status = &statusVal
 	}
 
-	// For pagination, you might want to get total count as well
-	// This is simplified - you'd typically need a separate count query
-	total := len(users)
-
-	response.JSON(w, http.StatusOK, response.Paginated(users, page, limit, total))
+	return result, true
 }
 
 // Login authenticates a user
@@ -201,21 +305,278 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	// Validate and parse JSON
 	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
 		h.logger.Error().Err(err).Msg("validation failed")
-		response.JSON(w, http.StatusBadRequest, response.Error(err.Error()))
+		validationErrorResponse(w, r, err)
 		return
 	}
 
 	user, err := h.userService.Login(r.Context(), &req)
 	if err != nil {
 		h.logger.Error().Err(err).Str("email", req.Email).Msg("login failed")
-		if strings.Contains(err.Error(), "credentials") || strings.Contains(err.Error(), "inactive") {
-			response.JSON(w, http.StatusUnauthorized, response.Error(err.Error()))
-			return
-		}
-		response.JSON(w, http.StatusInternalServerError, response.Error("Internal server error"))
+		serviceErrorResponse(w, r, err)
+		return
+	}
+	if !h.localLoginAllowed(w, r, user) {
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
+
+// completeLogin runs the login security checks for a user who proved who
+// they are, then issues a token or holds the login for step-up
+func (h *UserHandler) completeLogin(w http.ResponseWriter, r *http.Request, user *models.UserResponse) {
+	challenge, err := h.loginSecurity.CheckLogin(r.Context(), loginAttempt(r, user.ID))
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("failed to check login")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+	if challenge != nil {
+		h.logger.Info().Str("user_id", user.ID.String()).Str("challenge_id", challenge.ID.String()).Msg("login held for verification")
+		response.JSON(w, http.StatusAccepted, response.SuccessWithMessage(models.LoginChallengeResponse{
+			ChallengeID: challenge.ID,
+			ExpiresAt:   challenge.ExpiresAt,
+		}, "Verification code sent"))
+		return
+	}
+
+	h.issueLoginToken(w, r, user)
+}
+
+// VerifyLogin completes a login held for step-up verification
+// POST /api/v1/auth/login/verify
+func (h *UserHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.VerifyLoginRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	userID, err := h.loginSecurity.VerifyChallenge(r.Context(), req.ChallengeID, req.Code, req.TrustDevice)
+	if err != nil {
+		h.logger.Error().Err(err).Str("challenge_id", req.ChallengeID.String()).Msg("login verification failed")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	// The account may have been suspended while the code was in flight
+	user, ok := h.activeUser(w, r, userID)
+	if !ok {
+		return
+	}
+
+	h.issueLoginToken(w, r, user)
+}
+
+// SendMagicLink emails a single-use sign-in link. The answer is the same
+// whether or not the account exists.
+// POST /api/v1/auth/magic-link
+func (h *UserHandler) SendMagicLink(w http.ResponseWriter, r *http.Request) {
+	var req models.MagicLinkRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	if err := h.magicLinks.Send(r.Context(), req.Email, middleware.ClientIP(r)); err != nil {
+		h.logger.Error().Err(err).Msg("failed to send magic link")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, response.SuccessWithMessage(nil, "If an account exists for that email, a sign-in link is on its way"))
+}
+
+// VerifyMagicLink exchanges a sign-in link's token for an access token
+// GET /api/v1/auth/magic-link/verify?token=...
+func (h *UserHandler) VerifyMagicLink(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		errorResponse(w, r, http.StatusBadRequest, "error.missing_magic_link_token")
+		return
+	}
+
+	userID, err := h.magicLinks.Redeem(r.Context(), token)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("magic link verification failed")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	// The link only proves access to the mailbox, not that the account may log in
+	user, ok := h.activeUser(w, r, userID)
+	if !ok || !h.localLoginAllowed(w, r, user) {
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
+
+// BeginPasskeyLogin returns the options for navigator.credentials.get.
+// No email is asked for; the passkey the user picks names the account.
+// POST /api/v1/auth/webauthn/login/begin
+func (h *UserHandler) BeginPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	begin, err := h.passkeys.BeginLogin(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to begin passkey login")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(begin))
+}
+
+// FinishPasskeyLogin verifies a passkey assertion and logs its user in
+// POST /api/v1/auth/webauthn/login/finish
+func (h *UserHandler) FinishPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.FinishPasskeyLoginRequest
+
+	// Validate and parse JSON
+	if err := h.validator.ValidateAndParseJSON(r, &req); err != nil {
+		h.logger.Error().Err(err).Msg("validation failed")
+		validationErrorResponse(w, r, err)
+		return
+	}
+
+	userID, err := h.passkeys.FinishLogin(r.Context(), &req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("session_id", req.SessionID.String()).Msg("passkey login failed")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	user, ok := h.activeUser(w, r, userID)
+	if !ok || !h.localLoginAllowed(w, r, user) {
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
+
+// BeginSSOLogin returns where to send the browser to sign in with the
+// identity provider
+// POST /api/v1/auth/sso/begin
+func (h *UserHandler) BeginSSOLogin(w http.ResponseWriter, r *http.Request) {
+	begin, err := h.sso.Begin(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to begin sso login")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(begin))
+}
+
+// FinishSSOLogin completes the identity provider's callback and logs its
+// user in. The redirect URL registered with the provider can be this
+// endpoint or an admin console page that passes the query through.
+// GET /api/v1/auth/sso/callback?code=...&state=...
+func (h *UserHandler) FinishSSOLogin(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		h.logger.Warn().Str("error", providerErr).Str("description", query.Get("error_description")).Msg("sso provider returned an error")
+		errorResponse(w, r, http.StatusUnauthorized, "error.sso_login_failed")
+		return
+	}
+	req := models.FinishSSOLoginRequest{State: query.Get("state"), Code: query.Get("code")}
+	if req.State == "" || req.Code == "" {
+		errorResponse(w, r, http.StatusBadRequest, "error.sso_session_invalid")
+		return
+	}
+
+	userID, err := h.sso.Finish(r.Context(), &req)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("sso login failed")
+		serviceErrorResponse(w, r, err)
+		return
+	}
+
+	user, ok := h.activeUser(w, r, userID)
+	if !ok {
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
+
+// localLoginAllowed answers the request itself when the account must sign
+// in through SSO instead
+func (h *UserHandler) localLoginAllowed(w http.ResponseWriter, r *http.Request, user *models.UserResponse) bool {
+	if h.sso == nil || h.sso.LocalLoginAllowed(user.Role) {
+		return true
+	}
+	h.logger.Info().Str("user_id", user.ID.String()).Msg("local login refused, sso required")
+	errorResponse(w, r, http.StatusForbidden, "error.sso_required")
+	return false
+}
+
+// activeUser loads a user who authenticated some other way than with a
+// password and answers the request itself unless they may log in
+func (h *UserHandler) activeUser(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (*models.UserResponse, bool) {
+	user, err := h.userService.GetUserByID(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to get user")
+		serviceErrorResponse(w, r, err)
+		return nil, false
+	}
+	switch user.Status {
+	case models.StatusActive:
+		return user, true
+	case models.StatusSuspended:
+		errorResponse(w, r, http.StatusForbidden, "error.account_suspended")
+	default:
+		errorResponse(w, r, http.StatusUnauthorized, "error.account_inactive")
+	}
+	return nil, false
+}
+
+func (h *UserHandler) issueLoginToken(w http.ResponseWriter, r *http.Request, user *models.UserResponse) {
+	token, expiresAt, err := h.tokens.Issue(user.ID, user.Role)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("failed to issue token")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
 		return
 	}
 
 	h.logger.Info().Str("user_id", user.ID.String()).Msg("user logged in successfully")
-	response.JSON(w, http.StatusOK, response.SuccessWithMessage(user, "Login successful"))
+	response.JSON(w, http.StatusOK, response.SuccessWithMessage(models.LoginResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: expiresAt,
+		User:      user,
+	}, "Login successful"))
+}
+
+// ListLoginLocations lists where the caller's recent logins came from
+// GET /api/v1/me/login-locations
+func (h *UserHandler) ListLoginLocations(w http.ResponseWriter, r *http.Request) {
+	userID := auth.PrincipalFromContext(r.Context()).UserID
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	locations, err := h.loginSecurity.ListLocations(r.Context(), userID, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to list login locations")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, locations)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.Success(data))
 }
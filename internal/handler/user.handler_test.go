@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+func TestSignupIgnoresRequestedRole(t *testing.T) {
+	h := apptest.New(t)
+
+	resp := h.Do(t, http.MethodPost, "/api/v1/users", map[string]string{
+		"email":      "mallory@example.com",
+		"password":   apptest.Password,
+		"first_name": "Mallory",
+		"last_name":  "Example",
+		"role":       string(models.RoleSuperAdmin),
+	}, "")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("signup status = %d, want %d: %s", resp.StatusCode, http.StatusCreated, resp.Body)
+	}
+	var user models.UserResponse
+	resp.Data(t, &user)
+	if user.Role != models.RoleGamer {
+		t.Fatalf("signed up with role %q, want %q", user.Role, models.RoleGamer)
+	}
+}
+
+func TestUpdateAndDeleteUserNeedOwnAccount(t *testing.T) {
+	h := apptest.New(t)
+	alice := h.CreateUser(t, models.RoleGamer)
+	bob := h.CreateUser(t, models.RoleGamer)
+	update := map[string]string{"first_name": "Changed", "last_name": "Name"}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		body   interface{}
+		target *models.User
+		token  string
+		want   int
+	}{
+		{"anonymous update", http.MethodPut, update, alice, "", http.StatusUnauthorized},
+		{"anonymous delete", http.MethodDelete, nil, alice, "", http.StatusUnauthorized},
+		{"update someone else", http.MethodPut, update, alice, h.TokenFor(t, bob), http.StatusForbidden},
+		{"delete someone else", http.MethodDelete, nil, alice, h.TokenFor(t, bob), http.StatusForbidden},
+		{"update own account", http.MethodPut, update, alice, h.TokenFor(t, alice), http.StatusOK},
+		{"delete own account", http.MethodDelete, nil, bob, h.TokenFor(t, bob), http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := h.Do(t, tc.method, "/api/v1/users/"+tc.target.ID.String(), tc.body, tc.token)
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tc.want, resp.Body)
+			}
+		})
+	}
+
+	if stored, _ := h.Repos.User.GetByID(t.Context(), alice.ID); stored.FirstName != "Changed" || stored.Status != models.StatusActive {
+		t.Fatalf("alice = %+v, want renamed and still active", stored)
+	}
+}
+
+func TestPublicUserLookupsHideAccountDetails(t *testing.T) {
+	h := apptest.New(t)
+	username := "johnd"
+	phone := "+447700900123"
+	user := h.Repos.User.Add(&models.User{
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Role:      models.RoleAdmin,
+		Status:    models.StatusActive,
+		Phone:     &phone,
+		Username:  &username,
+	})
+
+	for _, path := range []string{
+		"/api/v1/users/by-username/" + username,
+		"/api/v1/users?ids=" + user.ID.String(),
+		"/api/v2/users",
+	} {
+		t.Run(path, func(t *testing.T) {
+			resp := h.Do(t, http.MethodGet, path, nil, "")
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, http.StatusOK, resp.Body)
+			}
+			body := string(resp.Body)
+			if !strings.Contains(body, `"display_name":"John D."`) {
+				t.Fatalf("no display name in %s", body)
+			}
+			for _, private := range []string{"john@example.com", phone, `"role"`, `"status"`, "Doe"} {
+				if strings.Contains(body, private) {
+					t.Fatalf("%s exposes %s", body, private)
+				}
+			}
+		})
+	}
+}
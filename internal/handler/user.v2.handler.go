@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	v2 "github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/dto/v2"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+)
+
+// ListUsersV2 lists users with the v2 page format. It needs no login, so
+// each user is only what anyone may see of them and role and status
+// filters are ignored.
+// GET /api/v2/users
+func (h *UserHandler) ListUsersV2(w http.ResponseWriter, r *http.Request) {
+	query, ok := h.parseListUsersQuery(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := h.userService.ListPublicUsers(r.Context(), query.page, query.limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list users")
+		errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		return
+	}
+
+	data, ok := selectFields(w, r, users)
+	if !ok {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v2.NewList(data, query.page, query.limit, len(users)))
+}
@@ -0,0 +1,127 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func TestAddressDefaultsMoveBetweenAddresses(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	addresses := service.NewAddressService(repository.NewAddressRepository(queries()), repository.NewTransactor(testDB))
+
+	user := createUser(t, models.RoleGamer)
+	req := &models.AddressRequest{
+		RecipientName: "Test User",
+		Line1:         "1 Market St",
+		City:          "San Francisco",
+		PostalCode:    "94105",
+		CountryCode:   "US",
+	}
+
+	first, err := addresses.Create(ctx, user.ID, req)
+	if err != nil {
+		t.Fatalf("Create first: %v", err)
+	}
+	if !first.IsDefaultShipping || !first.IsDefaultBilling {
+		t.Fatalf("first address defaults = %v/%v, want true/true", first.IsDefaultShipping, first.IsDefaultBilling)
+	}
+
+	req.Line1 = "2 Market St"
+	req.IsDefaultShipping = true
+	if _, err := addresses.Create(ctx, user.ID, req); err != nil {
+		t.Fatalf("Create second: %v", err)
+	}
+
+	reloaded, err := addresses.Get(ctx, user.ID, first.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if reloaded.IsDefaultShipping || !reloaded.IsDefaultBilling {
+		t.Fatalf("first address defaults = %v/%v, want false/true", reloaded.IsDefaultShipping, reloaded.IsDefaultBilling)
+	}
+}
+
+func TestAddressOwnership(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	addresses := service.NewAddressService(repository.NewAddressRepository(queries()), repository.NewTransactor(testDB))
+
+	owner := createUser(t, models.RoleGamer)
+	other := createUser(t, models.RoleGamer)
+
+	address, err := addresses.Create(ctx, owner.ID, &models.AddressRequest{
+		RecipientName: "Owner",
+		Line1:         "1 Owner Way",
+		City:          "Addis Ababa",
+		PostalCode:    "1000",
+		CountryCode:   "ET",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := addresses.Get(ctx, other.ID, address.ID); err == nil {
+		t.Fatal("another user could read the address")
+	}
+	if err := addresses.Delete(ctx, other.ID, address.ID); err == nil {
+		t.Fatal("another user could delete the address")
+	}
+}
+
+func TestAddressDefaultsAreUnique(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewAddressRepository(queries())
+	addresses := service.NewAddressService(repo, repository.NewTransactor(testDB))
+	user := createUser(t, models.RoleGamer)
+
+	first, err := addresses.Create(ctx, user.ID, &models.AddressRequest{
+		RecipientName: "Test User",
+		Line1:         "1 Market St",
+		City:          "San Francisco",
+		PostalCode:    "94105",
+		CountryCode:   "US",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Bypassing the service, a second default is refused by the database
+	if _, err := repo.Create(ctx, &models.Address{
+		UserID:            user.ID,
+		RecipientName:     "Test User",
+		Line1:             "2 Market St",
+		City:              "San Francisco",
+		CountryCode:       "US",
+		IsDefaultShipping: true,
+	}); err == nil {
+		t.Fatal("created a second default shipping address")
+	}
+
+	// A failed update leaves the defaults where they were
+	if _, err := addresses.Update(ctx, user.ID, uuid.New(), &models.AddressRequest{
+		RecipientName:     "Test User",
+		Line1:             "3 Market St",
+		City:              "San Francisco",
+		CountryCode:       "US",
+		IsDefaultShipping: true,
+		IsDefaultBilling:  true,
+	}); err == nil {
+		t.Fatal("updated a missing address")
+	}
+	reloaded, err := addresses.Get(ctx, user.ID, first.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !reloaded.IsDefaultShipping || !reloaded.IsDefaultBilling {
+		t.Fatalf("first address defaults = %v/%v after a failed update, want true/true", reloaded.IsDefaultShipping, reloaded.IsDefaultBilling)
+	}
+}
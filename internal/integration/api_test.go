@@ -0,0 +1,85 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/app"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/rs/zerolog"
+)
+
+// TestSignupLoginAddressBook drives the assembled API over HTTP against the
+// real database
+func TestSignupLoginAddressBook(t *testing.T) {
+	reset(t)
+
+	// The lifecycle isn't run, so the shared database handle stays open
+	application := app.New(apptest.Config(), testDB, zerolog.Nop())
+	server := httptest.NewServer(application.Handler)
+	defer server.Close()
+
+	post := func(path, token string, body interface{}) *http.Response {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encoding body: %v", err)
+		}
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("building request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("POST %s: %v", path, err)
+		}
+		return resp
+	}
+
+	credentials := map[string]string{"email": "int@example.com", "password": apptest.Password}
+	signup := post("/api/v1/users", "", map[string]string{
+		"email":      credentials["email"],
+		"password":   credentials["password"],
+		"first_name": "Integration",
+		"last_name":  "Test",
+	})
+	signup.Body.Close()
+	if signup.StatusCode != http.StatusCreated {
+		t.Fatalf("signup status = %d, want %d", signup.StatusCode, http.StatusCreated)
+	}
+
+	login := post("/api/v1/auth/login", "", credentials)
+	defer login.Body.Close()
+	if login.StatusCode != http.StatusOK {
+		t.Fatalf("login status = %d, want %d", login.StatusCode, http.StatusOK)
+	}
+	var envelope struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(login.Body).Decode(&envelope); err != nil || envelope.Data.Token == "" {
+		t.Fatalf("login response has no token (err %v)", err)
+	}
+
+	address := post("/api/v1/me/addresses", envelope.Data.Token, map[string]string{
+		"recipient_name": "Integration Test",
+		"line1":          "1 Test Rd",
+		"city":           "Berlin",
+		"postal_code":    "10115",
+		"country_code":   "DE",
+	})
+	address.Body.Close()
+	if address.StatusCode != http.StatusCreated {
+		t.Fatalf("create address status = %d, want %d", address.StatusCode, http.StatusCreated)
+	}
+}
@@ -0,0 +1,125 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func newConsentService() service.ConsentService {
+	q := queries()
+	return service.NewConsentService(
+		repository.NewConsentRepository(q),
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestConsentPendingFollowsMandatoryVersions(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	consents := newConsentService()
+
+	admin := createUser(t, models.RoleSuperAdmin)
+	gamer := createUser(t, models.RoleGamer)
+	actor := models.Actor{UserID: &admin.ID, Role: admin.Role}
+
+	publish := func(kind models.LegalDocumentKind, version string, mandatory bool, effectiveAt *time.Time) *models.LegalDocument {
+		t.Helper()
+		doc, err := consents.Publish(ctx, &models.PublishLegalDocumentRequest{
+			Kind:        kind,
+			Version:     version,
+			URL:         "https://example.com/legal/" + string(kind) + "/" + version,
+			Mandatory:   ptr(mandatory),
+			EffectiveAt: effectiveAt,
+		}, actor)
+		if err != nil {
+			t.Fatalf("Publish %s %s: %v", kind, version, err)
+		}
+		return doc
+	}
+	assertPending := func(want ...*models.LegalDocument) {
+		t.Helper()
+		pending, err := consents.Pending(ctx, gamer.ID)
+		if err != nil {
+			t.Fatalf("Pending: %v", err)
+		}
+		if len(pending) != len(want) {
+			t.Fatalf("%d documents pending, want %d", len(pending), len(want))
+		}
+		for i := range want {
+			if pending[i].ID != want[i].ID {
+				t.Fatalf("pending %s %s, want %s %s", pending[i].Kind, pending[i].Version, want[i].Kind, want[i].Version)
+			}
+		}
+	}
+	accept := func(doc *models.LegalDocument) {
+		t.Helper()
+		if _, err := consents.Accept(ctx, gamer.ID, doc.ID, "203.0.113.7"); err != nil {
+			t.Fatalf("Accept: %v", err)
+		}
+	}
+
+	assertPending()
+
+	v1 := publish(models.LegalTerms, "1", true, nil)
+	assertPending(v1)
+	accept(v1)
+	assertPending()
+
+	// Optional versions and versions not yet in force don't block
+	publish(models.LegalTerms, "1.1", false, nil)
+	publish(models.LegalPrivacy, "1", true, ptr(time.Now().Add(time.Hour)))
+	assertPending()
+
+	v2 := publish(models.LegalTerms, "2", true, nil)
+	assertPending(v2)
+	accept(v2)
+	accept(v2)
+	assertPending()
+
+	status, err := consents.Status(ctx, gamer.ID)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status.Accepted) != 2 {
+		t.Fatalf("%d acceptances recorded, want 2", len(status.Accepted))
+	}
+
+	current, err := consents.ListCurrent(ctx)
+	if err != nil {
+		t.Fatalf("ListCurrent: %v", err)
+	}
+	if len(current) != 1 || current[0].ID != v2.ID {
+		t.Fatalf("current documents are %+v, want only terms 2", current)
+	}
+}
+
+func TestConsentPublishDuplicateVersion(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	consents := newConsentService()
+
+	admin := createUser(t, models.RoleSuperAdmin)
+	actor := models.Actor{UserID: &admin.ID, Role: admin.Role}
+	req := &models.PublishLegalDocumentRequest{
+		Kind:      models.LegalPrivacy,
+		Version:   "2024-01",
+		URL:       "https://example.com/legal/privacy",
+		Mandatory: ptr(true),
+	}
+
+	if _, err := consents.Publish(ctx, req, actor); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if _, err := consents.Publish(ctx, req, actor); !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("publishing the same version again returned %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,130 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/fraud"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/rs/zerolog"
+)
+
+// newScreenedUserService runs signups through the disposable email and
+// velocity rules, two signups per IP and hour
+func newScreenedUserService() (service.UserService, service.ModerationService, service.FraudService) {
+	q := queries()
+	userRepo := repository.NewUserRepository(q)
+	fraudRepo := repository.NewFraudRepository(q)
+	pipeline := fraud.NewPipeline(fraud.Thresholds{Review: 50, Block: 80},
+		fraud.NewDisposableEmailRule(),
+		fraud.NewVelocityRule(fraudRepo, 2, time.Hour),
+	)
+
+	moderations := service.NewModerationService(repository.NewModerationRepository(q), userRepo, notification.NewLogNotifier(zerolog.Nop()))
+	frauds := service.NewFraudService(fraudRepo, userRepo, pipeline)
+	users := service.NewUserService(userRepo, moderations, frauds, repository.NewTransactor(testDB), outbox.NewPublisher(repository.NewOutboxRepository(q)))
+	return users, moderations, frauds
+}
+
+func signupRequest(email string) *models.CreateUserRequest {
+	return &models.CreateUserRequest{
+		Email:     email,
+		Password:  "Corr3ct-Horse!",
+		FirstName: "Risky",
+		LastName:  "User",
+	}
+}
+
+func TestFraudDisposableEmailHeldForReview(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users, moderations, frauds := newScreenedUserService()
+
+	created, err := users.CreateUser(ctx, signupRequest("throwaway@mailinator.com"), "203.0.113.7")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if created.Status != models.StatusInactive {
+		t.Fatalf("status %s, want inactive until reviewed", created.Status)
+	}
+
+	assessments, err := frauds.ListForUser(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("ListForUser: %v", err)
+	}
+	if len(assessments) != 1 || assessments[0].Decision != models.FraudReview || len(assessments[0].Signals) != 1 {
+		t.Fatalf("assessments %+v, want one review with one signal", assessments)
+	}
+
+	subject := models.SubjectSignup
+	items, err := moderations.List(ctx, nil, &subject, 1, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(items) != 1 || items[0].SubjectID != created.ID {
+		t.Fatalf("moderation items %+v, want the signup queued", items)
+	}
+
+	if _, err := moderations.Approve(ctx, items[0].ID, nil); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	approved, err := users.GetUserByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if approved.Status != models.StatusActive {
+		t.Fatalf("status %s after approval, want active", approved.Status)
+	}
+}
+
+func TestFraudVelocityCountsBlockedAttempts(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users, _, _ := newScreenedUserService()
+	const ip = "198.51.100.23"
+
+	// Two clean signups pass; from the limit on signups are reviewed
+	for i := range 4 {
+		created, err := users.CreateUser(ctx, signupRequest(fmt.Sprintf("player%d@example.com", i)), ip)
+		if err != nil {
+			t.Fatalf("CreateUser %d: %v", i, err)
+		}
+		want := models.StatusActive
+		if i >= 2 {
+			want = models.StatusInactive
+		}
+		if created.Status != want {
+			t.Fatalf("signup %d status %s, want %s", i, created.Status, want)
+		}
+	}
+
+	// At twice the limit the score is maxed out and the signup refused
+	_, err := users.CreateUser(ctx, signupRequest("player4@example.com"), ip)
+	if err == nil || !strings.Contains(err.Error(), "signup rejected") {
+		t.Fatalf("CreateUser returned %v, want a rejection", err)
+	}
+
+	// Refusals are recorded too, so they keep counting against the IP
+	fraudRepo := repository.NewFraudRepository(queries())
+	count, err := fraudRepo.CountRecentByIP(ctx, models.FraudCheckSignup, ip, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("CountRecentByIP: %v", err)
+	}
+	if count != 5 {
+		t.Fatalf("counted %d attempts, want 5", count)
+	}
+
+	// Other addresses are unaffected
+	if _, err := users.CreateUser(ctx, signupRequest("elsewhere@example.com"), "192.0.2.1"); err != nil {
+		t.Fatalf("CreateUser from another IP: %v", err)
+	}
+}
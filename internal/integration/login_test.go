@@ -0,0 +1,212 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/geoip"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+// staticLocator places a fixed set of addresses
+type staticLocator map[string]*geoip.Location
+
+func (l staticLocator) Locate(ctx context.Context, ip string) (*geoip.Location, error) {
+	return l[ip], nil
+}
+
+// inbox keeps every notification sent
+type inbox struct {
+	sent []notification.Notification
+}
+
+func (i *inbox) Notify(ctx context.Context, userID uuid.UUID, n notification.Notification) error {
+	i.sent = append(i.sent, n)
+	return nil
+}
+
+var testLocations = staticLocator{
+	"203.0.113.10": {CountryCode: "DE", Country: "Germany", City: "Berlin", Latitude: 52.52, Longitude: 13.40},
+	"203.0.113.11": {CountryCode: "DE", Country: "Germany", City: "Hamburg", Latitude: 53.55, Longitude: 9.99},
+	"203.0.113.20": {CountryCode: "AU", Country: "Australia", City: "Sydney", Latitude: -33.87, Longitude: 151.21},
+}
+
+func newLoginSecurityService(notifier notification.Notifier, stepUp bool) service.LoginSecurityService {
+	return service.NewLoginSecurityService(repository.NewLoginRepository(queries()), repository.NewDeviceRepository(queries()), testLocations, notifier, repository.NewTransactor(testDB), service.LoginPolicy{
+		MaxTravelSpeed:         1000,
+		StepUp:                 stepUp,
+		ChallengeTTL:           10 * time.Minute,
+		MaxChallengesPerWindow: 3,
+		ChallengeWindow:        time.Hour,
+	})
+}
+
+func loginFrom(userID uuid.UUID, ip, deviceID string) models.LoginAttempt {
+	return models.LoginAttempt{UserID: userID, IPAddress: ip, DeviceID: deviceID, DeviceName: deviceID}
+}
+
+func TestLoginSecurityAlertsOnImpossibleTravel(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	logins := newLoginSecurityService(notifications, false)
+	gamer := createUser(t, models.RoleGamer)
+
+	// The first login sets the baseline; a nearby one is unremarkable
+	for _, ip := range []string{"203.0.113.10", "203.0.113.11"} {
+		if challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, ip, "")); err != nil || challenge != nil {
+			t.Fatalf("CheckLogin %s = %v, %v; want no challenge", ip, challenge, err)
+		}
+	}
+	if len(notifications.sent) != 0 {
+		t.Fatalf("%d alerts sent for ordinary logins", len(notifications.sent))
+	}
+
+	if _, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.20", "")); err != nil {
+		t.Fatalf("CheckLogin from Sydney: %v", err)
+	}
+	if len(notifications.sent) != 1 || notifications.sent[0].Data["anomaly"] != string(models.LoginImpossibleTravel) {
+		t.Fatalf("alerts %+v, want one impossible_travel alert", notifications.sent)
+	}
+
+	locations, err := logins.ListLocations(ctx, gamer.ID, 10)
+	if err != nil {
+		t.Fatalf("ListLocations: %v", err)
+	}
+	if len(locations) != 3 || locations[0].Anomaly == nil || !locations[0].Verified {
+		t.Fatalf("locations %+v, want the flagged login first and verified", locations)
+	}
+}
+
+func TestLoginSecurityStepUpChallenge(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	logins := newLoginSecurityService(notifications, true)
+	gamer := createUser(t, models.RoleGamer)
+
+	if _, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.10", "")); err != nil {
+		t.Fatalf("baseline CheckLogin: %v", err)
+	}
+	challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.20", ""))
+	if err != nil {
+		t.Fatalf("CheckLogin: %v", err)
+	}
+	if challenge == nil || len(notifications.sent) != 1 {
+		t.Fatalf("challenge %v with %d notifications, want a challenge and its code", challenge, len(notifications.sent))
+	}
+	code := regexp.MustCompile(`\b\d{6}\b`).FindString(notifications.sent[0].Body)
+
+	if _, err := logins.VerifyChallenge(ctx, challenge.ID, "000000", false); err == nil || !strings.Contains(err.Error(), "invalid verification code") {
+		t.Fatalf("wrong code returned %v", err)
+	}
+	userID, err := logins.VerifyChallenge(ctx, challenge.ID, code, false)
+	if err != nil {
+		t.Fatalf("VerifyChallenge: %v", err)
+	}
+	if userID != gamer.ID {
+		t.Fatalf("verified %s, want %s", userID, gamer.ID)
+	}
+	if _, err := logins.VerifyChallenge(ctx, challenge.ID, code, false); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("reused code returned %v", err)
+	}
+
+	// Once verified, Sydney is a known location for the next login
+	last, err := repository.NewLoginRepository(queries()).LastLocation(ctx, gamer.ID)
+	if err != nil {
+		t.Fatalf("LastLocation: %v", err)
+	}
+	if last == nil || last.CountryCode == nil || *last.CountryCode != "AU" {
+		t.Fatalf("last location %+v, want the verified Sydney login", last)
+	}
+}
+
+func TestLoginSecurityLimitsChallenges(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	logins := newLoginSecurityService(notifications, true)
+	gamer := createUser(t, models.RoleGamer)
+
+	if _, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.10", "")); err != nil {
+		t.Fatalf("baseline CheckLogin: %v", err)
+	}
+	// Each unverified login from Sydney raises a fresh code, up to the limit
+	for i := 0; i < 3; i++ {
+		if challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.20", "")); err != nil || challenge == nil {
+			t.Fatalf("CheckLogin %d = %v, %v; want a challenge", i, challenge, err)
+		}
+	}
+	if _, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.20", "")); err == nil || !strings.Contains(err.Error(), "too many login challenges") {
+		t.Fatalf("CheckLogin past the limit returned %v", err)
+	}
+	if len(notifications.sent) != 3 {
+		t.Fatalf("sent %d codes, want 3", len(notifications.sent))
+	}
+
+	// Logins that need no code aren't held back by the limit
+	if challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.11", "")); err != nil || challenge != nil {
+		t.Fatalf("ordinary CheckLogin = %v, %v; want no challenge", challenge, err)
+	}
+}
+
+func TestLoginSecurityTrustedDeviceSkipsStepUp(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	logins := newLoginSecurityService(notifications, true)
+	gamer := createUser(t, models.RoleGamer)
+
+	if challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.10", "laptop")); err != nil || challenge != nil {
+		t.Fatalf("first CheckLogin = %v, %v; want no challenge", challenge, err)
+	}
+
+	// Same city, new device: held back until the code is entered
+	challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.10", "phone"))
+	if err != nil || challenge == nil || challenge.DeviceID == nil {
+		t.Fatalf("new device CheckLogin = %+v, %v; want a challenge for the device", challenge, err)
+	}
+	if notifications.sent[0].Data["anomaly"] != string(models.LoginNewDevice) {
+		t.Fatalf("notification %+v, want a new_device code", notifications.sent[0])
+	}
+	code := regexp.MustCompile(`\b\d{6}\b`).FindString(notifications.sent[0].Body)
+	if _, err := logins.VerifyChallenge(ctx, challenge.ID, code, true); err != nil {
+		t.Fatalf("VerifyChallenge: %v", err)
+	}
+
+	// Impossible travel from the trusted phone is alerted on, not challenged
+	if challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.20", "phone")); err != nil || challenge != nil {
+		t.Fatalf("trusted device CheckLogin = %v, %v; want no challenge", challenge, err)
+	}
+	if len(notifications.sent) != 2 || notifications.sent[1].Type != "suspicious_login" {
+		t.Fatalf("notifications %+v, want a suspicious_login alert", notifications.sent)
+	}
+
+	devices, err := logins.ListDevices(ctx, gamer.ID)
+	if err != nil {
+		t.Fatalf("ListDevices: %v", err)
+	}
+	if len(devices) != 2 || devices[0].Name != "phone" || !devices[0].Trusted || devices[1].Trusted {
+		t.Fatalf("devices %+v, want the trusted phone first", devices)
+	}
+
+	// Revoked, the phone is a stranger again
+	if err := logins.RevokeDevice(ctx, gamer.ID, devices[0].ID); err != nil {
+		t.Fatalf("RevokeDevice: %v", err)
+	}
+	if err := logins.RevokeDevice(ctx, gamer.ID, devices[0].ID); err == nil || !strings.Contains(err.Error(), "device not found") {
+		t.Fatalf("second RevokeDevice returned %v", err)
+	}
+	if challenge, err := logins.CheckLogin(ctx, loginFrom(gamer.ID, "203.0.113.20", "phone")); err != nil || challenge == nil {
+		t.Fatalf("revoked device CheckLogin = %v, %v; want a challenge", challenge, err)
+	}
+}
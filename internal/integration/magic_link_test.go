@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func newMagicLinkService(notifier notification.Notifier, ttl time.Duration) service.MagicLinkService {
+	return service.NewMagicLinkService(repository.NewMagicLinkRepository(queries()), repository.NewUserRepository(queries()), notifier, service.MagicLinkPolicy{
+		URL:          "https://marketplace.test/sign-in",
+		TTL:          ttl,
+		MaxPerWindow: 2,
+		Window:       time.Hour,
+	})
+}
+
+var signInLink = regexp.MustCompile(`https://\S+`)
+
+// linkToken is the token in the sign-in link emailed in n
+func linkToken(t *testing.T, n notification.Notification) string {
+	t.Helper()
+	link, err := url.Parse(signInLink.FindString(n.Body))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("no sign-in link in %q", n.Body)
+	}
+	return link.Query().Get("token")
+}
+
+func TestMagicLinkIsSingleUse(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	links := newMagicLinkService(notifications, 15*time.Minute)
+	gamer := createUser(t, models.RoleGamer)
+
+	if err := links.Send(ctx, gamer.Email, "203.0.113.10"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(notifications.sent) != 1 {
+		t.Fatalf("%d notifications sent, want the link", len(notifications.sent))
+	}
+	token := linkToken(t, notifications.sent[0])
+
+	userID, err := links.Redeem(ctx, token)
+	if err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+	if userID != gamer.ID {
+		t.Fatalf("redeemed for %s, want %s", userID, gamer.ID)
+	}
+	if _, err := links.Redeem(ctx, token); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("second Redeem returned %v", err)
+	}
+}
+
+func TestMagicLinkExpires(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	links := newMagicLinkService(notifications, time.Millisecond)
+	gamer := createUser(t, models.RoleGamer)
+
+	if err := links.Send(ctx, gamer.Email, ""); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if _, err := links.Redeem(ctx, linkToken(t, notifications.sent[0])); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("Redeem of an expired link returned %v", err)
+	}
+}
+
+func TestMagicLinkRateLimited(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	notifications := &inbox{}
+	links := newMagicLinkService(notifications, 15*time.Minute)
+	gamer := createUser(t, models.RoleGamer)
+
+	// Over the limit and for unknown addresses Send still succeeds, so the
+	// caller learns nothing, but no email goes out
+	for i := 0; i < 4; i++ {
+		if err := links.Send(ctx, gamer.Email, ""); err != nil {
+			t.Fatalf("Send %d: %v", i, err)
+		}
+	}
+	if err := links.Send(ctx, "nobody@example.com", ""); err != nil {
+		t.Fatalf("Send to an unknown address: %v", err)
+	}
+	if len(notifications.sent) != 2 {
+		t.Fatalf("%d links sent, want the limit of 2", len(notifications.sent))
+	}
+}
@@ -0,0 +1,161 @@
+//go:build integration
+
+// Package integration exercises repositories and services against a real
+// Postgres started with testcontainers. Run with:
+//
+//	go test -tags=integration ./internal/integration/...
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	_ "github.com/lib/pq"
+)
+
+const migrationsDir = "../../db/migrations"
+
+// testDB is shared by every test; each test starts from empty tables via reset
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx, "postgres:16-alpine",
+		postgres.WithDatabase("marketplace_test"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		postgres.BasicWaitStrategies(),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "starting postgres container: %v\n", err)
+		return 1
+	}
+	defer testcontainers.TerminateContainer(container)
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "getting connection string: %v\n", err)
+		return 1
+	}
+
+	testDB, err = sql.Open("postgres", dsn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "opening database: %v\n", err)
+		return 1
+	}
+	defer testDB.Close()
+
+	if err := migrate(ctx, testDB, migrationsDir); err != nil {
+		fmt.Fprintf(os.Stderr, "running migrations: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// migrate applies every up migration in filename order, the same order
+// golang-migrate uses
+func migrate(ctx context.Context, database *sql.DB, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		script, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if _, err := database.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// reset empties every table written by the application; seeded lookup
+// tables such as permissions are left alone, as is the default tenant
+func reset(t *testing.T) {
+	t.Helper()
+
+	tables := []string{
+		"outbox",
+		"events",
+		"login_challenges",
+		"devices",
+		"push_deliveries",
+		"push_tokens",
+		"notification_preferences",
+		"phone_verifications",
+		"verified_phones",
+		"webauthn_sessions",
+		"webauthn_credentials",
+		"sso_login_states",
+		"sso_identities",
+		"magic_links",
+		"login_locations",
+		"fraud_assessments",
+		"consent_acceptances",
+		"legal_documents",
+		"profile_preferences",
+		"addresses",
+		"user_permissions",
+		"audit_logs",
+		"suspensions",
+		"moderation_items",
+		"query_plans",
+		"oauth_tokens",
+		"oauth_authorization_codes",
+		"oauth_grants",
+		"oauth_clients",
+		"organization_members",
+		"organizations",
+		"users",
+	}
+	if _, err := testDB.Exec("TRUNCATE " + strings.Join(tables, ", ") + " CASCADE"); err != nil {
+		t.Fatalf("truncating tables: %v", err)
+	}
+	if _, err := testDB.Exec("DELETE FROM tenants WHERE id <> $1", models.DefaultTenantID); err != nil {
+		t.Fatalf("deleting tenants: %v", err)
+	}
+}
+
+func queries() *db.Queries {
+	return db.New(db.NewTxConn(testDB))
+}
+
+// createUser inserts a user with role directly through the repository
+func createUser(t *testing.T, role models.UserRole) *models.User {
+	t.Helper()
+
+	user, err := repository.NewUserRepository(queries()).Create(context.Background(), &models.User{
+		Email:        uuid.NewString() + "@example.com",
+		PasswordHash: "not-a-real-hash",
+		FirstName:    "Test",
+		LastName:     "User",
+		Role:         role,
+	})
+	if err != nil {
+		t.Fatalf("creating user: %v", err)
+	}
+	return user
+}
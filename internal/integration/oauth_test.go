@@ -0,0 +1,204 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+const codeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func newOAuthService() service.OAuthService {
+	q := queries()
+	return service.NewOAuthService(
+		repository.NewOAuthRepository(q),
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+		service.OAuthPolicy{CodeTTL: time.Minute, AccessTokenTTL: time.Hour, RefreshTokenTTL: 24 * time.Hour},
+	)
+}
+
+func oauthErrorCode(err error) string {
+	var oauthErr *service.OAuthError
+	if errors.As(err, &oauthErr) {
+		return oauthErr.Code
+	}
+	return ""
+}
+
+func TestOAuthAuthorizationCodeFlow(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	oauth := newOAuthService()
+
+	admin := createUser(t, models.RoleSuperAdmin)
+	gamer := createUser(t, models.RoleGamer)
+
+	client, err := oauth.RegisterClient(ctx, &models.CreateOAuthClientRequest{
+		Name:         "Companion",
+		RedirectURIs: []string{"https://companion.test/callback"},
+		Scopes:       []models.OAuthScope{models.ScopeProfileRead, models.ScopeAddressesRead},
+		Confidential: true,
+	}, models.Actor{UserID: &admin.ID})
+	if err != nil {
+		t.Fatalf("RegisterClient: %v", err)
+	}
+	if client.ClientSecret == "" {
+		t.Fatal("confidential client registered without a secret")
+	}
+	creds := models.OAuthClientCredentials{ClientID: client.ID.String(), ClientSecret: client.ClientSecret}
+
+	sum := sha256.Sum256([]byte(codeVerifier))
+	authorize := models.OAuthAuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            client.ID.String(),
+		RedirectURI:         "https://companion.test/callback",
+		Scope:               string(models.ScopeProfileRead),
+		State:               "xyz",
+		CodeChallenge:       base64.RawURLEncoding.EncodeToString(sum[:]),
+		CodeChallengeMethod: "S256",
+	}
+
+	consent, err := oauth.Authorize(ctx, gamer.ID, &authorize)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if consent.Granted || len(consent.Scopes) != 1 {
+		t.Fatalf("consent screen = %+v, want one ungranted scope", consent)
+	}
+
+	approve := true
+	decision, err := oauth.Decide(ctx, gamer.ID, &models.OAuthDecisionRequest{OAuthAuthorizeRequest: authorize, Approve: &approve})
+	if err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+	redirect, err := url.Parse(decision.RedirectURI)
+	if err != nil {
+		t.Fatalf("parsing redirect %q: %v", decision.RedirectURI, err)
+	}
+	if redirect.Query().Get("state") != "xyz" {
+		t.Fatalf("redirect %q lost the state", decision.RedirectURI)
+	}
+	code := redirect.Query().Get("code")
+
+	// The user isn't asked again for what they already approved
+	if consent, err = oauth.Authorize(ctx, gamer.ID, &authorize); err != nil || !consent.Granted {
+		t.Fatalf("second Authorize returned %+v, %v, want granted", consent, err)
+	}
+
+	exchange := &models.OAuthTokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  authorize.RedirectURI,
+		CodeVerifier: codeVerifier,
+	}
+	tokens, err := oauth.Exchange(ctx, creds, exchange)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if tokens.Scope != string(models.ScopeProfileRead) {
+		t.Fatalf("issued scope %q, want %q", tokens.Scope, models.ScopeProfileRead)
+	}
+	if _, err := oauth.Exchange(ctx, creds, exchange); oauthErrorCode(err) != "invalid_grant" {
+		t.Fatalf("reusing the code returned %v, want invalid_grant", err)
+	}
+
+	access, err := oauth.VerifyAccessToken(ctx, tokens.AccessToken)
+	if err != nil || access == nil || access.UserID != gamer.ID {
+		t.Fatalf("VerifyAccessToken returned %+v, %v", access, err)
+	}
+	introspection, err := oauth.Introspect(ctx, creds, tokens.AccessToken)
+	if err != nil || !introspection.Active || introspection.Subject != gamer.ID.String() {
+		t.Fatalf("Introspect returned %+v, %v", introspection, err)
+	}
+
+	// Refresh tokens rotate, and replaying a used one revokes the grant's tokens
+	refreshed, err := oauth.Exchange(ctx, creds, &models.OAuthTokenRequest{GrantType: "refresh_token", RefreshToken: tokens.RefreshToken})
+	if err != nil {
+		t.Fatalf("refreshing: %v", err)
+	}
+	if _, err := oauth.Exchange(ctx, creds, &models.OAuthTokenRequest{GrantType: "refresh_token", RefreshToken: tokens.RefreshToken}); oauthErrorCode(err) != "invalid_grant" {
+		t.Fatalf("replaying the refresh token returned %v, want invalid_grant", err)
+	}
+	if access, err := oauth.VerifyAccessToken(ctx, refreshed.AccessToken); err != nil || access != nil {
+		t.Fatalf("access token after replay = %+v, %v, want revoked", access, err)
+	}
+
+	if err := oauth.RevokeGrant(ctx, gamer.ID, client.ID); err != nil {
+		t.Fatalf("RevokeGrant: %v", err)
+	}
+	grants, err := oauth.ListGrants(ctx, gamer.ID)
+	if err != nil {
+		t.Fatalf("ListGrants: %v", err)
+	}
+	if len(grants) != 0 {
+		t.Fatalf("got %d grants after revoking, want 0", len(grants))
+	}
+}
+
+func TestOAuthRejectsUnregisteredRedirectURI(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	oauth := newOAuthService()
+
+	admin := createUser(t, models.RoleSuperAdmin)
+	gamer := createUser(t, models.RoleGamer)
+
+	client, err := oauth.RegisterClient(ctx, &models.CreateOAuthClientRequest{
+		Name:         "Mobile",
+		RedirectURIs: []string{"http://127.0.0.1:8400/callback"},
+		Scopes:       []models.OAuthScope{models.ScopeProfileRead},
+	}, models.Actor{UserID: &admin.ID})
+	if err != nil {
+		t.Fatalf("RegisterClient: %v", err)
+	}
+	if client.Confidential || client.ClientSecret != "" {
+		t.Fatal("public client registered with a secret")
+	}
+
+	// Errors before the redirect URI checks out go to the user, not the URI
+	_, err = oauth.Authorize(ctx, gamer.ID, &models.OAuthAuthorizeRequest{
+		ResponseType: "code",
+		ClientID:     client.ID.String(),
+		RedirectURI:  "https://attacker.test/callback",
+	})
+	if err == nil {
+		t.Fatal("Authorize accepted an unregistered redirect URI")
+	}
+
+	// After it does, they're reported on the redirect
+	rejection, err := oauth.Authorize(ctx, gamer.ID, &models.OAuthAuthorizeRequest{
+		ResponseType: "code",
+		ClientID:     client.ID.String(),
+		RedirectURI:  "http://127.0.0.1:8400/callback",
+		Scope:        string(models.ScopeAddressesRead),
+	})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	redirect, err := url.Parse(rejection.RedirectURI)
+	if err != nil {
+		t.Fatalf("parsing redirect %q: %v", rejection.RedirectURI, err)
+	}
+	if redirect.Query().Get("error") != "invalid_request" {
+		t.Fatalf("redirect %q, want invalid_request for the missing PKCE challenge", rejection.RedirectURI)
+	}
+
+	if _, err := oauth.RegisterClient(ctx, &models.CreateOAuthClientRequest{
+		Name:         "Insecure",
+		RedirectURIs: []string{"http://companion.test/callback"},
+		Scopes:       []models.OAuthScope{models.ScopeProfileRead},
+	}, models.Actor{UserID: &admin.ID}); err == nil {
+		t.Fatal("RegisterClient accepted a plain HTTP redirect URI")
+	}
+}
@@ -0,0 +1,116 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newOpsService() service.OpsService {
+	q := queries()
+	outboxRepo := repository.NewOutboxRepository(q)
+	return service.NewOpsService(
+		repository.NewUserRepository(q),
+		outboxRepo,
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+		outbox.NewPublisher(outboxRepo),
+	)
+}
+
+func TestOpsCreatesOnlyTheFirstSuperAdmin(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	ops := newOpsService()
+
+	req := &models.CreateUserRequest{
+		Email:     "ops@example.com",
+		Password:  "Correct-Horse-42",
+		FirstName: "Ops",
+		LastName:  "Team",
+	}
+	user, err := ops.CreateSuperAdmin(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateSuperAdmin: %v", err)
+	}
+	if user.Role != models.RoleSuperAdmin {
+		t.Fatalf("role = %s, want %s", user.Role, models.RoleSuperAdmin)
+	}
+
+	req.Email = "second@example.com"
+	if _, err := ops.CreateSuperAdmin(ctx, req); err == nil || !strings.Contains(err.Error(), "already has a super admin") {
+		t.Fatalf("second CreateSuperAdmin = %v, want refused", err)
+	}
+}
+
+func TestOpsSetPassword(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	gamer := createUser(t, models.RoleGamer)
+
+	if err := newOpsService().SetPassword(ctx, gamer.Email, "Rotated-Password-7"); err != nil {
+		t.Fatalf("SetPassword: %v", err)
+	}
+
+	user, err := repository.NewUserRepository(queries()).GetByID(ctx, gamer.ID)
+	if err != nil || user == nil {
+		t.Fatalf("GetByID = %v, %v", user, err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("Rotated-Password-7")) != nil {
+		t.Fatal("stored hash does not match the new password")
+	}
+}
+
+func TestOpsReplayOutbox(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	outboxRepo := repository.NewOutboxRepository(queries())
+
+	old, err := outboxRepo.Create(ctx, &models.OutboxMessage{EventName: "user.created", Payload: []byte(`{}`), OccurredAt: time.Now().Add(-2 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	recent, err := outboxRepo.Create(ctx, &models.OutboxMessage{EventName: "user.created", Payload: []byte(`{}`), OccurredAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	other, err := outboxRepo.Create(ctx, &models.OutboxMessage{EventName: "user.suspended", Payload: []byte(`{}`), OccurredAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for _, message := range []*models.OutboxMessage{old, recent, other} {
+		if err := outboxRepo.MarkSent(ctx, message.ID); err != nil {
+			t.Fatalf("MarkSent: %v", err)
+		}
+	}
+
+	eventName := "user.created"
+	requeued, err := newOpsService().ReplayOutbox(ctx, time.Now().Add(-time.Hour), &eventName)
+	if err != nil {
+		t.Fatalf("ReplayOutbox: %v", err)
+	}
+	if requeued != 1 {
+		t.Fatalf("requeued %d messages, want 1", requeued)
+	}
+
+	var pending []*models.OutboxMessage
+	err = repository.NewTransactor(testDB).WithinTx(ctx, func(ctx context.Context) error {
+		pending, err = outboxRepo.Claim(ctx, 10)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != recent.ID {
+		t.Fatalf("pending = %+v, want only the recent user.created", pending)
+	}
+}
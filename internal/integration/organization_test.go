@@ -0,0 +1,109 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func newOrganizationService() service.OrganizationService {
+	q := queries()
+	return service.NewOrganizationService(
+		repository.NewOrganizationRepository(q),
+		repository.NewUserRepository(q),
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestOrganizationMemberRoles(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	orgs := newOrganizationService()
+
+	owner := createUser(t, models.RoleGamer)
+	manager := createUser(t, models.RoleGamer)
+	support := createUser(t, models.RoleGamer)
+	ownerActor := models.Actor{UserID: &owner.ID}
+	managerActor := models.Actor{UserID: &manager.ID}
+
+	org, err := orgs.Create(ctx, &models.CreateOrganizationRequest{Name: "Pixel Forge"}, ownerActor)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if org.Role != models.OrgRoleOwner {
+		t.Fatalf("creator role = %q, want owner", org.Role)
+	}
+
+	add := func(actor models.Actor, user *models.User, role models.OrganizationRole) error {
+		_, err := orgs.AddMember(ctx, org.ID, &models.AddOrganizationMemberRequest{UserID: user.ID, Role: role}, actor)
+		return err
+	}
+	if err := add(ownerActor, manager, models.OrgRoleManager); err != nil {
+		t.Fatalf("owner adding a manager: %v", err)
+	}
+	if err := add(ownerActor, manager, models.OrgRoleSupport); !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("adding an existing member returned %v, want ErrConflict", err)
+	}
+
+	// Managers look after support staff only
+	if err := add(managerActor, support, models.OrgRoleManager); err == nil || !strings.Contains(err.Error(), "insufficient privileges") {
+		t.Fatalf("manager adding a manager returned %v, want insufficient privileges", err)
+	}
+	if err := add(managerActor, support, models.OrgRoleSupport); err != nil {
+		t.Fatalf("manager adding support: %v", err)
+	}
+	if err := orgs.RemoveMember(ctx, org.ID, owner.ID, managerActor); err == nil || !strings.Contains(err.Error(), "insufficient privileges") {
+		t.Fatalf("manager removing the owner returned %v, want insufficient privileges", err)
+	}
+
+	// The last owner can neither be demoted nor leave
+	if _, err := orgs.UpdateMember(ctx, org.ID, owner.ID, &models.UpdateOrganizationMemberRequest{Role: models.OrgRoleManager}, ownerActor); err == nil || !strings.Contains(err.Error(), "must keep an owner") {
+		t.Fatalf("demoting the last owner returned %v, want must keep an owner", err)
+	}
+	if err := orgs.RemoveMember(ctx, org.ID, owner.ID, ownerActor); err == nil || !strings.Contains(err.Error(), "must keep an owner") {
+		t.Fatalf("last owner leaving returned %v, want must keep an owner", err)
+	}
+
+	if _, err := orgs.UpdateMember(ctx, org.ID, manager.ID, &models.UpdateOrganizationMemberRequest{Role: models.OrgRoleOwner}, ownerActor); err != nil {
+		t.Fatalf("promoting the manager: %v", err)
+	}
+	if err := orgs.RemoveMember(ctx, org.ID, owner.ID, ownerActor); err != nil {
+		t.Fatalf("owner leaving once another owner exists: %v", err)
+	}
+	if _, err := orgs.Get(ctx, owner.ID, org.ID); err == nil || !strings.Contains(err.Error(), "organization not found") {
+		t.Fatalf("former member getting the organization returned %v, want not found", err)
+	}
+
+	members, err := orgs.ListMembers(ctx, manager.ID, org.ID)
+	if err != nil {
+		t.Fatalf("ListMembers: %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("got %d members, want 2", len(members))
+	}
+
+	// Every change is attributed to the member who made it
+	var byManager int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM audit_logs WHERE target_id = $1 AND actor_id = $2", org.ID, manager.ID).Scan(&byManager); err != nil {
+		t.Fatalf("counting audit logs: %v", err)
+	}
+	if byManager != 1 {
+		t.Fatalf("got %d audit logs by the manager, want 1", byManager)
+	}
+	var total int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM audit_logs WHERE target_type = 'organization' AND target_id = $1", org.ID).Scan(&total); err != nil {
+		t.Fatalf("counting audit logs: %v", err)
+	}
+	// created, manager added, support added, manager promoted, owner left
+	if total != 5 {
+		t.Fatalf("got %d organization audit logs, want 5", total)
+	}
+}
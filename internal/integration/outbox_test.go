@@ -0,0 +1,159 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+func countOutbox(t *testing.T, where string) int {
+	t.Helper()
+
+	var count int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM outbox WHERE " + where).Scan(&count); err != nil {
+		t.Fatalf("counting outbox rows: %v", err)
+	}
+	return count
+}
+
+func TestOutboxRolledBackWithChange(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	tx := repository.NewTransactor(testDB)
+	publisher := outbox.NewPublisher(repository.NewOutboxRepository(queries()))
+
+	errAbort := errors.New("abort")
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := repository.NewUserRepository(queries()).Create(ctx, &models.User{
+			Email:        "rollback@example.com",
+			PasswordHash: "x",
+			FirstName:    "Roll",
+			LastName:     "Back",
+			Role:         models.RoleGamer,
+			Status:       models.StatusActive,
+		}); err != nil {
+			return err
+		}
+		if err := publisher.Publish(ctx, events.UserCreated{UserID: uuid.New()}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithinTx: %v, want abort", err)
+	}
+
+	// Neither the change nor its event may survive the rollback
+	if n := countOutbox(t, "TRUE"); n != 0 {
+		t.Fatalf("outbox has %d rows after rollback, want 0", n)
+	}
+	var users int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM users").Scan(&users); err != nil {
+		t.Fatalf("counting users: %v", err)
+	}
+	if users != 0 {
+		t.Fatalf("users has %d rows after rollback, want 0", users)
+	}
+}
+
+type failingSink struct{ err error }
+
+func (s failingSink) Send(ctx context.Context, id uuid.UUID, event events.Event, occurredAt time.Time) error {
+	return s.err
+}
+
+func TestOutboxRelayMarksSentAndRetriesFailures(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewOutboxRepository(queries())
+	tx := repository.NewTransactor(testDB)
+	publisher := outbox.NewPublisher(repo)
+
+	for i := 0; i < 2; i++ {
+		if err := publisher.Publish(ctx, events.UserReinstated{UserID: uuid.New()}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	// A failing sink leaves every message pending and records the attempt
+	failing := outbox.NewRelay(repo, tx, events.NewBus(nil, zerolog.Nop()), failingSink{errors.New("broker down")}, 0, zerolog.Nop())
+	if sent, err := failing.RelayBatch(ctx, 10); err == nil || sent != 0 {
+		t.Fatalf("RelayBatch with failing sink = %d, %v; want 0 and an error", sent, err)
+	}
+	if n := countOutbox(t, "sent_at IS NULL"); n != 2 {
+		t.Fatalf("%d messages pending, want 2", n)
+	}
+	if n := countOutbox(t, "attempts = 1 AND last_error = 'broker down'"); n != 1 {
+		t.Fatalf("%d messages recorded the failure, want 1", n)
+	}
+
+	bus := events.NewBus(nil, zerolog.Nop())
+	delivered := 0
+	events.On(bus, "counter", func(ctx context.Context, e events.UserReinstated) error {
+		delivered++
+		return nil
+	})
+	sent, err := outbox.NewRelay(repo, tx, bus, nil, 0, zerolog.Nop()).RelayBatch(ctx, 10)
+	if err != nil {
+		t.Fatalf("RelayBatch: %v", err)
+	}
+	if sent != 2 || delivered != 2 {
+		t.Fatalf("sent %d and delivered %d, want 2", sent, delivered)
+	}
+	if n := countOutbox(t, "sent_at IS NULL"); n != 0 {
+		t.Fatalf("%d messages still pending, want 0", n)
+	}
+}
+
+func TestOutboxRelayParksAndWaitsForTheLock(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewOutboxRepository(queries())
+	tx := repository.NewTransactor(testDB)
+	if err := outbox.NewPublisher(repo).Publish(ctx, events.UserReinstated{UserID: uuid.New()}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	// Out of attempts on the first failure, the message is parked
+	failing := outbox.NewRelay(repo, tx, events.NewBus(nil, zerolog.Nop()), failingSink{errors.New("broker down")}, 1, zerolog.Nop())
+	if sent, err := failing.RelayBatch(ctx, 10); err != nil || sent != 0 {
+		t.Fatalf("RelayBatch = %d, %v; want the message parked without an error", sent, err)
+	}
+	if n := countOutbox(t, "dead_at IS NOT NULL AND attempts = 1 AND last_error = 'broker down'"); n != 1 {
+		t.Fatalf("%d messages parked, want 1", n)
+	}
+	if requeued, err := repo.Requeue(ctx, time.Time{}, nil); err != nil || requeued != 1 {
+		t.Fatalf("Requeue = %d, %v; want the parked message", requeued, err)
+	}
+
+	// Another relay holding the lock keeps this one idle
+	other, err := testDB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	defer other.Rollback()
+	if _, err := other.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('outbox_relay'))"); err != nil {
+		t.Fatalf("taking the relay lock: %v", err)
+	}
+	relay := outbox.NewRelay(repo, tx, events.NewBus(nil, zerolog.Nop()), nil, 1, zerolog.Nop())
+	if sent, err := relay.RelayBatch(ctx, 10); err != nil || sent != 0 {
+		t.Fatalf("RelayBatch while locked = %d, %v; want nothing sent", sent, err)
+	}
+
+	if err := other.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if sent, err := relay.RelayBatch(ctx, 10); err != nil || sent != 1 {
+		t.Fatalf("RelayBatch = %d, %v; want the message sent", sent, err)
+	}
+}
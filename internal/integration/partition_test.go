@@ -0,0 +1,71 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/jobs"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+func partitionExists(t *testing.T, name string) bool {
+	t.Helper()
+
+	var exists bool
+	if err := testDB.QueryRow("SELECT to_regclass($1) IS NOT NULL", name).Scan(&exists); err != nil {
+		t.Fatalf("looking up %s: %v", name, err)
+	}
+	return exists
+}
+
+func monthPartition(table string, month time.Time) string {
+	return fmt.Sprintf("%s_y%04dm%02d", table, month.Year(), month.Month())
+}
+
+func TestPartitionMaintenance(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	partitions := repository.NewPartitionRepository(queries())
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	old := thisMonth.AddDate(0, -6, 0)
+
+	if _, err := partitions.CreateMonthly(ctx, "events", old); err != nil {
+		t.Fatalf("CreateMonthly: %v", err)
+	}
+	_, err := repository.NewEventRepository(queries()).Create(ctx, &models.StoredEvent{Name: "user.created", Payload: []byte(`{}`), OccurredAt: old.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("creating event: %v", err)
+	}
+
+	job := jobs.NewPartitionMaintenanceJob(partitions, []jobs.PartitionedTable{
+		{Name: "audit_logs"},
+		{Name: "events", Retention: 90 * 24 * time.Hour},
+	}, 2, zerolog.Nop())
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, table := range []string{"audit_logs", "events"} {
+		for i := 0; i <= 2; i++ {
+			if name := monthPartition(table, thisMonth.AddDate(0, i, 0)); !partitionExists(t, name) {
+				t.Errorf("%s was not created", name)
+			}
+		}
+	}
+	if name := monthPartition("events", old); partitionExists(t, name) {
+		t.Errorf("%s is past retention but was kept", name)
+	}
+
+	// Running again changes nothing
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+func TestPasskeySessionIsSingleUse(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	passkeys := repository.NewPasskeyRepository(queries())
+	gamer := createUser(t, models.RoleGamer)
+
+	session, err := passkeys.CreateSession(ctx, &models.WebAuthnSession{
+		UserID:    &gamer.ID,
+		Ceremony:  models.WebAuthnRegistration,
+		Data:      json.RawMessage(`{"challenge":"abc"}`),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	// The wrong ceremony must not consume it
+	if got, err := passkeys.ConsumeSession(ctx, session.ID, models.WebAuthnLogin); err != nil || got != nil {
+		t.Fatalf("ConsumeSession as login = %v, %v; want nil, nil", got, err)
+	}
+
+	got, err := passkeys.ConsumeSession(ctx, session.ID, models.WebAuthnRegistration)
+	if err != nil {
+		t.Fatalf("ConsumeSession: %v", err)
+	}
+	if got == nil || got.UserID == nil || *got.UserID != gamer.ID {
+		t.Fatalf("consumed %+v, want the session for %s", got, gamer.ID)
+	}
+	if got, err := passkeys.ConsumeSession(ctx, session.ID, models.WebAuthnRegistration); err != nil || got != nil {
+		t.Fatalf("second ConsumeSession = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestPasskeySessionExpires(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	passkeys := repository.NewPasskeyRepository(queries())
+
+	session, err := passkeys.CreateSession(ctx, &models.WebAuthnSession{
+		Ceremony:  models.WebAuthnLogin,
+		Data:      json.RawMessage(`{"challenge":"abc"}`),
+		ExpiresAt: time.Now().Add(-time.Second),
+	})
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	if got, err := passkeys.ConsumeSession(ctx, session.ID, models.WebAuthnLogin); err != nil || got != nil {
+		t.Fatalf("ConsumeSession of expired session = %v, %v; want nil, nil", got, err)
+	}
+	if err := passkeys.DeleteExpiredSessions(ctx); err != nil {
+		t.Fatalf("DeleteExpiredSessions: %v", err)
+	}
+}
+
+func TestPasskeyCredentialIsUnique(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	passkeys := repository.NewPasskeyRepository(queries())
+	gamer := createUser(t, models.RoleGamer)
+	other := createUser(t, models.RoleGamer)
+
+	passkey := &models.Passkey{
+		UserID:       gamer.ID,
+		Name:         "Laptop",
+		CredentialID: []byte("credential-1"),
+		Credential:   json.RawMessage(`{"id":"Y3JlZGVudGlhbC0x"}`),
+	}
+	created, err := passkeys.Create(ctx, passkey)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// One authenticator credential can belong to one account only
+	passkey.UserID = other.ID
+	if _, err := passkeys.Create(ctx, passkey); !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("duplicate Create returned %v, want ErrConflict", err)
+	}
+
+	if err := passkeys.RecordUse(ctx, passkey.CredentialID, json.RawMessage(`{"id":"Y3JlZGVudGlhbC0x","authenticator":{"signCount":7}}`)); err != nil {
+		t.Fatalf("RecordUse: %v", err)
+	}
+	listed, err := passkeys.ListByUser(ctx, gamer.ID)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(listed) != 1 || listed[0].LastUsedAt == nil {
+		t.Fatalf("listed %+v, want one used passkey", listed)
+	}
+
+	if deleted, err := passkeys.Delete(ctx, created.ID, other.ID); err != nil || deleted {
+		t.Fatalf("Delete by another user = %v, %v; want false", deleted, err)
+	}
+	if deleted, err := passkeys.Delete(ctx, created.ID, gamer.ID); err != nil || !deleted {
+		t.Fatalf("Delete = %v, %v; want true", deleted, err)
+	}
+}
@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func TestPermissionRepositoryEffectiveCombinesRoleAndGrants(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewPermissionRepository(queries())
+
+	admin := createUser(t, models.RoleAdmin)
+
+	before, err := repo.ListEffective(ctx, admin.ID, admin.Role)
+	if err != nil {
+		t.Fatalf("ListEffective: %v", err)
+	}
+	if contains(before, models.PermPermissionsManage) {
+		t.Fatal("admins should not manage permissions by default")
+	}
+
+	granted, err := repo.Grant(ctx, admin.ID, models.PermPermissionsManage, nil)
+	if err != nil || !granted {
+		t.Fatalf("Grant = %v, %v; want true, nil", granted, err)
+	}
+	again, err := repo.Grant(ctx, admin.ID, models.PermPermissionsManage, nil)
+	if err != nil || again {
+		t.Fatalf("second Grant = %v, %v; want false, nil", again, err)
+	}
+
+	after, err := repo.ListEffective(ctx, admin.ID, admin.Role)
+	if err != nil {
+		t.Fatalf("ListEffective: %v", err)
+	}
+	if !contains(after, models.PermPermissionsManage) || len(after) != len(before)+1 {
+		t.Fatalf("ListEffective after grant = %v", after)
+	}
+}
+
+func contains(permissions []models.Permission, want models.Permission) bool {
+	for _, permission := range permissions {
+		if permission == want {
+			return true
+		}
+	}
+	return false
+}
+
+// failingAudit refuses every entry
+type failingAudit struct{}
+
+func (failingAudit) Create(ctx context.Context, entry *models.AuditLog) (*models.AuditLog, error) {
+	return nil, errors.New("audit log unavailable")
+}
+
+func TestPermissionGrantRolledBackWithoutAudit(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewPermissionRepository(queries())
+	permissions := service.NewPermissionService(repo, repository.NewUserRepository(queries()), failingAudit{}, repository.NewTransactor(testDB))
+
+	superAdmin := createUser(t, models.RoleSuperAdmin)
+	admin := createUser(t, models.RoleAdmin)
+	actor := models.Actor{UserID: &superAdmin.ID, Role: superAdmin.Role}
+
+	if _, err := permissions.Grant(ctx, admin.ID, models.PermPermissionsManage, actor); err == nil {
+		t.Fatal("Grant succeeded without an audit entry")
+	}
+	effective, err := repo.ListEffective(ctx, admin.ID, admin.Role)
+	if err != nil {
+		t.Fatalf("ListEffective: %v", err)
+	}
+	if contains(effective, models.PermPermissionsManage) {
+		t.Fatal("the grant outlived its failed audit entry")
+	}
+}
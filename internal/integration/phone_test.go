@@ -0,0 +1,208 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/rs/zerolog"
+)
+
+// fakeSMSSender keeps what it was asked to send
+type fakeSMSSender struct {
+	sent []fakeSMS
+}
+
+type fakeSMS struct {
+	from, to, body string
+}
+
+func (s *fakeSMSSender) Send(ctx context.Context, from, to, body string) (string, error) {
+	s.sent = append(s.sent, fakeSMS{from: from, to: to, body: body})
+	return "SM" + to, nil
+}
+
+var smsCode = regexp.MustCompile(`\d{6}`)
+
+// lastCode is the code in the last text sent
+func (s *fakeSMSSender) lastCode(t *testing.T) string {
+	t.Helper()
+
+	if len(s.sent) == 0 {
+		t.Fatal("no text sent")
+	}
+	code := smsCode.FindString(s.sent[len(s.sent)-1].body)
+	if code == "" {
+		t.Fatalf("no code in %q", s.sent[len(s.sent)-1].body)
+	}
+	return code
+}
+
+// wrongCode is a code that isn't code
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
+
+func setPhone(t *testing.T, users repository.UserRepository, user *models.User, phone string) {
+	t.Helper()
+
+	user.Phone = &phone
+	if _, err := users.Update(context.Background(), user); err != nil {
+		t.Fatalf("setting phone: %v", err)
+	}
+}
+
+func TestPhoneVerification(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users := repository.NewUserRepository(queries())
+	phones := repository.NewPhoneRepository(queries())
+	sender := &fakeSMSSender{}
+	texter := notification.NewTexter(sender, notification.SMSRules{
+		Allowed: []string{"+44", "+1"},
+		Senders: map[string]string{"+44": "Marketplace"},
+		From:    "+15550000000",
+	})
+	phoneService := service.NewPhoneService(phones, users, texter, repository.NewTransactor(testDB), service.PhonePolicy{
+		CodeTTL:      10 * time.Minute,
+		MaxPerWindow: 2,
+		Window:       time.Hour,
+	})
+	user := createUser(t, models.RoleGamer)
+
+	if _, err := phoneService.SendCode(ctx, user.ID); err == nil || !strings.Contains(err.Error(), "phone number not set") {
+		t.Fatalf("SendCode without a phone: %v", err)
+	}
+
+	// Outside the allowed countries nothing is sent
+	setPhone(t, users, user, "+33612345678")
+	if _, err := phoneService.SendCode(ctx, user.ID); err == nil || !strings.Contains(err.Error(), "phone number not supported") {
+		t.Fatalf("SendCode to a disallowed country: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("sent %d texts, want none", len(sender.sent))
+	}
+
+	setPhone(t, users, user, "+447700900123")
+	if _, err := phoneService.SendCode(ctx, user.ID); err != nil {
+		t.Fatalf("SendCode: %v", err)
+	}
+	if sender.sent[0].from != "Marketplace" {
+		t.Fatalf("texted from %q, want the UK sender", sender.sent[0].from)
+	}
+	code := sender.lastCode(t)
+
+	if _, err := phoneService.Confirm(ctx, user.ID, wrongCode(code)); err == nil || !strings.Contains(err.Error(), "code is incorrect") {
+		t.Fatalf("Confirm with a wrong code: %v", err)
+	}
+	status, err := phoneService.Confirm(ctx, user.ID, code)
+	if err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	if !status.Verified {
+		t.Fatal("phone not verified after confirming")
+	}
+	if _, err := phoneService.Confirm(ctx, user.ID, code); err == nil {
+		t.Fatal("a code worked twice")
+	}
+	if _, err := phoneService.SendCode(ctx, user.ID); err == nil || !strings.Contains(err.Error(), "already verified") {
+		t.Fatalf("SendCode for a verified phone: %v", err)
+	}
+
+	// A new number has to be verified again
+	setPhone(t, users, user, "+12025550123")
+	if status, err := phoneService.GetStatus(ctx, user.ID); err != nil || status.Verified {
+		t.Fatalf("status after changing number = %+v, %v; want unverified", status, err)
+	}
+	if _, err := phoneService.SendCode(ctx, user.ID); err != nil {
+		t.Fatalf("SendCode: %v", err)
+	}
+	if _, err := phoneService.SendCode(ctx, user.ID); err == nil || !strings.Contains(err.Error(), "too many verification codes") {
+		t.Fatalf("SendCode past the limit: %v", err)
+	}
+}
+
+func TestPhoneVerificationLocksAfterWrongCodes(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users := repository.NewUserRepository(queries())
+	sender := &fakeSMSSender{}
+	phoneService := service.NewPhoneService(repository.NewPhoneRepository(queries()), users, notification.NewTexter(sender, notification.SMSRules{From: "+15550000000"}), repository.NewTransactor(testDB), service.PhonePolicy{
+		CodeTTL: 10 * time.Minute,
+	})
+	user := createUser(t, models.RoleGamer)
+	setPhone(t, users, user, "+447700900123")
+
+	if _, err := phoneService.SendCode(ctx, user.ID); err != nil {
+		t.Fatalf("SendCode: %v", err)
+	}
+	code := sender.lastCode(t)
+	for i := 0; i < 5; i++ {
+		if _, err := phoneService.Confirm(ctx, user.ID, wrongCode(code)); err == nil {
+			t.Fatal("a wrong code was accepted")
+		}
+	}
+	if _, err := phoneService.Confirm(ctx, user.ID, code); err == nil || !strings.Contains(err.Error(), "expired or already used") {
+		t.Fatalf("Confirm after too many attempts: %v", err)
+	}
+}
+
+func TestSMSNotificationsNeedOptInAndVerifiedPhone(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users := repository.NewUserRepository(queries())
+	phones := repository.NewPhoneRepository(queries())
+	notifications := repository.NewNotificationRepository(queries())
+	sender := &fakeSMSSender{}
+	notifier := notification.NewRouter(notifications, map[notification.Channel]notification.Notifier{
+		notification.ChannelSMS: notification.NewSMSNotifier(phones, notification.NewTexter(sender, notification.SMSRules{From: "+15550000000"}), zerolog.Nop()),
+	})
+	user := createUser(t, models.RoleGamer)
+	setPhone(t, users, user, "+447700900123")
+	if err := phones.SetVerified(ctx, user.ID, "+447700900123"); err != nil {
+		t.Fatalf("SetVerified: %v", err)
+	}
+	alert := notification.Notification{Type: "suspicious_login", Title: "New sign-in to your account"}
+
+	// Texts are opt-in
+	if err := notifier.Notify(ctx, user.ID, alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("texted %d times before opting in", len(sender.sent))
+	}
+
+	if _, err := notifications.UpsertPreferences(ctx, &models.NotificationPreferences{UserID: user.ID, Email: true, SMS: true}); err != nil {
+		t.Fatalf("UpsertPreferences: %v", err)
+	}
+	if err := notifier.Notify(ctx, user.ID, alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	// Only urgent notifications are worth a text
+	if err := notifier.Notify(ctx, user.ID, notification.Notification{Type: "account_reinstated", Title: "Reinstated"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].to != "+447700900123" {
+		t.Fatalf("sent %+v, want the alert texted to the verified phone", sender.sent)
+	}
+
+	// Nor is an unverified number texted
+	setPhone(t, users, user, "+12025550123")
+	if err := notifier.Notify(ctx, user.ID, alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("texted the new, unverified number")
+	}
+}
@@ -0,0 +1,70 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func TestProfilePreferencesUpsert(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	profiles := service.NewProfileService(repository.NewProfileRepository(queries()), repository.NewUserRepository(queries()))
+	user := createUser(t, models.RoleGamer)
+
+	prefs, err := profiles.GetPreferences(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetPreferences: %v", err)
+	}
+	if prefs.ShowLibrary || prefs.ShowWishlist || prefs.ShowActivity {
+		t.Fatalf("default preferences %+v share a section, want all hidden", prefs)
+	}
+
+	for _, showLibrary := range []bool{true, false} {
+		_, err := profiles.UpdatePreferences(ctx, user.ID, &models.UpdateProfilePreferencesRequest{
+			ShowLibrary:  ptr(showLibrary),
+			ShowWishlist: ptr(true),
+			ShowActivity: ptr(false),
+		})
+		if err != nil {
+			t.Fatalf("UpdatePreferences: %v", err)
+		}
+	}
+
+	prefs, err = profiles.GetPreferences(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetPreferences: %v", err)
+	}
+	if prefs.ShowLibrary || !prefs.ShowWishlist || prefs.ShowActivity {
+		t.Fatalf("preferences are %+v after the second update", prefs)
+	}
+}
+
+func TestProfileHidesInactiveUsers(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users := repository.NewUserRepository(queries())
+	profiles := service.NewProfileService(repository.NewProfileRepository(queries()), users)
+
+	user := createUser(t, models.RoleGamer)
+	user.Username = ptr("shadow")
+	if _, err := users.Update(ctx, user); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := profiles.GetPublic(ctx, "SHADOW"); err != nil {
+		t.Fatalf("GetPublic: %v", err)
+	}
+
+	if err := users.UpdateStatus(ctx, user.ID, models.StatusSuspended); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if _, err := profiles.GetPublic(ctx, "shadow"); err == nil {
+		t.Fatal("GetPublic returned a suspended user's profile")
+	}
+}
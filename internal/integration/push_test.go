@@ -0,0 +1,139 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/rs/zerolog"
+)
+
+// fakePusher answers by token: "stale" is unregistered, "flaky" fails and
+// anything else is accepted
+type fakePusher struct {
+	pushed []string
+}
+
+func (p *fakePusher) Push(ctx context.Context, token string, n notification.Notification) (string, error) {
+	p.pushed = append(p.pushed, token)
+	switch token {
+	case "stale":
+		return "", fmt.Errorf("Unregistered: %w", notification.ErrTokenInvalid)
+	case "flaky":
+		return "", errors.New("service unavailable")
+	}
+	return "message-" + token, nil
+}
+
+func registerToken(t *testing.T, notifications service.NotificationService, user *models.User, token string) *models.PushToken {
+	t.Helper()
+
+	registered, err := notifications.RegisterPushToken(context.Background(), user.ID, &models.RegisterPushTokenRequest{
+		Platform: models.PushFCM,
+		Token:    token,
+	})
+	if err != nil {
+		t.Fatalf("RegisterPushToken(%s): %v", token, err)
+	}
+	return registered
+}
+
+func TestPushTokenRegistration(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewNotificationRepository(queries())
+	notifications := service.NewNotificationService(repo, repository.NewUserRepository(queries()), repository.NewTransactor(testDB), 2)
+	alice := createUser(t, models.RoleGamer)
+	bob := createUser(t, models.RoleGamer)
+
+	// Past the limit the least recently registered install goes
+	registerToken(t, notifications, alice, "phone")
+	registerToken(t, notifications, alice, "tablet")
+	registerToken(t, notifications, alice, "laptop")
+	tokens, err := notifications.ListPushTokens(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("ListPushTokens: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0].Token != "laptop" || tokens[1].Token != "tablet" {
+		t.Fatalf("tokens = %+v, want laptop then tablet", tokens)
+	}
+
+	// The tablet changing hands moves its token
+	moved := registerToken(t, notifications, bob, "tablet")
+	if moved.ID != tokens[1].ID {
+		t.Fatalf("re-registered token has ID %s, want %s", moved.ID, tokens[1].ID)
+	}
+	if tokens, _ := notifications.ListPushTokens(ctx, alice.ID); len(tokens) != 1 {
+		t.Fatalf("alice has %d tokens after the tablet moved, want 1", len(tokens))
+	}
+
+	if err := notifications.DeletePushToken(ctx, alice.ID, moved.ID); err == nil {
+		t.Fatal("alice deleted bob's token")
+	}
+	if err := notifications.DeletePushToken(ctx, bob.ID, moved.ID); err != nil {
+		t.Fatalf("DeletePushToken: %v", err)
+	}
+}
+
+func TestPushDeliveriesFollowPreferences(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewNotificationRepository(queries())
+	notifications := service.NewNotificationService(repo, repository.NewUserRepository(queries()), repository.NewTransactor(testDB), 10)
+	pusher := &fakePusher{}
+	notifier := notification.NewRouter(repo, map[notification.Channel]notification.Notifier{
+		notification.ChannelPush: notification.NewPushNotifier(repo, map[models.PushPlatform]notification.Pusher{
+			models.PushFCM: pusher,
+		}, zerolog.Nop()),
+	})
+	user := createUser(t, models.RoleGamer)
+	registerToken(t, notifications, user, "good")
+	registerToken(t, notifications, user, "stale")
+	registerToken(t, notifications, user, "flaky")
+
+	if err := notifier.Notify(ctx, user.ID, notification.Notification{Type: "account_reinstated", Title: "Reinstated"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	deliveries, err := notifications.ListDeliveries(ctx, user.ID, 1, 20)
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+	statuses := map[models.PushDeliveryStatus]int{}
+	for _, delivery := range deliveries {
+		statuses[delivery.Status]++
+	}
+	if len(deliveries) != 3 || statuses[models.PushSent] != 1 || statuses[models.PushFailed] != 1 || statuses[models.PushTokenInvalid] != 1 {
+		t.Fatalf("deliveries by status = %v, want one of each", statuses)
+	}
+	tokens, _ := notifications.ListPushTokens(ctx, user.ID)
+	if len(tokens) != 2 {
+		t.Fatalf("%d tokens left, want the unregistered one dropped", len(tokens))
+	}
+
+	// Security notifications and users who turned push off get nothing pushed
+	pusher.pushed = nil
+	if err := notifier.Notify(ctx, user.ID, notification.Notification{Type: "login_verification", Title: "Code"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if _, err := notifications.UpdatePreferences(ctx, user.ID, &models.UpdateNotificationPreferencesRequest{
+		Push:  ptr(false),
+		Email: ptr(true),
+		InApp: ptr(true),
+	}); err != nil {
+		t.Fatalf("UpdatePreferences: %v", err)
+	}
+	if err := notifier.Notify(ctx, user.ID, notification.Notification{Type: "account_reinstated", Title: "Reinstated"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(pusher.pushed) != 0 {
+		t.Fatalf("pushed to %v, want nothing", pusher.pushed)
+	}
+}
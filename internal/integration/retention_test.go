@@ -0,0 +1,183 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/retention"
+	"github.com/rs/zerolog"
+)
+
+func TestRetentionPurgesInBatches(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	q := queries()
+	eventRepo := repository.NewEventRepository(q)
+	outboxRepo := repository.NewOutboxRepository(q)
+
+	// Five old events take three batches of two; the recent one stays
+	for i := 0; i < 5; i++ {
+		if _, err := eventRepo.Create(ctx, &models.StoredEvent{Name: "user.created", Payload: []byte(`{}`), OccurredAt: time.Now().Add(-48 * time.Hour)}); err != nil {
+			t.Fatalf("creating event: %v", err)
+		}
+	}
+	recent, err := eventRepo.Create(ctx, &models.StoredEvent{Name: "user.created", Payload: []byte(`{}`), OccurredAt: time.Now()})
+	if err != nil {
+		t.Fatalf("creating event: %v", err)
+	}
+
+	// Only delivered outbox messages go, however old the pending one is
+	sent, err := outboxRepo.Create(ctx, &models.OutboxMessage{EventName: "user.created", Payload: []byte(`{}`)})
+	if err != nil {
+		t.Fatalf("creating outbox message: %v", err)
+	}
+	if err := outboxRepo.MarkSent(ctx, sent.ID); err != nil {
+		t.Fatalf("MarkSent: %v", err)
+	}
+	if _, err := testDB.Exec("UPDATE outbox SET sent_at = NOW() - INTERVAL '2 days' WHERE id = $1", sent.ID); err != nil {
+		t.Fatalf("ageing outbox message: %v", err)
+	}
+	pending, err := outboxRepo.Create(ctx, &models.OutboxMessage{EventName: "user.created", Payload: []byte(`{}`), OccurredAt: time.Now().Add(-48 * time.Hour)})
+	if err != nil {
+		t.Fatalf("creating outbox message: %v", err)
+	}
+
+	purger := retention.NewPurger(repository.NewRetentionRepository(q), config.RetentionConfig{
+		BatchSize: 2,
+		Events:    24 * time.Hour,
+		Outbox:    24 * time.Hour,
+	}, zerolog.Nop())
+	if err := purger.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	events, err := eventRepo.ListAfter(ctx, time.Time{}, uuid.Nil, 10)
+	if err != nil {
+		t.Fatalf("ListAfter: %v", err)
+	}
+	if len(events) != 1 || events[0].ID != recent.ID {
+		t.Fatalf("events left = %+v, want only the recent one", events)
+	}
+
+	var ids []string
+	rows, err := testDB.Query("SELECT id FROM outbox")
+	if err != nil {
+		t.Fatalf("listing outbox: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("scanning outbox: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 1 || ids[0] != pending.ID.String() {
+		t.Fatalf("outbox left = %v, want only %s", ids, pending.ID)
+	}
+}
+
+func TestRetentionAnonymizesAuditIPs(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	admin := createUser(t, models.RoleSuperAdmin)
+	ip := "203.0.113.7"
+
+	entry, err := repository.NewAuditRepository(queries()).Create(ctx, &models.AuditLog{
+		ActorID:    &admin.ID,
+		Action:     models.AuditUserStatusChanged,
+		TargetType: "user",
+		TargetID:   admin.ID,
+		IPAddress:  &ip,
+	})
+	if err != nil {
+		t.Fatalf("creating audit log: %v", err)
+	}
+	if _, err := testDB.Exec("UPDATE audit_logs SET created_at = NOW() - INTERVAL '2 days' WHERE id = $1", entry.ID); err != nil {
+		t.Fatalf("ageing audit log: %v", err)
+	}
+
+	purger := retention.NewPurger(repository.NewRetentionRepository(queries()), config.RetentionConfig{
+		BatchSize: 100,
+		AuditIPs:  24 * time.Hour,
+	}, zerolog.Nop())
+	if err := purger.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var stored *string
+	var action string
+	if err := testDB.QueryRow("SELECT ip_address, action FROM audit_logs WHERE id = $1", entry.ID).Scan(&stored, &action); err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	if stored != nil || action != models.AuditUserStatusChanged {
+		t.Fatalf("audit log = %v, %s; want the entry kept without its IP", stored, action)
+	}
+}
+
+func TestRetentionPurgesSignInRecords(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	user := createUser(t, models.RoleGamer)
+
+	var clientID uuid.UUID
+	if err := testDB.QueryRow(
+		"INSERT INTO oauth_clients (tenant_id, name, redirect_uris, scopes) VALUES ($1, 'App', '{https://app.example.com/callback}', '{profile:read}') RETURNING id",
+		models.DefaultTenantID,
+	).Scan(&clientID); err != nil {
+		t.Fatalf("creating oauth client: %v", err)
+	}
+
+	// One row past its retention and one still kept in each table; "age"
+	// is how long ago it expired, or for login locations was created
+	for _, age := range []string{"3 days", "1 hour"} {
+		var locationID uuid.UUID
+		if err := testDB.QueryRow(
+			"INSERT INTO login_locations (user_id, ip_address, created_at) VALUES ($1, '203.0.113.7', NOW() - $2::interval) RETURNING id",
+			user.ID, age,
+		).Scan(&locationID); err != nil {
+			t.Fatalf("creating login location: %v", err)
+		}
+		for _, row := range []struct {
+			statement string
+			args      []interface{}
+		}{
+			{"INSERT INTO login_challenges (user_id, location_id, code_hash, expires_at) VALUES ($1, $2, md5(random()::text), NOW() - $3::interval)", []interface{}{user.ID, locationID, age}},
+			{"INSERT INTO magic_links (user_id, token_hash, expires_at) VALUES ($1, md5(random()::text), NOW() - $2::interval)", []interface{}{user.ID, age}},
+			{"INSERT INTO phone_verifications (user_id, phone, code_hash, expires_at) VALUES ($1, '+447700900123', md5(random()::text), NOW() - $2::interval)", []interface{}{user.ID, age}},
+			{"INSERT INTO oauth_authorization_codes (code_hash, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at) VALUES (md5(random()::text), $1, $2, 'https://app.example.com/callback', '{profile:read}', 'challenge', NOW() - $3::interval)", []interface{}{clientID, user.ID, age}},
+			{"INSERT INTO oauth_tokens (token_hash, kind, client_id, user_id, scopes, expires_at) VALUES (md5(random()::text), 'access', $1, $2, '{profile:read}', NOW() - $3::interval)", []interface{}{clientID, user.ID, age}},
+		} {
+			if _, err := testDB.Exec(row.statement, row.args...); err != nil {
+				t.Fatalf("%s: %v", row.statement, err)
+			}
+		}
+	}
+
+	purger := retention.NewPurger(repository.NewRetentionRepository(queries()), config.RetentionConfig{
+		BatchSize:          100,
+		LoginLocations:     48 * time.Hour,
+		ExpiredCodes:       24 * time.Hour,
+		ExpiredOAuthTokens: 24 * time.Hour,
+	}, zerolog.Nop())
+	if err := purger.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, table := range []string{"login_locations", "login_challenges", "magic_links", "phone_verifications", "oauth_authorization_codes", "oauth_tokens"} {
+		var count int
+		if err := testDB.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+			t.Fatalf("counting %s: %v", table, err)
+		}
+		if count != 1 {
+			t.Fatalf("%s has %d rows, want only the recent one", table, count)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/oidc"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+// fakeProvider signs in whoever identity is, once the nonce from the
+// authorization URL comes back
+type fakeProvider struct {
+	identity oidc.Identity
+	nonce    string
+}
+
+func (p *fakeProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	p.nonce = nonce
+	return "https://idp.test/authorize?" + url.Values{"state": {state}}.Encode(), nil
+}
+
+func (p *fakeProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Identity, error) {
+	if nonce != p.nonce {
+		return nil, &oidc.RejectedError{Description: "nonce mismatch"}
+	}
+	identity := p.identity
+	return &identity, nil
+}
+
+func newSSOService(provider oidc.Provider, required bool) service.SSOService {
+	q := queries()
+	return service.NewSSOService(
+		provider,
+		repository.NewSSORepository(q),
+		repository.NewUserRepository(q),
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+		service.SSOPolicy{
+			GroupRoles: map[string]models.UserRole{
+				"store-admins": models.RoleAdmin,
+				"store-owners": models.RoleSuperAdmin,
+			},
+			Required: required,
+			StateTTL: time.Minute,
+		},
+	)
+}
+
+// signIn runs a whole sign-in through sso
+func signIn(t *testing.T, sso service.SSOService) (uuid.UUID, error) {
+	t.Helper()
+
+	begin, err := sso.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	authURL, err := url.Parse(begin.AuthorizationURL)
+	if err != nil {
+		t.Fatalf("parsing authorization URL: %v", err)
+	}
+	return sso.Finish(context.Background(), &models.FinishSSOLoginRequest{
+		State: authURL.Query().Get("state"),
+		Code:  "code",
+	})
+}
+
+func TestSSOProvisionsAndSyncsRole(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	provider := &fakeProvider{identity: oidc.Identity{
+		Issuer:        "https://idp.test",
+		Subject:       "staff-1",
+		Email:         "staff@example.com",
+		EmailVerified: true,
+		GivenName:     "Sam",
+		Groups:        []string{"engineering", "store-admins"},
+	}}
+	sso := newSSOService(provider, false)
+	users := repository.NewUserRepository(queries())
+
+	userID, err := signIn(t, sso)
+	if err != nil {
+		t.Fatalf("first sign-in: %v", err)
+	}
+	user, err := users.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		t.Fatalf("GetByID = %v, %v", user, err)
+	}
+	if user.Role != models.RoleAdmin || user.Email != "staff@example.com" {
+		t.Fatalf("provisioned %+v, want an admin with the provider's email", user)
+	}
+
+	// Joining a more privileged group promotes them on the next sign-in
+	provider.identity.Groups = []string{"store-admins", "store-owners"}
+	again, err := signIn(t, sso)
+	if err != nil {
+		t.Fatalf("second sign-in: %v", err)
+	}
+	if again != userID {
+		t.Fatalf("second sign-in as %s, want %s", again, userID)
+	}
+	user, err = users.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		t.Fatalf("GetByID = %v, %v", user, err)
+	}
+	if user.Role != models.RoleSuperAdmin {
+		t.Fatalf("role = %s, want %s", user.Role, models.RoleSuperAdmin)
+	}
+}
+
+func TestSSORejectsUnmappedGroups(t *testing.T) {
+	reset(t)
+	sso := newSSOService(&fakeProvider{identity: oidc.Identity{
+		Issuer:        "https://idp.test",
+		Subject:       "contractor-1",
+		Email:         "contractor@example.com",
+		EmailVerified: true,
+		Groups:        []string{"engineering"},
+	}}, false)
+
+	_, err := signIn(t, sso)
+	if err == nil || !strings.Contains(err.Error(), "sso account not authorized") {
+		t.Fatalf("sign-in = %v, want not authorized", err)
+	}
+}
+
+func TestSSOStateIsSingleUse(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	sso := newSSOService(&fakeProvider{identity: oidc.Identity{
+		Issuer:        "https://idp.test",
+		Subject:       "staff-1",
+		Email:         "staff@example.com",
+		EmailVerified: true,
+		Groups:        []string{"store-admins"},
+	}}, false)
+
+	begin, err := sso.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	authURL, _ := url.Parse(begin.AuthorizationURL)
+	req := &models.FinishSSOLoginRequest{State: authURL.Query().Get("state"), Code: "code"}
+
+	if _, err := sso.Finish(ctx, req); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := sso.Finish(ctx, req); err == nil || !strings.Contains(err.Error(), "sso session expired or already used") {
+		t.Fatalf("replayed Finish = %v, want session expired", err)
+	}
+}
+
+func TestSSORequiredBlocksLocalAdminLogin(t *testing.T) {
+	sso := newSSOService(&fakeProvider{}, true)
+
+	if sso.LocalLoginAllowed(models.RoleAdmin) || sso.LocalLoginAllowed(models.RoleSuperAdmin) {
+		t.Fatal("admins may log in locally while SSO is required")
+	}
+	if !sso.LocalLoginAllowed(models.RoleGamer) {
+		t.Fatal("gamers may not log in locally while SSO is required")
+	}
+}
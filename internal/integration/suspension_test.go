@@ -0,0 +1,141 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func newSuspensionService() service.SuspensionService {
+	q := queries()
+	return service.NewSuspensionService(
+		repository.NewSuspensionRepository(q),
+		repository.NewUserRepository(q),
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+		outbox.NewPublisher(repository.NewOutboxRepository(q)),
+	)
+}
+
+func TestSuspensionIssueAndLift(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	suspensions := newSuspensionService()
+
+	admin := createUser(t, models.RoleAdmin)
+	gamer := createUser(t, models.RoleGamer)
+	actor := models.Actor{UserID: &admin.ID, Role: admin.Role, IPAddress: "203.0.113.7"}
+
+	if _, err := suspensions.Issue(ctx, gamer.ID, &models.IssueSuspensionRequest{Reason: "spam"}, actor); err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	assertStatus(t, gamer.ID.String(), models.StatusSuspended)
+
+	if err := suspensions.Lift(ctx, gamer.ID, actor); err != nil {
+		t.Fatalf("Lift: %v", err)
+	}
+	assertStatus(t, gamer.ID.String(), models.StatusActive)
+
+	var audits int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM audit_logs WHERE target_id = $1", gamer.ID).Scan(&audits); err != nil {
+		t.Fatalf("counting audit logs: %v", err)
+	}
+	if audits != 2 {
+		t.Fatalf("wrote %d audit logs, want 2", audits)
+	}
+}
+
+func TestSuspensionReinstateExpired(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	suspensions := newSuspensionService()
+
+	admin := createUser(t, models.RoleAdmin)
+	gamer := createUser(t, models.RoleGamer)
+	actor := models.Actor{UserID: &admin.ID, Role: admin.Role}
+
+	expiresAt := time.Now().Add(time.Hour)
+	suspension, err := suspensions.Issue(ctx, gamer.ID, &models.IssueSuspensionRequest{Reason: "cool off", ExpiresAt: &expiresAt}, actor)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	// Expiry must be in the future when issued, so age it directly
+	if _, err := testDB.Exec("UPDATE suspensions SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", suspension.ID); err != nil {
+		t.Fatalf("expiring suspension: %v", err)
+	}
+
+	reinstated, err := suspensions.ReinstateExpired(ctx, 10)
+	if err != nil {
+		t.Fatalf("ReinstateExpired: %v", err)
+	}
+	if reinstated != 1 {
+		t.Fatalf("reinstated %d users, want 1", reinstated)
+	}
+	assertStatus(t, gamer.ID.String(), models.StatusActive)
+}
+
+func TestSuspensionBulkUpdateStatus(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	suspensions := newSuspensionService()
+
+	admin := createUser(t, models.RoleAdmin)
+	first := createUser(t, models.RoleGamer)
+	second := createUser(t, models.RoleGamer)
+	actor := models.Actor{UserID: &admin.ID, Role: admin.Role}
+
+	results, err := suspensions.BulkUpdateStatus(ctx, &models.BulkStatusRequest{
+		UserIDs: []uuid.UUID{first.ID, second.ID, admin.ID},
+		Status:  models.StatusSuspended,
+		Reason:  "bot network",
+	}, actor)
+	if err != nil {
+		t.Fatalf("BulkUpdateStatus: %v", err)
+	}
+	want := []string{models.BulkStatusUpdated, models.BulkStatusUpdated, models.BulkStatusFailed}
+	for i, result := range results {
+		if result.Result != want[i] {
+			t.Fatalf("result %d is %s, want %s", i, result.Result, want[i])
+		}
+	}
+	assertStatus(t, first.ID.String(), models.StatusSuspended)
+	assertStatus(t, second.ID.String(), models.StatusSuspended)
+	assertStatus(t, admin.ID.String(), models.StatusActive)
+
+	if _, err := suspensions.BulkUpdateStatus(ctx, &models.BulkStatusRequest{
+		UserIDs: []uuid.UUID{first.ID, second.ID},
+		Status:  models.StatusActive,
+	}, actor); err != nil {
+		t.Fatalf("BulkUpdateStatus: %v", err)
+	}
+	assertStatus(t, first.ID.String(), models.StatusActive)
+
+	active, err := suspensions.GetActive(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("GetActive: %v", err)
+	}
+	if active != nil {
+		t.Fatalf("suspension %s still active after bulk reactivation", active.ID)
+	}
+}
+
+func assertStatus(t *testing.T, id string, want models.UserStatus) {
+	t.Helper()
+
+	var status models.UserStatus
+	if err := testDB.QueryRow("SELECT status FROM users WHERE id = $1", id).Scan(&status); err != nil {
+		t.Fatalf("reading status: %v", err)
+	}
+	if status != want {
+		t.Fatalf("user status is %s, want %s", status, want)
+	}
+}
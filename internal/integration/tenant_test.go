@@ -0,0 +1,228 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+func newTenantService() service.TenantService {
+	q := queries()
+	return service.NewTenantService(
+		repository.NewTenantRepository(q),
+		repository.NewAuditRepository(q),
+		repository.NewTransactor(testDB),
+		time.Minute,
+	)
+}
+
+// createTenant inserts a tenant served on hostname
+func createTenant(t *testing.T, slug, hostname string) *models.Tenant {
+	t.Helper()
+
+	id := uuid.New()
+	if _, err := testDB.Exec("INSERT INTO tenants (id, slug, name) VALUES ($1, $2, $3)", id, slug, "Store "+slug); err != nil {
+		t.Fatalf("creating tenant: %v", err)
+	}
+	if _, err := testDB.Exec("INSERT INTO tenant_hostnames (hostname, tenant_id) VALUES ($1, $2)", hostname, id); err != nil {
+		t.Fatalf("creating tenant hostname: %v", err)
+	}
+	tenant, err := repository.NewTenantRepository(queries()).GetByID(context.Background(), id)
+	if err != nil || tenant == nil {
+		t.Fatalf("GetByID: %v, %+v", err, tenant)
+	}
+	return tenant
+}
+
+func TestTenantScopesUsers(t *testing.T) {
+	reset(t)
+	repo := repository.NewUserRepository(queries())
+
+	defaultTenant, err := repository.NewTenantRepository(queries()).GetByID(context.Background(), models.DefaultTenantID)
+	if err != nil || defaultTenant == nil {
+		t.Fatalf("default tenant: %v, %+v", err, defaultTenant)
+	}
+	other := createTenant(t, "other", "other.test")
+	ctxDefault := tenancy.WithTenant(context.Background(), defaultTenant)
+	ctxOther := tenancy.WithTenant(context.Background(), other)
+
+	create := func(ctx context.Context, email, username string) (*models.User, error) {
+		return repo.Create(ctx, &models.User{
+			Email:        email,
+			PasswordHash: "not-a-real-hash",
+			FirstName:    "Test",
+			LastName:     "User",
+			Role:         models.RoleGamer,
+			Username:     &username,
+		})
+	}
+
+	mine, err := create(ctxDefault, "same@example.com", "same_name")
+	if err != nil {
+		t.Fatalf("Create in default tenant: %v", err)
+	}
+	theirs, err := create(ctxOther, "same@example.com", "Same_Name")
+	if err != nil {
+		t.Fatalf("Create with the same email and username in another tenant: %v", err)
+	}
+	if theirs.TenantID != other.ID {
+		t.Fatalf("TenantID = %s, want %s", theirs.TenantID, other.ID)
+	}
+
+	_, err = create(ctxOther, "same@example.com", "another_name")
+	if !errors.Is(err, repository.ErrConflict) || !strings.Contains(err.Error(), "users_tenant_email_key") {
+		t.Fatalf("duplicate email within a tenant: got %v, want users_tenant_email_key conflict", err)
+	}
+
+	found, err := repo.GetByEmail(ctxOther, "same@example.com")
+	if err != nil || found == nil || found.ID != theirs.ID {
+		t.Fatalf("GetByEmail in other tenant = %+v, %v; want %s", found, err, theirs.ID)
+	}
+	found, err = repo.GetByUsername(ctxDefault, "SAME_NAME")
+	if err != nil || found == nil || found.ID != mine.ID {
+		t.Fatalf("GetByUsername in default tenant = %+v, %v; want %s", found, err, mine.ID)
+	}
+
+	// Another tenant's user is invisible by ID inside a request...
+	if found, err := repo.GetByID(ctxDefault, theirs.ID); err != nil || found != nil {
+		t.Fatalf("GetByID across tenants = %+v, %v; want nil", found, err)
+	}
+	// ...but jobs, which carry no tenant, see every user
+	if found, err := repo.GetByID(context.Background(), theirs.ID); err != nil || found == nil {
+		t.Fatalf("GetByID without a tenant = %+v, %v; want the user", found, err)
+	}
+
+	users, err := repo.GetByIDs(ctxOther, []uuid.UUID{mine.ID, theirs.ID})
+	if err != nil || len(users) != 1 || users[0].ID != theirs.ID {
+		t.Fatalf("GetByIDs in other tenant = %+v, %v; want only %s", users, err, theirs.ID)
+	}
+
+	listed, err := repo.List(ctxOther, nil, nil, 10, 0)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("List in other tenant = %d users, %v; want 1", len(listed), err)
+	}
+}
+
+func TestTenantResolveAndUpdate(t *testing.T) {
+	reset(t)
+	tenants := newTenantService()
+
+	other := createTenant(t, "other", "other.test")
+	admin := createUser(t, models.RoleSuperAdmin)
+
+	tests := []struct {
+		name       string
+		slug, host string
+		want       uuid.UUID
+	}{
+		{name: "hostname ignoring case", host: "Other.Test", want: other.ID},
+		{name: "slug over hostname", slug: "default", host: "other.test", want: models.DefaultTenantID},
+		{name: "unknown hostname", host: "unknown.test", want: models.DefaultTenantID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant, err := tenants.Resolve(context.Background(), tt.slug, tt.host)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if tenant.ID != tt.want {
+				t.Fatalf("Resolve = %s, want %s", tenant.ID, tt.want)
+			}
+		})
+	}
+
+	if _, err := tenants.Resolve(context.Background(), "missing", "other.test"); err == nil || !strings.Contains(err.Error(), "tenant not found") {
+		t.Fatalf("Resolve unknown slug: got %v, want tenant not found", err)
+	}
+
+	ctx := tenancy.WithTenant(context.Background(), other)
+	logo := "https://other.test/logo.png"
+	updated, err := tenants.Update(ctx, &models.UpdateTenantRequest{
+		Name:     "Other Store",
+		Branding: models.TenantBranding{LogoURL: &logo},
+		Currency: "EUR",
+		FeeBps:   ptr(250),
+	}, models.Actor{UserID: &admin.ID, Role: admin.Role})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Currency != "EUR" || updated.FeeBps != 250 || updated.Branding.LogoURL == nil || *updated.Branding.LogoURL != logo {
+		t.Fatalf("Update = %+v", updated)
+	}
+
+	// The update drops cached lookups, so the next request sees it
+	resolved, err := tenants.Resolve(context.Background(), "", "other.test")
+	if err != nil || resolved.FeeBps != 250 {
+		t.Fatalf("Resolve after Update = %+v, %v; want fee 250", resolved, err)
+	}
+
+	var audits int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM audit_logs WHERE action = $1 AND target_id = $2", models.AuditTenantUpdated, other.ID).Scan(&audits); err != nil {
+		t.Fatalf("counting audit logs: %v", err)
+	}
+	if audits != 1 {
+		t.Fatalf("audit logs = %d, want 1", audits)
+	}
+}
+
+func TestTenantScopesModeration(t *testing.T) {
+	reset(t)
+	repo := repository.NewModerationRepository(queries())
+	other := createTenant(t, "other", "other.test")
+	defaultTenant, err := repository.NewTenantRepository(queries()).GetByID(context.Background(), models.DefaultTenantID)
+	if err != nil || defaultTenant == nil {
+		t.Fatalf("default tenant: %v, %+v", err, defaultTenant)
+	}
+	ctxDefault := tenancy.WithTenant(context.Background(), defaultTenant)
+	ctxOther := tenancy.WithTenant(context.Background(), other)
+
+	theirs, err := repository.NewUserRepository(queries()).Create(ctxOther, &models.User{
+		Email:        "theirs@example.com",
+		PasswordHash: "not-a-real-hash",
+		FirstName:    "Test",
+		LastName:     "User",
+		Role:         models.RoleGamer,
+	})
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	// Items take the submitter's tenant, even when created outside a request
+	item, err := repo.Create(context.Background(), &models.ModerationItem{
+		SubjectType: models.SubjectAvatar,
+		SubjectID:   theirs.ID,
+		SubmittedBy: theirs.ID,
+		Content:     "https://cdn.example.com/avatar.png",
+		Status:      models.ModerationPending,
+	})
+	if err != nil {
+		t.Fatalf("Create item: %v", err)
+	}
+	if item.TenantID != other.ID {
+		t.Fatalf("TenantID = %s, want %s", item.TenantID, other.ID)
+	}
+
+	if items, err := repo.List(ctxDefault, nil, nil, 10, 0); err != nil || len(items) != 0 {
+		t.Fatalf("List in default tenant = %d items, %v; want none", len(items), err)
+	}
+	if found, err := repo.GetByID(ctxDefault, item.ID); err != nil || found != nil {
+		t.Fatalf("GetByID across tenants = %+v, %v; want nil", found, err)
+	}
+	if reviewed, err := repo.Review(ctxDefault, item.ID, models.ModerationApproved, nil, nil); err != nil || reviewed != nil {
+		t.Fatalf("Review across tenants = %+v, %v; want nil", reviewed, err)
+	}
+
+	if items, err := repo.List(ctxOther, nil, nil, 10, 0); err != nil || len(items) != 1 {
+		t.Fatalf("List in other tenant = %d items, %v; want 1", len(items), err)
+	}
+}
@@ -0,0 +1,189 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+)
+
+func TestUserRepositoryGetMissingReturnsNil(t *testing.T) {
+	reset(t)
+	repo := repository.NewUserRepository(queries())
+
+	user, err := repo.GetByID(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if user != nil {
+		t.Fatalf("GetByID returned %+v for a missing user", user)
+	}
+}
+
+func TestUserRepositoryListFilters(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(queries())
+
+	gamer := createUser(t, models.RoleGamer)
+	createUser(t, models.RoleAdmin)
+	suspended := createUser(t, models.RoleGamer)
+	if err := repo.UpdateStatus(ctx, suspended.ID, models.StatusSuspended); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		role   *models.UserRole
+		status *models.UserStatus
+		want   int
+	}{
+		{name: "all", want: 3},
+		{name: "gamers", role: ptr(models.RoleGamer), want: 2},
+		{name: "active gamers", role: ptr(models.RoleGamer), status: ptr(models.StatusActive), want: 1},
+		{name: "suspended", status: ptr(models.StatusSuspended), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := repo.List(ctx, tt.role, tt.status, 10, 0)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(users) != tt.want {
+				t.Fatalf("List returned %d users, want %d", len(users), tt.want)
+			}
+		})
+	}
+
+	active, err := repo.List(ctx, ptr(models.RoleGamer), ptr(models.StatusActive), 10, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if active[0].ID != gamer.ID {
+		t.Fatalf("List returned %s, want %s", active[0].ID, gamer.ID)
+	}
+}
+
+func TestUserServiceBatchKeepsRequestOrder(t *testing.T) {
+	reset(t)
+	users := service.NewUserService(repository.NewUserRepository(queries()), nil, nil, repository.NewTransactor(testDB), outbox.NewPublisher(repository.NewOutboxRepository(queries())))
+
+	first := createUser(t, models.RoleGamer)
+	second := createUser(t, models.RoleAdmin)
+	missing := uuid.New()
+
+	ids := []uuid.UUID{second.ID, missing, first.ID, second.ID}
+	results, err := users.GetUsersByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetUsersByIDs: %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("GetUsersByIDs returned %d results, want %d", len(results), len(ids))
+	}
+	for i, result := range results {
+		wantFound := ids[i] != missing
+		if result.ID != ids[i] || result.Found != wantFound {
+			t.Fatalf("result %d = %s found=%v, want %s found=%v", i, result.ID, result.Found, ids[i], wantFound)
+		}
+		if wantFound && result.PublicUserResponse.ID != ids[i] {
+			t.Fatalf("result %d carries user %s, want %s", i, result.PublicUserResponse.ID, ids[i])
+		}
+	}
+}
+
+func TestUserServiceRejectsDuplicateEmail(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users := service.NewUserService(repository.NewUserRepository(queries()), nil, nil, repository.NewTransactor(testDB), outbox.NewPublisher(repository.NewOutboxRepository(queries())))
+
+	req := &models.CreateUserRequest{
+		Email:     "dup@example.com",
+		Password:  "Corr3ct-Horse!",
+		FirstName: "Dup",
+		LastName:  "User",
+	}
+	if _, err := users.CreateUser(ctx, req, ""); err != nil {
+		t.Fatalf("first CreateUser: %v", err)
+	}
+	if _, err := users.CreateUser(ctx, req, ""); !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("second CreateUser returned %v, want ErrConflict", err)
+	}
+}
+
+func TestUserServiceConcurrentSignupsConflict(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	users := service.NewUserService(repository.NewUserRepository(queries()), nil, nil, repository.NewTransactor(testDB), outbox.NewPublisher(repository.NewOutboxRepository(queries())))
+
+	req := &models.CreateUserRequest{
+		Email:     "race@example.com",
+		Password:  "Corr3ct-Horse!",
+		FirstName: "Race",
+		LastName:  "User",
+	}
+
+	const attempts = 8
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := users.CreateUser(ctx, req, "")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, repository.ErrConflict):
+			t.Fatalf("CreateUser returned %v, want success or ErrConflict", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("created %d users, want 1", created)
+	}
+}
+
+func TestUserRepositoryUsernameIgnoresCase(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(queries())
+
+	first := createUser(t, models.RoleGamer)
+	first.Username = ptr("Neo")
+	if _, err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	found, err := repo.GetByUsername(ctx, "NEO")
+	if err != nil {
+		t.Fatalf("GetByUsername: %v", err)
+	}
+	if found == nil || found.ID != first.ID {
+		t.Fatalf("GetByUsername returned %+v, want user %s", found, first.ID)
+	}
+
+	second := createUser(t, models.RoleGamer)
+	second.Username = ptr("neo")
+	if _, err := repo.Update(ctx, second); !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("Update with a username differing only in case returned %v, want ErrConflict", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/backup"
+	"github.com/rs/zerolog"
+)
+
+// BackupJob backs the database up once the newest backup is interval old,
+// then prunes backups past retention. Run it more often than interval;
+// runs in between only check the newest backup's age.
+type BackupJob struct {
+	manager  *backup.Manager
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+func NewBackupJob(manager *backup.Manager, interval time.Duration, logger zerolog.Logger) *BackupJob {
+	return &BackupJob{
+		manager:  manager,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+func (j *BackupJob) Name() string {
+	return "database_backup"
+}
+
+func (j *BackupJob) Run(ctx context.Context) error {
+	latest, err := j.manager.Latest(ctx)
+	if err != nil {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < j.interval {
+		return nil
+	}
+
+	start := time.Now()
+	name, err := j.manager.Backup(ctx)
+	if err != nil {
+		return err
+	}
+	j.logger.Info().Str("backup", name).Dur("duration", time.Since(start)).Msg("database backed up")
+
+	deleted, err := j.manager.Prune(ctx)
+	if len(deleted) > 0 {
+		j.logger.Info().Strs("backups", deleted).Msg("expired backups deleted")
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/rs/zerolog"
+)
+
+// OutboxRelayJob delivers pending outbox messages to the broker and the event bus
+type OutboxRelayJob struct {
+	relay     *outbox.Relay
+	batchSize int
+	logger    zerolog.Logger
+}
+
+func NewOutboxRelayJob(relay *outbox.Relay, batchSize int, logger zerolog.Logger) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		relay:     relay,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+func (j *OutboxRelayJob) Name() string {
+	return "outbox_relay"
+}
+
+func (j *OutboxRelayJob) Run(ctx context.Context) error {
+	// Drain in batches so a backlog clears in a single run
+	for {
+		sent, err := j.relay.RelayBatch(ctx, j.batchSize)
+		if sent > 0 {
+			j.logger.Debug().Int("count", sent).Msg("outbox messages relayed")
+		}
+		if err != nil {
+			return err
+		}
+		if sent < j.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// PartitionedTable is a table partitioned by month. Retention drops its
+// months once they are entirely that old; zero keeps them all.
+type PartitionedTable struct {
+	Name      string
+	Retention time.Duration
+}
+
+// PartitionMaintenanceJob creates the coming months' partitions ahead of
+// the rows that need them and drops months past retention
+type PartitionMaintenanceJob struct {
+	repo        repository.PartitionRepository
+	tables      []PartitionedTable
+	monthsAhead int
+	logger      zerolog.Logger
+}
+
+func NewPartitionMaintenanceJob(repo repository.PartitionRepository, tables []PartitionedTable, monthsAhead int, logger zerolog.Logger) *PartitionMaintenanceJob {
+	return &PartitionMaintenanceJob{
+		repo:        repo,
+		tables:      tables,
+		monthsAhead: monthsAhead,
+		logger:      logger,
+	}
+}
+
+func (j *PartitionMaintenanceJob) Name() string {
+	return "partition_maintenance"
+}
+
+func (j *PartitionMaintenanceJob) Run(ctx context.Context) error {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for _, table := range j.tables {
+		for i := 0; i <= j.monthsAhead; i++ {
+			month := thisMonth.AddDate(0, i, 0)
+			created, err := j.repo.CreateMonthly(ctx, table.Name, month)
+			if err != nil {
+				return err
+			}
+			if created {
+				j.logger.Info().Str("table", table.Name).Str("month", month.Format("2006-01")).Msg("partition created")
+			}
+		}
+
+		if table.Retention <= 0 {
+			continue
+		}
+		dropped, err := j.repo.DropMonthlyBefore(ctx, table.Name, now.Add(-table.Retention))
+		if len(dropped) > 0 {
+			j.logger.Info().Str("table", table.Name).Strs("partitions", dropped).Msg("expired partitions dropped")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
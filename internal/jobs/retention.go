@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/retention"
+)
+
+// RetentionJob purges data past its retention period
+type RetentionJob struct {
+	purger *retention.Purger
+}
+
+func NewRetentionJob(purger *retention.Purger) *RetentionJob {
+	return &RetentionJob{purger: purger}
+}
+
+func (j *RetentionJob) Name() string {
+	return "retention"
+}
+
+func (j *RetentionJob) Run(ctx context.Context) error {
+	return j.purger.Run(ctx)
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler runs each registered job on its own interval
+type Scheduler struct {
+	entries []entry
+	logger  zerolog.Logger
+}
+
+func NewScheduler(logger zerolog.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Every registers job to run once per interval
+func (s *Scheduler) Every(interval time.Duration, job Job) {
+	s.entries = append(s.entries, entry{job: job, interval: interval})
+}
+
+// Start runs all jobs until ctx is cancelled and waits for in-flight runs to finish
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range s.entries {
+		wg.Add(1)
+		go func(e entry) {
+			defer wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, e.job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Error().Err(err).Str("job", job.Name()).Dur("duration", time.Since(start)).Msg("job failed")
+		return
+	}
+	s.logger.Debug().Str("job", job.Name()).Dur("duration", time.Since(start)).Msg("job completed")
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/rs/zerolog"
+)
+
+// SuspensionReinstatementJob lifts expired suspensions and reactivates users
+type SuspensionReinstatementJob struct {
+	suspensionService service.SuspensionService
+	batchSize         int
+	logger            zerolog.Logger
+}
+
+func NewSuspensionReinstatementJob(suspensionService service.SuspensionService, batchSize int, logger zerolog.Logger) *SuspensionReinstatementJob {
+	return &SuspensionReinstatementJob{
+		suspensionService: suspensionService,
+		batchSize:         batchSize,
+		logger:            logger,
+	}
+}
+
+func (j *SuspensionReinstatementJob) Name() string {
+	return "suspension_reinstatement"
+}
+
+func (j *SuspensionReinstatementJob) Run(ctx context.Context) error {
+	// Drain in batches so a backlog clears in a single run
+	for {
+		reinstated, err := j.suspensionService.ReinstateExpired(ctx, j.batchSize)
+		if reinstated > 0 {
+			j.logger.Info().Int("count", reinstated).Msg("expired suspensions lifted")
+		}
+		if err != nil {
+			return err
+		}
+		if reinstated < j.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// AccessLogOptions tunes how much the access log writes under load
+type AccessLogOptions struct {
+	// SuccessSampleRate is the fraction of 1xx-3xx requests logged, from 0
+	// to 1; 4xx and 5xx are always logged
+	SuccessSampleRate float64
+	// LogBodies adds redacted JSON request bodies, up to MaxBodyBytes, and
+	// redacted headers to each entry
+	LogBodies    bool
+	MaxBodyBytes int
+}
+
+// AccessLog writes one structured entry per request
+type AccessLog struct {
+	logger  zerolog.Logger
+	options AccessLogOptions
+}
+
+func NewAccessLog(logger zerolog.Logger, options AccessLogOptions) *AccessLog {
+	return &AccessLog{
+		logger:  logger.With().Str("component", "access_log").Logger(),
+		options: options,
+	}
+}
+
+// accessEntry collects values set deeper in the chain, such as the
+// authenticated user, that the access log can't read from its own context
+type accessEntry struct {
+	userID *uuid.UUID
+}
+
+type accessEntryKey struct{}
+
+// setAccessLogUser attributes the request to userID in the access log
+func setAccessLogUser(ctx context.Context, userID uuid.UUID) {
+	if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+		entry.userID = &userID
+	}
+}
+
+func (a *AccessLog) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		var body []byte
+		if a.options.LogBodies && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			body = a.captureBody(r)
+		}
+
+		entry := &accessEntry{}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), accessEntryKey{}, entry)))
+
+		if recorder.status < 400 && !a.sampled() {
+			return
+		}
+
+		event := a.logger.Info()
+		switch {
+		case recorder.status >= 500:
+			event = a.logger.Error()
+		case recorder.status >= 400:
+			event = a.logger.Warn()
+		}
+
+		event = event.
+			Str("request_id", RequestIDFromContext(r.Context())).
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Int("status", recorder.status).
+			Int64("size", recorder.size).
+			Dur("latency", time.Since(start)).
+			Str("remote_addr", r.RemoteAddr).
+			Str("user_agent", r.UserAgent())
+		if entry.userID != nil {
+			event = event.Str("user_id", entry.userID.String())
+		}
+		if a.options.LogBodies {
+			event = event.Interface("headers", RedactHeaders(r.Header))
+			if len(body) > 0 {
+				event = event.RawJSON("body", RedactJSON(body))
+			}
+		}
+		event.Msg("HTTP request")
+	})
+}
+
+// captureBody reads up to MaxBodyBytes for logging and restores the body for
+// the handler. Truncated bodies aren't valid JSON and are logged as omitted.
+func (a *AccessLog) captureBody(r *http.Request) []byte {
+	if r.Body == nil {
+		return nil
+	}
+
+	captured, err := io.ReadAll(io.LimitReader(r.Body, int64(a.options.MaxBodyBytes)))
+	if err != nil {
+		return nil
+	}
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(captured), r.Body), r.Body}
+	return captured
+}
+
+func (a *AccessLog) sampled() bool {
+	rate := a.options.SuccessSampleRate
+	if rate >= 1 {
+		return true
+	}
+	return rand.Float64() < rate
+}
+
+// statusRecorder captures the status code and bytes written for logging
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	size        int64
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	s.wroteHeader = true
+	n, err := s.ResponseWriter.Write(b)
+	s.size += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
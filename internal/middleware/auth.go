@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/auth"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/response"
+	"github.com/rs/zerolog"
+)
+
+type Authenticator struct {
+	tokens            *auth.TokenManager
+	userService       service.UserService
+	suspensionService service.SuspensionService
+	permissionService service.PermissionService
+	consentService    service.ConsentService
+	oauthService      service.OAuthService
+	logger            zerolog.Logger
+}
+
+func NewAuthenticator(tokens *auth.TokenManager, userService service.UserService, suspensionService service.SuspensionService, permissionService service.PermissionService, consentService service.ConsentService, oauthService service.OAuthService, logger zerolog.Logger) *Authenticator {
+	return &Authenticator{
+		tokens:            tokens,
+		userService:       userService,
+		suspensionService: suspensionService,
+		permissionService: permissionService,
+		consentService:    consentService,
+		oauthService:      oauthService,
+		logger:            logger,
+	}
+}
+
+// Authenticate requires a valid bearer token for an active, unsuspended user
+// and attaches the caller to the request context
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return a.authenticate(next, false)
+}
+
+// AuthenticateDelegated is Authenticate that also accepts OAuth access
+// tokens issued to third-party apps. Every route behind it must check the
+// caller's scope with RequireScope.
+func (a *Authenticator) AuthenticateDelegated(next http.Handler) http.Handler {
+	return a.authenticate(next, true)
+}
+
+func (a *Authenticator) authenticate(next http.Handler, delegated bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || tokenString == "" {
+			errorResponse(w, r, http.StatusUnauthorized, "error.unauthorized")
+			return
+		}
+
+		var principal *auth.Principal
+		if claims, err := a.tokens.Parse(tokenString); err == nil {
+			userID, err := claims.UserID()
+			if err != nil {
+				errorResponse(w, r, http.StatusUnauthorized, "error.invalid_token")
+				return
+			}
+			principal = &auth.Principal{UserID: userID}
+		} else if delegated {
+			// Not a JWT, so possibly an opaque OAuth access token
+			token, err := a.oauthService.VerifyAccessToken(r.Context(), tokenString)
+			if err != nil {
+				a.logger.Error().Err(err).Msg("failed to verify oauth access token")
+				errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+				return
+			}
+			if token == nil {
+				errorResponse(w, r, http.StatusUnauthorized, "error.invalid_token")
+				return
+			}
+			principal = &auth.Principal{UserID: token.UserID, ClientID: &token.ClientID, Scopes: token.Scopes}
+		} else {
+			errorResponse(w, r, http.StatusUnauthorized, "error.invalid_token")
+			return
+		}
+		userID := principal.UserID
+
+		// Load the user on every request so bans and role changes apply immediately
+		user, err := a.userService.GetUserByID(r.Context(), userID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				errorResponse(w, r, http.StatusUnauthorized, "error.invalid_token")
+				return
+			}
+			a.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to load authenticated user")
+			errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+			return
+		}
+
+		switch user.Status {
+		case models.StatusSuspended:
+			suspension, err := a.suspensionService.GetActive(r.Context(), userID)
+			if err != nil {
+				a.logger.Error().Err(err).Str("user_id", userID.String()).Msg("failed to check suspension")
+				errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+				return
+			}
+			// No active suspension means it expired and is awaiting reinstatement
+			if suspension != nil {
+				lang := i18n.FromContext(r.Context())
+				key, msg := "error.account_suspended", i18n.T(lang, "error.account_suspended")
+				if suspension.ExpiresAt != nil {
+					key, msg = "error.account_suspended_until", i18n.T(lang, "error.account_suspended_until", suspension.ExpiresAt.UTC().Format(time.RFC3339))
+				}
+				writeError(w, r, http.StatusForbidden, key, msg)
+				return
+			}
+		case models.StatusInactive:
+			errorResponse(w, r, http.StatusUnauthorized, "error.account_inactive")
+			return
+		}
+
+		setAccessLogUser(r.Context(), user.ID)
+
+		principal.Role = user.Role
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// RequireConsent rejects callers who have yet to accept an effective
+// mandatory terms of service or privacy policy version. It must run after
+// Authenticate, on every route except those used to accept.
+func (a *Authenticator) RequireConsent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.PrincipalFromContext(r.Context())
+		if principal == nil {
+			errorResponse(w, r, http.StatusUnauthorized, "error.unauthorized")
+			return
+		}
+
+		pending, err := a.consentService.Pending(r.Context(), principal.UserID)
+		if err != nil {
+			a.logger.Error().Err(err).Str("user_id", principal.UserID.String()).Msg("failed to check consent")
+			errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+			return
+		}
+		if len(pending) > 0 {
+			errorResponse(w, r, http.StatusForbidden, "error.consent_required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole rejects authenticated callers whose role is not listed. It
+// must run after Authenticate.
+func RequireRole(roles ...models.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := auth.PrincipalFromContext(r.Context())
+			if principal == nil {
+				errorResponse(w, r, http.StatusUnauthorized, "error.unauthorized")
+				return
+			}
+
+			for _, role := range roles {
+				if principal.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			errorResponse(w, r, http.StatusForbidden, "error.forbidden")
+		})
+	}
+}
+
+// RequireOperator limits deployment-wide routes, whose effects reach every
+// store, to super admins of the default tenant, which runs the deployment.
+// It must run after Authenticate.
+func RequireOperator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.PrincipalFromContext(r.Context())
+		if principal == nil {
+			errorResponse(w, r, http.StatusUnauthorized, "error.unauthorized")
+			return
+		}
+		if principal.Role != models.RoleSuperAdmin || tenancy.ID(r.Context()) != models.DefaultTenantID {
+			errorResponse(w, r, http.StatusForbidden, "error.forbidden")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequirePermission rejects authenticated callers who hold permission neither
+// through their role nor a direct grant. It must run after Authenticate.
+func (a *Authenticator) RequirePermission(permission models.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := auth.PrincipalFromContext(r.Context())
+			if principal == nil {
+				errorResponse(w, r, http.StatusUnauthorized, "error.unauthorized")
+				return
+			}
+
+			allowed, err := a.permissionService.HasPermission(r.Context(), principal.UserID, principal.Role, permission)
+			if err != nil {
+				a.logger.Error().Err(err).Str("user_id", principal.UserID.String()).Str("permission", string(permission)).Msg("failed to check permission")
+				errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+				return
+			}
+			if !allowed {
+				errorResponse(w, r, http.StatusForbidden, "error.forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope rejects third-party apps whose OAuth access token lacks
+// scope; the user's own sessions pass. It must run after
+// AuthenticateDelegated.
+func RequireScope(scope models.OAuthScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := auth.PrincipalFromContext(r.Context())
+			if principal == nil {
+				errorResponse(w, r, http.StatusUnauthorized, "error.unauthorized")
+				return
+			}
+
+			if !principal.HasScope(scope) {
+				// RFC 6750 3.1: tell the client which scope it needs
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, scope))
+				errorResponse(w, r, http.StatusForbidden, "error.insufficient_scope")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func errorResponse(w http.ResponseWriter, r *http.Request, statusCode int, key string) {
+	writeError(w, r, statusCode, key, i18n.T(i18n.FromContext(r.Context()), key))
+}
+
+// writeError writes msg as problem+json when the client accepts it and the
+// standard envelope otherwise
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, key, msg string) {
+	if response.WantsProblem(r) {
+		response.WriteProblem(w, response.NewProblem(statusCode, key, msg, r, RequestIDFromContext(r.Context())))
+		return
+	}
+	response.JSON(w, statusCode, response.Error(msg))
+}
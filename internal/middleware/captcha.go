@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/captcha"
+	"github.com/rs/zerolog"
+)
+
+// CaptchaTokenHeader carries the token the client got from the CAPTCHA widget
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// CaptchaRequiredHeader is set to "true" once a client must send a token,
+// so it knows to show the widget before retrying
+const CaptchaRequiredHeader = "X-Captcha-Required"
+
+// failureSweepSize is how many tracked clients trigger a sweep of expired ones
+const failureSweepSize = 10000
+
+// CaptchaOptions picks the flows that are challenged
+type CaptchaOptions struct {
+	// Signup challenges every signup
+	Signup bool
+	// LoginFailures is how many failed logins a client may have within
+	// FailureWindow before login is challenged too; 0 never challenges it
+	LoginFailures int
+	FailureWindow time.Duration
+}
+
+// Captcha verifies CAPTCHA tokens before requests reach the handlers it
+// wraps. A nil Captcha lets everything through.
+type Captcha struct {
+	verifier captcha.Verifier
+	options  CaptchaOptions
+	logger   zerolog.Logger
+
+	mu       sync.Mutex
+	failures map[string]*loginFailures
+}
+
+// loginFailures counts one client's failed logins since the first of them
+type loginFailures struct {
+	count int
+	since time.Time
+}
+
+func NewCaptcha(verifier captcha.Verifier, options CaptchaOptions, logger zerolog.Logger) *Captcha {
+	return &Captcha{
+		verifier: verifier,
+		options:  options,
+		logger:   logger,
+		failures: make(map[string]*loginFailures),
+	}
+}
+
+// Signup wraps the signup handler
+func (c *Captcha) Signup(fn http.HandlerFunc) http.Handler {
+	if c == nil || !c.options.Signup {
+		return fn
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.verify(w, r) {
+			fn(w, r)
+		}
+	})
+}
+
+// Login wraps the login handler. Clients are counted by ClientIP: each
+// 401 is a failure and a success clears the count. Counts are kept in
+// memory, so each instance enforces the limit on its own.
+func (c *Captcha) Login(fn http.HandlerFunc) http.Handler {
+	if c == nil || c.options.LoginFailures <= 0 {
+		return fn
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := ClientIP(r)
+		if c.failureCount(client) >= c.options.LoginFailures && !c.verify(w, r) {
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		fn(rec, r)
+
+		switch {
+		case rec.status == http.StatusUnauthorized:
+			c.recordFailure(client)
+		case rec.status < http.StatusBadRequest:
+			c.clearFailures(client)
+		}
+	})
+}
+
+// verify checks the request's token, answering the request itself and
+// returning false when it is missing or wrong
+func (c *Captcha) verify(w http.ResponseWriter, r *http.Request) bool {
+	token := r.Header.Get(CaptchaTokenHeader)
+	if token == "" {
+		w.Header().Set(CaptchaRequiredHeader, "true")
+		errorResponse(w, r, http.StatusBadRequest, "error.captcha_required")
+		return false
+	}
+
+	ok, err := c.verifier.Verify(r.Context(), token, ClientIP(r))
+	if err != nil {
+		// Failing closed: letting requests through would switch the check
+		// off whenever the provider is down
+		c.logger.Error().Err(err).Msg("captcha verification failed")
+		errorResponse(w, r, http.StatusServiceUnavailable, "error.captcha_unavailable")
+		return false
+	}
+	if !ok {
+		w.Header().Set(CaptchaRequiredHeader, "true")
+		errorResponse(w, r, http.StatusForbidden, "error.captcha_failed")
+		return false
+	}
+	return true
+}
+
+func (c *Captcha) failureCount(client string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.failures[client]
+	if !ok {
+		return 0
+	}
+	if time.Since(entry.since) > c.options.FailureWindow {
+		delete(c.failures, client)
+		return 0
+	}
+	return entry.count
+}
+
+func (c *Captcha) recordFailure(client string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := c.failures[client]
+	if !ok || now.Sub(entry.since) > c.options.FailureWindow {
+		if len(c.failures) >= failureSweepSize {
+			c.sweep(now)
+		}
+		entry = &loginFailures{since: now}
+		c.failures[client] = entry
+	}
+	entry.count++
+}
+
+func (c *Captcha) clearFailures(client string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.failures, client)
+}
+
+// sweep drops clients whose window has passed; c.mu must be held
+func (c *Captcha) sweep(now time.Time) {
+	for client, entry := range c.failures {
+		if now.Sub(entry.since) > c.options.FailureWindow {
+			delete(c.failures, client)
+		}
+	}
+}
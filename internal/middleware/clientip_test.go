@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPResolver(t *testing.T) {
+	_, balancers, _ := net.ParseCIDR("10.0.0.0/8")
+	resolver := NewClientIPResolver([]*net.IPNet{balancers})
+
+	for _, tc := range []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		wantClientIP string
+	}{
+		{"direct client", "203.0.113.7:51000", nil, "203.0.113.7"},
+		{"direct client forging the header", "203.0.113.7:51000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"behind the balancer", "10.0.0.5:443", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"client prepending a forged hop", "10.0.0.5:443", []string{"192.0.2.99, 198.51.100.1"}, "198.51.100.1"},
+		{"through two trusted proxies", "10.0.0.5:443", []string{"198.51.100.1, 10.1.2.3"}, "198.51.100.1"},
+		{"headers split across lines", "10.0.0.5:443", []string{"198.51.100.1", "10.1.2.3"}, "198.51.100.1"},
+		{"only trusted hops", "10.0.0.5:443", []string{"10.1.2.3"}, "10.1.2.3"},
+		{"garbage hop", "10.0.0.5:443", []string{"198.51.100.1, not-an-ip"}, "10.0.0.5"},
+		{"balancer without the header", "10.0.0.5:443", nil, "10.0.0.5"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			handler := resolver.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, value := range tc.forwardedFor {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tc.wantClientIP {
+				t.Fatalf("ClientIP = %q, want %q", got, tc.wantClientIP)
+			}
+		})
+	}
+}
+
+func TestClientIPWithoutResolver(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	if got := ClientIP(req); got != "203.0.113.7" {
+		t.Fatalf("ClientIP = %q, want the connection's address", got)
+	}
+}
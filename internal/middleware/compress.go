@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// brotliLevel trades some ratio for speed; responses are compressed per request
+const brotliLevel = 5
+
+// DefaultCompressTypes are the media types compressed when none are configured
+var DefaultCompressTypes = []string{"application/json", "application/problem+json", "text/csv", "text/plain"}
+
+// CompressOptions decides which responses are worth compressing
+type CompressOptions struct {
+	// MinSize is the smallest body, in bytes, that is compressed
+	MinSize int
+	// ContentTypes lists the media types compressed, without parameters
+	ContentTypes []string
+}
+
+// Compressor encodes responses with brotli or gzip, whichever the client
+// prefers, once a body reaches MinSize
+type Compressor struct {
+	minSize      int
+	contentTypes map[string]struct{}
+	gzipWriters  sync.Pool
+}
+
+func NewCompressor(options CompressOptions) *Compressor {
+	types := options.ContentTypes
+	if len(types) == 0 {
+		types = DefaultCompressTypes
+	}
+	contentTypes := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		contentTypes[strings.ToLower(strings.TrimSpace(t))] = struct{}{}
+	}
+
+	return &Compressor{
+		minSize:      options.MinSize,
+		contentTypes: contentTypes,
+	}
+}
+
+func (c *Compressor) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, compressor: c, encoding: encoding, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		// Not deferred: after a panic the buffered body is dropped so
+		// recovery can still answer with a clean 500
+		cw.close()
+	})
+}
+
+func (c *Compressor) compressible(header http.Header, status int) bool {
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	_, ok := c.contentTypes[mediaType]
+	return ok
+}
+
+func (c *Compressor) newEncoder(encoding string, w io.Writer) io.WriteCloser {
+	if encoding == "br" {
+		return brotli.NewWriterLevel(w, brotliLevel)
+	}
+	if gz, ok := c.gzipWriters.Get().(*gzip.Writer); ok {
+		gz.Reset(w)
+		return gz
+	}
+	return gzip.NewWriter(w)
+}
+
+func (c *Compressor) release(encoder io.WriteCloser) {
+	if gz, ok := encoder.(*gzip.Writer); ok {
+		c.gzipWriters.Put(gz)
+	}
+}
+
+// negotiateEncoding picks br or gzip from Accept-Encoding, preferring br
+// on equal quality; "" means send the body as-is
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "br" && coding != "gzip" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		if quality > bestQuality || (quality == bestQuality && coding == "br") {
+			best, bestQuality = coding, quality
+		}
+	}
+	return best
+}
+
+// compressWriter holds the body back until it reaches MinSize, then
+// commits to compressing or to passing it through
+type compressWriter struct {
+	http.ResponseWriter
+	compressor  *Compressor
+	encoding    string
+	status      int
+	wroteHeader bool
+	buf         []byte
+	decided     bool
+	encoder     io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.status = status
+	cw.wroteHeader = true
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	cw.wroteHeader = true
+
+	if cw.decided {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.compressor.minSize {
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// decide writes the real header and the buffered body, switching to the
+// encoder when the response qualifies
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+	header := cw.ResponseWriter.Header()
+
+	if len(cw.buf) >= cw.compressor.minSize && cw.compressor.compressible(header, cw.status) {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.ResponseWriter.WriteHeader(cw.status)
+		cw.encoder = cw.compressor.newEncoder(cw.encoding, cw.ResponseWriter)
+		_, err := cw.encoder.Write(cw.buf)
+		cw.buf = nil
+		return err
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	_, err := cw.ResponseWriter.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// Nothing was written; leave the implicit 200 to net/http
+			return
+		}
+		cw.decide()
+	}
+	if cw.encoder != nil {
+		cw.encoder.Close()
+		cw.compressor.release(cw.encoder)
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
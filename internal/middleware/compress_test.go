@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"br", "br"},
+		{"gzip, br", "br"},
+		{"GZIP", "gzip"},
+		{"gzip;q=1.0, br;q=0.5", "gzip"},
+		{"gzip;q=0.5, br;q=0.5", "br"},
+		{"br;q=0, gzip", "gzip"},
+		{"br;q=0, gzip;q=0", ""},
+		{"br;q=oops, gzip;q=0.1", "gzip"},
+		{"deflate, *", ""},
+	} {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			if got := negotiateEncoding(tc.acceptEncoding); got != tc.want {
+				t.Fatalf("negotiateEncoding(%q) = %q, want %q", tc.acceptEncoding, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompressor(t *testing.T) {
+	compressor := NewCompressor(CompressOptions{MinSize: 100})
+	large := strings.Repeat(`{"name":"x"}`, 20)
+
+	for _, tc := range []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		contentType    string
+		body           string
+		want           string
+	}{
+		{"large JSON", http.MethodGet, "gzip", "application/json", large, "gzip"},
+		{"client prefers brotli", http.MethodGet, "gzip, br", "application/json; charset=utf-8", large, "br"},
+		{"below the minimum size", http.MethodGet, "gzip", "application/json", `{"name":"x"}`, ""},
+		{"type not compressed", http.MethodGet, "gzip", "image/png", large, ""},
+		{"client can't decode", http.MethodGet, "", "application/json", large, ""},
+		{"HEAD", http.MethodHead, "gzip", "application/json", large, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := compressor.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.Write([]byte(tc.body))
+			}))
+			req := httptest.NewRequest(tc.method, "/", nil)
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tc.want {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tc.want)
+			}
+			if tc.want == "" && tc.method != http.MethodHead && rec.Body.String() != tc.body {
+				t.Fatalf("body = %q, want it unchanged", rec.Body.String())
+			}
+			if vary := rec.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Fatalf("Vary = %q, want Accept-Encoding", vary)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
+	"github.com/rs/zerolog"
+)
+
+// panics is published at /debug/vars as "http_panics"
+var panics = expvar.NewInt("http_panics")
+
+// Recovery turns a handler panic into a logged stack trace and a structured
+// 500 rather than a dropped connection
+type Recovery struct {
+	logger zerolog.Logger
+}
+
+func NewRecovery(logger zerolog.Logger) *Recovery {
+	return &Recovery{logger: logger.With().Str("component", "recovery").Logger()}
+}
+
+// Middleware runs outside the router, so it negotiates the error language itself
+func (rc *Recovery) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tracker := &headerTracker{ResponseWriter: w}
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			// net/http's sentinel for aborting a response on purpose
+			if p == http.ErrAbortHandler {
+				panic(p)
+			}
+
+			stack := debug.Stack()
+			if gp, ok := p.(*goroutinePanic); ok {
+				p, stack = gp.value, gp.stack
+			}
+
+			panics.Add(1)
+			rc.logger.Error().
+				Str("request_id", RequestIDFromContext(r.Context())).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Str("panic", fmt.Sprint(p)).
+				Bytes("stack", stack).
+				Msg("handler panicked")
+
+			if tracker.wroteHeader {
+				// Too late for a clean error; abort so the client sees a broken response
+				panic(http.ErrAbortHandler)
+			}
+			r = r.WithContext(i18n.WithLanguage(r.Context(), i18n.Negotiate(r.Header.Get("Accept-Language"))))
+			errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+		}()
+
+		next.ServeHTTP(tracker, r)
+	})
+}
+
+// goroutinePanic carries a panic that a middleware recovered on another
+// goroutine and re-raised on the serving one, with the stack where it
+// happened
+type goroutinePanic struct {
+	value interface{}
+	stack []byte
+}
+
+func (p *goroutinePanic) String() string {
+	return fmt.Sprint(p.value)
+}
+
+// headerTracker records whether the response has started
+type headerTracker struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (h *headerTracker) WriteHeader(status int) {
+	h.wroteHeader = true
+	h.ResponseWriter.WriteHeader(status)
+}
+
+func (h *headerTracker) Write(b []byte) (int, error) {
+	h.wroteHeader = true
+	return h.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (h *headerTracker) Unwrap() http.ResponseWriter {
+	return h.ResponseWriter
+}
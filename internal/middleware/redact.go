@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are the header and JSON field names whose values are
+// masked. Names match exactly, ignoring case and with dashes read as
+// underscores, so country_code or token_type are still logged.
+var sensitiveKeys = map[string]bool{
+	// Credentials
+	"password":      true,
+	"old_password":  true,
+	"new_password":  true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"secret":        true,
+	"client_secret": true,
+	"api_key":       true,
+	"apikey":        true,
+	"credential":    true,
+	// Verification and sign-in codes
+	"code":          true,
+	"otp":           true,
+	"code_verifier": true,
+	// Contact details
+	"email": true,
+	"phone": true,
+	// Headers
+	"authorization":       true,
+	"proxy_authorization": true,
+	"cookie":              true,
+	"set_cookie":          true,
+	"x_api_key":           true,
+	"x_captcha_token":     true,
+}
+
+func isSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ReplaceAll(strings.ToLower(key), "-", "_")]
+}
+
+// RedactHeaders flattens headers for logging with sensitive values masked
+func RedactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if isSensitiveKey(name) {
+			result[name] = redacted
+			continue
+		}
+		result[name] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// RedactJSON masks the values of sensitive fields at any depth. Bodies that
+// aren't valid JSON are dropped entirely, since they can't be inspected.
+func RedactJSON(body []byte) json.RawMessage {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return json.RawMessage(`"[UNPARSEABLE BODY OMITTED]"`)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return json.RawMessage(`"[UNPARSEABLE BODY OMITTED]"`)
+	}
+	return redactedBody
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+		return v
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{"plain fields kept", `{"first_name":"Ada","token_type":"Bearer"}`, `{"first_name":"Ada","token_type":"Bearer"}`},
+		{"password", `{"first_name":"Ada","password":"hunter2"}`, `{"first_name":"Ada","password":"[REDACTED]"}`},
+		{"any case", `{"Old_Password":"hunter2"}`, `{"Old_Password":"[REDACTED]"}`},
+		{"verification code", `{"code":"123456"}`, `{"code":"[REDACTED]"}`},
+		{"otp", `{"otp":"123456"}`, `{"otp":"[REDACTED]"}`},
+		{"other codes kept", `{"country_code":"GB","postal_code":"SW1A 1AA"}`, `{"country_code":"GB","postal_code":"SW1A 1AA"}`},
+		{"contact details", `{"email":"a@example.com","phone":"+447700900123"}`, `{"email":"[REDACTED]","phone":"[REDACTED]"}`},
+		{"credential", `{"credential":{"id":"abc","response":{}}}`, `{"credential":"[REDACTED]"}`},
+		{"refresh token", `{"refresh_token":"abc"}`, `{"refresh_token":"[REDACTED]"}`},
+		{"nested", `{"user":{"api_key":"k","name":"Ada"}}`, `{"user":{"api_key":"[REDACTED]","name":"Ada"}}`},
+		{"in arrays", `[{"secret":"s"},{"id":1}]`, `[{"secret":"[REDACTED]"},{"id":1}]`},
+		{"not an object", `"hunter2"`, `"hunter2"`},
+		{"invalid JSON", `password=hunter2`, `"[UNPARSEABLE BODY OMITTED]"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := string(RedactJSON([]byte(tc.body))); got != tc.want {
+				t.Fatalf("RedactJSON(%s) = %s, want %s", tc.body, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	got := RedactHeaders(http.Header{
+		"Authorization":   {"Bearer abc"},
+		"Cookie":          {"session=abc"},
+		"X-Captcha-Token": {"abc"},
+		"Accept":          {"application/json", "text/plain"},
+		"X-Device-Id":     {"laptop"},
+	})
+
+	want := map[string]string{
+		"Authorization":   redacted,
+		"Cookie":          redacted,
+		"X-Captcha-Token": redacted,
+		"Accept":          "application/json, text/plain",
+		"X-Device-Id":     "laptop",
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Fatalf("%s = %q, want %q", name, got[name], value)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/service"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+	"github.com/rs/zerolog"
+)
+
+// TenantHeader names the tenant by slug, for clients that share a hostname
+// such as native apps; it takes precedence over the request's host
+const TenantHeader = "X-Tenant"
+
+// TenantResolver attaches the tenant a request is for to its context,
+// resolved from TenantHeader or else the Host header
+type TenantResolver struct {
+	tenants service.TenantService
+	logger  zerolog.Logger
+}
+
+func NewTenantResolver(tenants service.TenantService, logger zerolog.Logger) *TenantResolver {
+	return &TenantResolver{tenants: tenants, logger: logger}
+}
+
+func (t *TenantResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := strings.TrimSpace(r.Header.Get(TenantHeader))
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		tenant, err := t.tenants.Resolve(r.Context(), slug, host)
+		if err != nil {
+			if strings.Contains(err.Error(), "tenant not found") {
+				errorResponse(w, r, http.StatusNotFound, "error.tenant_not_found")
+				return
+			}
+			t.logger.Error().Err(err).Str("tenant", slug).Str("host", host).Msg("failed to resolve tenant")
+			errorResponse(w, r, http.StatusInternalServerError, "error.internal")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), tenant)))
+	})
+}
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+)
+
+// TimeoutOptions bounds how long a handler may run before the client gets a 504
+type TimeoutOptions struct {
+	// Default applies to routes without an override; zero disables it
+	Default time.Duration
+	// Routes overrides Default per route, keyed by "METHOD /path/template"
+	// or by the bare path template for every method. Zero disables the
+	// timeout for that route.
+	Routes map[string]time.Duration
+}
+
+// Timeout gives each request a context deadline and answers with a
+// structured 504 when the handler misses it. The handler's response is
+// buffered so a late write can't race the 504.
+type Timeout struct {
+	options TimeoutOptions
+	logger  zerolog.Logger
+}
+
+func NewTimeout(logger zerolog.Logger, options TimeoutOptions) *Timeout {
+	return &Timeout{
+		options: options,
+		logger:  logger.With().Str("component", "timeout").Logger(),
+	}
+}
+
+// Middleware must be registered on the router so the matched route is known
+func (t *Timeout) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		timeout := t.timeoutFor(r.Method, route)
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Cancelling the context aborts in-flight queries once the deadline passes
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		// Seeded with headers set by outer middleware so copying back keeps them
+		tw := &timeoutWriter{header: w.Header().Clone(), status: http.StatusOK}
+		done := make(chan struct{})
+		panicked := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					// The stack is only available here, on the handler's goroutine
+					if p != http.ErrAbortHandler {
+						p = &goroutinePanic{value: p, stack: debug.Stack()}
+					}
+					panicked <- p
+				}
+			}()
+			next.ServeHTTP(tw, r)
+			close(done)
+		}()
+
+		select {
+		case p := <-panicked:
+			// Re-raised on the serving goroutine so outer recovery sees it
+			panic(p)
+		case <-done:
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			dst := w.Header()
+			for k := range dst {
+				if _, ok := tw.header[k]; !ok {
+					delete(dst, k)
+				}
+			}
+			for k, v := range tw.header {
+				dst[k] = v
+			}
+			w.WriteHeader(tw.status)
+			w.Write(tw.body.Bytes())
+		case <-ctx.Done():
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			tw.timedOut = true
+
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// The client went away; there is no one to answer
+				return
+			}
+
+			t.logger.Warn().
+				Str("request_id", RequestIDFromContext(r.Context())).
+				Str("method", r.Method).
+				Str("route", route).
+				Dur("timeout", timeout).
+				Msg("request timed out")
+			errorResponse(w, r, http.StatusGatewayTimeout, "error.timeout")
+		}
+	})
+}
+
+func (t *Timeout) timeoutFor(method, route string) time.Duration {
+	if timeout, ok := t.options.Routes[method+" "+route]; ok {
+		return timeout
+	}
+	if timeout, ok := t.options.Routes[route]; ok {
+		return timeout
+	}
+	return t.options.Default
+}
+
+// routeTemplate is the matched route's path template, or the raw path
+// when no route matched
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return r.URL.Path
+}
+
+// timeoutWriter buffers a response until the handler finishes; writes
+// after the deadline fail with http.ErrHandlerTimeout
+type timeoutWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.status = status
+	tw.wroteHeader = true
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	tw.wroteHeader = true
+	return tw.body.Write(p)
+}
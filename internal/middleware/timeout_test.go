@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestTimeout(t *testing.T) {
+	timeouts := NewTimeout(zerolog.Nop(), TimeoutOptions{
+		Default: 20 * time.Millisecond,
+		Routes: map[string]time.Duration{
+			"POST /slow": 0,
+			"/quick":     5 * time.Millisecond,
+		},
+	})
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		delay      time.Duration
+		wantStatus int
+		wantBody   string
+	}{
+		{"fast handler", http.MethodGet, "/", 0, http.StatusTeapot, "brewed"},
+		{"slow handler", http.MethodGet, "/", time.Second, http.StatusGatewayTimeout, "error"},
+		{"route override", http.MethodGet, "/quick", 50 * time.Millisecond, http.StatusGatewayTimeout, "error"},
+		{"disabled for method and route", http.MethodPost, "/slow", 50 * time.Millisecond, http.StatusTeapot, "brewed"},
+		{"override is per method", http.MethodGet, "/slow", time.Second, http.StatusGatewayTimeout, "error"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := timeouts.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tc.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Header().Set("X-Brewed", "yes")
+				w.WriteHeader(http.StatusTeapot)
+				w.Write([]byte("brewed"))
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tc.wantBody) {
+				t.Fatalf("body = %q, want it to contain %q", rec.Body.String(), tc.wantBody)
+			}
+			if tc.wantStatus == http.StatusTeapot && rec.Header().Get("X-Brewed") != "yes" {
+				t.Fatal("handler headers not copied to the response")
+			}
+		})
+	}
+}
+
+func panickingHandler(w http.ResponseWriter, r *http.Request) {
+	panic("boom")
+}
+
+func TestTimeoutPanicKeepsHandlerStack(t *testing.T) {
+	var logs bytes.Buffer
+	logger := zerolog.New(&logs)
+	handler := NewRecovery(logger).Middleware(
+		NewTimeout(logger, TimeoutOptions{Default: time.Second}).Middleware(http.HandlerFunc(panickingHandler)),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(logs.String(), `"panic":"boom"`) {
+		t.Fatalf("panic value not logged: %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "panickingHandler") {
+		t.Fatalf("logged stack doesn't reach the handler: %s", logs.String())
+	}
+}
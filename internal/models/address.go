@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Address struct {
+	ID                uuid.UUID `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	Label             *string   `json:"label,omitempty"`
+	RecipientName     string    `json:"recipient_name"`
+	Line1             string    `json:"line1"`
+	Line2             *string   `json:"line2,omitempty"`
+	City              string    `json:"city"`
+	Region            *string   `json:"region,omitempty"`
+	PostalCode        string    `json:"postal_code"`
+	CountryCode       string    `json:"country_code"`
+	Phone             *string   `json:"phone,omitempty"`
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// AddressRequest creates or replaces an address. Setting a default flag
+// moves that default off the user's other addresses.
+type AddressRequest struct {
+	Label             string `json:"label,omitempty" validate:"omitempty,max=50"`
+	RecipientName     string `json:"recipient_name" validate:"required,min=2,max=200"`
+	Line1             string `json:"line1" validate:"required,max=200"`
+	Line2             string `json:"line2,omitempty" validate:"omitempty,max=200"`
+	City              string `json:"city" validate:"required,max=100"`
+	Region            string `json:"region,omitempty" validate:"omitempty,max=100"`
+	PostalCode        string `json:"postal_code" validate:"max=20,postal_code=CountryCode"`
+	CountryCode       string `json:"country_code" validate:"required,iso3166_1_alpha2"`
+	Phone             string `json:"phone,omitempty" validate:"omitempty,phone"`
+	IsDefaultShipping bool   `json:"is_default_shipping"`
+	IsDefaultBilling  bool   `json:"is_default_billing"`
+}
+
+func (r *AddressRequest) GetSchema() interface{} {
+	return r
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type AnalyticsGranularity string
+
+const (
+	GranularityDay   AnalyticsGranularity = "day"
+	GranularityWeek  AnalyticsGranularity = "week"
+	GranularityMonth AnalyticsGranularity = "month"
+)
+
+// AnalyticsRange is a half-open [From, To) window split into buckets
+type AnalyticsRange struct {
+	From        time.Time
+	To          time.Time
+	Granularity AnalyticsGranularity
+}
+
+type SignupBucket struct {
+	Bucket  time.Time `json:"bucket" example:"2024-01-01T00:00:00Z"`
+	Signups int64     `json:"signups" example:"42"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Actor is who performed an action, for audit attribution. A nil UserID is
+// the system itself, e.g. a scheduled job.
+type Actor struct {
+	UserID    *uuid.UUID
+	Role      UserRole
+	IPAddress string
+}
+
+type AuditLog struct {
+	ID         uuid.UUID              `json:"id"`
+	ActorID    *uuid.UUID             `json:"actor_id,omitempty"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   uuid.UUID              `json:"target_id"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	IPAddress  *string                `json:"ip_address,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// Audit actions
+const (
+	AuditSuspensionIssued   = "suspension.issued"
+	AuditSuspensionLifted   = "suspension.lifted"
+	AuditSuspensionExpired  = "suspension.expired"
+	AuditPermissionGranted  = "permission.granted"
+	AuditPermissionRevoked  = "permission.revoked"
+	AuditUserStatusChanged  = "user.status_changed"
+	AuditUserRoleChanged    = "user.role_changed"
+	AuditUserProvisioned    = "user.provisioned"
+	AuditUserPasswordReset  = "user.password_reset"
+	AuditLegalPublished     = "legal_document.published"
+	AuditTenantUpdated      = "tenant.updated"
+	AuditOrgCreated         = "organization.created"
+	AuditOrgMemberAdded     = "organization.member_added"
+	AuditOrgMemberRole      = "organization.member_role_changed"
+	AuditOrgMemberRemoved   = "organization.member_removed"
+	AuditOAuthClientCreated = "oauth_client.created"
+	AuditOAuthClientDeleted = "oauth_client.deleted"
+)
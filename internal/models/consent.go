@@ -0,0 +1,65 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LegalDocumentKind is which policy a document version belongs to
+type LegalDocumentKind string
+
+const (
+	LegalTerms   LegalDocumentKind = "terms"
+	LegalPrivacy LegalDocumentKind = "privacy"
+)
+
+// LegalDocument is one published version of the terms of service or the
+// privacy policy. Once a mandatory version is effective, users must accept
+// it, or a later version of the same kind, before using the API again.
+type LegalDocument struct {
+	ID          uuid.UUID         `json:"id"`
+	Kind        LegalDocumentKind `json:"kind"`
+	Version     string            `json:"version"`
+	URL         string            `json:"url"`
+	Mandatory   bool              `json:"mandatory"`
+	EffectiveAt time.Time         `json:"effective_at"`
+	PublishedBy *uuid.UUID        `json:"published_by,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// ConsentAcceptance records a user accepting one document version
+type ConsentAcceptance struct {
+	DocumentID uuid.UUID         `json:"document_id"`
+	Kind       LegalDocumentKind `json:"kind"`
+	Version    string            `json:"version"`
+	IPAddress  *string           `json:"-"`
+	AcceptedAt time.Time         `json:"accepted_at"`
+}
+
+// ConsentStatus is what a user accepted and what they must still accept
+// before the API serves them again
+type ConsentStatus struct {
+	Pending  []*LegalDocument     `json:"pending"`
+	Accepted []*ConsentAcceptance `json:"accepted"`
+}
+
+type PublishLegalDocumentRequest struct {
+	Kind      LegalDocumentKind `json:"kind" validate:"required,oneof=terms privacy"`
+	Version   string            `json:"version" validate:"required,max=50"`
+	URL       string            `json:"url" validate:"required,url,max=2048"`
+	Mandatory *bool             `json:"mandatory" validate:"required"`
+	// EffectiveAt defaults to now; a future date gives users notice
+	EffectiveAt *time.Time `json:"effective_at"`
+}
+
+func (r *PublishLegalDocumentRequest) GetSchema() interface{} {
+	return r
+}
+
+type AcceptConsentRequest struct {
+	DocumentID uuid.UUID `json:"document_id" validate:"required"`
+}
+
+func (r *AcceptConsentRequest) GetSchema() interface{} {
+	return r
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoredEvent is a domain event as persisted for replay
+type StoredEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	Name       string          `json:"name"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// OutboxMessage is an event awaiting relay, stored in the same transaction
+// as the change it describes
+type OutboxMessage struct {
+	ID         uuid.UUID       `json:"id"`
+	EventName  string          `json:"event_name"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Attempts   int             `json:"attempts"`
+	LastError  *string         `json:"last_error,omitempty"`
+	SentAt     *time.Time      `json:"sent_at,omitempty"`
+	// DeadAt is set when the relay gave up on the message
+	DeadAt *time.Time `json:"dead_at,omitempty"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// FraudCheck is the flow a fraud assessment was made for
+type FraudCheck string
+
+const (
+	FraudCheckSignup FraudCheck = "signup"
+)
+
+// FraudDecision is what the score led to
+type FraudDecision string
+
+const (
+	FraudPass FraudDecision = "pass"
+	// FraudReview lets the flow continue on hold until an admin decides
+	FraudReview FraudDecision = "review"
+	FraudBlock  FraudDecision = "block"
+)
+
+// FraudSignal is one rule's finding; its score adds to the assessment's
+type FraudSignal struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+// FraudAssessment is the risk score of one signup. UserID is nil when
+// the signup was blocked before an account existed.
+type FraudAssessment struct {
+	ID        uuid.UUID     `json:"id"`
+	Check     FraudCheck    `json:"check"`
+	UserID    *uuid.UUID    `json:"user_id,omitempty"`
+	Email     string        `json:"email"`
+	IPAddress *string       `json:"ip_address,omitempty"`
+	Score     int           `json:"score"`
+	Decision  FraudDecision `json:"decision"`
+	Signals   []FraudSignal `json:"signals"`
+	CreatedAt time.Time     `json:"created_at"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LoginAnomaly is why a login location looked suspicious
+type LoginAnomaly string
+
+const (
+	// LoginNewCountry is a login from a country the user never logged in from
+	LoginNewCountry LoginAnomaly = "new_country"
+	// LoginImpossibleTravel is a login too far from the previous one for the
+	// time between them
+	LoginImpossibleTravel LoginAnomaly = "impossible_travel"
+	// LoginNewDevice is a login from a device the user never logged in from
+	LoginNewDevice LoginAnomaly = "new_device"
+)
+
+// LoginAttempt describes a login that passed the password check
+type LoginAttempt struct {
+	UserID    uuid.UUID
+	IPAddress string
+	// DeviceID identifies the client: the device ID it sent or, failing
+	// that, its user agent. Empty when nothing identifies it.
+	DeviceID   string
+	DeviceName string
+}
+
+// Device is a client the user has logged in from. Trusted devices skip
+// step-up verification.
+type Device struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Fingerprint string     `json:"-"`
+	Name        string     `json:"name"`
+	LastIP      *string    `json:"last_ip,omitempty"`
+	Trusted     bool       `json:"trusted"`
+	TrustedAt   *time.Time `json:"trusted_at,omitempty"`
+	FirstSeenAt time.Time  `json:"first_seen_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at"`
+}
+
+// LoginLocation is where one successful login came from. The geo fields
+// are nil when the address could not be placed; Verified is false while
+// the login waits on a step-up code.
+type LoginLocation struct {
+	ID          uuid.UUID     `json:"id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	IPAddress   string        `json:"ip_address"`
+	CountryCode *string       `json:"country_code,omitempty"`
+	Country     *string       `json:"country,omitempty"`
+	City        *string       `json:"city,omitempty"`
+	Latitude    *float64      `json:"latitude,omitempty"`
+	Longitude   *float64      `json:"longitude,omitempty"`
+	Anomaly     *LoginAnomaly `json:"anomaly,omitempty"`
+	Verified    bool          `json:"verified"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// LoginChallenge holds back a suspicious login until the user enters the
+// code sent to them
+type LoginChallenge struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	LocationID uuid.UUID  `json:"location_id"`
+	DeviceID   *uuid.UUID `json:"device_id,omitempty"`
+	CodeHash   string     `json:"-"`
+	Attempts   int        `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// LoginChallengeResponse is returned instead of a token when a login
+// needs step-up verification
+type LoginChallengeResponse struct {
+	ChallengeID uuid.UUID `json:"challenge_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type VerifyLoginRequest struct {
+	ChallengeID uuid.UUID `json:"challenge_id" validate:"required"`
+	Code        string    `json:"code" validate:"required,len=6,numeric"`
+	// TrustDevice lets the device that logged in skip step-up next time
+	TrustDevice bool `json:"trust_device"`
+}
+
+func (r *VerifyLoginRequest) GetSchema() interface{} {
+	return r
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ModerationSubject identifies what kind of content a moderation item holds
+type ModerationSubject string
+
+const (
+	SubjectAvatar ModerationSubject = "avatar"
+	// SubjectSignup holds an account flagged by fraud checks; approving
+	// activates it
+	SubjectSignup ModerationSubject = "signup"
+)
+
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending"
+	ModerationApproved ModerationStatus = "approved"
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+type ModerationItem struct {
+	ID              uuid.UUID         `json:"id"`
+	SubjectType     ModerationSubject `json:"subject_type"`
+	SubjectID       uuid.UUID         `json:"subject_id"`
+	SubmittedBy     uuid.UUID         `json:"submitted_by"`
+	Content         string            `json:"content"`
+	Status          ModerationStatus  `json:"status"`
+	RejectionReason *string           `json:"rejection_reason,omitempty"`
+	ReviewedBy      *uuid.UUID        `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time        `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	TenantID        uuid.UUID         `json:"tenant_id"`
+}
+
+type RejectModerationRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+func (r *RejectModerationRequest) GetSchema() interface{} {
+	return r
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PushPlatform is the service that delivers to a push token
+type PushPlatform string
+
+const (
+	PushFCM  PushPlatform = "fcm"
+	PushAPNs PushPlatform = "apns"
+)
+
+// PushToken is an app install registered to receive push notifications.
+// The token itself is only ever sent to the platform.
+type PushToken struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Platform   PushPlatform `json:"platform"`
+	Token      string       `json:"-"`
+	DeviceName *string      `json:"device_name,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+}
+
+type RegisterPushTokenRequest struct {
+	Platform   PushPlatform `json:"platform" validate:"required,oneof=fcm apns"`
+	Token      string       `json:"token" validate:"required,max=4096"`
+	DeviceName *string      `json:"device_name" validate:"omitempty,max=255"`
+}
+
+func (r *RegisterPushTokenRequest) GetSchema() interface{} {
+	return r
+}
+
+// NotificationPreferences are the channels a user receives notifications
+// on; every channel but SMS is on until the user turns it off. Security
+// notifications, such as sign-in codes, are sent regardless.
+type NotificationPreferences struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Push      bool       `json:"push"`
+	Email     bool       `json:"email"`
+	InApp     bool       `json:"in_app"`
+	SMS       bool       `json:"sms"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateNotificationPreferencesRequest replaces the preferences; SMS is
+// optional for clients that predate it and stays off when left out
+type UpdateNotificationPreferencesRequest struct {
+	Push  *bool `json:"push" validate:"required"`
+	Email *bool `json:"email" validate:"required"`
+	InApp *bool `json:"in_app" validate:"required"`
+	SMS   *bool `json:"sms"`
+}
+
+func (r *UpdateNotificationPreferencesRequest) GetSchema() interface{} {
+	return r
+}
+
+// PushDeliveryStatus is what the platform made of a push
+type PushDeliveryStatus string
+
+const (
+	PushSent PushDeliveryStatus = "sent"
+	// PushFailed may succeed if tried again; the token is kept
+	PushFailed PushDeliveryStatus = "failed"
+	// PushTokenInvalid means the app was uninstalled or the token
+	// expired; the token is dropped
+	PushTokenInvalid PushDeliveryStatus = "token_invalid"
+)
+
+// PushDelivery records one push to one token. PushTokenID is nil once the
+// token is gone.
+type PushDelivery struct {
+	ID                uuid.UUID          `json:"id"`
+	UserID            uuid.UUID          `json:"user_id"`
+	PushTokenID       *uuid.UUID         `json:"push_token_id,omitempty"`
+	Platform          PushPlatform       `json:"platform"`
+	NotificationType  string             `json:"notification_type"`
+	Status            PushDeliveryStatus `json:"status"`
+	ProviderMessageID *string            `json:"provider_message_id,omitempty"`
+	Error             *string            `json:"error,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// DefaultNotificationPreferences are what a user who never chose gets;
+// texts are opt-in
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID: userID,
+		Push:   true,
+		Email:  true,
+		InApp:  true,
+	}
+}
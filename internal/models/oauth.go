@@ -0,0 +1,185 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthScope is a slice of a user's data a third-party app can be granted
+type OAuthScope string
+
+const (
+	ScopeProfileRead   OAuthScope = "profile:read"
+	ScopeAddressesRead OAuthScope = "addresses:read"
+)
+
+// OAuthScopes describes each scope the way the consent screen shows it
+var OAuthScopes = map[OAuthScope]string{
+	ScopeProfileRead:   "See your profile visibility preferences",
+	ScopeAddressesRead: "See your saved addresses",
+}
+
+// OAuthClient is a third-party app registered to act for users. Public
+// clients, such as mobile apps, have no secret and rely on PKCE alone.
+type OAuthClient struct {
+	ID           uuid.UUID    `json:"id"`
+	TenantID     uuid.UUID    `json:"tenant_id"`
+	Name         string       `json:"name"`
+	Confidential bool         `json:"confidential"`
+	SecretHash   *string      `json:"-"`
+	RedirectURIs []string     `json:"redirect_uris"`
+	Scopes       []OAuthScope `json:"scopes"`
+	CreatedBy    *uuid.UUID   `json:"created_by,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// RegisteredOAuthClient is returned once, on registration; the secret
+// can't be read back afterwards
+type RegisteredOAuthClient struct {
+	*OAuthClient
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// OAuthGrant is what a user has approved for a client
+type OAuthGrant struct {
+	UserID     uuid.UUID    `json:"user_id"`
+	ClientID   uuid.UUID    `json:"client_id"`
+	ClientName string       `json:"client_name,omitempty"`
+	Scopes     []OAuthScope `json:"scopes"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// OAuthAuthorizationCode is a single-use code a client trades for tokens
+type OAuthAuthorizationCode struct {
+	CodeHash      string
+	ClientID      uuid.UUID
+	UserID        uuid.UUID
+	RedirectURI   string
+	Scopes        []OAuthScope
+	CodeChallenge string
+	ExpiresAt     time.Time
+	UsedAt        *time.Time
+	CreatedAt     time.Time
+}
+
+type OAuthTokenKind string
+
+const (
+	OAuthAccessToken  OAuthTokenKind = "access"
+	OAuthRefreshToken OAuthTokenKind = "refresh"
+)
+
+// OAuthToken is an opaque access or refresh token issued to a client
+type OAuthToken struct {
+	ID        uuid.UUID
+	TokenHash string
+	Kind      OAuthTokenKind
+	ClientID  uuid.UUID
+	UserID    uuid.UUID
+	Scopes    []OAuthScope
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+type CreateOAuthClientRequest struct {
+	Name         string       `json:"name" validate:"required,min=2,max=100"`
+	RedirectURIs []string     `json:"redirect_uris" validate:"required,min=1,max=10,dive,url,max=2000"`
+	Scopes       []OAuthScope `json:"scopes" validate:"required,min=1,dive,oneof=profile:read addresses:read"`
+	// Confidential clients get a secret; leave it off for apps that can't keep one
+	Confidential bool `json:"confidential"`
+}
+
+// OAuthAuthorizeRequest is an authorization request as RFC 6749 defines
+// it. Only response_type=code with an S256 PKCE challenge is supported.
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+}
+
+// OAuthDecisionRequest is the user's answer on the consent screen
+type OAuthDecisionRequest struct {
+	OAuthAuthorizeRequest
+	Approve *bool `json:"approve" validate:"required"`
+}
+
+// OAuthAuthorization is the consent screen for an authorization request.
+// Once the request is decided, or can't go ahead, only RedirectURI is set
+// and the browser should be sent there.
+type OAuthAuthorization struct {
+	RedirectURI string                  `json:"redirect_uri,omitempty"`
+	Client      *OAuthClientSummary     `json:"client,omitempty"`
+	Scopes      []OAuthScopeDescription `json:"scopes,omitempty"`
+	// Granted is set when the user already approved every requested scope
+	Granted bool `json:"granted,omitempty"`
+}
+
+type OAuthClientSummary struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type OAuthScopeDescription struct {
+	Name        OAuthScope `json:"name"`
+	Description string     `json:"description"`
+}
+
+// OAuthClientCredentials is how a client identifies itself to the token,
+// introspection and revocation endpoints
+type OAuthClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// OAuthTokenRequest is a token endpoint request for the authorization_code
+// or refresh_token grant
+type OAuthTokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthTokenResponse is the token endpoint's success body (RFC 6749 5.1)
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope"`
+}
+
+// OAuthIntrospection is the introspection endpoint's body (RFC 7662). An
+// inactive token reports nothing but active=false.
+type OAuthIntrospection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// OAuthErrorResponse is the client endpoints' error body (RFC 6749 5.2)
+type OAuthErrorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (r *CreateOAuthClientRequest) GetSchema() interface{} {
+	return r
+}
+
+func (r *OAuthDecisionRequest) GetSchema() interface{} {
+	return r
+}
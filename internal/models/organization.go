@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRole is what a member may do on behalf of an organization
+type OrganizationRole string
+
+const (
+	// OrgRoleOwner manages members, including other owners
+	OrgRoleOwner OrganizationRole = "owner"
+	// OrgRoleManager runs the storefront and adds or removes support members
+	OrgRoleManager OrganizationRole = "manager"
+	// OrgRoleSupport answers buyers
+	OrgRoleSupport OrganizationRole = "support"
+)
+
+// Organization is a publisher account shared by its members. Role is the
+// caller's own role when it is listed for them.
+type Organization struct {
+	ID        uuid.UUID        `json:"id"`
+	TenantID  uuid.UUID        `json:"tenant_id"`
+	Name      string           `json:"name"`
+	CreatedBy *uuid.UUID       `json:"created_by,omitempty"`
+	Role      OrganizationRole `json:"role,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	UserID         uuid.UUID        `json:"user_id"`
+	Role           OrganizationRole `json:"role"`
+	AddedBy        *uuid.UUID       `json:"added_by,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type AddOrganizationMemberRequest struct {
+	UserID uuid.UUID        `json:"user_id" validate:"required"`
+	Role   OrganizationRole `json:"role" validate:"required,oneof=owner manager support"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role OrganizationRole `json:"role" validate:"required,oneof=owner manager support"`
+}
+
+func (r *CreateOrganizationRequest) GetSchema() interface{} {
+	return r
+}
+
+func (r *AddOrganizationMemberRequest) GetSchema() interface{} {
+	return r
+}
+
+func (r *UpdateOrganizationMemberRequest) GetSchema() interface{} {
+	return r
+}
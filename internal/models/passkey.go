@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Passkey is a WebAuthn credential a user registered to sign in without a
+// password. Credential is the verifier's record of it: the public key,
+// signature counter and authenticator flags.
+type Passkey struct {
+	ID           uuid.UUID       `json:"id"`
+	UserID       uuid.UUID       `json:"user_id"`
+	Name         string          `json:"name"`
+	CredentialID []byte          `json:"-"`
+	Credential   json.RawMessage `json:"-"`
+	CreatedAt    time.Time       `json:"created_at"`
+	LastUsedAt   *time.Time      `json:"last_used_at,omitempty"`
+}
+
+// WebAuthnCeremony is which WebAuthn exchange a session belongs to
+type WebAuthnCeremony string
+
+const (
+	WebAuthnRegistration WebAuthnCeremony = "registration"
+	WebAuthnLogin        WebAuthnCeremony = "login"
+)
+
+// WebAuthnSession holds the challenge issued by a begin step until the
+// matching finish step consumes it. UserID is nil for passkey logins,
+// where the authenticator says who the user is.
+type WebAuthnSession struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    *uuid.UUID       `json:"user_id,omitempty"`
+	Ceremony  WebAuthnCeremony `json:"ceremony"`
+	Data      json.RawMessage  `json:"-"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// WebAuthnBeginResponse carries the options to pass to
+// navigator.credentials.create or .get, and the session to finish with
+type WebAuthnBeginResponse struct {
+	SessionID uuid.UUID   `json:"session_id"`
+	Options   interface{} `json:"options"`
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	SessionID uuid.UUID `json:"session_id" validate:"required"`
+	Name      string    `json:"name" validate:"omitempty,max=100"`
+	// Credential is the PublicKeyCredential the browser returned, as JSON
+	Credential json.RawMessage `json:"credential" validate:"required"`
+}
+
+func (r *FinishPasskeyRegistrationRequest) GetSchema() interface{} {
+	return r
+}
+
+type FinishPasskeyLoginRequest struct {
+	SessionID  uuid.UUID       `json:"session_id" validate:"required"`
+	Credential json.RawMessage `json:"credential" validate:"required"`
+}
+
+func (r *FinishPasskeyLoginRequest) GetSchema() interface{} {
+	return r
+}
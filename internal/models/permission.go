@@ -0,0 +1,51 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Permission is a fine-grained capability checked on top of the admin role
+type Permission string
+
+const (
+	PermUsersRead         Permission = "users:read"
+	PermUsersSuspend      Permission = "users:suspend"
+	PermModerationReview  Permission = "moderation:review"
+	PermAnalyticsRead     Permission = "analytics:read"
+	PermDiagnosticsRead   Permission = "diagnostics:read"
+	PermPermissionsManage Permission = "permissions:manage"
+	PermLegalPublish      Permission = "legal:publish"
+	PermTenantManage      Permission = "tenant:manage"
+	PermOAuthManage       Permission = "oauth:manage"
+)
+
+type PermissionDefinition struct {
+	Name        Permission `json:"name"`
+	Description string     `json:"description"`
+}
+
+// PermissionGrant is a permission granted directly to a user, on top of
+// those their role carries
+type PermissionGrant struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Permission Permission `json:"permission"`
+	GrantedBy  *uuid.UUID `json:"granted_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// UserPermissions is a user's effective permission set and where it comes from
+type UserPermissions struct {
+	UserID    uuid.UUID          `json:"user_id"`
+	Role      UserRole           `json:"role"`
+	Effective []Permission       `json:"effective"`
+	Grants    []*PermissionGrant `json:"grants"`
+}
+
+type GrantPermissionRequest struct {
+	Permission Permission `json:"permission" validate:"required,max=100"`
+}
+
+func (r *GrantPermissionRequest) GetSchema() interface{} {
+	return r
+}
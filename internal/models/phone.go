@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhoneVerification is a one-time code texted to the phone number on a
+// user's account, proving they hold it
+type PhoneVerification struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Phone      string     `json:"phone"`
+	CodeHash   string     `json:"-"`
+	Attempts   int        `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// VerifiedPhone is the number a user last proved they hold. It stops
+// counting once they change the number on their account.
+type VerifiedPhone struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Phone      string    `json:"phone"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// PhoneStatus is the caller's phone number and whether it is verified
+type PhoneStatus struct {
+	Phone      *string    `json:"phone,omitempty"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// PhoneVerificationResponse says where the code went and until when it
+// works
+type PhoneVerificationResponse struct {
+	Phone     string    `json:"phone"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ConfirmPhoneRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+func (r *ConfirmPhoneRequest) GetSchema() interface{} {
+	return r
+}
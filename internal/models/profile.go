@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PublicProfile is what anyone may see of a user. It never carries contact
+// details or account state.
+type PublicProfile struct {
+	Username    string    `json:"username" example:"johnd"`
+	DisplayName string    `json:"display_name" example:"John D."`
+	AvatarURL   *string   `json:"avatar_url,omitempty" example:"https://example.com/avatar.jpg"`
+	MemberSince time.Time `json:"member_since" example:"2024-01-01T00:00:00Z"`
+	// Visibility tells clients which optional sections the user shares
+	Visibility ProfileVisibility `json:"visibility"`
+}
+
+type ProfileVisibility struct {
+	Library  bool `json:"library"`
+	Wishlist bool `json:"wishlist"`
+	Activity bool `json:"activity"`
+}
+
+// ProfilePreferences are a user's choices about their public profile;
+// every section is hidden until the user opts in
+type ProfilePreferences struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	ShowLibrary  bool       `json:"show_library"`
+	ShowWishlist bool       `json:"show_wishlist"`
+	ShowActivity bool       `json:"show_activity"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+type UpdateProfilePreferencesRequest struct {
+	ShowLibrary  *bool `json:"show_library" validate:"required"`
+	ShowWishlist *bool `json:"show_wishlist" validate:"required"`
+	ShowActivity *bool `json:"show_activity" validate:"required"`
+}
+
+func (r *UpdateProfilePreferencesRequest) GetSchema() interface{} {
+	return r
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// QueryPlan is an EXPLAIN snapshot of a hot repository query
+type QueryPlan struct {
+	ID               uuid.UUID   `json:"id"`
+	QueryName        string      `json:"query_name"`
+	DeployVersion    string      `json:"deploy_version"`
+	Plan             interface{} `json:"plan"`
+	Scans            []PlanScan  `json:"scans"`
+	Regression       bool        `json:"regression"`
+	RegressionDetail *string     `json:"regression_detail,omitempty"`
+	CapturedAt       time.Time   `json:"captured_at"`
+}
+
+// PlanScan is a single scan node found in a query plan
+type PlanScan struct {
+	Relation string `json:"relation"`
+	NodeType string `json:"node_type"`
+	Index    string `json:"index,omitempty"`
+}
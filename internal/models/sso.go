@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSOLoginState is held between sending the browser to the identity
+// provider and its callback
+type SSOLoginState struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+// SSOIdentity links a provider account, by its issuer and subject, to the
+// user it signs in as
+type SSOIdentity struct {
+	Issuer      string    `json:"issuer"`
+	Subject     string    `json:"subject"`
+	UserID      uuid.UUID `json:"user_id"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+// SSOBeginResponse is where to send the browser to sign in
+type SSOBeginResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// FinishSSOLoginRequest is the provider's callback, passed through by the
+// page it redirected to
+type FinishSSOLoginRequest struct {
+	State string
+	Code  string
+}
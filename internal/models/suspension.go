@@ -0,0 +1,56 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Suspension is a ban on a user; a nil ExpiresAt means it never expires
+type Suspension struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Reason    string     `json:"reason"`
+	IssuedBy  *uuid.UUID `json:"issued_by,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	LiftedAt  *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy  *uuid.UUID `json:"lifted_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type IssueSuspensionRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+	// ExpiresAt omitted issues a permanent ban
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (r *IssueSuspensionRequest) GetSchema() interface{} {
+	return r
+}
+
+// BulkStatusRequest moves up to 100 users to one status
+type BulkStatusRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" validate:"required,min=1,max=100,unique"`
+	Status  UserStatus  `json:"status" validate:"required,user_status"`
+	// Reason is recorded on the suspensions issued when Status is suspended
+	Reason    string     `json:"reason" validate:"required_if=Status suspended,omitempty,min=3,max=500"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (r *BulkStatusRequest) GetSchema() interface{} {
+	return r
+}
+
+// Bulk status outcomes, one per requested user
+const (
+	BulkStatusUpdated   = "updated"
+	BulkStatusUnchanged = "unchanged"
+	BulkStatusFailed    = "failed"
+)
+
+type BulkStatusResult struct {
+	UserID uuid.UUID `json:"user_id"`
+	Result string    `json:"result"`
+	Error  string    `json:"error,omitempty"`
+	// Err is why the user was skipped; handlers localize it into Error
+	Err error `json:"-"`
+}
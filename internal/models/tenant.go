@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID is the store seeded by the tenants migration. Requests
+// whose host maps to no tenant, and every user that predates tenancy,
+// belong to it.
+var DefaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Tenant is a white-label storefront. Users, and everything scoped to
+// them, belong to exactly one tenant; FeeBps is the marketplace fee in
+// basis points.
+type Tenant struct {
+	ID        uuid.UUID      `json:"id"`
+	Slug      string         `json:"slug"`
+	Name      string         `json:"name"`
+	Branding  TenantBranding `json:"branding"`
+	Currency  string         `json:"currency"`
+	FeeBps    int            `json:"fee_bps"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// TenantBranding is what a storefront shows in place of the default look
+type TenantBranding struct {
+	LogoURL      *string `json:"logo_url,omitempty" validate:"omitempty,url"`
+	PrimaryColor *string `json:"primary_color,omitempty" validate:"omitempty,hexcolor"`
+	SupportEmail *string `json:"support_email,omitempty" validate:"omitempty,email"`
+}
+
+type UpdateTenantRequest struct {
+	Name     string         `json:"name" validate:"required,min=2,max=100"`
+	Branding TenantBranding `json:"branding"`
+	Currency string         `json:"currency" validate:"required,iso4217"`
+	FeeBps   *int           `json:"fee_bps" validate:"required,min=0,max=10000"`
+}
+
+func (r *UpdateTenantRequest) GetSchema() interface{} {
+	return r
+}
@@ -16,6 +16,8 @@ type User struct {
 	Status       UserStatus `json:"status"`
 	AvatarURL    *string    `json:"avatar_url,omitempty"`
 	Phone        *string    `json:"phone,omitempty"`
+	Username     *string    `json:"username,omitempty"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 }
@@ -37,20 +39,24 @@ const (
 )
 
 // Request/Response DTOs with validation
+
+// CreateUserRequest signs a user up. There is no role: signups are always
+// gamers, and admins are provisioned with adminctl, SSO or permissions.
 type CreateUserRequest struct {
-	Email     string   `json:"email" validate:"required,email"`
-	Password  string   `json:"password" validate:"required,min=8"`
-	FirstName string   `json:"first_name" validate:"required,min=2,max=100"`
-	LastName  string   `json:"last_name" validate:"required,min=2,max=100"`
-	Role      UserRole `json:"role" validate:"required,oneof=gamer admin su-admin"`
-	Phone     string   `json:"phone,omitempty" validate:"omitempty,min=10"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required,min=8,password_strength"`
+	FirstName string `json:"first_name" validate:"required,min=2,max=100"`
+	LastName  string `json:"last_name" validate:"required,min=2,max=100"`
+	Phone     string `json:"phone,omitempty" validate:"omitempty,phone"`
+	Username  string `json:"username,omitempty" validate:"omitempty,username"`
 }
 
 type UpdateUserRequest struct {
 	FirstName string `json:"first_name" validate:"required,min=2,max=100"`
 	LastName  string `json:"last_name" validate:"required,min=2,max=100"`
-	Phone     string `json:"phone,omitempty" validate:"omitempty,min=10"`
+	Phone     string `json:"phone,omitempty" validate:"omitempty,phone"`
 	AvatarURL string `json:"avatar_url,omitempty" validate:"omitempty,url"`
+	Username  string `json:"username,omitempty" validate:"omitempty,username"`
 }
 
 type LoginRequest struct {
@@ -67,18 +73,47 @@ type UserResponse struct {
 	Status    UserStatus `json:"status" example:"active"`
 	AvatarURL *string    `json:"avatar_url,omitempty" example:"https://example.com/avatar.jpg"`
 	Phone     *string    `json:"phone,omitempty" example:"+1234567890"`
+	Username  *string    `json:"username,omitempty" example:"johnd"`
 	CreatedAt time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
 	UpdatedAt time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 }
 
-func (r CreateUserRequest) GetSchema() interface{} {
+// PublicUserResponse is what anyone may see of another user's account. Like
+// PublicProfile it never carries contact details or account state.
+type PublicUserResponse struct {
+	ID          uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Username    *string   `json:"username,omitempty" example:"johnd"`
+	DisplayName string    `json:"display_name" example:"John D."`
+	AvatarURL   *string   `json:"avatar_url,omitempty" example:"https://example.com/avatar.jpg"`
+	MemberSince time.Time `json:"member_since" example:"2024-01-01T00:00:00Z"`
+}
+
+// MaxBatchUserIDs caps the IDs one batch lookup resolves
+const MaxBatchUserIDs = 100
+
+// BatchUserResult is one ID of a batch lookup, in request order. Found is
+// false, and the user's fields are absent, when no user has the ID.
+type BatchUserResult struct {
+	ID    uuid.UUID `json:"id"`
+	Found bool      `json:"found"`
+	*PublicUserResponse
+}
+
+type LoginResponse struct {
+	Token     string        `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType string        `json:"token_type" example:"Bearer"`
+	ExpiresAt time.Time     `json:"expires_at" example:"2024-01-02T00:00:00Z"`
+	User      *UserResponse `json:"user"`
+}
+
+func (r *CreateUserRequest) GetSchema() interface{} {
 	return r
 }
 
-func (r UpdateUserRequest) GetSchema() interface{} {
+func (r *UpdateUserRequest) GetSchema() interface{} {
 	return r
 }
 
-func (r LoginRequest) GetSchema() interface{} {
+func (r *LoginRequest) GetSchema() interface{} {
 	return r
 }
@@ -0,0 +1,48 @@
+package models
+
+import (
+	_ "embed"
+	"regexp"
+	"strings"
+)
+
+//go:embed reserved_usernames.txt
+var reservedUsernameList string
+
+var reservedUsernames = func() map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, line := range strings.Split(reservedUsernameList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return names
+}()
+
+// usernamePattern: 3-30 letters, digits or underscores, starting with a letter
+var usernamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{2,29}$`)
+
+// Why a username is unavailable
+const (
+	UsernameInvalid  = "invalid"
+	UsernameReserved = "reserved"
+	UsernameTaken    = "taken"
+)
+
+type UsernameAvailability struct {
+	Username  string `json:"username"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ValidUsername reports whether name has the allowed shape
+func ValidUsername(name string) bool {
+	return usernamePattern.MatchString(name)
+}
+
+// ReservedUsername reports whether name is held back for the platform,
+// ignoring case
+func ReservedUsername(name string) bool {
+	_, reserved := reservedUsernames[strings.ToLower(name)]
+	return reserved
+}
@@ -0,0 +1,45 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+// Result is the outcome of an automated pre-screen. Content that is not
+// rejected still waits in the queue for a human decision.
+type Result struct {
+	Reject bool
+	Reason string
+}
+
+// Screener is a pre-screening hook run when content enters the queue
+type Screener interface {
+	Screen(ctx context.Context, subject models.ModerationSubject, content string) (Result, error)
+}
+
+// WordListScreener rejects content containing any of a list of banned words
+type WordListScreener struct {
+	words []string
+}
+
+func NewWordListScreener(words ...string) *WordListScreener {
+	lowered := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			lowered = append(lowered, strings.ToLower(word))
+		}
+	}
+	return &WordListScreener{words: lowered}
+}
+
+func (s *WordListScreener) Screen(ctx context.Context, subject models.ModerationSubject, content string) (Result, error) {
+	content = strings.ToLower(content)
+	for _, word := range s.words {
+		if strings.Contains(content, word) {
+			return Result{Reject: true, Reason: "content contains prohibited language"}, nil
+		}
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,163 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsHost        = "https://api.push.apple.com"
+	apnsSandboxHost = "https://api.sandbox.push.apple.com"
+	// apnsTokenRefresh is how long a provider token is reused. Apple
+	// rejects tokens over an hour old and ones replaced too often.
+	apnsTokenRefresh = 50 * time.Minute
+)
+
+// apnsInvalidReasons are the rejections that mean the token is dead
+var apnsInvalidReasons = map[string]bool{
+	"BadDeviceToken":         true,
+	"Unregistered":           true,
+	"DeviceTokenNotForTopic": true,
+}
+
+// APNs pushes to iOS installs through the Apple Push Notification
+// service, authenticated with a token-based signing key
+type APNs struct {
+	key    *ecdsa.PrivateKey
+	keyID  string
+	teamID string
+	topic  string
+	host   string
+	http   *http.Client
+
+	mu       sync.Mutex
+	bearer   string
+	issuedAt time.Time
+}
+
+// NewAPNs pushes to the app topic with key, the .p8 authentication key
+// keyID names in the team's developer account. sandbox reaches
+// development builds.
+func NewAPNs(key []byte, keyID, teamID, topic string, sandbox bool, timeout time.Duration) (*APNs, error) {
+	signingKey, err := jwt.ParseECPrivateKeyFromPEM(key)
+	if err != nil {
+		return nil, fmt.Errorf("apns: parsing signing key: %w", err)
+	}
+
+	host := apnsHost
+	if sandbox {
+		host = apnsSandboxHost
+	}
+	return &APNs{
+		key:    signingKey,
+		keyID:  keyID,
+		teamID: teamID,
+		topic:  topic,
+		host:   host,
+		// The default transport speaks HTTP/2, which APNs requires
+		http: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+type apnsPayload struct {
+	APS struct {
+		Alert apnsAlert `json:"alert"`
+		Sound string    `json:"sound"`
+	} `json:"aps"`
+	Data map[string]string `json:"data"`
+}
+
+func (a *APNs) Push(ctx context.Context, token string, n Notification) (string, error) {
+	bearer, err := a.providerToken()
+	if err != nil {
+		return "", err
+	}
+
+	var payload apnsPayload
+	payload.APS.Alert = apnsAlert{Title: n.Title, Body: n.Body}
+	payload.APS.Sound = "default"
+	payload.Data = pushData(n)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.host+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "bearer "+bearer)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("apns: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return resp.Header.Get("apns-id"), nil
+	}
+
+	var rejection struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rejection); err != nil {
+		return "", fmt.Errorf("apns: status %d", resp.StatusCode)
+	}
+	if apnsInvalidReasons[rejection.Reason] {
+		return "", fmt.Errorf("apns: %s: %w", rejection.Reason, ErrTokenInvalid)
+	}
+	if rejection.Reason == "ExpiredProviderToken" {
+		a.forgetToken()
+	}
+	return "", fmt.Errorf("apns: status %d: %s", resp.StatusCode, rejection.Reason)
+}
+
+// providerToken returns the signed token requests are authorized with,
+// reusing it until it is due for replacement
+func (a *APNs) providerToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.bearer != "" && time.Since(a.issuedAt) < apnsTokenRefresh {
+		return a.bearer, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": a.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = a.keyID
+	bearer, err := token.SignedString(a.key)
+	if err != nil {
+		return "", fmt.Errorf("apns: signing provider token: %w", err)
+	}
+
+	a.bearer = bearer
+	a.issuedAt = now
+	return bearer, nil
+}
+
+// forgetToken makes the next push sign a new provider token
+func (a *APNs) forgetToken() {
+	a.mu.Lock()
+	a.bearer = ""
+	a.mu.Unlock()
+}
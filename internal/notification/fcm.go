@@ -0,0 +1,218 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	// fcmTokenTTL is the lifetime asked for; Google caps it at an hour
+	fcmTokenTTL = time.Hour
+)
+
+// FCM pushes to Android and web installs through Firebase Cloud
+// Messaging's HTTP v1 API, signed in as a service account
+type FCM struct {
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	key         *rsa.PrivateKey
+	http        *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// serviceAccount is the fields of a service account key FCM needs
+type serviceAccount struct {
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// NewFCM signs in with credentials, a service account key as downloaded
+// from the Firebase console
+func NewFCM(credentials []byte, timeout time.Duration) (*FCM, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, fmt.Errorf("fcm: parsing service account key: %w", err)
+	}
+	if account.ProjectID == "" || account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("fcm: service account key lacks project_id, client_email or token_uri")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("fcm: parsing private key: %w", err)
+	}
+
+	return &FCM{
+		projectID:   account.ProjectID,
+		clientEmail: account.ClientEmail,
+		tokenURI:    account.TokenURI,
+		key:         key,
+		http:        &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type fcmMessage struct {
+	Message struct {
+		Token        string            `json:"token"`
+		Notification fcmNotification   `json:"notification"`
+		Data         map[string]string `json:"data"`
+	} `json:"message"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmResponse struct {
+	Name  string `json:"name"`
+	Error *struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (f *FCM) Push(ctx context.Context, token string, n Notification) (string, error) {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var msg fcmMessage
+	msg.Message.Token = token
+	msg.Message.Notification = fcmNotification{Title: n.Title, Body: n.Body}
+	msg.Message.Data = pushData(n)
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(fcmEndpoint, f.projectID), bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body fcmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("fcm: decoding response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return body.Name, nil
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		f.forgetToken()
+	}
+	if body.Error == nil {
+		return "", fmt.Errorf("fcm: status %d", resp.StatusCode)
+	}
+	for _, detail := range body.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return "", fmt.Errorf("fcm: %s: %w", body.Error.Message, ErrTokenInvalid)
+		}
+	}
+	return "", fmt.Errorf("fcm: %s: %s", body.Error.Status, body.Error.Message)
+}
+
+// token returns an access token, signing in again shortly before the
+// cached one expires
+func (f *FCM) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.accessToken != "" && time.Now().Add(time.Minute).Before(f.expiresAt) {
+		return f.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   f.clientEmail,
+		"scope": fcmScope,
+		"aud":   f.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(fcmTokenTTL).Unix(),
+	}).SignedString(f.key)
+	if err != nil {
+		return "", fmt.Errorf("fcm: signing token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm: token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("fcm: decoding token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return "", fmt.Errorf("fcm: signing in: %s: %s", body.Error, body.ErrorDescription)
+	}
+
+	f.accessToken = body.AccessToken
+	f.expiresAt = now.Add(time.Duration(body.ExpiresIn) * time.Second)
+	return f.accessToken, nil
+}
+
+// forgetToken makes the next push sign in again
+func (f *FCM) forgetToken() {
+	f.mu.Lock()
+	f.accessToken = ""
+	f.mu.Unlock()
+}
+
+// pushData is what the app receives besides the text: the notification's
+// data and its type, so the app can tell notifications apart
+func pushData(n Notification) map[string]string {
+	data := make(map[string]string, len(n.Data)+1)
+	for k, v := range n.Data {
+		data[k] = v
+	}
+	data["type"] = n.Type
+	return data
+}
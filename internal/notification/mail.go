@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// MailSender sends an email through a provider
+type MailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// EmailNotifier emails notifications to the address on the user's account
+type EmailNotifier struct {
+	users  repository.UserRepository
+	sender MailSender
+	logger zerolog.Logger
+}
+
+func NewEmailNotifier(users repository.UserRepository, sender MailSender, logger zerolog.Logger) *EmailNotifier {
+	return &EmailNotifier{
+		users:  users,
+		sender: sender,
+		logger: logger,
+	}
+}
+
+func (e *EmailNotifier) Notify(ctx context.Context, userID uuid.UUID, n Notification) error {
+	user, err := e.users.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting user: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	if err := e.sender.Send(ctx, user.Email, n.Title, n.Body); err != nil {
+		return err
+	}
+	e.logger.Info().
+		Str("user_id", userID.String()).
+		Str("type", n.Type).
+		Msg("email sent")
+	return nil
+}
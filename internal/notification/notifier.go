@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type Notification struct {
+	Type  string            `json:"type"`
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// Notifier delivers a notification to a single user
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, n Notification) error
+}
+
+// LogNotifier writes notifications to the log; it stands in for email
+// until a mail provider is configured. Bodies can carry sign-in codes and
+// links, so only the type and title are logged.
+type LogNotifier struct {
+	logger zerolog.Logger
+}
+
+func NewLogNotifier(logger zerolog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, userID uuid.UUID, notification Notification) error {
+	n.logger.Info().
+		Str("user_id", userID.String()).
+		Str("type", notification.Type).
+		Str("title", notification.Title).
+		Msg("notification")
+	return nil
+}
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// ErrTokenInvalid is returned by a Pusher when the platform no longer
+// accepts the token, usually because the app was uninstalled
+var ErrTokenInvalid = errors.New("push token is no longer valid")
+
+// Pusher sends a notification to one app install through its platform
+type Pusher interface {
+	// Push returns the platform's ID for the message
+	Push(ctx context.Context, token string, n Notification) (string, error)
+}
+
+// PushNotifier pushes notifications to every app install the user
+// registered. Each push is recorded with what the platform answered;
+// tokens the platform rejects are dropped.
+type PushNotifier struct {
+	repo    repository.NotificationRepository
+	pushers map[models.PushPlatform]Pusher
+	logger  zerolog.Logger
+}
+
+// NewPushNotifier pushes through pushers by platform. Tokens for a
+// platform without a pusher are skipped.
+func NewPushNotifier(repo repository.NotificationRepository, pushers map[models.PushPlatform]Pusher, logger zerolog.Logger) *PushNotifier {
+	return &PushNotifier{
+		repo:    repo,
+		pushers: pushers,
+		logger:  logger,
+	}
+}
+
+// Notify only fails when the tokens or deliveries can't be read or
+// written; pushes that fail are recorded, not returned, so callers that
+// retry don't push again to the installs that got it
+func (p *PushNotifier) Notify(ctx context.Context, userID uuid.UUID, n Notification) error {
+	tokens, err := p.repo.ListPushTokens(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("listing push tokens: %w", err)
+	}
+
+	for _, token := range tokens {
+		pusher, ok := p.pushers[token.Platform]
+		if !ok {
+			continue
+		}
+		if err := p.push(ctx, pusher, token, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PushNotifier) push(ctx context.Context, pusher Pusher, token *models.PushToken, n Notification) error {
+	tokenID := token.ID
+	delivery := &models.PushDelivery{
+		UserID:           token.UserID,
+		PushTokenID:      &tokenID,
+		Platform:         token.Platform,
+		NotificationType: n.Type,
+		Status:           models.PushSent,
+	}
+
+	messageID, err := pusher.Push(ctx, token.Token, n)
+	switch {
+	case err == nil:
+		delivery.ProviderMessageID = &messageID
+		if err := p.repo.TouchPushToken(ctx, token.ID); err != nil {
+			return fmt.Errorf("touching push token: %w", err)
+		}
+	case errors.Is(err, ErrTokenInvalid):
+		delivery.Status = models.PushTokenInvalid
+		delivery.Error = stringPtr(err.Error())
+		// The delivery outlives the token, so it no longer points at it
+		delivery.PushTokenID = nil
+		if err := p.repo.DropPushToken(ctx, token.ID); err != nil {
+			return fmt.Errorf("dropping push token: %w", err)
+		}
+	default:
+		delivery.Status = models.PushFailed
+		delivery.Error = stringPtr(err.Error())
+		p.logger.Warn().Err(err).
+			Str("user_id", token.UserID.String()).
+			Str("platform", string(token.Platform)).
+			Str("type", n.Type).
+			Msg("push failed")
+	}
+
+	if err := p.repo.CreateDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("recording push delivery: %w", err)
+	}
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
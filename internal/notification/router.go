@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// Channel is a way of reaching a user that they can turn off
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelPush  Channel = "push"
+	ChannelInApp Channel = "in_app"
+	ChannelSMS   Channel = "sms"
+)
+
+// securityTypes carry sign-in codes and links or warn of sign-ins. They
+// go by email whatever the user's preferences, and never to a lock screen;
+// users who opted into texts may get them texted as well.
+var securityTypes = map[string]bool{
+	"login_verification": true,
+	"magic_link":         true,
+	"suspicious_login":   true,
+}
+
+// Router sends each notification over the channels the user left on
+type Router struct {
+	repo     repository.NotificationRepository
+	channels map[Channel]Notifier
+}
+
+// NewRouter delivers through channels. A channel without a notifier is
+// skipped, whatever the user chose.
+func NewRouter(repo repository.NotificationRepository, channels map[Channel]Notifier) *Router {
+	return &Router{
+		repo:     repo,
+		channels: channels,
+	}
+}
+
+// Notify tries every channel the user left on and returns what failed
+func (r *Router) Notify(ctx context.Context, userID uuid.UUID, n Notification) error {
+	prefs, err := r.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting notification preferences: %w", err)
+	}
+	if prefs == nil {
+		prefs = models.DefaultNotificationPreferences(userID)
+	}
+	if securityTypes[n.Type] {
+		prefs = &models.NotificationPreferences{UserID: userID, Email: true, SMS: prefs.SMS}
+	}
+
+	var errs []error
+	for _, choice := range []struct {
+		channel Channel
+		enabled bool
+	}{
+		{ChannelEmail, prefs.Email},
+		{ChannelPush, prefs.Push},
+		{ChannelInApp, prefs.InApp},
+		{ChannelSMS, prefs.SMS},
+	} {
+		if choice.enabled {
+			errs = append(errs, r.send(ctx, choice.channel, userID, n))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) send(ctx context.Context, channel Channel, userID uuid.UUID, n Notification) error {
+	notifier, ok := r.channels[channel]
+	if !ok {
+		return nil
+	}
+	if err := notifier.Notify(ctx, userID, n); err != nil {
+		return fmt.Errorf("%s: %w", channel, err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// ErrSMSNotAllowed is returned when the sending rules don't cover a number
+var ErrSMSNotAllowed = errors.New("sms to this number is not allowed")
+
+// SMSSender sends a text message through a provider
+type SMSSender interface {
+	// Send returns the provider's ID for the message
+	Send(ctx context.Context, from, to, body string) (string, error)
+}
+
+// SMSRules decide which numbers may be texted and from which sender.
+// Numbers are E.164, so a prefix such as "+44" picks a country and a
+// longer one such as "+1809" an area within it.
+type SMSRules struct {
+	// Allowed lists the prefixes that may be texted; empty allows any
+	Allowed []string
+	// Senders overrides From by prefix, for countries that require a
+	// local number or a registered sender ID; the longest prefix wins
+	Senders map[string]string
+	From    string
+}
+
+// sender returns who to text to from, or "" when the rules don't allow it
+func (r SMSRules) sender(to string) string {
+	if !strings.HasPrefix(to, "+") {
+		return ""
+	}
+	if len(r.Allowed) > 0 {
+		allowed := false
+		for _, prefix := range r.Allowed {
+			if strings.HasPrefix(to, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ""
+		}
+	}
+
+	from, longest := r.From, 0
+	for prefix, sender := range r.Senders {
+		if strings.HasPrefix(to, prefix) && len(prefix) > longest {
+			from, longest = sender, len(prefix)
+		}
+	}
+	return from
+}
+
+// Texter sends texts within the sending rules
+type Texter struct {
+	sender SMSSender
+	rules  SMSRules
+}
+
+func NewTexter(sender SMSSender, rules SMSRules) *Texter {
+	return &Texter{
+		sender: sender,
+		rules:  rules,
+	}
+}
+
+// Allowed reports whether the rules let phone be texted
+func (t *Texter) Allowed(phone string) bool {
+	return t.rules.sender(phone) != ""
+}
+
+// Text sends body to to and returns the provider's message ID
+func (t *Texter) Text(ctx context.Context, to, body string) (string, error) {
+	from := t.rules.sender(to)
+	if from == "" {
+		return "", ErrSMSNotAllowed
+	}
+	return t.sender.Send(ctx, from, to, body)
+}
+
+// smsTypes are the notifications worth a text: they need the user's
+// attention even away from email and the app
+var smsTypes = map[string]bool{
+	"suspicious_login": true,
+}
+
+// SMSNotifier texts the few notifications in smsTypes to the user's
+// verified phone number. Users without one are skipped.
+type SMSNotifier struct {
+	phones repository.PhoneRepository
+	texter *Texter
+	logger zerolog.Logger
+}
+
+func NewSMSNotifier(phones repository.PhoneRepository, texter *Texter, logger zerolog.Logger) *SMSNotifier {
+	return &SMSNotifier{
+		phones: phones,
+		texter: texter,
+		logger: logger,
+	}
+}
+
+func (s *SMSNotifier) Notify(ctx context.Context, userID uuid.UUID, n Notification) error {
+	if !smsTypes[n.Type] {
+		return nil
+	}
+
+	phone, err := s.phones.GetVerified(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting verified phone: %w", err)
+	}
+	if phone == nil || !s.texter.Allowed(phone.Phone) {
+		return nil
+	}
+
+	body := n.Title
+	if n.Body != "" {
+		body += ": " + n.Body
+	}
+	messageID, err := s.texter.Text(ctx, phone.Phone, body)
+	if err != nil {
+		return err
+	}
+	s.logger.Info().
+		Str("user_id", userID.String()).
+		Str("type", n.Type).
+		Str("message_id", messageID).
+		Msg("sms sent")
+	return nil
+}
@@ -0,0 +1,36 @@
+package notification
+
+import "testing"
+
+func TestSMSRulesSender(t *testing.T) {
+	rules := SMSRules{
+		Allowed: []string{"+44", "+1"},
+		Senders: map[string]string{
+			"+1":    "+15550000001",
+			"+1204": "+12045550000",
+			"+44":   "Marketplace",
+		},
+		From: "+15550000000",
+	}
+
+	for _, tc := range []struct {
+		name  string
+		rules SMSRules
+		to    string
+		want  string
+	}{
+		{"not international", rules, "07700900123", ""},
+		{"not allowed", rules, "+33612345678", ""},
+		{"sender for the country", rules, "+447700900123", "Marketplace"},
+		{"longest prefix wins", rules, "+12045550123", "+12045550000"},
+		{"shorter prefix", rules, "+12025550123", "+15550000001"},
+		{"any number when none listed", SMSRules{From: "+15550000000"}, "+33612345678", "+15550000000"},
+		{"default sender", SMSRules{Allowed: []string{"+33"}, From: "+15550000000"}, "+33612345678", "+15550000000"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rules.sender(tc.to); got != tc.want {
+				t.Fatalf("sender(%q) = %q, want %q", tc.to, got, tc.want)
+			}
+		})
+	}
+}
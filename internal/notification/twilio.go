@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const twilioEndpoint = "https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json"
+
+// Twilio sends texts through Twilio's Programmable Messaging API
+type Twilio struct {
+	accountSID string
+	authToken  string
+	http       *http.Client
+}
+
+func NewTwilio(accountSID, authToken string, timeout time.Duration) *Twilio {
+	return &Twilio{
+		accountSID: accountSID,
+		authToken:  authToken,
+		http:       &http.Client{Timeout: timeout},
+	}
+}
+
+type twilioResponse struct {
+	SID     string `json:"sid"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (t *Twilio) Send(ctx context.Context, from, to, body string) (string, error) {
+	form := url.Values{
+		"From": {from},
+		"To":   {to},
+		"Body": {body},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(twilioEndpoint, t.accountSID), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.accountSID, t.authToken)
+
+	resp, err := t.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("twilio: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var message twilioResponse
+	if err := json.NewDecoder(resp.Body).Decode(&message); err != nil {
+		return "", fmt.Errorf("twilio: decoding response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("twilio: status %d: error %d: %s", resp.StatusCode, message.Code, message.Message)
+	}
+	return message.SID, nil
+}
@@ -0,0 +1,166 @@
+// Package outbox makes event delivery reliable. Services publish into the
+// outbox table inside the transaction that makes their change, so an event
+// exists exactly when the change committed. The relay later delivers each
+// pending message to the broker and the in-process bus, then marks it sent.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Publisher is an events.Publisher that writes to the outbox. Call it with
+// the context of the caller's transaction.
+type Publisher struct {
+	repo repository.OutboxRepository
+}
+
+func NewPublisher(repo repository.OutboxRepository) *Publisher {
+	return &Publisher{repo: repo}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", event.EventName(), err)
+	}
+
+	_, err = p.repo.Create(ctx, &models.OutboxMessage{
+		EventName:  event.EventName(),
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing %s to outbox: %w", event.EventName(), err)
+	}
+	return nil
+}
+
+// Sink delivers an event outside the process, e.g. streaming.Mirror
+type Sink interface {
+	Send(ctx context.Context, id uuid.UUID, event events.Event, occurredAt time.Time) error
+}
+
+// deadLetters is published at /debug/vars as "outbox_dead_letters"; alert
+// when it grows, since parked messages wait for an operator to requeue them
+var deadLetters = expvar.NewInt("outbox_dead_letters")
+
+type Relay struct {
+	repo        repository.OutboxRepository
+	tx          repository.Transactor
+	bus         *events.Bus
+	sink        Sink
+	maxAttempts int
+	logger      zerolog.Logger
+}
+
+// NewRelay delivers to sink, when not nil, and then to bus. A message that
+// fails maxAttempts times is parked; zero retries it forever.
+func NewRelay(repo repository.OutboxRepository, tx repository.Transactor, bus *events.Bus, sink Sink, maxAttempts int, logger zerolog.Logger) *Relay {
+	return &Relay{
+		repo:        repo,
+		tx:          tx,
+		bus:         bus,
+		sink:        sink,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+// RelayBatch delivers up to batchSize pending messages in order and returns
+// how many were sent. It stops at the first failed delivery so later events
+// for the same aggregate are never sent ahead of it; the failure is recorded
+// on the message and retried on the next run. A message out of attempts is
+// parked instead and the rest carry on without it, so its aggregate's
+// later events overtake it.
+//
+// Only one relay runs at a time: each batch takes an advisory lock, and a
+// relay that can't get it sends nothing. With several workers, SKIP LOCKED
+// alone would let a second relay deliver the messages behind a slow one.
+//
+// A crash after delivery but before commit redelivers the batch, so sinks
+// and subscribers see each message at least once, identified by its ID.
+func (r *Relay) RelayBatch(ctx context.Context, batchSize int) (int, error) {
+	sent := 0
+	var deliveryErr error
+
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := r.repo.Lock(ctx)
+		if err != nil {
+			return fmt.Errorf("error locking outbox relay: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+
+		messages, err := r.repo.Claim(ctx, batchSize)
+		if err != nil {
+			return fmt.Errorf("error claiming outbox messages: %w", err)
+		}
+
+		for _, message := range messages {
+			if err := r.deliver(ctx, message); err != nil {
+				if r.maxAttempts > 0 && message.Attempts+1 >= r.maxAttempts {
+					if err := r.park(ctx, message, err); err != nil {
+						return err
+					}
+					continue
+				}
+				deliveryErr = fmt.Errorf("error delivering outbox message %s: %w", message.ID, err)
+				// Recorded in this transaction so the attempt survives the commit
+				return r.repo.MarkFailed(ctx, message.ID, err.Error())
+			}
+			if err := r.repo.MarkSent(ctx, message.ID); err != nil {
+				return fmt.Errorf("error marking outbox message %s sent: %w", message.ID, err)
+			}
+			sent++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, deliveryErr
+}
+
+// park gives up on a message that failed its last attempt
+func (r *Relay) park(ctx context.Context, message *models.OutboxMessage, deliveryErr error) error {
+	if err := r.repo.MarkDead(ctx, message.ID, deliveryErr.Error()); err != nil {
+		return fmt.Errorf("error parking outbox message %s: %w", message.ID, err)
+	}
+	deadLetters.Add(1)
+	r.logger.Error().
+		Err(deliveryErr).
+		Str("outbox_id", message.ID.String()).
+		Str("event", message.EventName).
+		Int("attempts", message.Attempts+1).
+		Msg("parked outbox message after too many failed deliveries")
+	return nil
+}
+
+func (r *Relay) deliver(ctx context.Context, message *models.OutboxMessage) error {
+	event, err := events.Decode(message.EventName, message.Payload)
+	if err != nil {
+		// Retrying can't fix a payload; skip it rather than block the outbox
+		r.logger.Error().Err(err).Str("outbox_id", message.ID.String()).Msg("dropping undecodable outbox message")
+		return nil
+	}
+
+	if r.sink != nil {
+		if err := r.sink.Send(ctx, message.ID, event, message.OccurredAt); err != nil {
+			return err
+		}
+	}
+
+	return r.bus.Publish(ctx, event)
+}
@@ -0,0 +1,75 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/apptest"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/outbox"
+	"github.com/rs/zerolog"
+)
+
+// flakySink fails every event for the users in failing
+type flakySink struct {
+	failing map[uuid.UUID]bool
+	sent    []uuid.UUID
+}
+
+func (s *flakySink) Send(ctx context.Context, id uuid.UUID, event events.Event, occurredAt time.Time) error {
+	userID := event.(events.UserReinstated).UserID
+	if s.failing[userID] {
+		return errors.New("broker rejected the message")
+	}
+	s.sent = append(s.sent, userID)
+	return nil
+}
+
+func TestRelayParksMessagesOutOfAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := apptest.NewFakeOutboxRepository()
+	publisher := outbox.NewPublisher(repo)
+	poison, next := uuid.New(), uuid.New()
+	for _, userID := range []uuid.UUID{poison, next} {
+		if err := publisher.Publish(ctx, events.UserReinstated{UserID: userID}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	sink := &flakySink{failing: map[uuid.UUID]bool{poison: true}}
+	relay := outbox.NewRelay(repo, apptest.FakeTransactor{}, events.NewBus(nil, zerolog.Nop()), sink, 3, zerolog.Nop())
+
+	for _, tc := range []struct {
+		name     string
+		wantSent int
+		wantErr  bool
+	}{
+		{"first failure holds the queue", 0, true},
+		{"second failure holds the queue", 0, true},
+		{"last attempt parks it", 1, false},
+		{"nothing left", 0, false},
+	} {
+		sent, err := relay.RelayBatch(ctx, 10)
+		if sent != tc.wantSent || (err != nil) != tc.wantErr {
+			t.Fatalf("%s: RelayBatch = %d, %v; want %d sent, error %v", tc.name, sent, err, tc.wantSent, tc.wantErr)
+		}
+	}
+
+	if len(sink.sent) != 1 || sink.sent[0] != next {
+		t.Fatalf("sink received %v, want only %s", sink.sent, next)
+	}
+	messages := repo.Messages()
+	if messages[0].DeadAt == nil || messages[0].Attempts != 3 || messages[0].SentAt != nil {
+		t.Fatalf("poison message = %+v, want parked after 3 attempts", messages[0])
+	}
+
+	// Requeueing revives it for another round of attempts
+	if requeued, err := repo.Requeue(ctx, time.Time{}, nil); err != nil || requeued != 2 {
+		t.Fatalf("Requeue = %d, %v; want both messages", requeued, err)
+	}
+	if messages := repo.Messages(); messages[0].DeadAt != nil || messages[0].Attempts != 0 {
+		t.Fatalf("requeued message = %+v, want pending with no attempts", messages[0])
+	}
+}
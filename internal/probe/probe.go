@@ -0,0 +1,209 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/middleware"
+	"github.com/rs/zerolog"
+)
+
+// metrics is published at /debug/vars as "synthetic_probes"
+var metrics = expvar.NewMap("synthetic_probes")
+
+// Flow is one critical user journey exercised against the running service.
+// Flows run in order and share state, so later flows can use earlier results.
+type Flow struct {
+	Name string
+	Run  func(ctx context.Context, c *Client, state *State) error
+}
+
+// State carries values between flows in a single probe round
+type State struct {
+	UserID string
+}
+
+// Account is the sandbox account the probe signs up and logs in with. It
+// lives in its own store, Tenant, so it stays out of real stores' data.
+type Account struct {
+	Tenant   string
+	Email    string
+	Password string
+	// Provisioned skips the signup flow, for when signups need a CAPTCHA
+	// the probe can't solve and the account was created beforehand
+	Provisioned bool
+}
+
+type Runner struct {
+	client   *Client
+	account  Account
+	interval time.Duration
+	timeout  time.Duration
+	flows    []Flow
+	logger   zerolog.Logger
+}
+
+func NewRunner(baseURL string, account Account, interval, timeout time.Duration, logger zerolog.Logger) *Runner {
+	return &Runner{
+		client:   &Client{baseURL: baseURL, tenant: account.Tenant, http: &http.Client{Timeout: timeout}},
+		account:  account,
+		interval: interval,
+		timeout:  timeout,
+		flows:    DefaultFlows(account),
+		logger:   logger.With().Str("component", "synthetic_probe").Logger(),
+	}
+}
+
+// Start runs a probe round every interval until ctx is cancelled. The first
+// round waits one interval so the server has time to start listening.
+func (r *Runner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.runRound(ctx)
+		}
+	}
+}
+
+func (r *Runner) runRound(ctx context.Context) {
+	state := &State{}
+
+	for _, flow := range r.flows {
+		flowCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		start := time.Now()
+		err := flow.Run(flowCtx, r.client, state)
+		duration := time.Since(start)
+		cancel()
+
+		latency := new(expvar.Int)
+		latency.Set(duration.Milliseconds())
+		metrics.Set(flow.Name+".last_duration_ms", latency)
+
+		if err != nil {
+			metrics.Add(flow.Name+".fail", 1)
+			r.logger.Error().Err(err).Str("flow", flow.Name).Dur("duration", duration).Msg("synthetic probe failed")
+			// Later flows depend on earlier ones, so stop the round here
+			return
+		}
+
+		metrics.Add(flow.Name+".pass", 1)
+		r.logger.Debug().Str("flow", flow.Name).Dur("duration", duration).Msg("synthetic probe passed")
+	}
+}
+
+// DefaultFlows covers signup, login and profile fetch for the sandbox account
+func DefaultFlows(account Account) []Flow {
+	flows := []Flow{
+		{
+			Name: "signup",
+			Run: func(ctx context.Context, c *Client, state *State) error {
+				body := map[string]string{
+					"email":      account.Email,
+					"password":   account.Password,
+					"first_name": "Synthetic",
+					"last_name":  "Probe",
+				}
+				// The sandbox account persists between rounds, so a conflict is a pass
+				_, err := c.do(ctx, http.MethodPost, "/api/v1/users", body, http.StatusCreated, http.StatusConflict)
+				return err
+			},
+		},
+		{
+			Name: "login",
+			Run: func(ctx context.Context, c *Client, state *State) error {
+				body := map[string]string{
+					"email":    account.Email,
+					"password": account.Password,
+				}
+				data, err := c.do(ctx, http.MethodPost, "/api/v1/auth/login", body, http.StatusOK)
+				if err != nil {
+					return err
+				}
+
+				var login struct {
+					Token string `json:"token"`
+					User  struct {
+						ID string `json:"id"`
+					} `json:"user"`
+				}
+				if err := json.Unmarshal(data, &login); err != nil || login.User.ID == "" || login.Token == "" {
+					return fmt.Errorf("login response has no token or user id")
+				}
+				state.UserID = login.User.ID
+				return nil
+			},
+		},
+		{
+			Name: "get_user",
+			Run: func(ctx context.Context, c *Client, state *State) error {
+				_, err := c.do(ctx, http.MethodGet, "/api/v1/users/"+state.UserID, nil, http.StatusOK)
+				return err
+			},
+		},
+	}
+	if account.Provisioned {
+		flows = flows[1:]
+	}
+	return flows
+}
+
+// Client is a minimal JSON client for the service's own API, calling it
+// as tenant
+type Client struct {
+	baseURL string
+	tenant  string
+	http    *http.Client
+}
+
+// do sends the request and returns the envelope's data field when the
+// response status is one of expected
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, expected ...int) (json.RawMessage, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "marketplace-synthetic-probe")
+	req.Header.Set(middleware.TenantHeader, c.tenant)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s %s: invalid response body: %w", method, path, err)
+	}
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return envelope.Data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+}
@@ -0,0 +1,218 @@
+package queryplan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// sqlc prefixes every statement with "-- name: <QueryName> :<kind>"
+var queryNamePattern = regexp.MustCompile(`^-- name: (\w+)`)
+
+// Guard wraps a db.DBTX and EXPLAINs each repository query once it becomes hot,
+// recording the plan and flagging index scans that turned into sequential scans
+// since the previous deploy.
+type Guard struct {
+	db           db.DBTX
+	plans        repository.QueryPlanRepository
+	logger       zerolog.Logger
+	version      string
+	hotThreshold int64
+
+	mu       sync.Mutex
+	counts   map[string]int64
+	captured map[string]bool
+}
+
+// NewGuard creates a guard around conn. plans must be backed by the unwrapped
+// connection so that recording a plan is not itself observed.
+func NewGuard(conn db.DBTX, plans repository.QueryPlanRepository, version string, hotThreshold int, logger zerolog.Logger) *Guard {
+	if hotThreshold < 1 {
+		hotThreshold = 1
+	}
+	return &Guard{
+		db:           conn,
+		plans:        plans,
+		logger:       logger,
+		version:      version,
+		hotThreshold: int64(hotThreshold),
+		counts:       make(map[string]int64),
+		captured:     make(map[string]bool),
+	}
+}
+
+func (g *Guard) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	g.observe(query, args)
+	return g.db.ExecContext(ctx, query, args...)
+}
+
+func (g *Guard) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return g.db.PrepareContext(ctx, query)
+}
+
+func (g *Guard) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	g.observe(query, args)
+	return g.db.QueryContext(ctx, query, args...)
+}
+
+func (g *Guard) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	g.observe(query, args)
+	return g.db.QueryRowContext(ctx, query, args...)
+}
+
+// observe counts executions per named query and captures the plan the first
+// time the query crosses the hot threshold in this process.
+func (g *Guard) observe(query string, args []interface{}) {
+	match := queryNamePattern.FindStringSubmatch(query)
+	if match == nil {
+		return
+	}
+	name := match[1]
+
+	g.mu.Lock()
+	g.counts[name]++
+	hot := g.counts[name] >= g.hotThreshold && !g.captured[name]
+	if hot {
+		g.captured[name] = true
+	}
+	g.mu.Unlock()
+
+	if hot {
+		go g.capture(name, query, args)
+	}
+}
+
+func (g *Guard) capture(name, query string, args []interface{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var raw []byte
+	if err := g.db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON)\n"+query, args...).Scan(&raw); err != nil {
+		g.logger.Error().Err(err).Str("query", name).Msg("failed to explain query")
+		return
+	}
+
+	scans, err := ParseScans(raw)
+	if err != nil {
+		g.logger.Error().Err(err).Str("query", name).Msg("failed to parse query plan")
+		return
+	}
+
+	plan := &models.QueryPlan{
+		QueryName:     name,
+		DeployVersion: g.version,
+		Plan:          json.RawMessage(raw),
+		Scans:         scans,
+	}
+
+	baseline, err := g.plans.GetBaseline(ctx, name, g.version)
+	if err != nil {
+		g.logger.Error().Err(err).Str("query", name).Msg("failed to load baseline query plan")
+		return
+	}
+	if baseline != nil {
+		if detail := Regressions(baseline.Scans, scans); detail != "" {
+			plan.Regression = true
+			plan.RegressionDetail = &detail
+
+			g.logger.Warn().
+				Str("alert", "query_plan_regression").
+				Str("query", name).
+				Str("deploy_version", g.version).
+				Str("baseline_version", baseline.DeployVersion).
+				Str("detail", detail).
+				Msg("query plan regression detected")
+		}
+	}
+
+	if _, err := g.plans.Create(ctx, plan); err != nil {
+		g.logger.Error().Err(err).Str("query", name).Msg("failed to record query plan")
+		return
+	}
+
+	g.logger.Debug().Str("query", name).Int("scans", len(scans)).Msg("query plan recorded")
+}
+
+// planNode mirrors the subset of EXPLAIN (FORMAT JSON) output we inspect
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// ParseScans extracts every scan node that touches a relation from an
+// EXPLAIN (FORMAT JSON) result.
+func ParseScans(raw []byte) ([]models.PlanScan, error) {
+	var explained []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &explained); err != nil {
+		return nil, fmt.Errorf("invalid plan JSON: %w", err)
+	}
+
+	var scans []models.PlanScan
+	var walk func(node planNode)
+	walk = func(node planNode) {
+		if node.RelationName != "" {
+			scans = append(scans, models.PlanScan{
+				Relation: node.RelationName,
+				NodeType: node.NodeType,
+				Index:    node.IndexName,
+			})
+		}
+		for _, child := range node.Plans {
+			walk(child)
+		}
+	}
+	for _, e := range explained {
+		walk(e.Plan)
+	}
+
+	return scans, nil
+}
+
+// Regressions describes relations that were read through an index in the
+// baseline plan and are sequentially scanned in the current one. It returns
+// an empty string when nothing regressed.
+func Regressions(baseline, current []models.PlanScan) string {
+	indexed := make(map[string]string)
+	for _, scan := range baseline {
+		if isIndexScan(scan.NodeType) {
+			indexed[scan.Relation] = scan.NodeType
+		}
+	}
+
+	var details []string
+	seen := make(map[string]bool)
+	for _, scan := range current {
+		previous, ok := indexed[scan.Relation]
+		if !ok || scan.NodeType != "Seq Scan" || seen[scan.Relation] {
+			continue
+		}
+		seen[scan.Relation] = true
+		details = append(details, fmt.Sprintf("%s: %s -> Seq Scan", scan.Relation, previous))
+	}
+	sort.Strings(details)
+
+	return strings.Join(details, "; ")
+}
+
+func isIndexScan(nodeType string) bool {
+	switch nodeType {
+	case "Index Scan", "Index Only Scan", "Bitmap Heap Scan", "Bitmap Index Scan":
+		return true
+	}
+	return false
+}
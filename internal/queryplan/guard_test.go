@@ -0,0 +1,75 @@
+package queryplan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+func TestParseScans(t *testing.T) {
+	plan := `[{"Plan": {
+		"Node Type": "Nested Loop",
+		"Plans": [
+			{"Node Type": "Index Scan", "Relation Name": "users", "Index Name": "users_pkey"},
+			{"Node Type": "Hash", "Plans": [
+				{"Node Type": "Seq Scan", "Relation Name": "addresses"}
+			]}
+		]
+	}}]`
+
+	scans, err := ParseScans([]byte(plan))
+	if err != nil {
+		t.Fatalf("ParseScans: %v", err)
+	}
+	want := []models.PlanScan{
+		{Relation: "users", NodeType: "Index Scan", Index: "users_pkey"},
+		{Relation: "addresses", NodeType: "Seq Scan"},
+	}
+	if !reflect.DeepEqual(scans, want) {
+		t.Fatalf("ParseScans = %+v, want %+v", scans, want)
+	}
+
+	if _, err := ParseScans([]byte(`{"Plan":`)); err == nil {
+		t.Fatal("ParseScans accepted invalid JSON")
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	indexScan := func(relation string) models.PlanScan {
+		return models.PlanScan{Relation: relation, NodeType: "Index Scan", Index: relation + "_pkey"}
+	}
+	seqScan := func(relation string) models.PlanScan {
+		return models.PlanScan{Relation: relation, NodeType: "Seq Scan"}
+	}
+
+	for _, tc := range []struct {
+		name              string
+		baseline, current []models.PlanScan
+		want              string
+	}{
+		{"unchanged", []models.PlanScan{indexScan("users")}, []models.PlanScan{indexScan("users")}, ""},
+		{"index to seq scan", []models.PlanScan{indexScan("users")}, []models.PlanScan{seqScan("users")}, "users: Index Scan -> Seq Scan"},
+		{
+			"bitmap to seq scan",
+			[]models.PlanScan{{Relation: "events", NodeType: "Bitmap Heap Scan"}},
+			[]models.PlanScan{seqScan("events")},
+			"events: Bitmap Heap Scan -> Seq Scan",
+		},
+		{"always sequential", []models.PlanScan{seqScan("tenants")}, []models.PlanScan{seqScan("tenants")}, ""},
+		{"new relation", nil, []models.PlanScan{seqScan("tenants")}, ""},
+		{"seq to index scan", []models.PlanScan{seqScan("users")}, []models.PlanScan{indexScan("users")}, ""},
+		{
+			"each relation once, sorted",
+			[]models.PlanScan{indexScan("users"), indexScan("addresses")},
+			[]models.PlanScan{seqScan("users"), seqScan("addresses"), seqScan("users")},
+			"addresses: Index Scan -> Seq Scan; users: Index Scan -> Seq Scan",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Regressions(tc.baseline, tc.current); got != tc.want {
+				t.Fatalf("Regressions = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type AddressRepository interface {
+	// Lock holds the user's address book until the surrounding transaction
+	// ends; call it within Transactor.WithinTx
+	Lock(ctx context.Context, userID uuid.UUID) error
+	Create(ctx context.Context, address *models.Address) (*models.Address, error)
+	GetForUser(ctx context.Context, id, userID uuid.UUID) (*models.Address, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Address, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Update returns nil when the address doesn't belong to the user
+	Update(ctx context.Context, address *models.Address) (*models.Address, error)
+	// Delete reports whether the user had the address
+	Delete(ctx context.Context, id, userID uuid.UUID) (bool, error)
+	ClearOtherDefaultShipping(ctx context.Context, userID, keepID uuid.UUID) error
+	ClearOtherDefaultBilling(ctx context.Context, userID, keepID uuid.UUID) error
+}
+
+type addressRepository struct {
+	queries *db.Queries
+}
+
+func NewAddressRepository(queries *db.Queries) AddressRepository {
+	return &addressRepository{queries: queries}
+}
+
+func (r *addressRepository) Lock(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.queries.LockAddressBook(ctx, userID)
+	return err
+}
+
+func (r *addressRepository) Create(ctx context.Context, address *models.Address) (*models.Address, error) {
+	dbAddress, err := r.queries.CreateAddress(ctx, db.CreateAddressParams{
+		UserID:            address.UserID,
+		Label:             address.Label,
+		RecipientName:     address.RecipientName,
+		Line1:             address.Line1,
+		Line2:             address.Line2,
+		City:              address.City,
+		Region:            address.Region,
+		PostalCode:        address.PostalCode,
+		CountryCode:       address.CountryCode,
+		Phone:             address.Phone,
+		IsDefaultShipping: address.IsDefaultShipping,
+		IsDefaultBilling:  address.IsDefaultBilling,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbAddressToModel(dbAddress), nil
+}
+
+func (r *addressRepository) GetForUser(ctx context.Context, id, userID uuid.UUID) (*models.Address, error) {
+	dbAddress, err := r.queries.GetAddressForUser(ctx, db.GetAddressForUserParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbAddressToModel(dbAddress), nil
+}
+
+func (r *addressRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Address, error) {
+	dbAddresses, err := r.queries.ListAddressesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]*models.Address, len(dbAddresses))
+	for i, dbAddress := range dbAddresses {
+		addresses[i] = r.dbAddressToModel(dbAddress)
+	}
+	return addresses, nil
+}
+
+func (r *addressRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.queries.CountAddressesByUser(ctx, userID)
+}
+
+func (r *addressRepository) Update(ctx context.Context, address *models.Address) (*models.Address, error) {
+	dbAddress, err := r.queries.UpdateAddress(ctx, db.UpdateAddressParams{
+		ID:                address.ID,
+		UserID:            address.UserID,
+		Label:             address.Label,
+		RecipientName:     address.RecipientName,
+		Line1:             address.Line1,
+		Line2:             address.Line2,
+		City:              address.City,
+		Region:            address.Region,
+		PostalCode:        address.PostalCode,
+		CountryCode:       address.CountryCode,
+		Phone:             address.Phone,
+		IsDefaultShipping: address.IsDefaultShipping,
+		IsDefaultBilling:  address.IsDefaultBilling,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbAddressToModel(dbAddress), nil
+}
+
+func (r *addressRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeleteAddress(ctx, db.DeleteAddressParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *addressRepository) ClearOtherDefaultShipping(ctx context.Context, userID, keepID uuid.UUID) error {
+	return r.queries.ClearOtherDefaultShipping(ctx, db.ClearOtherDefaultShippingParams{
+		UserID: userID,
+		ID:     keepID,
+	})
+}
+
+func (r *addressRepository) ClearOtherDefaultBilling(ctx context.Context, userID, keepID uuid.UUID) error {
+	return r.queries.ClearOtherDefaultBilling(ctx, db.ClearOtherDefaultBillingParams{
+		UserID: userID,
+		ID:     keepID,
+	})
+}
+
+// Helper function to convert database address to domain model
+func (r *addressRepository) dbAddressToModel(dbAddress db.Address) *models.Address {
+	return &models.Address{
+		ID:                dbAddress.ID,
+		UserID:            dbAddress.UserID,
+		Label:             dbAddress.Label,
+		RecipientName:     dbAddress.RecipientName,
+		Line1:             dbAddress.Line1,
+		Line2:             dbAddress.Line2,
+		City:              dbAddress.City,
+		Region:            dbAddress.Region,
+		PostalCode:        dbAddress.PostalCode,
+		CountryCode:       dbAddress.CountryCode,
+		Phone:             dbAddress.Phone,
+		IsDefaultShipping: dbAddress.IsDefaultShipping,
+		IsDefaultBilling:  dbAddress.IsDefaultBilling,
+		CreatedAt:         dbAddress.CreatedAt,
+		UpdatedAt:         dbAddress.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// AnalyticsRepository counts within the tenant in the context
+type AnalyticsRepository interface {
+	SignupsOverTime(ctx context.Context, rng models.AnalyticsRange) ([]*models.SignupBucket, error)
+}
+
+type analyticsRepository struct {
+	queries *db.Queries
+}
+
+func NewAnalyticsRepository(queries *db.Queries) AnalyticsRepository {
+	return &analyticsRepository{queries: queries}
+}
+
+func (r *analyticsRepository) SignupsOverTime(ctx context.Context, rng models.AnalyticsRange) ([]*models.SignupBucket, error) {
+	rows, err := r.queries.CountSignupsByBucket(ctx, db.CountSignupsByBucketParams{
+		Granularity: string(rng.Granularity),
+		From:        rng.From,
+		To:          rng.To,
+		TenantID:    tenancy.ID(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*models.SignupBucket, len(rows))
+	for i, row := range rows {
+		buckets[i] = &models.SignupBucket{
+			Bucket:  row.Bucket,
+			Signups: row.Signups,
+		}
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) (*models.AuditLog, error)
+}
+
+type auditRepository struct {
+	queries *db.Queries
+}
+
+func NewAuditRepository(queries *db.Queries) AuditRepository {
+	return &auditRepository{queries: queries}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditLog) (*models.AuditLog, error) {
+	metadata := entry.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	dbEntry, err := r.queries.CreateAuditLog(ctx, db.CreateAuditLogParams{
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Metadata:   metadataJSON,
+		IpAddress:  entry.IPAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbAuditLogToModel(dbEntry), nil
+}
+
+// Helper function to convert database audit log to domain model
+func (r *auditRepository) dbAuditLogToModel(dbEntry db.AuditLog) *models.AuditLog {
+	entry := &models.AuditLog{
+		ID:         dbEntry.ID,
+		ActorID:    dbEntry.ActorID,
+		Action:     dbEntry.Action,
+		TargetType: dbEntry.TargetType,
+		TargetID:   dbEntry.TargetID,
+		IPAddress:  dbEntry.IpAddress,
+		CreatedAt:  dbEntry.CreatedAt,
+	}
+	// Metadata is written by Create, so a decode failure only leaves it empty
+	_ = json.Unmarshal(dbEntry.Metadata, &entry.Metadata)
+
+	return entry
+}
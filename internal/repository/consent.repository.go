@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type ConsentRepository interface {
+	// CreateDocument fails with ErrConflict when the kind already has the version
+	CreateDocument(ctx context.Context, doc *models.LegalDocument) (*models.LegalDocument, error)
+	GetDocument(ctx context.Context, id uuid.UUID) (*models.LegalDocument, error)
+	ListDocuments(ctx context.Context) ([]*models.LegalDocument, error)
+	// ListCurrent returns the latest effective version of each kind
+	ListCurrent(ctx context.Context) ([]*models.LegalDocument, error)
+	// ListPending returns the mandatory versions the user has yet to accept
+	ListPending(ctx context.Context, userID uuid.UUID) ([]*models.LegalDocument, error)
+	// Accept is idempotent; accepting again keeps the first acceptance
+	Accept(ctx context.Context, userID, documentID uuid.UUID, ipAddress *string) (time.Time, error)
+	ListAcceptances(ctx context.Context, userID uuid.UUID) ([]*models.ConsentAcceptance, error)
+}
+
+type consentRepository struct {
+	queries *db.Queries
+}
+
+func NewConsentRepository(queries *db.Queries) ConsentRepository {
+	return &consentRepository{queries: queries}
+}
+
+func (r *consentRepository) CreateDocument(ctx context.Context, doc *models.LegalDocument) (*models.LegalDocument, error) {
+	dbDoc, err := r.queries.CreateLegalDocument(ctx, db.CreateLegalDocumentParams{
+		Kind:        string(doc.Kind),
+		Version:     doc.Version,
+		Url:         doc.URL,
+		Mandatory:   doc.Mandatory,
+		EffectiveAt: doc.EffectiveAt,
+		PublishedBy: doc.PublishedBy,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbDocumentToModel(dbDoc), nil
+}
+
+func (r *consentRepository) GetDocument(ctx context.Context, id uuid.UUID) (*models.LegalDocument, error) {
+	dbDoc, err := r.queries.GetLegalDocument(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbDocumentToModel(dbDoc), nil
+}
+
+func (r *consentRepository) ListDocuments(ctx context.Context) ([]*models.LegalDocument, error) {
+	dbDocs, err := r.queries.ListLegalDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbDocumentsToModels(dbDocs), nil
+}
+
+func (r *consentRepository) ListCurrent(ctx context.Context) ([]*models.LegalDocument, error) {
+	dbDocs, err := r.queries.ListCurrentLegalDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbDocumentsToModels(dbDocs), nil
+}
+
+func (r *consentRepository) ListPending(ctx context.Context, userID uuid.UUID) ([]*models.LegalDocument, error) {
+	dbDocs, err := r.queries.ListPendingLegalDocuments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbDocumentsToModels(dbDocs), nil
+}
+
+func (r *consentRepository) Accept(ctx context.Context, userID, documentID uuid.UUID, ipAddress *string) (time.Time, error) {
+	acceptance, err := r.queries.AcceptLegalDocument(ctx, db.AcceptLegalDocumentParams{
+		UserID:     userID,
+		DocumentID: documentID,
+		IpAddress:  ipAddress,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return acceptance.AcceptedAt, nil
+}
+
+func (r *consentRepository) ListAcceptances(ctx context.Context, userID uuid.UUID) ([]*models.ConsentAcceptance, error) {
+	rows, err := r.queries.ListConsentAcceptancesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptances := make([]*models.ConsentAcceptance, len(rows))
+	for i, row := range rows {
+		acceptances[i] = &models.ConsentAcceptance{
+			DocumentID: row.DocumentID,
+			Kind:       models.LegalDocumentKind(row.Kind),
+			Version:    row.Version,
+			IPAddress:  row.IpAddress,
+			AcceptedAt: row.AcceptedAt,
+		}
+	}
+	return acceptances, nil
+}
+
+func (r *consentRepository) dbDocumentsToModels(dbDocs []db.LegalDocument) []*models.LegalDocument {
+	docs := make([]*models.LegalDocument, len(dbDocs))
+	for i, dbDoc := range dbDocs {
+		docs[i] = r.dbDocumentToModel(dbDoc)
+	}
+	return docs
+}
+
+func (r *consentRepository) dbDocumentToModel(dbDoc db.LegalDocument) *models.LegalDocument {
+	return &models.LegalDocument{
+		ID:          dbDoc.ID,
+		Kind:        models.LegalDocumentKind(dbDoc.Kind),
+		Version:     dbDoc.Version,
+		URL:         dbDoc.Url,
+		Mandatory:   dbDoc.Mandatory,
+		EffectiveAt: dbDoc.EffectiveAt,
+		PublishedBy: dbDoc.PublishedBy,
+		CreatedAt:   dbDoc.CreatedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type DeviceRepository interface {
+	// Touch records a login from the device, adding it on first sight;
+	// created reports whether it was new
+	Touch(ctx context.Context, device *models.Device) (touched *models.Device, created bool, err error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Device, error)
+	// Trust is a no-op for a device that is already trusted
+	Trust(ctx context.Context, id uuid.UUID) error
+	// Delete reports whether the user had the device
+	Delete(ctx context.Context, id, userID uuid.UUID) (bool, error)
+}
+
+type deviceRepository struct {
+	queries *db.Queries
+}
+
+func NewDeviceRepository(queries *db.Queries) DeviceRepository {
+	return &deviceRepository{queries: queries}
+}
+
+func (r *deviceRepository) Touch(ctx context.Context, device *models.Device) (*models.Device, bool, error) {
+	row, err := r.queries.UpsertDevice(ctx, db.UpsertDeviceParams{
+		UserID:      device.UserID,
+		Fingerprint: device.Fingerprint,
+		Name:        device.Name,
+		LastIp:      device.LastIP,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return r.dbDeviceToModel(db.Device{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Fingerprint: row.Fingerprint,
+		Name:        row.Name,
+		LastIp:      row.LastIp,
+		TrustedAt:   row.TrustedAt,
+		FirstSeenAt: row.FirstSeenAt,
+		LastSeenAt:  row.LastSeenAt,
+	}), row.Created, nil
+}
+
+func (r *deviceRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountDevicesByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *deviceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Device, error) {
+	dbDevices, err := r.queries.ListDevicesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*models.Device, len(dbDevices))
+	for i, dbDevice := range dbDevices {
+		devices[i] = r.dbDeviceToModel(dbDevice)
+	}
+	return devices, nil
+}
+
+func (r *deviceRepository) Trust(ctx context.Context, id uuid.UUID) error {
+	return r.queries.TrustDevice(ctx, id)
+}
+
+func (r *deviceRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeleteDevice(ctx, db.DeleteDeviceParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *deviceRepository) dbDeviceToModel(dbDevice db.Device) *models.Device {
+	return &models.Device{
+		ID:          dbDevice.ID,
+		UserID:      dbDevice.UserID,
+		Fingerprint: dbDevice.Fingerprint,
+		Name:        dbDevice.Name,
+		LastIP:      dbDevice.LastIp,
+		Trusted:     dbDevice.TrustedAt != nil,
+		TrustedAt:   dbDevice.TrustedAt,
+		FirstSeenAt: dbDevice.FirstSeenAt,
+		LastSeenAt:  dbDevice.LastSeenAt,
+	}
+}
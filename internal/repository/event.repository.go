@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type EventRepository interface {
+	Create(ctx context.Context, event *models.StoredEvent) (*models.StoredEvent, error)
+	// ListAfter returns up to limit events ordered after the (occurredAt, id)
+	// position, oldest first; pass the last row's values to fetch the next page
+	ListAfter(ctx context.Context, occurredAt time.Time, id uuid.UUID, limit int) ([]*models.StoredEvent, error)
+}
+
+type eventRepository struct {
+	queries *db.Queries
+}
+
+func NewEventRepository(queries *db.Queries) EventRepository {
+	return &eventRepository{queries: queries}
+}
+
+func (r *eventRepository) Create(ctx context.Context, event *models.StoredEvent) (*models.StoredEvent, error) {
+	dbEvent, err := r.queries.CreateEvent(ctx, db.CreateEventParams{
+		Name:       event.Name,
+		Payload:    event.Payload,
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbEventToModel(dbEvent), nil
+}
+
+func (r *eventRepository) ListAfter(ctx context.Context, occurredAt time.Time, id uuid.UUID, limit int) ([]*models.StoredEvent, error) {
+	dbEvents, err := r.queries.ListEventsAfter(ctx, db.ListEventsAfterParams{
+		OccurredAt: occurredAt,
+		ID:         id,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.StoredEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = r.dbEventToModel(dbEvent)
+	}
+
+	return events, nil
+}
+
+// Helper function to convert database event to domain model
+func (r *eventRepository) dbEventToModel(dbEvent db.Event) *models.StoredEvent {
+	return &models.StoredEvent{
+		ID:         dbEvent.ID,
+		Name:       dbEvent.Name,
+		Payload:    dbEvent.Payload,
+		OccurredAt: dbEvent.OccurredAt,
+	}
+}
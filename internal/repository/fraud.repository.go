@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type FraudRepository interface {
+	Create(ctx context.Context, assessment *models.FraudAssessment) (*models.FraudAssessment, error)
+	// CountRecentByIP counts assessments of check from ipAddress since then,
+	// blocked attempts included
+	CountRecentByIP(ctx context.Context, check models.FraudCheck, ipAddress string, since time.Time) (int, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.FraudAssessment, error)
+}
+
+type fraudRepository struct {
+	queries *db.Queries
+}
+
+func NewFraudRepository(queries *db.Queries) FraudRepository {
+	return &fraudRepository{queries: queries}
+}
+
+func (r *fraudRepository) Create(ctx context.Context, assessment *models.FraudAssessment) (*models.FraudAssessment, error) {
+	signals := assessment.Signals
+	if signals == nil {
+		signals = []models.FraudSignal{}
+	}
+	signalsJSON, err := json.Marshal(signals)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAssessment, err := r.queries.CreateFraudAssessment(ctx, db.CreateFraudAssessmentParams{
+		CheckType: string(assessment.Check),
+		UserID:    assessment.UserID,
+		Email:     assessment.Email,
+		IpAddress: assessment.IPAddress,
+		Score:     int32(assessment.Score),
+		Decision:  string(assessment.Decision),
+		Signals:   signalsJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbAssessmentToModel(dbAssessment), nil
+}
+
+func (r *fraudRepository) CountRecentByIP(ctx context.Context, check models.FraudCheck, ipAddress string, since time.Time) (int, error) {
+	count, err := r.queries.CountRecentFraudAssessmentsByIP(ctx, db.CountRecentFraudAssessmentsByIPParams{
+		CheckType: string(check),
+		IpAddress: &ipAddress,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *fraudRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.FraudAssessment, error) {
+	dbAssessments, err := r.queries.ListFraudAssessmentsByUser(ctx, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assessments := make([]*models.FraudAssessment, len(dbAssessments))
+	for i, dbAssessment := range dbAssessments {
+		assessments[i] = r.dbAssessmentToModel(dbAssessment)
+	}
+	return assessments, nil
+}
+
+func (r *fraudRepository) dbAssessmentToModel(dbAssessment db.FraudAssessment) *models.FraudAssessment {
+	assessment := &models.FraudAssessment{
+		ID:        dbAssessment.ID,
+		Check:     models.FraudCheck(dbAssessment.CheckType),
+		UserID:    dbAssessment.UserID,
+		Email:     dbAssessment.Email,
+		IPAddress: dbAssessment.IpAddress,
+		Score:     int(dbAssessment.Score),
+		Decision:  models.FraudDecision(dbAssessment.Decision),
+		CreatedAt: dbAssessment.CreatedAt,
+	}
+	// Signals are written by Create, so a decode failure only leaves them empty
+	_ = json.Unmarshal(dbAssessment.Signals, &assessment.Signals)
+
+	return assessment
+}
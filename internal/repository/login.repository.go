@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type LoginRepository interface {
+	CreateLocation(ctx context.Context, location *models.LoginLocation) (*models.LoginLocation, error)
+	// LastLocation returns the user's latest verified login that could be
+	// placed, or nil before the first one
+	LastLocation(ctx context.Context, userID uuid.UUID) (*models.LoginLocation, error)
+	// CountInCountry counts the user's verified logins from countryCode
+	CountInCountry(ctx context.Context, userID uuid.UUID, countryCode string) (int, error)
+	VerifyLocation(ctx context.Context, id uuid.UUID) error
+	ListLocations(ctx context.Context, userID uuid.UUID, limit int) ([]*models.LoginLocation, error)
+	CreateChallenge(ctx context.Context, challenge *models.LoginChallenge) (*models.LoginChallenge, error)
+	// CountChallengesSince counts the challenges raised for the user after since
+	CountChallengesSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	GetChallenge(ctx context.Context, id uuid.UUID) (*models.LoginChallenge, error)
+	// IncrementAttempts records a wrong code and returns the attempts so far
+	IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	// CompleteChallenge marks the challenge used; it returns nil when it is
+	// already used, expired or out of attempts, so a code works only once
+	CompleteChallenge(ctx context.Context, id uuid.UUID, maxAttempts int) (*models.LoginChallenge, error)
+}
+
+type loginRepository struct {
+	queries *db.Queries
+}
+
+func NewLoginRepository(queries *db.Queries) LoginRepository {
+	return &loginRepository{queries: queries}
+}
+
+func (r *loginRepository) CreateLocation(ctx context.Context, location *models.LoginLocation) (*models.LoginLocation, error) {
+	var anomaly *string
+	if location.Anomaly != nil {
+		a := string(*location.Anomaly)
+		anomaly = &a
+	}
+
+	dbLocation, err := r.queries.CreateLoginLocation(ctx, db.CreateLoginLocationParams{
+		UserID:      location.UserID,
+		IpAddress:   location.IPAddress,
+		CountryCode: location.CountryCode,
+		Country:     location.Country,
+		City:        location.City,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+		Anomaly:     anomaly,
+		Verified:    location.Verified,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbLocationToModel(dbLocation), nil
+}
+
+func (r *loginRepository) LastLocation(ctx context.Context, userID uuid.UUID) (*models.LoginLocation, error) {
+	dbLocation, err := r.queries.GetLastLoginLocation(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbLocationToModel(dbLocation), nil
+}
+
+func (r *loginRepository) CountInCountry(ctx context.Context, userID uuid.UUID, countryCode string) (int, error) {
+	count, err := r.queries.CountLoginLocationsInCountry(ctx, db.CountLoginLocationsInCountryParams{
+		UserID:      userID,
+		CountryCode: &countryCode,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *loginRepository) VerifyLocation(ctx context.Context, id uuid.UUID) error {
+	return r.queries.VerifyLoginLocation(ctx, id)
+}
+
+func (r *loginRepository) ListLocations(ctx context.Context, userID uuid.UUID, limit int) ([]*models.LoginLocation, error) {
+	dbLocations, err := r.queries.ListLoginLocationsByUser(ctx, db.ListLoginLocationsByUserParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]*models.LoginLocation, len(dbLocations))
+	for i, dbLocation := range dbLocations {
+		locations[i] = r.dbLocationToModel(dbLocation)
+	}
+	return locations, nil
+}
+
+func (r *loginRepository) CreateChallenge(ctx context.Context, challenge *models.LoginChallenge) (*models.LoginChallenge, error) {
+	dbChallenge, err := r.queries.CreateLoginChallenge(ctx, db.CreateLoginChallengeParams{
+		UserID:     challenge.UserID,
+		LocationID: challenge.LocationID,
+		CodeHash:   challenge.CodeHash,
+		ExpiresAt:  challenge.ExpiresAt,
+		DeviceID:   challenge.DeviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbChallengeToModel(dbChallenge), nil
+}
+
+func (r *loginRepository) CountChallengesSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	count, err := r.queries.CountLoginChallengesSince(ctx, db.CountLoginChallengesSinceParams{
+		UserID:    userID,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *loginRepository) GetChallenge(ctx context.Context, id uuid.UUID) (*models.LoginChallenge, error) {
+	dbChallenge, err := r.queries.GetLoginChallenge(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbChallengeToModel(dbChallenge), nil
+}
+
+func (r *loginRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	attempts, err := r.queries.IncrementLoginChallengeAttempts(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(attempts), nil
+}
+
+func (r *loginRepository) CompleteChallenge(ctx context.Context, id uuid.UUID, maxAttempts int) (*models.LoginChallenge, error) {
+	dbChallenge, err := r.queries.CompleteLoginChallenge(ctx, db.CompleteLoginChallengeParams{
+		ID:       id,
+		Attempts: int32(maxAttempts),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbChallengeToModel(dbChallenge), nil
+}
+
+func (r *loginRepository) dbLocationToModel(dbLocation db.LoginLocation) *models.LoginLocation {
+	location := &models.LoginLocation{
+		ID:          dbLocation.ID,
+		UserID:      dbLocation.UserID,
+		IPAddress:   dbLocation.IpAddress,
+		CountryCode: dbLocation.CountryCode,
+		Country:     dbLocation.Country,
+		City:        dbLocation.City,
+		Latitude:    dbLocation.Latitude,
+		Longitude:   dbLocation.Longitude,
+		Verified:    dbLocation.Verified,
+		CreatedAt:   dbLocation.CreatedAt,
+	}
+	if dbLocation.Anomaly != nil {
+		anomaly := models.LoginAnomaly(*dbLocation.Anomaly)
+		location.Anomaly = &anomaly
+	}
+	return location
+}
+
+func (r *loginRepository) dbChallengeToModel(dbChallenge db.LoginChallenge) *models.LoginChallenge {
+	return &models.LoginChallenge{
+		ID:         dbChallenge.ID,
+		UserID:     dbChallenge.UserID,
+		LocationID: dbChallenge.LocationID,
+		DeviceID:   dbChallenge.DeviceID,
+		CodeHash:   dbChallenge.CodeHash,
+		Attempts:   int(dbChallenge.Attempts),
+		ExpiresAt:  dbChallenge.ExpiresAt,
+		VerifiedAt: dbChallenge.VerifiedAt,
+		CreatedAt:  dbChallenge.CreatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type MagicLinkRepository interface {
+	Create(ctx context.Context, link *models.MagicLink) (*models.MagicLink, error)
+	// CountSince counts the links sent to the user after since
+	CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	// Redeem marks the link with tokenHash used; it returns nil when there
+	// is none or it is already used or expired, so a link works only once
+	Redeem(ctx context.Context, tokenHash string) (*models.MagicLink, error)
+}
+
+type magicLinkRepository struct {
+	queries *db.Queries
+}
+
+func NewMagicLinkRepository(queries *db.Queries) MagicLinkRepository {
+	return &magicLinkRepository{queries: queries}
+}
+
+func (r *magicLinkRepository) Create(ctx context.Context, link *models.MagicLink) (*models.MagicLink, error) {
+	dbLink, err := r.queries.CreateMagicLink(ctx, db.CreateMagicLinkParams{
+		UserID:    link.UserID,
+		TokenHash: link.TokenHash,
+		IpAddress: link.IPAddress,
+		ExpiresAt: link.ExpiresAt,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbLinkToModel(dbLink), nil
+}
+
+func (r *magicLinkRepository) CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	count, err := r.queries.CountMagicLinksSince(ctx, db.CountMagicLinksSinceParams{
+		UserID:    userID,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *magicLinkRepository) Redeem(ctx context.Context, tokenHash string) (*models.MagicLink, error) {
+	dbLink, err := r.queries.RedeemMagicLink(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbLinkToModel(dbLink), nil
+}
+
+func (r *magicLinkRepository) dbLinkToModel(dbLink db.MagicLink) *models.MagicLink {
+	return &models.MagicLink{
+		ID:        dbLink.ID,
+		UserID:    dbLink.UserID,
+		TokenHash: dbLink.TokenHash,
+		IPAddress: dbLink.IpAddress,
+		ExpiresAt: dbLink.ExpiresAt,
+		UsedAt:    dbLink.UsedAt,
+		CreatedAt: dbLink.CreatedAt,
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// ModerationRepository scopes items to the tenant in the context; an item
+// belongs to its submitter's tenant
+type ModerationRepository interface {
+	Create(ctx context.Context, item *models.ModerationItem) (*models.ModerationItem, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ModerationItem, error)
+	// GetLatest returns the newest submission for a subject, whatever its status
+	GetLatest(ctx context.Context, subject models.ModerationSubject, subjectID uuid.UUID) (*models.ModerationItem, error)
+	List(ctx context.Context, status *models.ModerationStatus, subject *models.ModerationSubject, limit, offset int) ([]*models.ModerationItem, error)
+	// Review returns nil when the item no longer exists or was already reviewed
+	Review(ctx context.Context, id uuid.UUID, status models.ModerationStatus, reason *string, reviewedBy *uuid.UUID) (*models.ModerationItem, error)
+}
+
+type moderationRepository struct {
+	queries *db.Queries
+}
+
+func NewModerationRepository(queries *db.Queries) ModerationRepository {
+	return &moderationRepository{queries: queries}
+}
+
+func (r *moderationRepository) Create(ctx context.Context, item *models.ModerationItem) (*models.ModerationItem, error) {
+	dbItem, err := r.queries.CreateModerationItem(ctx, db.CreateModerationItemParams{
+		SubjectType:     string(item.SubjectType),
+		SubjectID:       item.SubjectID,
+		SubmittedBy:     item.SubmittedBy,
+		Content:         item.Content,
+		Status:          db.ModerationStatus(item.Status),
+		RejectionReason: item.RejectionReason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbItemToModel(dbItem), nil
+}
+
+func (r *moderationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ModerationItem, error) {
+	dbItem, err := r.queries.GetModerationItem(ctx, db.GetModerationItemParams{
+		ID:       id,
+		TenantID: tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbItemToModel(dbItem), nil
+}
+
+func (r *moderationRepository) GetLatest(ctx context.Context, subject models.ModerationSubject, subjectID uuid.UUID) (*models.ModerationItem, error) {
+	dbItem, err := r.queries.GetLatestModerationItem(ctx, db.GetLatestModerationItemParams{
+		SubjectType: string(subject),
+		SubjectID:   subjectID,
+		TenantID:    tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbItemToModel(dbItem), nil
+}
+
+func (r *moderationRepository) List(ctx context.Context, status *models.ModerationStatus, subject *models.ModerationSubject, limit, offset int) ([]*models.ModerationItem, error) {
+	var dbStatus *db.ModerationStatus
+	var dbSubject *string
+
+	if status != nil {
+		dbStatusVal := db.ModerationStatus(*status)
+		dbStatus = &dbStatusVal
+	}
+	if subject != nil {
+		dbSubjectVal := string(*subject)
+		dbSubject = &dbSubjectVal
+	}
+
+	dbItems, err := r.queries.ListModerationItems(ctx, db.ListModerationItemsParams{
+		Status:      dbStatus,
+		SubjectType: dbSubject,
+		Limit:       int32(limit),
+		Offset:      int32(offset),
+		TenantID:    tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.ModerationItem, len(dbItems))
+	for i, dbItem := range dbItems {
+		items[i] = r.dbItemToModel(dbItem)
+	}
+
+	return items, nil
+}
+
+func (r *moderationRepository) Review(ctx context.Context, id uuid.UUID, status models.ModerationStatus, reason *string, reviewedBy *uuid.UUID) (*models.ModerationItem, error) {
+	dbItem, err := r.queries.ReviewModerationItem(ctx, db.ReviewModerationItemParams{
+		ID:              id,
+		Status:          db.ModerationStatus(status),
+		RejectionReason: reason,
+		ReviewedBy:      reviewedBy,
+		TenantID:        tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbItemToModel(dbItem), nil
+}
+
+// Helper function to convert database moderation item to domain model
+func (r *moderationRepository) dbItemToModel(dbItem db.ModerationItem) *models.ModerationItem {
+	return &models.ModerationItem{
+		ID:              dbItem.ID,
+		SubjectType:     models.ModerationSubject(dbItem.SubjectType),
+		SubjectID:       dbItem.SubjectID,
+		SubmittedBy:     dbItem.SubmittedBy,
+		Content:         dbItem.Content,
+		Status:          models.ModerationStatus(dbItem.Status),
+		RejectionReason: dbItem.RejectionReason,
+		ReviewedBy:      dbItem.ReviewedBy,
+		ReviewedAt:      dbItem.ReviewedAt,
+		CreatedAt:       dbItem.CreatedAt,
+		TenantID:        dbItem.TenantID,
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type NotificationRepository interface {
+	// UpsertPushToken registers the token to its user, taking it over
+	// from whoever had it before
+	UpsertPushToken(ctx context.Context, token *models.PushToken) (*models.PushToken, error)
+	// TrimPushTokens deletes all but the user's keep most recently
+	// registered tokens and returns how many went
+	TrimPushTokens(ctx context.Context, userID uuid.UUID, keep int) (int64, error)
+	ListPushTokens(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error)
+	// DeletePushToken reports whether the user had the token
+	DeletePushToken(ctx context.Context, id, userID uuid.UUID) (bool, error)
+	// DropPushToken deletes a token the platform no longer accepts
+	DropPushToken(ctx context.Context, id uuid.UUID) error
+	TouchPushToken(ctx context.Context, id uuid.UUID) error
+	// GetPreferences returns nil when the user never saved preferences
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpsertPreferences(ctx context.Context, prefs *models.NotificationPreferences) (*models.NotificationPreferences, error)
+	CreateDelivery(ctx context.Context, delivery *models.PushDelivery) error
+	// ListDeliveries returns the user's pushes, newest first
+	ListDeliveries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.PushDelivery, error)
+}
+
+type notificationRepository struct {
+	queries *db.Queries
+}
+
+func NewNotificationRepository(queries *db.Queries) NotificationRepository {
+	return &notificationRepository{queries: queries}
+}
+
+func (r *notificationRepository) UpsertPushToken(ctx context.Context, token *models.PushToken) (*models.PushToken, error) {
+	dbToken, err := r.queries.UpsertPushToken(ctx, db.UpsertPushTokenParams{
+		UserID:     token.UserID,
+		Platform:   string(token.Platform),
+		Token:      token.Token,
+		DeviceName: token.DeviceName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbPushTokenToModel(dbToken), nil
+}
+
+func (r *notificationRepository) TrimPushTokens(ctx context.Context, userID uuid.UUID, keep int) (int64, error) {
+	return r.queries.TrimPushTokens(ctx, db.TrimPushTokensParams{
+		UserID: userID,
+		Limit:  int32(keep),
+	})
+}
+
+func (r *notificationRepository) ListPushTokens(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error) {
+	dbTokens, err := r.queries.ListPushTokensByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*models.PushToken, len(dbTokens))
+	for i, dbToken := range dbTokens {
+		tokens[i] = r.dbPushTokenToModel(dbToken)
+	}
+	return tokens, nil
+}
+
+func (r *notificationRepository) DeletePushToken(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeletePushToken(ctx, db.DeletePushTokenParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *notificationRepository) DropPushToken(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeletePushTokenByID(ctx, id)
+}
+
+func (r *notificationRepository) TouchPushToken(ctx context.Context, id uuid.UUID) error {
+	return r.queries.TouchPushToken(ctx, id)
+}
+
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	dbPrefs, err := r.queries.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbPreferencesToModel(dbPrefs), nil
+}
+
+func (r *notificationRepository) UpsertPreferences(ctx context.Context, prefs *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	dbPrefs, err := r.queries.UpsertNotificationPreferences(ctx, db.UpsertNotificationPreferencesParams{
+		UserID: prefs.UserID,
+		Push:   prefs.Push,
+		Email:  prefs.Email,
+		InApp:  prefs.InApp,
+		Sms:    prefs.SMS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbPreferencesToModel(dbPrefs), nil
+}
+
+func (r *notificationRepository) CreateDelivery(ctx context.Context, delivery *models.PushDelivery) error {
+	return r.queries.CreatePushDelivery(ctx, db.CreatePushDeliveryParams{
+		UserID:            delivery.UserID,
+		PushTokenID:       delivery.PushTokenID,
+		Platform:          string(delivery.Platform),
+		NotificationType:  delivery.NotificationType,
+		Status:            string(delivery.Status),
+		ProviderMessageID: delivery.ProviderMessageID,
+		Error:             delivery.Error,
+	})
+}
+
+func (r *notificationRepository) ListDeliveries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.PushDelivery, error) {
+	dbDeliveries, err := r.queries.ListPushDeliveriesByUser(ctx, db.ListPushDeliveriesByUserParams{
+		UserID: userID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*models.PushDelivery, len(dbDeliveries))
+	for i, dbDelivery := range dbDeliveries {
+		deliveries[i] = &models.PushDelivery{
+			ID:                dbDelivery.ID,
+			UserID:            dbDelivery.UserID,
+			PushTokenID:       dbDelivery.PushTokenID,
+			Platform:          models.PushPlatform(dbDelivery.Platform),
+			NotificationType:  dbDelivery.NotificationType,
+			Status:            models.PushDeliveryStatus(dbDelivery.Status),
+			ProviderMessageID: dbDelivery.ProviderMessageID,
+			Error:             dbDelivery.Error,
+			CreatedAt:         dbDelivery.CreatedAt,
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *notificationRepository) dbPushTokenToModel(dbToken db.PushToken) *models.PushToken {
+	return &models.PushToken{
+		ID:         dbToken.ID,
+		UserID:     dbToken.UserID,
+		Platform:   models.PushPlatform(dbToken.Platform),
+		Token:      dbToken.Token,
+		DeviceName: dbToken.DeviceName,
+		CreatedAt:  dbToken.CreatedAt,
+		UpdatedAt:  dbToken.UpdatedAt,
+		LastUsedAt: dbToken.LastUsedAt,
+	}
+}
+
+func (r *notificationRepository) dbPreferencesToModel(dbPrefs db.NotificationPreference) *models.NotificationPreferences {
+	return &models.NotificationPreferences{
+		UserID:    dbPrefs.UserID,
+		Push:      dbPrefs.Push,
+		Email:     dbPrefs.Email,
+		InApp:     dbPrefs.InApp,
+		SMS:       dbPrefs.Sms,
+		UpdatedAt: &dbPrefs.UpdatedAt,
+	}
+}
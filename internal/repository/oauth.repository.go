@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// OAuthRepository stores OAuth clients, in the tenant in the context, and
+// the grants, codes and tokens issued to them. Codes and tokens are looked
+// up by their hash.
+type OAuthRepository interface {
+	CreateClient(ctx context.Context, client *models.OAuthClient) (*models.OAuthClient, error)
+	GetClient(ctx context.Context, id uuid.UUID) (*models.OAuthClient, error)
+	ListClients(ctx context.Context) ([]*models.OAuthClient, error)
+	// DeleteClient reports whether the client existed; its grants, codes
+	// and tokens go with it
+	DeleteClient(ctx context.Context, id uuid.UUID) (bool, error)
+	GetGrant(ctx context.Context, userID, clientID uuid.UUID) (*models.OAuthGrant, error)
+	// SaveGrant creates the grant or replaces its scopes
+	SaveGrant(ctx context.Context, grant *models.OAuthGrant) (*models.OAuthGrant, error)
+	// ListGrants returns the user's grants with client names, oldest first
+	ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.OAuthGrant, error)
+	// DeleteGrant reports whether the grant existed
+	DeleteGrant(ctx context.Context, userID, clientID uuid.UUID) (bool, error)
+	CreateCode(ctx context.Context, code *models.OAuthAuthorizationCode) error
+	// RedeemCode marks the code used; it returns nil when there is none or
+	// it is already used or expired, so a code works only once
+	RedeemCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error)
+	CreateToken(ctx context.Context, token *models.OAuthToken) (*models.OAuthToken, error)
+	GetToken(ctx context.Context, tokenHash string) (*models.OAuthToken, error)
+	// RedeemRefreshToken revokes a live refresh token and returns it, or
+	// nil, so each refresh token is exchanged once
+	RedeemRefreshToken(ctx context.Context, tokenHash string) (*models.OAuthToken, error)
+	RevokeToken(ctx context.Context, id uuid.UUID) error
+	// RevokeGrantTokens revokes every token the client holds for the user
+	RevokeGrantTokens(ctx context.Context, userID, clientID uuid.UUID) error
+}
+
+type oauthRepository struct {
+	queries *db.Queries
+}
+
+func NewOAuthRepository(queries *db.Queries) OAuthRepository {
+	return &oauthRepository{queries: queries}
+}
+
+func (r *oauthRepository) CreateClient(ctx context.Context, client *models.OAuthClient) (*models.OAuthClient, error) {
+	dbClient, err := r.queries.CreateOAuthClient(ctx, db.CreateOAuthClientParams{
+		TenantID:     tenancy.ID(ctx),
+		Name:         client.Name,
+		SecretHash:   client.SecretHash,
+		RedirectUris: client.RedirectURIs,
+		Scopes:       scopesToStrings(client.Scopes),
+		CreatedBy:    client.CreatedBy,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbClientToModel(dbClient), nil
+}
+
+func (r *oauthRepository) GetClient(ctx context.Context, id uuid.UUID) (*models.OAuthClient, error) {
+	dbClient, err := r.queries.GetOAuthClient(ctx, db.GetOAuthClientParams{
+		ID:       id,
+		TenantID: tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbClientToModel(dbClient), nil
+}
+
+func (r *oauthRepository) ListClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	dbClients, err := r.queries.ListOAuthClients(ctx, tenancy.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]*models.OAuthClient, len(dbClients))
+	for i, dbClient := range dbClients {
+		clients[i] = r.dbClientToModel(dbClient)
+	}
+	return clients, nil
+}
+
+func (r *oauthRepository) DeleteClient(ctx context.Context, id uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeleteOAuthClient(ctx, db.DeleteOAuthClientParams{
+		ID:       id,
+		TenantID: tenancy.ID(ctx),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *oauthRepository) GetGrant(ctx context.Context, userID, clientID uuid.UUID) (*models.OAuthGrant, error) {
+	dbGrant, err := r.queries.GetOAuthGrant(ctx, db.GetOAuthGrantParams{
+		UserID:   userID,
+		ClientID: clientID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbGrantToModel(dbGrant), nil
+}
+
+func (r *oauthRepository) SaveGrant(ctx context.Context, grant *models.OAuthGrant) (*models.OAuthGrant, error) {
+	dbGrant, err := r.queries.UpsertOAuthGrant(ctx, db.UpsertOAuthGrantParams{
+		UserID:   grant.UserID,
+		ClientID: grant.ClientID,
+		Scopes:   scopesToStrings(grant.Scopes),
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbGrantToModel(dbGrant), nil
+}
+
+func (r *oauthRepository) ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.OAuthGrant, error) {
+	rows, err := r.queries.ListOAuthGrantsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]*models.OAuthGrant, len(rows))
+	for i, row := range rows {
+		grants[i] = &models.OAuthGrant{
+			UserID:     row.UserID,
+			ClientID:   row.ClientID,
+			ClientName: row.ClientName,
+			Scopes:     stringsToScopes(row.Scopes),
+			CreatedAt:  row.CreatedAt,
+			UpdatedAt:  row.UpdatedAt,
+		}
+	}
+	return grants, nil
+}
+
+func (r *oauthRepository) DeleteGrant(ctx context.Context, userID, clientID uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeleteOAuthGrant(ctx, db.DeleteOAuthGrantParams{
+		UserID:   userID,
+		ClientID: clientID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *oauthRepository) CreateCode(ctx context.Context, code *models.OAuthAuthorizationCode) error {
+	err := r.queries.CreateOAuthAuthorizationCode(ctx, db.CreateOAuthAuthorizationCodeParams{
+		CodeHash:      code.CodeHash,
+		ClientID:      code.ClientID,
+		UserID:        code.UserID,
+		RedirectUri:   code.RedirectURI,
+		Scopes:        scopesToStrings(code.Scopes),
+		CodeChallenge: code.CodeChallenge,
+		ExpiresAt:     code.ExpiresAt,
+	})
+	if err != nil {
+		return mapWriteError(err)
+	}
+
+	return nil
+}
+
+func (r *oauthRepository) RedeemCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	dbCode, err := r.queries.RedeemOAuthAuthorizationCode(ctx, codeHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &models.OAuthAuthorizationCode{
+		CodeHash:      dbCode.CodeHash,
+		ClientID:      dbCode.ClientID,
+		UserID:        dbCode.UserID,
+		RedirectURI:   dbCode.RedirectUri,
+		Scopes:        stringsToScopes(dbCode.Scopes),
+		CodeChallenge: dbCode.CodeChallenge,
+		ExpiresAt:     dbCode.ExpiresAt,
+		UsedAt:        dbCode.UsedAt,
+		CreatedAt:     dbCode.CreatedAt,
+	}, nil
+}
+
+func (r *oauthRepository) CreateToken(ctx context.Context, token *models.OAuthToken) (*models.OAuthToken, error) {
+	dbToken, err := r.queries.CreateOAuthToken(ctx, db.CreateOAuthTokenParams{
+		TokenHash: token.TokenHash,
+		Kind:      string(token.Kind),
+		ClientID:  token.ClientID,
+		UserID:    token.UserID,
+		Scopes:    scopesToStrings(token.Scopes),
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbTokenToModel(dbToken), nil
+}
+
+func (r *oauthRepository) GetToken(ctx context.Context, tokenHash string) (*models.OAuthToken, error) {
+	dbToken, err := r.queries.GetOAuthToken(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToModel(dbToken), nil
+}
+
+func (r *oauthRepository) RedeemRefreshToken(ctx context.Context, tokenHash string) (*models.OAuthToken, error) {
+	dbToken, err := r.queries.RedeemOAuthRefreshToken(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToModel(dbToken), nil
+}
+
+func (r *oauthRepository) RevokeToken(ctx context.Context, id uuid.UUID) error {
+	return r.queries.RevokeOAuthToken(ctx, id)
+}
+
+func (r *oauthRepository) RevokeGrantTokens(ctx context.Context, userID, clientID uuid.UUID) error {
+	return r.queries.RevokeOAuthTokensForGrant(ctx, db.RevokeOAuthTokensForGrantParams{
+		UserID:   userID,
+		ClientID: clientID,
+	})
+}
+
+func (r *oauthRepository) dbClientToModel(dbClient db.OauthClient) *models.OAuthClient {
+	return &models.OAuthClient{
+		ID:           dbClient.ID,
+		TenantID:     dbClient.TenantID,
+		Name:         dbClient.Name,
+		Confidential: dbClient.SecretHash != nil,
+		SecretHash:   dbClient.SecretHash,
+		RedirectURIs: dbClient.RedirectUris,
+		Scopes:       stringsToScopes(dbClient.Scopes),
+		CreatedBy:    dbClient.CreatedBy,
+		CreatedAt:    dbClient.CreatedAt,
+	}
+}
+
+func (r *oauthRepository) dbGrantToModel(dbGrant db.OauthGrant) *models.OAuthGrant {
+	return &models.OAuthGrant{
+		UserID:    dbGrant.UserID,
+		ClientID:  dbGrant.ClientID,
+		Scopes:    stringsToScopes(dbGrant.Scopes),
+		CreatedAt: dbGrant.CreatedAt,
+		UpdatedAt: dbGrant.UpdatedAt,
+	}
+}
+
+func (r *oauthRepository) dbTokenToModel(dbToken db.OauthToken) *models.OAuthToken {
+	return &models.OAuthToken{
+		ID:        dbToken.ID,
+		TokenHash: dbToken.TokenHash,
+		Kind:      models.OAuthTokenKind(dbToken.Kind),
+		ClientID:  dbToken.ClientID,
+		UserID:    dbToken.UserID,
+		Scopes:    stringsToScopes(dbToken.Scopes),
+		ExpiresAt: dbToken.ExpiresAt,
+		RevokedAt: dbToken.RevokedAt,
+		CreatedAt: dbToken.CreatedAt,
+	}
+}
+
+func scopesToStrings(scopes []models.OAuthScope) []string {
+	out := make([]string, len(scopes))
+	for i, scope := range scopes {
+		out[i] = string(scope)
+	}
+	return out
+}
+
+func stringsToScopes(scopes []string) []models.OAuthScope {
+	out := make([]models.OAuthScope, len(scopes))
+	for i, scope := range scopes {
+		out[i] = models.OAuthScope(scope)
+	}
+	return out
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+// OrganizationRepository creates organizations in the tenant in the context
+// and only finds them by ID within it
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) (*models.Organization, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	// Lock holds the organization's row until the transaction ends, so
+	// membership changes checking the owner count run one at a time
+	Lock(ctx context.Context, id uuid.UUID) error
+	// ListForMember returns the user's organizations with their role in each,
+	// oldest first
+	ListForMember(ctx context.Context, userID uuid.UUID) ([]*models.Organization, error)
+	// AddMember fails with ErrConflict when the user is already a member
+	AddMember(ctx context.Context, member *models.OrganizationMember) (*models.OrganizationMember, error)
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationMember, error)
+	CountOwners(ctx context.Context, orgID uuid.UUID) (int, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role models.OrganizationRole) (*models.OrganizationMember, error)
+	// RemoveMember reports whether the user was a member
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+}
+
+type organizationRepository struct {
+	queries *db.Queries
+}
+
+func NewOrganizationRepository(queries *db.Queries) OrganizationRepository {
+	return &organizationRepository{queries: queries}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	dbOrg, err := r.queries.CreateOrganization(ctx, db.CreateOrganizationParams{
+		TenantID:  tenancy.ID(ctx),
+		Name:      org.Name,
+		CreatedBy: org.CreatedBy,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbOrganizationToModel(dbOrg), nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	dbOrg, err := r.queries.GetOrganization(ctx, db.GetOrganizationParams{
+		ID:       id,
+		TenantID: tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbOrganizationToModel(dbOrg), nil
+}
+
+func (r *organizationRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	_, err := r.queries.LockOrganization(ctx, id)
+	return err
+}
+
+func (r *organizationRepository) ListForMember(ctx context.Context, userID uuid.UUID) ([]*models.Organization, error) {
+	rows, err := r.queries.ListOrganizationsByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]*models.Organization, len(rows))
+	for i, row := range rows {
+		orgs[i] = &models.Organization{
+			ID:        row.ID,
+			TenantID:  row.TenantID,
+			Name:      row.Name,
+			CreatedBy: row.CreatedBy,
+			Role:      models.OrganizationRole(row.Role),
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		}
+	}
+	return orgs, nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) (*models.OrganizationMember, error) {
+	dbMember, err := r.queries.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Role:           string(member.Role),
+		AddedBy:        member.AddedBy,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbMemberToModel(dbMember), nil
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	dbMember, err := r.queries.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbMemberToModel(dbMember), nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationMember, error) {
+	dbMembers, err := r.queries.ListOrganizationMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*models.OrganizationMember, len(dbMembers))
+	for i, dbMember := range dbMembers {
+		members[i] = r.dbMemberToModel(dbMember)
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) CountOwners(ctx context.Context, orgID uuid.UUID) (int, error) {
+	count, err := r.queries.CountOrganizationOwners(ctx, orgID)
+	return int(count), err
+}
+
+func (r *organizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role models.OrganizationRole) (*models.OrganizationMember, error) {
+	dbMember, err := r.queries.UpdateOrganizationMemberRole(ctx, db.UpdateOrganizationMemberRoleParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           string(role),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbMemberToModel(dbMember), nil
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeleteOrganizationMember(ctx, db.DeleteOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *organizationRepository) dbOrganizationToModel(dbOrg db.Organization) *models.Organization {
+	return &models.Organization{
+		ID:        dbOrg.ID,
+		TenantID:  dbOrg.TenantID,
+		Name:      dbOrg.Name,
+		CreatedBy: dbOrg.CreatedBy,
+		CreatedAt: dbOrg.CreatedAt,
+		UpdatedAt: dbOrg.UpdatedAt,
+	}
+}
+
+func (r *organizationRepository) dbMemberToModel(dbMember db.OrganizationMember) *models.OrganizationMember {
+	return &models.OrganizationMember{
+		OrganizationID: dbMember.OrganizationID,
+		UserID:         dbMember.UserID,
+		Role:           models.OrganizationRole(dbMember.Role),
+		AddedBy:        dbMember.AddedBy,
+		CreatedAt:      dbMember.CreatedAt,
+	}
+}
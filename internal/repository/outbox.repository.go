@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, message *models.OutboxMessage) (*models.OutboxMessage, error)
+	// Lock takes the relay lock until the surrounding transaction ends,
+	// reporting false when another relay holds it
+	Lock(ctx context.Context) (bool, error)
+	// Claim locks up to limit pending messages, oldest first, until the
+	// surrounding transaction ends; call it within Transactor.WithinTx
+	Claim(ctx context.Context, limit int) ([]*models.OutboxMessage, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string) error
+	// MarkDead records a final failed attempt and parks the message, so
+	// Claim no longer returns it
+	MarkDead(ctx context.Context, id uuid.UUID, reason string) error
+	// Requeue marks messages delivered or parked since then pending again,
+	// only those named eventName when it is set, and returns how many
+	Requeue(ctx context.Context, since time.Time, eventName *string) (int64, error)
+}
+
+type outboxRepository struct {
+	queries *db.Queries
+}
+
+func NewOutboxRepository(queries *db.Queries) OutboxRepository {
+	return &outboxRepository{queries: queries}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, message *models.OutboxMessage) (*models.OutboxMessage, error) {
+	dbMessage, err := r.queries.CreateOutboxMessage(ctx, db.CreateOutboxMessageParams{
+		EventName:  message.EventName,
+		Payload:    message.Payload,
+		OccurredAt: message.OccurredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbOutboxToModel(dbMessage), nil
+}
+
+func (r *outboxRepository) Lock(ctx context.Context) (bool, error) {
+	return r.queries.LockOutboxRelay(ctx)
+}
+
+func (r *outboxRepository) Claim(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	dbMessages, err := r.queries.ClaimOutboxMessages(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*models.OutboxMessage, len(dbMessages))
+	for i, dbMessage := range dbMessages {
+		messages[i] = r.dbOutboxToModel(dbMessage)
+	}
+
+	return messages, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	return r.queries.MarkOutboxMessageSent(ctx, id)
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	return r.queries.MarkOutboxMessageFailed(ctx, db.MarkOutboxMessageFailedParams{
+		ID:        id,
+		LastError: &reason,
+	})
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	return r.queries.MarkOutboxMessageDead(ctx, db.MarkOutboxMessageDeadParams{
+		ID:        id,
+		LastError: &reason,
+	})
+}
+
+func (r *outboxRepository) Requeue(ctx context.Context, since time.Time, eventName *string) (int64, error) {
+	return r.queries.RequeueOutboxMessages(ctx, db.RequeueOutboxMessagesParams{
+		OccurredAt: since,
+		EventName:  eventName,
+	})
+}
+
+// Helper function to convert database outbox row to domain model
+func (r *outboxRepository) dbOutboxToModel(dbMessage db.Outbox) *models.OutboxMessage {
+	return &models.OutboxMessage{
+		ID:         dbMessage.ID,
+		EventName:  dbMessage.EventName,
+		Payload:    dbMessage.Payload,
+		OccurredAt: dbMessage.OccurredAt,
+		Attempts:   int(dbMessage.Attempts),
+		LastError:  dbMessage.LastError,
+		SentAt:     dbMessage.SentAt,
+		DeadAt:     dbMessage.DeadAt,
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+)
+
+// PartitionRepository manages the monthly partitions of partitioned tables.
+// Months are UTC.
+type PartitionRepository interface {
+	// CreateMonthly creates table's partition for the month containing day,
+	// reporting false when it already existed
+	CreateMonthly(ctx context.Context, table string, day time.Time) (bool, error)
+	// DropMonthlyBefore detaches and drops table's partitions for months
+	// ending on or before cutoff and returns their names
+	DropMonthlyBefore(ctx context.Context, table string, cutoff time.Time) ([]string, error)
+}
+
+type partitionRepository struct {
+	queries *db.Queries
+}
+
+func NewPartitionRepository(queries *db.Queries) PartitionRepository {
+	return &partitionRepository{queries: queries}
+}
+
+func (r *partitionRepository) CreateMonthly(ctx context.Context, table string, day time.Time) (bool, error) {
+	return r.queries.CreateMonthlyPartition(ctx, db.CreateMonthlyPartitionParams{
+		Parent: table,
+		Day:    day.UTC(),
+	})
+}
+
+func (r *partitionRepository) DropMonthlyBefore(ctx context.Context, table string, cutoff time.Time) ([]string, error) {
+	return r.queries.DropMonthlyPartitionsBefore(ctx, db.DropMonthlyPartitionsBeforeParams{
+		Parent: table,
+		Cutoff: cutoff.UTC(),
+	})
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type PasskeyRepository interface {
+	// Create fails with ErrConflict when the credential is already registered
+	Create(ctx context.Context, passkey *models.Passkey) (*models.Passkey, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Passkey, error)
+	// RecordUse stores the credential record as updated by a login
+	RecordUse(ctx context.Context, credentialID []byte, credential json.RawMessage) error
+	// Delete reports whether the user had the passkey
+	Delete(ctx context.Context, id, userID uuid.UUID) (bool, error)
+	CreateSession(ctx context.Context, session *models.WebAuthnSession) (*models.WebAuthnSession, error)
+	// ConsumeSession deletes and returns the session; nil when there is
+	// none for the ceremony or it expired, so each works only once
+	ConsumeSession(ctx context.Context, id uuid.UUID, ceremony models.WebAuthnCeremony) (*models.WebAuthnSession, error)
+	DeleteExpiredSessions(ctx context.Context) error
+}
+
+type passkeyRepository struct {
+	queries *db.Queries
+}
+
+func NewPasskeyRepository(queries *db.Queries) PasskeyRepository {
+	return &passkeyRepository{queries: queries}
+}
+
+func (r *passkeyRepository) Create(ctx context.Context, passkey *models.Passkey) (*models.Passkey, error) {
+	dbCredential, err := r.queries.CreateWebAuthnCredential(ctx, db.CreateWebAuthnCredentialParams{
+		UserID:       passkey.UserID,
+		CredentialID: passkey.CredentialID,
+		Name:         passkey.Name,
+		Data:         passkey.Credential,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbCredentialToModel(dbCredential), nil
+}
+
+func (r *passkeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Passkey, error) {
+	dbCredentials, err := r.queries.ListWebAuthnCredentialsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys := make([]*models.Passkey, len(dbCredentials))
+	for i, dbCredential := range dbCredentials {
+		passkeys[i] = r.dbCredentialToModel(dbCredential)
+	}
+	return passkeys, nil
+}
+
+func (r *passkeyRepository) RecordUse(ctx context.Context, credentialID []byte, credential json.RawMessage) error {
+	return r.queries.UpdateWebAuthnCredentialUse(ctx, db.UpdateWebAuthnCredentialUseParams{
+		CredentialID: credentialID,
+		Data:         credential,
+	})
+}
+
+func (r *passkeyRepository) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	rows, err := r.queries.DeleteWebAuthnCredential(ctx, db.DeleteWebAuthnCredentialParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *passkeyRepository) CreateSession(ctx context.Context, session *models.WebAuthnSession) (*models.WebAuthnSession, error) {
+	dbSession, err := r.queries.CreateWebAuthnSession(ctx, db.CreateWebAuthnSessionParams{
+		UserID:    session.UserID,
+		Ceremony:  string(session.Ceremony),
+		Data:      session.Data,
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbSessionToModel(dbSession), nil
+}
+
+func (r *passkeyRepository) ConsumeSession(ctx context.Context, id uuid.UUID, ceremony models.WebAuthnCeremony) (*models.WebAuthnSession, error) {
+	dbSession, err := r.queries.ConsumeWebAuthnSession(ctx, db.ConsumeWebAuthnSessionParams{
+		ID:       id,
+		Ceremony: string(ceremony),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbSessionToModel(dbSession), nil
+}
+
+func (r *passkeyRepository) DeleteExpiredSessions(ctx context.Context) error {
+	return r.queries.DeleteExpiredWebAuthnSessions(ctx)
+}
+
+func (r *passkeyRepository) dbCredentialToModel(dbCredential db.WebauthnCredential) *models.Passkey {
+	return &models.Passkey{
+		ID:           dbCredential.ID,
+		UserID:       dbCredential.UserID,
+		Name:         dbCredential.Name,
+		CredentialID: dbCredential.CredentialID,
+		Credential:   dbCredential.Data,
+		CreatedAt:    dbCredential.CreatedAt,
+		LastUsedAt:   dbCredential.LastUsedAt,
+	}
+}
+
+func (r *passkeyRepository) dbSessionToModel(dbSession db.WebauthnSession) *models.WebAuthnSession {
+	return &models.WebAuthnSession{
+		ID:        dbSession.ID,
+		UserID:    dbSession.UserID,
+		Ceremony:  models.WebAuthnCeremony(dbSession.Ceremony),
+		Data:      dbSession.Data,
+		ExpiresAt: dbSession.ExpiresAt,
+		CreatedAt: dbSession.CreatedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type PermissionRepository interface {
+	List(ctx context.Context) ([]*models.PermissionDefinition, error)
+	Get(ctx context.Context, name models.Permission) (*models.PermissionDefinition, error)
+	// ListEffective returns the union of the role's and the user's own permissions
+	ListEffective(ctx context.Context, userID uuid.UUID, role models.UserRole) ([]models.Permission, error)
+	ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.PermissionGrant, error)
+	// Grant reports whether the grant is new
+	Grant(ctx context.Context, userID uuid.UUID, permission models.Permission, grantedBy *uuid.UUID) (bool, error)
+	// Revoke reports whether a grant existed
+	Revoke(ctx context.Context, userID uuid.UUID, permission models.Permission) (bool, error)
+}
+
+type permissionRepository struct {
+	queries *db.Queries
+}
+
+func NewPermissionRepository(queries *db.Queries) PermissionRepository {
+	return &permissionRepository{queries: queries}
+}
+
+func (r *permissionRepository) List(ctx context.Context) ([]*models.PermissionDefinition, error) {
+	dbPermissions, err := r.queries.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]*models.PermissionDefinition, len(dbPermissions))
+	for i, dbPermission := range dbPermissions {
+		permissions[i] = r.dbPermissionToModel(dbPermission)
+	}
+	return permissions, nil
+}
+
+func (r *permissionRepository) Get(ctx context.Context, name models.Permission) (*models.PermissionDefinition, error) {
+	dbPermission, err := r.queries.GetPermission(ctx, string(name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbPermissionToModel(dbPermission), nil
+}
+
+func (r *permissionRepository) ListEffective(ctx context.Context, userID uuid.UUID, role models.UserRole) ([]models.Permission, error) {
+	names, err := r.queries.ListEffectivePermissions(ctx, db.ListEffectivePermissionsParams{
+		Role:   db.UserRole(role),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]models.Permission, len(names))
+	for i, name := range names {
+		permissions[i] = models.Permission(name)
+	}
+	return permissions, nil
+}
+
+func (r *permissionRepository) ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.PermissionGrant, error) {
+	dbGrants, err := r.queries.ListUserPermissionGrants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]*models.PermissionGrant, len(dbGrants))
+	for i, dbGrant := range dbGrants {
+		grants[i] = &models.PermissionGrant{
+			UserID:     dbGrant.UserID,
+			Permission: models.Permission(dbGrant.Permission),
+			GrantedBy:  dbGrant.GrantedBy,
+			CreatedAt:  dbGrant.CreatedAt,
+		}
+	}
+	return grants, nil
+}
+
+func (r *permissionRepository) Grant(ctx context.Context, userID uuid.UUID, permission models.Permission, grantedBy *uuid.UUID) (bool, error) {
+	rows, err := r.queries.GrantUserPermission(ctx, db.GrantUserPermissionParams{
+		UserID:     userID,
+		Permission: string(permission),
+		GrantedBy:  grantedBy,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *permissionRepository) Revoke(ctx context.Context, userID uuid.UUID, permission models.Permission) (bool, error) {
+	rows, err := r.queries.RevokeUserPermission(ctx, db.RevokeUserPermissionParams{
+		UserID:     userID,
+		Permission: string(permission),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// Helper function to convert database permission to domain model
+func (r *permissionRepository) dbPermissionToModel(dbPermission db.Permission) *models.PermissionDefinition {
+	return &models.PermissionDefinition{
+		Name:        models.Permission(dbPermission.Name),
+		Description: dbPermission.Description,
+	}
+}
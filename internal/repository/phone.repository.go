@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type PhoneRepository interface {
+	CreateVerification(ctx context.Context, verification *models.PhoneVerification) (*models.PhoneVerification, error)
+	// CountVerificationsSince counts the codes texted to the user after since
+	CountVerificationsSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	// GetLatestVerification returns the last code texted to the user, or nil
+	GetLatestVerification(ctx context.Context, userID uuid.UUID) (*models.PhoneVerification, error)
+	// IncrementAttempts records a wrong code and returns the attempts so far
+	IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	// CompleteVerification marks the code used; it returns nil when it is
+	// already used, expired or out of attempts, so a code works only once
+	CompleteVerification(ctx context.Context, id uuid.UUID, maxAttempts int) (*models.PhoneVerification, error)
+	SetVerified(ctx context.Context, userID uuid.UUID, phone string) error
+	// GetVerified returns the user's verified number, or nil when they never
+	// verified one or have since changed it
+	GetVerified(ctx context.Context, userID uuid.UUID) (*models.VerifiedPhone, error)
+}
+
+type phoneRepository struct {
+	queries *db.Queries
+}
+
+func NewPhoneRepository(queries *db.Queries) PhoneRepository {
+	return &phoneRepository{queries: queries}
+}
+
+func (r *phoneRepository) CreateVerification(ctx context.Context, verification *models.PhoneVerification) (*models.PhoneVerification, error) {
+	dbVerification, err := r.queries.CreatePhoneVerification(ctx, db.CreatePhoneVerificationParams{
+		UserID:    verification.UserID,
+		Phone:     verification.Phone,
+		CodeHash:  verification.CodeHash,
+		ExpiresAt: verification.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbVerificationToModel(dbVerification), nil
+}
+
+func (r *phoneRepository) CountVerificationsSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	count, err := r.queries.CountPhoneVerificationsSince(ctx, db.CountPhoneVerificationsSinceParams{
+		UserID:    userID,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *phoneRepository) GetLatestVerification(ctx context.Context, userID uuid.UUID) (*models.PhoneVerification, error) {
+	dbVerification, err := r.queries.GetLatestPhoneVerification(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbVerificationToModel(dbVerification), nil
+}
+
+func (r *phoneRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	attempts, err := r.queries.IncrementPhoneVerificationAttempts(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(attempts), nil
+}
+
+func (r *phoneRepository) CompleteVerification(ctx context.Context, id uuid.UUID, maxAttempts int) (*models.PhoneVerification, error) {
+	dbVerification, err := r.queries.CompletePhoneVerification(ctx, db.CompletePhoneVerificationParams{
+		ID:       id,
+		Attempts: int32(maxAttempts),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbVerificationToModel(dbVerification), nil
+}
+
+func (r *phoneRepository) SetVerified(ctx context.Context, userID uuid.UUID, phone string) error {
+	return r.queries.UpsertVerifiedPhone(ctx, db.UpsertVerifiedPhoneParams{
+		UserID: userID,
+		Phone:  phone,
+	})
+}
+
+func (r *phoneRepository) GetVerified(ctx context.Context, userID uuid.UUID) (*models.VerifiedPhone, error) {
+	dbPhone, err := r.queries.GetVerifiedPhone(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &models.VerifiedPhone{
+		UserID:     dbPhone.UserID,
+		Phone:      dbPhone.Phone,
+		VerifiedAt: dbPhone.VerifiedAt,
+	}, nil
+}
+
+func (r *phoneRepository) dbVerificationToModel(dbVerification db.PhoneVerification) *models.PhoneVerification {
+	return &models.PhoneVerification{
+		ID:         dbVerification.ID,
+		UserID:     dbVerification.UserID,
+		Phone:      dbVerification.Phone,
+		CodeHash:   dbVerification.CodeHash,
+		Attempts:   int(dbVerification.Attempts),
+		ExpiresAt:  dbVerification.ExpiresAt,
+		VerifiedAt: dbVerification.VerifiedAt,
+		CreatedAt:  dbVerification.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type ProfileRepository interface {
+	// GetPreferences returns nil when the user never saved preferences
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.ProfilePreferences, error)
+	UpsertPreferences(ctx context.Context, prefs *models.ProfilePreferences) (*models.ProfilePreferences, error)
+}
+
+type profileRepository struct {
+	queries *db.Queries
+}
+
+func NewProfileRepository(queries *db.Queries) ProfileRepository {
+	return &profileRepository{queries: queries}
+}
+
+func (r *profileRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.ProfilePreferences, error) {
+	dbPrefs, err := r.queries.GetProfilePreferences(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbPreferencesToModel(dbPrefs), nil
+}
+
+func (r *profileRepository) UpsertPreferences(ctx context.Context, prefs *models.ProfilePreferences) (*models.ProfilePreferences, error) {
+	dbPrefs, err := r.queries.UpsertProfilePreferences(ctx, db.UpsertProfilePreferencesParams{
+		UserID:       prefs.UserID,
+		ShowLibrary:  prefs.ShowLibrary,
+		ShowWishlist: prefs.ShowWishlist,
+		ShowActivity: prefs.ShowActivity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbPreferencesToModel(dbPrefs), nil
+}
+
+func (r *profileRepository) dbPreferencesToModel(dbPrefs db.ProfilePreference) *models.ProfilePreferences {
+	return &models.ProfilePreferences{
+		UserID:       dbPrefs.UserID,
+		ShowLibrary:  dbPrefs.ShowLibrary,
+		ShowWishlist: dbPrefs.ShowWishlist,
+		ShowActivity: dbPrefs.ShowActivity,
+		UpdatedAt:    &dbPrefs.UpdatedAt,
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type QueryPlanRepository interface {
+	Create(ctx context.Context, plan *models.QueryPlan) (*models.QueryPlan, error)
+	GetBaseline(ctx context.Context, queryName, deployVersion string) (*models.QueryPlan, error)
+	List(ctx context.Context, regression *bool, limit, offset int) ([]*models.QueryPlan, error)
+	Count(ctx context.Context, regression *bool) (int, error)
+}
+
+type queryPlanRepository struct {
+	queries *db.Queries
+}
+
+func NewQueryPlanRepository(queries *db.Queries) QueryPlanRepository {
+	return &queryPlanRepository{queries: queries}
+}
+
+func (r *queryPlanRepository) Create(ctx context.Context, plan *models.QueryPlan) (*models.QueryPlan, error) {
+	planJSON, err := json.Marshal(plan.Plan)
+	if err != nil {
+		return nil, err
+	}
+	scansJSON, err := json.Marshal(plan.Scans)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPlan, err := r.queries.CreateQueryPlan(ctx, db.CreateQueryPlanParams{
+		QueryName:        plan.QueryName,
+		DeployVersion:    plan.DeployVersion,
+		Plan:             planJSON,
+		Scans:            scansJSON,
+		Regression:       plan.Regression,
+		RegressionDetail: plan.RegressionDetail,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbQueryPlanToModel(dbPlan), nil
+}
+
+func (r *queryPlanRepository) GetBaseline(ctx context.Context, queryName, deployVersion string) (*models.QueryPlan, error) {
+	dbPlan, err := r.queries.GetBaselineQueryPlan(ctx, db.GetBaselineQueryPlanParams{
+		QueryName:     queryName,
+		DeployVersion: deployVersion,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbQueryPlanToModel(dbPlan), nil
+}
+
+func (r *queryPlanRepository) List(ctx context.Context, regression *bool, limit, offset int) ([]*models.QueryPlan, error) {
+	dbPlans, err := r.queries.ListQueryPlans(ctx, db.ListQueryPlansParams{
+		Regression: regression,
+		Limit:      int32(limit),
+		Offset:     int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*models.QueryPlan, len(dbPlans))
+	for i, dbPlan := range dbPlans {
+		plans[i] = r.dbQueryPlanToModel(dbPlan)
+	}
+
+	return plans, nil
+}
+
+func (r *queryPlanRepository) Count(ctx context.Context, regression *bool) (int, error) {
+	count, err := r.queries.CountQueryPlans(ctx, regression)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// Helper function to convert database query plan to domain model
+func (r *queryPlanRepository) dbQueryPlanToModel(dbPlan db.QueryPlan) *models.QueryPlan {
+	plan := &models.QueryPlan{
+		ID:               dbPlan.ID,
+		QueryName:        dbPlan.QueryName,
+		DeployVersion:    dbPlan.DeployVersion,
+		Plan:             dbPlan.Plan,
+		Regression:       dbPlan.Regression,
+		RegressionDetail: dbPlan.RegressionDetail,
+		CapturedAt:       dbPlan.CapturedAt,
+	}
+	// Scans are written by Create, so a decode failure only leaves them empty
+	_ = json.Unmarshal(dbPlan.Scans, &plan.Scans)
+
+	return plan
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+)
+
+// RetentionRepository purges data past its retention period, at most limit
+// rows per call; each method returns how many rows it changed
+type RetentionRepository interface {
+	DeleteEventsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	// DeleteSentOutboxBefore leaves undelivered messages, however old
+	DeleteSentOutboxBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	// AnonymizeAuditIPsBefore clears the IP address and keeps the entry
+	AnonymizeAuditIPsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeletePushDeliveriesBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	// DeleteLoginLocationsBefore takes the locations' login challenges too
+	DeleteLoginLocationsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	// The DeleteExpired methods remove rows that expired before before
+	DeleteExpiredLoginChallengesBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteExpiredMagicLinksBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteExpiredPhoneVerificationsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteExpiredOAuthCodesBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteExpiredOAuthTokensBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+type retentionRepository struct {
+	queries *db.Queries
+}
+
+func NewRetentionRepository(queries *db.Queries) RetentionRepository {
+	return &retentionRepository{queries: queries}
+}
+
+func (r *retentionRepository) DeleteEventsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteEventsBefore(ctx, db.DeleteEventsBeforeParams{
+		OccurredAt: before,
+		Limit:      int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteSentOutboxBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteSentOutboxBefore(ctx, db.DeleteSentOutboxBeforeParams{
+		SentAt: before,
+		Limit:  int32(limit),
+	})
+}
+
+func (r *retentionRepository) AnonymizeAuditIPsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.AnonymizeAuditLogIPsBefore(ctx, db.AnonymizeAuditLogIPsBeforeParams{
+		CreatedAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeletePushDeliveriesBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeletePushDeliveriesBefore(ctx, db.DeletePushDeliveriesBeforeParams{
+		CreatedAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteLoginLocationsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteLoginLocationsBefore(ctx, db.DeleteLoginLocationsBeforeParams{
+		CreatedAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteExpiredLoginChallengesBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteExpiredLoginChallengesBefore(ctx, db.DeleteExpiredLoginChallengesBeforeParams{
+		ExpiresAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteExpiredMagicLinksBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteExpiredMagicLinksBefore(ctx, db.DeleteExpiredMagicLinksBeforeParams{
+		ExpiresAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteExpiredPhoneVerificationsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteExpiredPhoneVerificationsBefore(ctx, db.DeleteExpiredPhoneVerificationsBeforeParams{
+		ExpiresAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteExpiredOAuthCodesBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteExpiredOAuthCodesBefore(ctx, db.DeleteExpiredOAuthCodesBeforeParams{
+		ExpiresAt: before,
+		Limit:     int32(limit),
+	})
+}
+
+func (r *retentionRepository) DeleteExpiredOAuthTokensBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.queries.DeleteExpiredOAuthTokensBefore(ctx, db.DeleteExpiredOAuthTokensBeforeParams{
+		ExpiresAt: before,
+		Limit:     int32(limit),
+	})
+}
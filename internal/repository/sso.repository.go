@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type SSORepository interface {
+	CreateState(ctx context.Context, state *models.SSOLoginState) error
+	// ConsumeState deletes the live state with stateHash and returns it, or
+	// nil, so each state is used once
+	ConsumeState(ctx context.Context, stateHash string) (*models.SSOLoginState, error)
+	GetIdentity(ctx context.Context, issuer, subject string) (*models.SSOIdentity, error)
+	CreateIdentity(ctx context.Context, identity *models.SSOIdentity) (*models.SSOIdentity, error)
+	// TouchIdentity records a login, keeping the email the provider sent
+	TouchIdentity(ctx context.Context, issuer, subject, email string) error
+}
+
+type ssoRepository struct {
+	queries *db.Queries
+}
+
+func NewSSORepository(queries *db.Queries) SSORepository {
+	return &ssoRepository{queries: queries}
+}
+
+func (r *ssoRepository) CreateState(ctx context.Context, state *models.SSOLoginState) error {
+	err := r.queries.CreateSSOLoginState(ctx, db.CreateSSOLoginStateParams{
+		StateHash:    state.StateHash,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	})
+	if err != nil {
+		return mapWriteError(err)
+	}
+
+	return nil
+}
+
+func (r *ssoRepository) ConsumeState(ctx context.Context, stateHash string) (*models.SSOLoginState, error) {
+	dbState, err := r.queries.ConsumeSSOLoginState(ctx, stateHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &models.SSOLoginState{
+		StateHash:    dbState.StateHash,
+		Nonce:        dbState.Nonce,
+		CodeVerifier: dbState.CodeVerifier,
+		ExpiresAt:    dbState.ExpiresAt,
+		CreatedAt:    dbState.CreatedAt,
+	}, nil
+}
+
+func (r *ssoRepository) GetIdentity(ctx context.Context, issuer, subject string) (*models.SSOIdentity, error) {
+	dbIdentity, err := r.queries.GetSSOIdentity(ctx, db.GetSSOIdentityParams{
+		Issuer:  issuer,
+		Subject: subject,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbIdentityToModel(dbIdentity), nil
+}
+
+func (r *ssoRepository) CreateIdentity(ctx context.Context, identity *models.SSOIdentity) (*models.SSOIdentity, error) {
+	dbIdentity, err := r.queries.CreateSSOIdentity(ctx, db.CreateSSOIdentityParams{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		UserID:  identity.UserID,
+		Email:   identity.Email,
+	})
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbIdentityToModel(dbIdentity), nil
+}
+
+func (r *ssoRepository) TouchIdentity(ctx context.Context, issuer, subject, email string) error {
+	return r.queries.TouchSSOIdentity(ctx, db.TouchSSOIdentityParams{
+		Issuer:  issuer,
+		Subject: subject,
+		Email:   email,
+	})
+}
+
+func (r *ssoRepository) dbIdentityToModel(dbIdentity db.SsoIdentity) *models.SSOIdentity {
+	return &models.SSOIdentity{
+		Issuer:      dbIdentity.Issuer,
+		Subject:     dbIdentity.Subject,
+		UserID:      dbIdentity.UserID,
+		Email:       dbIdentity.Email,
+		CreatedAt:   dbIdentity.CreatedAt,
+		LastLoginAt: dbIdentity.LastLoginAt,
+	}
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type SuspensionRepository interface {
+	Create(ctx context.Context, userID uuid.UUID, reason string, issuedBy *uuid.UUID, expiresAt *time.Time) (*models.Suspension, error)
+	GetActiveByUser(ctx context.Context, userID uuid.UUID) (*models.Suspension, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Suspension, error)
+	ListExpired(ctx context.Context, limit int) ([]*models.Suspension, error)
+	// Lift reports whether the suspension was still open
+	Lift(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID) (bool, error)
+	LiftAllForUser(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID) (int64, error)
+}
+
+type suspensionRepository struct {
+	queries *db.Queries
+}
+
+func NewSuspensionRepository(queries *db.Queries) SuspensionRepository {
+	return &suspensionRepository{queries: queries}
+}
+
+func (r *suspensionRepository) Create(ctx context.Context, userID uuid.UUID, reason string, issuedBy *uuid.UUID, expiresAt *time.Time) (*models.Suspension, error) {
+	dbSuspension, err := r.queries.CreateSuspension(ctx, db.CreateSuspensionParams{
+		UserID:    userID,
+		Reason:    reason,
+		IssuedBy:  issuedBy,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbSuspensionToModel(dbSuspension), nil
+}
+
+func (r *suspensionRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) (*models.Suspension, error) {
+	dbSuspension, err := r.queries.GetActiveSuspensionByUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbSuspensionToModel(dbSuspension), nil
+}
+
+func (r *suspensionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Suspension, error) {
+	dbSuspensions, err := r.queries.ListSuspensionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbSuspensionsToModels(dbSuspensions), nil
+}
+
+func (r *suspensionRepository) ListExpired(ctx context.Context, limit int) ([]*models.Suspension, error) {
+	dbSuspensions, err := r.queries.ListExpiredSuspensions(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.dbSuspensionsToModels(dbSuspensions), nil
+}
+
+func (r *suspensionRepository) Lift(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID) (bool, error) {
+	rows, err := r.queries.LiftSuspension(ctx, db.LiftSuspensionParams{
+		ID:       id,
+		LiftedBy: liftedBy,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *suspensionRepository) LiftAllForUser(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID) (int64, error) {
+	return r.queries.LiftSuspensionsByUser(ctx, db.LiftSuspensionsByUserParams{
+		UserID:   userID,
+		LiftedBy: liftedBy,
+	})
+}
+
+func (r *suspensionRepository) dbSuspensionsToModels(dbSuspensions []db.Suspension) []*models.Suspension {
+	suspensions := make([]*models.Suspension, len(dbSuspensions))
+	for i, dbSuspension := range dbSuspensions {
+		suspensions[i] = r.dbSuspensionToModel(dbSuspension)
+	}
+	return suspensions
+}
+
+// Helper function to convert database suspension to domain model
+func (r *suspensionRepository) dbSuspensionToModel(dbSuspension db.Suspension) *models.Suspension {
+	return &models.Suspension{
+		ID:        dbSuspension.ID,
+		UserID:    dbSuspension.UserID,
+		Reason:    dbSuspension.Reason,
+		IssuedBy:  dbSuspension.IssuedBy,
+		ExpiresAt: dbSuspension.ExpiresAt,
+		LiftedAt:  dbSuspension.LiftedAt,
+		LiftedBy:  dbSuspension.LiftedBy,
+		CreatedAt: dbSuspension.CreatedAt,
+	}
+}
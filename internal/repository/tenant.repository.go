@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type TenantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Tenant, error)
+	// GetByHostname matches hostname ignoring case
+	GetByHostname(ctx context.Context, hostname string) (*models.Tenant, error)
+	Update(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error)
+}
+
+type tenantRepository struct {
+	queries *db.Queries
+}
+
+func NewTenantRepository(queries *db.Queries) TenantRepository {
+	return &tenantRepository{queries: queries}
+}
+
+func (r *tenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Tenant, error) {
+	return r.get(r.queries.GetTenant(ctx, id))
+}
+
+func (r *tenantRepository) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	return r.get(r.queries.GetTenantBySlug(ctx, slug))
+}
+
+func (r *tenantRepository) GetByHostname(ctx context.Context, hostname string) (*models.Tenant, error) {
+	return r.get(r.queries.GetTenantByHostname(ctx, hostname))
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error) {
+	brandingJSON, err := json.Marshal(tenant.Branding)
+	if err != nil {
+		return nil, err
+	}
+
+	dbTenant, err := r.queries.UpdateTenant(ctx, db.UpdateTenantParams{
+		ID:       tenant.ID,
+		Name:     tenant.Name,
+		Branding: brandingJSON,
+		Currency: tenant.Currency,
+		FeeBps:   int32(tenant.FeeBps),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, mapWriteError(err)
+	}
+
+	return r.dbTenantToModel(dbTenant), nil
+}
+
+func (r *tenantRepository) get(dbTenant db.Tenant, err error) (*models.Tenant, error) {
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbTenantToModel(dbTenant), nil
+}
+
+func (r *tenantRepository) dbTenantToModel(dbTenant db.Tenant) *models.Tenant {
+	tenant := &models.Tenant{
+		ID:        dbTenant.ID,
+		Slug:      dbTenant.Slug,
+		Name:      dbTenant.Name,
+		Currency:  dbTenant.Currency,
+		FeeBps:    int(dbTenant.FeeBps),
+		CreatedAt: dbTenant.CreatedAt,
+		UpdatedAt: dbTenant.UpdatedAt,
+	}
+	_ = json.Unmarshal(dbTenant.Branding, &tenant.Branding)
+	return tenant
+}
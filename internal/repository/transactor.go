@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
+)
+
+// Transactor runs fn atomically. Repositories called with the context fn
+// receives take part in the same transaction.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	database *sql.DB
+}
+
+// NewTransactor requires the repositories' queries to be built on a
+// db.TxConn wrapping database
+func NewTransactor(database *sql.DB) Transactor {
+	return &transactor{database: database}
+}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.RunInTx(ctx, t.database, fn)
+}
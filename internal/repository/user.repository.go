@@ -7,14 +7,26 @@ import (
 	"github.com/google/uuid"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/db"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
 )
 
+// UserRepository is scoped to the tenant in the context: users are created
+// in it, and emails, usernames and listings resolve within it. Lookups by ID
+// only check the tenant when the context carries one.
 type UserRepository interface {
+	// Create stores user with its status, active when unset
 	Create(ctx context.Context, user *models.User) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	// GetByIDs returns the users that exist among ids, in no particular order
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error)
+	// GetByUsername matches username ignoring case
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.UserStatus) error
+	UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL *string) error
+	UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 	List(ctx context.Context, role *models.UserRole, status *models.UserStatus, limit, offset int) ([]*models.User, error)
 }
 
@@ -27,6 +39,10 @@ func NewUserRepository(queries *db.Queries) UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	status := user.Status
+	if status == "" {
+		status = models.StatusActive
+	}
 	dbUser, err := r.queries.CreateUser(ctx, db.CreateUserParams{
 		Email:        user.Email,
 		PasswordHash: user.PasswordHash,
@@ -34,16 +50,22 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) (*models
 		LastName:     user.LastName,
 		Role:         db.UserRole(user.Role),
 		Phone:        user.Phone,
+		Username:     user.Username,
+		Status:       db.UserStatus(status),
+		TenantID:     tenancy.ID(ctx),
 	})
 	if err != nil {
-		return nil, err
+		return nil, mapWriteError(err)
 	}
 
 	return r.dbUserToModel(dbUser), nil
 }
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
-	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+	dbUser, err := r.queries.GetUserByEmail(ctx, db.GetUserByEmailParams{
+		TenantID: tenancy.ID(ctx),
+		Email:    email,
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -55,7 +77,42 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	dbUser, err := r.queries.GetUserByID(ctx, id)
+	dbUser, err := r.queries.GetUserByID(ctx, db.GetUserByIDParams{
+		ID:       id,
+		TenantID: tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.dbUserToModel(dbUser), nil
+}
+
+func (r *userRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
+	dbUsers, err := r.queries.ListUsersByIDs(ctx, db.ListUsersByIDsParams{
+		Dollar1:  ids,
+		TenantID: tenancy.ScopeID(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*models.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = r.dbUserToModel(dbUser)
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	dbUser, err := r.queries.GetUserByUsername(ctx, db.GetUserByUsernameParams{
+		TenantID: tenancy.ID(ctx),
+		Lower:    username,
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -73,9 +130,10 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) (*models
 		LastName:  user.LastName,
 		Phone:     user.Phone,
 		AvatarUrl: user.AvatarURL,
+		Username:  user.Username,
 	})
 	if err != nil {
-		return nil, err
+		return nil, mapWriteError(err)
 	}
 
 	return r.dbUserToModel(dbUser), nil
@@ -88,6 +146,27 @@ func (r *userRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 	})
 }
 
+func (r *userRepository) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL *string) error {
+	return r.queries.UpdateUserAvatar(ctx, db.UpdateUserAvatarParams{
+		ID:        id,
+		AvatarUrl: avatarURL,
+	})
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error {
+	return r.queries.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+		ID:   id,
+		Role: db.UserRole(role),
+	})
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	return r.queries.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		ID:           id,
+		PasswordHash: passwordHash,
+	})
+}
+
 func (r *userRepository) List(ctx context.Context, role *models.UserRole, status *models.UserStatus, limit, offset int) ([]*models.User, error) {
 	var dbRole *db.UserRole
 	var dbStatus *db.UserStatus
@@ -102,10 +181,11 @@ func (r *userRepository) List(ctx context.Context, role *models.UserRole, status
 	}
 
 	dbUsers, err := r.queries.ListUsers(ctx, db.ListUsersParams{
-		Role:   dbRole,
-		Status: dbStatus,
-		Limit:  int32(limit),
-		Offset: int32(offset),
+		Role:     dbRole,
+		Status:   dbStatus,
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+		TenantID: tenancy.ID(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -131,6 +211,8 @@ func (r *userRepository) dbUserToModel(dbUser db.User) *models.User {
 		Status:       models.UserStatus(dbUser.Status),
 		AvatarURL:    dbUser.AvatarUrl,
 		Phone:        dbUser.Phone,
+		Username:     dbUser.Username,
+		TenantID:     dbUser.TenantID,
 		CreatedAt:    dbUser.CreatedAt,
 		UpdatedAt:    dbUser.UpdatedAt,
 	}
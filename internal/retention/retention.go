@@ -0,0 +1,143 @@
+// Package retention purges data once it is past its retention period.
+// Policies work in batches with a pause between them, so clearing a
+// backlog never holds locks for long or starves the API of the database.
+// Progress is published at /debug/vars as "retention", keyed by policy.
+package retention
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/config"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// metrics is shared by every purger in the process
+var metrics = expvar.NewMap("retention")
+
+// Policy is one kind of data and how long it is kept
+type Policy struct {
+	Name   string
+	MaxAge time.Duration
+	// Purge handles at most limit rows older than before and returns how
+	// many it handled
+	Purge func(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Purger applies retention policies
+type Purger struct {
+	policies  []Policy
+	batchSize int
+	pause     time.Duration
+	logger    zerolog.Logger
+}
+
+// NewPurger applies the policies cfg enables; a zero age keeps that data
+// forever
+func NewPurger(repo repository.RetentionRepository, cfg config.RetentionConfig, logger zerolog.Logger) *Purger {
+	candidates := []Policy{
+		{Name: "events", MaxAge: cfg.Events, Purge: repo.DeleteEventsBefore},
+		{Name: "outbox", MaxAge: cfg.Outbox, Purge: repo.DeleteSentOutboxBefore},
+		{Name: "audit_ips", MaxAge: cfg.AuditIPs, Purge: repo.AnonymizeAuditIPsBefore},
+		{Name: "push_deliveries", MaxAge: cfg.PushDeliveries, Purge: repo.DeletePushDeliveriesBefore},
+		{Name: "login_locations", MaxAge: cfg.LoginLocations, Purge: repo.DeleteLoginLocationsBefore},
+		{Name: "login_challenges", MaxAge: cfg.ExpiredCodes, Purge: repo.DeleteExpiredLoginChallengesBefore},
+		{Name: "magic_links", MaxAge: cfg.ExpiredCodes, Purge: repo.DeleteExpiredMagicLinksBefore},
+		{Name: "phone_verifications", MaxAge: cfg.ExpiredCodes, Purge: repo.DeleteExpiredPhoneVerificationsBefore},
+		{Name: "oauth_codes", MaxAge: cfg.ExpiredCodes, Purge: repo.DeleteExpiredOAuthCodesBefore},
+		{Name: "oauth_tokens", MaxAge: cfg.ExpiredOAuthTokens, Purge: repo.DeleteExpiredOAuthTokensBefore},
+	}
+
+	var policies []Policy
+	for _, policy := range candidates {
+		if policy.MaxAge > 0 {
+			policies = append(policies, policy)
+		}
+	}
+	return &Purger{
+		policies:  policies,
+		batchSize: cfg.BatchSize,
+		pause:     cfg.BatchPause,
+		logger:    logger,
+	}
+}
+
+// Policies returns the policies being applied
+func (p *Purger) Policies() []Policy {
+	return p.policies
+}
+
+// Run applies every policy until nothing is left past its age, stopping at
+// the first error
+func (p *Purger) Run(ctx context.Context) error {
+	for _, policy := range p.policies {
+		if err := p.apply(ctx, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Purger) apply(ctx context.Context, policy Policy) error {
+	stats := policyStats(policy.Name)
+	start := time.Now()
+	// Fixed for the whole run, so rows ageing in meanwhile wait for the next
+	before := start.Add(-policy.MaxAge)
+
+	var total int64
+	defer func() {
+		stats.Set("last_run_purged", intVar(total))
+		stats.Set("last_run_at", stringVar(start.UTC().Format(time.RFC3339)))
+	}()
+
+	for {
+		purged, err := policy.Purge(ctx, before, p.batchSize)
+		if err != nil {
+			stats.Add("errors", 1)
+			return err
+		}
+		total += purged
+		stats.Add("purged", purged)
+		stats.Add("batches", 1)
+
+		if purged < int64(p.batchSize) {
+			break
+		}
+		p.logger.Debug().Str("policy", policy.Name).Int64("purged", total).Msg("retention batch done")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.pause):
+		}
+	}
+
+	if total > 0 {
+		p.logger.Info().Str("policy", policy.Name).Int64("purged", total).Dur("duration", time.Since(start)).Msg("retention policy applied")
+	}
+	return nil
+}
+
+// policyStats returns name's counters, creating them on first use
+func policyStats(name string) *expvar.Map {
+	if stats, ok := metrics.Get(name).(*expvar.Map); ok {
+		return stats
+	}
+	stats := new(expvar.Map)
+	metrics.Set(name, stats)
+	return stats
+}
+
+func intVar(n int64) *expvar.Int {
+	v := new(expvar.Int)
+	v.Set(n)
+	return v
+}
+
+func stringVar(s string) *expvar.String {
+	v := new(expvar.String)
+	v.Set(s)
+	return v
+}
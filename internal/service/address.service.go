@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// maxAddressesPerUser bounds the address book so it stays a picker, not storage
+const maxAddressesPerUser = 20
+
+type AddressService interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*models.Address, error)
+	Get(ctx context.Context, userID, id uuid.UUID) (*models.Address, error)
+	Create(ctx context.Context, userID uuid.UUID, req *models.AddressRequest) (*models.Address, error)
+	Update(ctx context.Context, userID, id uuid.UUID, req *models.AddressRequest) (*models.Address, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type addressService struct {
+	addressRepo repository.AddressRepository
+	tx          repository.Transactor
+}
+
+func NewAddressService(addressRepo repository.AddressRepository, tx repository.Transactor) AddressService {
+	return &addressService{
+		addressRepo: addressRepo,
+		tx:          tx,
+	}
+}
+
+func (s *addressService) List(ctx context.Context, userID uuid.UUID) ([]*models.Address, error) {
+	addresses, err := s.addressRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+func (s *addressService) Get(ctx context.Context, userID, id uuid.UUID) (*models.Address, error) {
+	address, err := s.addressRepo.GetForUser(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting address: %w", err)
+	}
+	if address == nil {
+		return nil, errors.New("address not found")
+	}
+
+	return address, nil
+}
+
+func (s *addressService) Create(ctx context.Context, userID uuid.UUID, req *models.AddressRequest) (*models.Address, error) {
+	address := addressFromRequest(req)
+	address.UserID = userID
+
+	var createdAddress *models.Address
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Locked so concurrent requests can't both pass the limit
+		if err := s.addressRepo.Lock(ctx, userID); err != nil {
+			return fmt.Errorf("error locking addresses: %w", err)
+		}
+		count, err := s.addressRepo.CountByUser(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error counting addresses: %w", err)
+		}
+		if count >= maxAddressesPerUser {
+			return errors.New("address limit reached")
+		}
+
+		// The first address is the default for everything
+		if count == 0 {
+			address.IsDefaultShipping = true
+			address.IsDefaultBilling = true
+		}
+
+		if err := s.moveDefaults(ctx, address); err != nil {
+			return err
+		}
+		createdAddress, err = s.addressRepo.Create(ctx, address)
+		if err != nil {
+			return fmt.Errorf("error creating address: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdAddress, nil
+}
+
+func (s *addressService) Update(ctx context.Context, userID, id uuid.UUID, req *models.AddressRequest) (*models.Address, error) {
+	address := addressFromRequest(req)
+	address.ID = id
+	address.UserID = userID
+
+	var updatedAddress *models.Address
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.addressRepo.Lock(ctx, userID); err != nil {
+			return fmt.Errorf("error locking addresses: %w", err)
+		}
+		if err := s.moveDefaults(ctx, address); err != nil {
+			return err
+		}
+
+		var err error
+		updatedAddress, err = s.addressRepo.Update(ctx, address)
+		if err != nil {
+			return fmt.Errorf("error updating address: %w", err)
+		}
+		if updatedAddress == nil {
+			// Rolls back the defaults cleared for it
+			return errors.New("address not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedAddress, nil
+}
+
+func (s *addressService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	deleted, err := s.addressRepo.Delete(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting address: %w", err)
+	}
+	if !deleted {
+		return errors.New("address not found")
+	}
+
+	return nil
+}
+
+// moveDefaults clears the default flags address is taking from the user's
+// other addresses. It runs before address is written, since the unique
+// indexes allow only one default of each kind at a time; a new address has
+// no ID yet, so every other address is cleared.
+func (s *addressService) moveDefaults(ctx context.Context, address *models.Address) error {
+	if address.IsDefaultShipping {
+		if err := s.addressRepo.ClearOtherDefaultShipping(ctx, address.UserID, address.ID); err != nil {
+			return fmt.Errorf("error updating default shipping address: %w", err)
+		}
+	}
+	if address.IsDefaultBilling {
+		if err := s.addressRepo.ClearOtherDefaultBilling(ctx, address.UserID, address.ID); err != nil {
+			return fmt.Errorf("error updating default billing address: %w", err)
+		}
+	}
+	return nil
+}
+
+func addressFromRequest(req *models.AddressRequest) *models.Address {
+	address := &models.Address{
+		RecipientName:     req.RecipientName,
+		Line1:             req.Line1,
+		City:              req.City,
+		PostalCode:        strings.ToUpper(strings.TrimSpace(req.PostalCode)),
+		CountryCode:       req.CountryCode,
+		IsDefaultShipping: req.IsDefaultShipping,
+		IsDefaultBilling:  req.IsDefaultBilling,
+	}
+	if req.Label != "" {
+		address.Label = &req.Label
+	}
+	if req.Line2 != "" {
+		address.Line2 = &req.Line2
+	}
+	if req.Region != "" {
+		address.Region = &req.Region
+	}
+	if req.Phone != "" {
+		address.Phone = &req.Phone
+	}
+	return address
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// maxAnalyticsRange bounds a single query so daily buckets stay reasonable
+const maxAnalyticsRange = 2 * 366 * 24 * time.Hour
+
+type AnalyticsService interface {
+	SignupsOverTime(ctx context.Context, rng models.AnalyticsRange) ([]*models.SignupBucket, error)
+}
+
+type analyticsService struct {
+	analyticsRepo repository.AnalyticsRepository
+}
+
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+func (s *analyticsService) SignupsOverTime(ctx context.Context, rng models.AnalyticsRange) ([]*models.SignupBucket, error) {
+	if !rng.From.Before(rng.To) {
+		return nil, errors.New("invalid range: from must be before to")
+	}
+	if rng.To.Sub(rng.From) > maxAnalyticsRange {
+		return nil, errors.New("invalid range: at most two years can be queried at once")
+	}
+
+	buckets, err := s.analyticsRepo.SignupsOverTime(ctx, rng)
+	if err != nil {
+		return nil, fmt.Errorf("error counting signups: %w", err)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type ConsentService interface {
+	// Publish adds a new version of a legal document, effective now or later
+	Publish(ctx context.Context, req *models.PublishLegalDocumentRequest, actor models.Actor) (*models.LegalDocument, error)
+	ListDocuments(ctx context.Context) ([]*models.LegalDocument, error)
+	// ListCurrent returns the version of each document in force right now
+	ListCurrent(ctx context.Context) ([]*models.LegalDocument, error)
+	// Pending returns the mandatory versions the user must accept before
+	// using the API; it is checked on every authenticated request
+	Pending(ctx context.Context, userID uuid.UUID) ([]*models.LegalDocument, error)
+	Status(ctx context.Context, userID uuid.UUID) (*models.ConsentStatus, error)
+	Accept(ctx context.Context, userID, documentID uuid.UUID, ipAddress string) (*models.ConsentAcceptance, error)
+}
+
+type consentService struct {
+	consentRepo repository.ConsentRepository
+	auditRepo   repository.AuditRepository
+	tx          repository.Transactor
+}
+
+func NewConsentService(consentRepo repository.ConsentRepository, auditRepo repository.AuditRepository, tx repository.Transactor) ConsentService {
+	return &consentService{
+		consentRepo: consentRepo,
+		auditRepo:   auditRepo,
+		tx:          tx,
+	}
+}
+
+func (s *consentService) Publish(ctx context.Context, req *models.PublishLegalDocumentRequest, actor models.Actor) (*models.LegalDocument, error) {
+	effectiveAt := time.Now()
+	if req.EffectiveAt != nil {
+		// Backdating would put users in breach of terms they never saw
+		if req.EffectiveAt.Before(effectiveAt) {
+			return nil, errors.New("invalid effective date: effective_at must not be in the past")
+		}
+		effectiveAt = *req.EffectiveAt
+	}
+
+	var doc *models.LegalDocument
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		doc, err = s.consentRepo.CreateDocument(ctx, &models.LegalDocument{
+			Kind:        req.Kind,
+			Version:     req.Version,
+			URL:         req.URL,
+			Mandatory:   *req.Mandatory,
+			EffectiveAt: effectiveAt,
+			PublishedBy: actor.UserID,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return fmt.Errorf("legal document version already published: %w", err)
+			}
+			return fmt.Errorf("error creating legal document: %w", err)
+		}
+
+		entry := &models.AuditLog{
+			ActorID:    actor.UserID,
+			Action:     models.AuditLegalPublished,
+			TargetType: "legal_document",
+			TargetID:   doc.ID,
+			Metadata: map[string]interface{}{
+				"kind":         string(doc.Kind),
+				"version":      doc.Version,
+				"mandatory":    doc.Mandatory,
+				"effective_at": doc.EffectiveAt.UTC().Format(time.RFC3339),
+			},
+		}
+		if actor.IPAddress != "" {
+			entry.IPAddress = &actor.IPAddress
+		}
+		if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+			return fmt.Errorf("error writing audit log: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (s *consentService) ListDocuments(ctx context.Context) ([]*models.LegalDocument, error) {
+	docs, err := s.consentRepo.ListDocuments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing legal documents: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (s *consentService) ListCurrent(ctx context.Context) ([]*models.LegalDocument, error) {
+	docs, err := s.consentRepo.ListCurrent(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing current legal documents: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (s *consentService) Pending(ctx context.Context, userID uuid.UUID) ([]*models.LegalDocument, error) {
+	pending, err := s.consentRepo.ListPending(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pending legal documents: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (s *consentService) Status(ctx context.Context, userID uuid.UUID) (*models.ConsentStatus, error) {
+	pending, err := s.Pending(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := s.consentRepo.ListAcceptances(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing consent acceptances: %w", err)
+	}
+
+	return &models.ConsentStatus{Pending: pending, Accepted: accepted}, nil
+}
+
+func (s *consentService) Accept(ctx context.Context, userID, documentID uuid.UUID, ipAddress string) (*models.ConsentAcceptance, error) {
+	doc, err := s.consentRepo.GetDocument(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting legal document: %w", err)
+	}
+	if doc == nil {
+		return nil, errors.New("legal document not found")
+	}
+
+	var ip *string
+	if ipAddress != "" {
+		ip = &ipAddress
+	}
+	acceptedAt, err := s.consentRepo.Accept(ctx, userID, doc.ID, ip)
+	if err != nil {
+		return nil, fmt.Errorf("error recording consent: %w", err)
+	}
+
+	return &models.ConsentAcceptance{
+		DocumentID: doc.ID,
+		Kind:       doc.Kind,
+		Version:    doc.Version,
+		IPAddress:  ip,
+		AcceptedAt: acceptedAt,
+	}, nil
+}
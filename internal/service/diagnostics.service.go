@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type DiagnosticsService interface {
+	// ListQueryPlans returns a page of plans and how many match in all
+	ListQueryPlans(ctx context.Context, regression *bool, page, limit int) ([]*models.QueryPlan, int, error)
+}
+
+type diagnosticsService struct {
+	queryPlanRepo repository.QueryPlanRepository
+}
+
+func NewDiagnosticsService(queryPlanRepo repository.QueryPlanRepository) DiagnosticsService {
+	return &diagnosticsService{
+		queryPlanRepo: queryPlanRepo,
+	}
+}
+
+func (s *diagnosticsService) ListQueryPlans(ctx context.Context, regression *bool, page, limit int) ([]*models.QueryPlan, int, error) {
+	offset := (page - 1) * limit
+
+	plans, err := s.queryPlanRepo.List(ctx, regression, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing query plans: %w", err)
+	}
+	total, err := s.queryPlanRepo.Count(ctx, regression)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting query plans: %w", err)
+	}
+
+	return plans, total, nil
+}
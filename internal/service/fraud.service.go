@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/fraud"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type FraudService interface {
+	// AssessSignup scores a signup without storing it; it returns nil when
+	// fraud checks are disabled
+	AssessSignup(ctx context.Context, email, ipAddress string) (*models.FraudAssessment, error)
+	Record(ctx context.Context, assessment *models.FraudAssessment) (*models.FraudAssessment, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.FraudAssessment, error)
+}
+
+type fraudService struct {
+	fraudRepo repository.FraudRepository
+	userRepo  repository.UserRepository
+	pipeline  *fraud.Pipeline
+}
+
+// NewFraudService scores signups with pipeline; a nil pipeline disables
+// the checks while keeping stored assessments readable
+func NewFraudService(fraudRepo repository.FraudRepository, userRepo repository.UserRepository, pipeline *fraud.Pipeline) FraudService {
+	return &fraudService{
+		fraudRepo: fraudRepo,
+		userRepo:  userRepo,
+		pipeline:  pipeline,
+	}
+}
+
+func (s *fraudService) AssessSignup(ctx context.Context, email, ipAddress string) (*models.FraudAssessment, error) {
+	if s.pipeline == nil {
+		return nil, nil
+	}
+
+	assessment, err := s.pipeline.AssessSignup(ctx, fraud.Signup{Email: email, IPAddress: ipAddress})
+	if err != nil {
+		return nil, fmt.Errorf("error assessing signup: %w", err)
+	}
+
+	return assessment, nil
+}
+
+func (s *fraudService) Record(ctx context.Context, assessment *models.FraudAssessment) (*models.FraudAssessment, error) {
+	recorded, err := s.fraudRepo.Create(ctx, assessment)
+	if err != nil {
+		return nil, fmt.Errorf("error recording fraud assessment: %w", err)
+	}
+
+	return recorded, nil
+}
+
+func (s *fraudService) ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.FraudAssessment, error) {
+	// Scoped to the request's tenant, so one store can't read another's
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	assessments, err := s.fraudRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing fraud assessments: %w", err)
+	}
+
+	return assessments, nil
+}
@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/geoip"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+const (
+	// minTravelDistanceKm ignores jumps within GeoIP's margin of error,
+	// which easily reaches a few hundred kilometres for mobile carriers
+	minTravelDistanceKm = 500
+	// maxChallengeAttempts is how many wrong codes burn a challenge
+	maxChallengeAttempts = 5
+	// maxDeviceNameLength matches devices.name
+	maxDeviceNameLength = 255
+)
+
+// LoginPolicy decides what counts as a suspicious login and what happens to it
+type LoginPolicy struct {
+	// MaxTravelSpeed, in km/h, flags consecutive logins further apart than
+	// anyone could travel in the time between them
+	MaxTravelSpeed float64
+	// StepUp holds suspicious logins back until the user enters a code
+	// sent to them; otherwise they only get an alert
+	StepUp       bool
+	ChallengeTTL time.Duration
+	// MaxChallengesPerWindow challenges are raised for one account per
+	// ChallengeWindow; further suspicious logins are refused, since each
+	// challenge is another round of guesses at a code. 0 means no limit.
+	MaxChallengesPerWindow int
+	ChallengeWindow        time.Duration
+}
+
+type LoginSecurityService interface {
+	// CheckLogin records where and on which device a successful login
+	// happened and alerts the user when it looks suspicious. A returned
+	// challenge means no token may be issued until VerifyChallenge succeeds.
+	CheckLogin(ctx context.Context, attempt models.LoginAttempt) (*models.LoginChallenge, error)
+	// VerifyChallenge checks a step-up code and returns the user it was for.
+	// With trustDevice the device that logged in skips step-up from then on.
+	VerifyChallenge(ctx context.Context, challengeID uuid.UUID, code string, trustDevice bool) (uuid.UUID, error)
+	ListLocations(ctx context.Context, userID uuid.UUID, limit int) ([]*models.LoginLocation, error)
+	ListDevices(ctx context.Context, userID uuid.UUID) ([]*models.Device, error)
+	// RevokeDevice forgets a device, so its next login counts as a new device
+	RevokeDevice(ctx context.Context, userID, deviceID uuid.UUID) error
+}
+
+type loginSecurityService struct {
+	loginRepo  repository.LoginRepository
+	deviceRepo repository.DeviceRepository
+	locator    geoip.Locator
+	notifier   notification.Notifier
+	tx         repository.Transactor
+	policy     LoginPolicy
+}
+
+// NewLoginSecurityService checks logins against their location and device
+// history; a nil locator turns the location checks off
+func NewLoginSecurityService(loginRepo repository.LoginRepository, deviceRepo repository.DeviceRepository, locator geoip.Locator, notifier notification.Notifier, tx repository.Transactor, policy LoginPolicy) LoginSecurityService {
+	return &loginSecurityService{
+		loginRepo:  loginRepo,
+		deviceRepo: deviceRepo,
+		locator:    locator,
+		notifier:   notifier,
+		tx:         tx,
+		policy:     policy,
+	}
+}
+
+func (s *loginSecurityService) CheckLogin(ctx context.Context, attempt models.LoginAttempt) (*models.LoginChallenge, error) {
+	device, newDevice, err := s.trackDevice(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if (s.locator == nil && device == nil) || attempt.IPAddress == "" {
+		return nil, nil
+	}
+
+	location := &models.LoginLocation{UserID: attempt.UserID, IPAddress: attempt.IPAddress, Verified: true}
+	if newDevice {
+		anomaly := models.LoginNewDevice
+		location.Anomaly = &anomaly
+	}
+
+	// A lookup outage must not lock users out; the login is kept without a place
+	if place, err := s.locate(ctx, attempt.IPAddress); err == nil && place != nil {
+		location.CountryCode = &place.CountryCode
+		location.Country = &place.Country
+		location.City = &place.City
+		location.Latitude = &place.Latitude
+		location.Longitude = &place.Longitude
+
+		// Where the login came from says more than which device it used
+		anomaly, err := s.detectAnomaly(ctx, attempt.UserID, place)
+		if err != nil {
+			return nil, err
+		}
+		if anomaly != nil {
+			location.Anomaly = anomaly
+		}
+	}
+
+	// A trusted device still gets the alert, just not the code
+	if location.Anomaly == nil || !s.policy.StepUp || (device != nil && device.Trusted) {
+		recorded, err := s.loginRepo.CreateLocation(ctx, location)
+		if err != nil {
+			return nil, fmt.Errorf("error recording login location: %w", err)
+		}
+		if recorded.Anomaly != nil {
+			s.alert(ctx, recorded, device)
+		}
+		return nil, nil
+	}
+
+	return s.challenge(ctx, location, device)
+}
+
+func (s *loginSecurityService) locate(ctx context.Context, ipAddress string) (*geoip.Location, error) {
+	if s.locator == nil {
+		return nil, nil
+	}
+	return s.locator.Locate(ctx, ipAddress)
+}
+
+// trackDevice records the device the login came from. newDevice is true
+// for a device the user never logged in from, except their first one.
+func (s *loginSecurityService) trackDevice(ctx context.Context, attempt models.LoginAttempt) (device *models.Device, newDevice bool, err error) {
+	if attempt.DeviceID == "" {
+		return nil, false, nil
+	}
+
+	name := attempt.DeviceName
+	if name == "" {
+		name = "Unknown device"
+	}
+	if runes := []rune(name); len(runes) > maxDeviceNameLength {
+		name = string(runes[:maxDeviceNameLength])
+	}
+	seen := &models.Device{
+		UserID:      attempt.UserID,
+		Fingerprint: fingerprintDevice(attempt.DeviceID),
+		Name:        name,
+	}
+	if attempt.IPAddress != "" {
+		seen.LastIP = &attempt.IPAddress
+	}
+
+	device, created, err := s.deviceRepo.Touch(ctx, seen)
+	if err != nil {
+		return nil, false, fmt.Errorf("error recording login device: %w", err)
+	}
+	if !created {
+		return device, false, nil
+	}
+
+	count, err := s.deviceRepo.CountByUser(ctx, attempt.UserID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error counting login devices: %w", err)
+	}
+	return device, count > 1, nil
+}
+
+// detectAnomaly compares a login with the user's verified history. The
+// first located login sets the baseline and is never suspicious.
+func (s *loginSecurityService) detectAnomaly(ctx context.Context, userID uuid.UUID, place *geoip.Location) (*models.LoginAnomaly, error) {
+	last, err := s.loginRepo.LastLocation(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting last login location: %w", err)
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	if last.Latitude != nil && last.Longitude != nil && s.policy.MaxTravelSpeed > 0 {
+		distance := geoip.DistanceKm(*last.Latitude, *last.Longitude, place.Latitude, place.Longitude)
+		hours := time.Since(last.CreatedAt).Hours()
+		if distance >= minTravelDistanceKm && distance > s.policy.MaxTravelSpeed*hours {
+			anomaly := models.LoginImpossibleTravel
+			return &anomaly, nil
+		}
+	}
+
+	seen, err := s.loginRepo.CountInCountry(ctx, userID, place.CountryCode)
+	if err != nil {
+		return nil, fmt.Errorf("error counting logins in country: %w", err)
+	}
+	if seen == 0 {
+		anomaly := models.LoginNewCountry
+		return &anomaly, nil
+	}
+	return nil, nil
+}
+
+// challenge records the login as unverified and sends the user a code
+func (s *loginSecurityService) challenge(ctx context.Context, location *models.LoginLocation, device *models.Device) (*models.LoginChallenge, error) {
+	if s.policy.MaxChallengesPerWindow > 0 {
+		raised, err := s.loginRepo.CountChallengesSince(ctx, location.UserID, time.Now().Add(-s.policy.ChallengeWindow))
+		if err != nil {
+			return nil, fmt.Errorf("error counting login challenges: %w", err)
+		}
+		if raised >= s.policy.MaxChallengesPerWindow {
+			return nil, errors.New("too many login challenges")
+		}
+	}
+
+	code, err := generateLoginCode()
+	if err != nil {
+		return nil, fmt.Errorf("error generating login code: %w", err)
+	}
+	location.Verified = false
+
+	var challenge *models.LoginChallenge
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		recorded, err := s.loginRepo.CreateLocation(ctx, location)
+		if err != nil {
+			return fmt.Errorf("error recording login location: %w", err)
+		}
+		pending := &models.LoginChallenge{
+			UserID:     recorded.UserID,
+			LocationID: recorded.ID,
+			CodeHash:   hashToken(code),
+			ExpiresAt:  time.Now().Add(s.policy.ChallengeTTL),
+		}
+		if device != nil {
+			pending.DeviceID = &device.ID
+		}
+		challenge, err = s.loginRepo.CreateChallenge(ctx, pending)
+		if err != nil {
+			return fmt.Errorf("error creating login challenge: %w", err)
+		}
+		location = recorded
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike an alert, the login cannot go ahead if the code never arrives
+	if err := s.notifier.Notify(ctx, location.UserID, notification.Notification{
+		Type:  "login_verification",
+		Title: "Confirm your sign-in",
+		Body:  fmt.Sprintf("Someone signed in to your account from %s. If it was you, enter code %s to continue.", describeLogin(location, device), code),
+		Data:  locationData(location, device),
+	}); err != nil {
+		return nil, fmt.Errorf("error sending login code: %w", err)
+	}
+
+	return challenge, nil
+}
+
+func (s *loginSecurityService) alert(ctx context.Context, location *models.LoginLocation, device *models.Device) {
+	// Delivery failures must not fail a login that already succeeded
+	_ = s.notifier.Notify(ctx, location.UserID, notification.Notification{
+		Type:  "suspicious_login",
+		Title: "New sign-in to your account",
+		Body:  fmt.Sprintf("Your account was signed in to from %s. If this wasn't you, change your password.", describeLogin(location, device)),
+		Data:  locationData(location, device),
+	})
+}
+
+func (s *loginSecurityService) VerifyChallenge(ctx context.Context, challengeID uuid.UUID, code string, trustDevice bool) (uuid.UUID, error) {
+	challenge, err := s.loginRepo.GetChallenge(ctx, challengeID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error getting login challenge: %w", err)
+	}
+	if challenge == nil {
+		return uuid.Nil, errors.New("login challenge not found")
+	}
+	if challenge.VerifiedAt != nil || challenge.Attempts >= maxChallengeAttempts || time.Now().After(challenge.ExpiresAt) {
+		return uuid.Nil, errors.New("login challenge expired or already used")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(code)), []byte(challenge.CodeHash)) != 1 {
+		if _, err := s.loginRepo.IncrementAttempts(ctx, challenge.ID); err != nil {
+			return uuid.Nil, fmt.Errorf("error recording login attempt: %w", err)
+		}
+		return uuid.Nil, errors.New("invalid verification code")
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Conditional, so two requests racing with the same code can't both pass
+		completed, err := s.loginRepo.CompleteChallenge(ctx, challenge.ID, maxChallengeAttempts)
+		if err != nil {
+			return fmt.Errorf("error completing login challenge: %w", err)
+		}
+		if completed == nil {
+			return errors.New("login challenge expired or already used")
+		}
+		if err := s.loginRepo.VerifyLocation(ctx, completed.LocationID); err != nil {
+			return fmt.Errorf("error verifying login location: %w", err)
+		}
+		// The device is gone if it was revoked while the code was in flight
+		if trustDevice && completed.DeviceID != nil {
+			if err := s.deviceRepo.Trust(ctx, *completed.DeviceID); err != nil {
+				return fmt.Errorf("error trusting login device: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return challenge.UserID, nil
+}
+
+func (s *loginSecurityService) ListLocations(ctx context.Context, userID uuid.UUID, limit int) ([]*models.LoginLocation, error) {
+	locations, err := s.loginRepo.ListLocations(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing login locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+func (s *loginSecurityService) ListDevices(ctx context.Context, userID uuid.UUID) ([]*models.Device, error) {
+	devices, err := s.deviceRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing login devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+func (s *loginSecurityService) RevokeDevice(ctx context.Context, userID, deviceID uuid.UUID) error {
+	deleted, err := s.deviceRepo.Delete(ctx, deviceID, userID)
+	if err != nil {
+		return fmt.Errorf("error revoking login device: %w", err)
+	}
+	if !deleted {
+		return errors.New("device not found")
+	}
+
+	return nil
+}
+
+// generateLoginCode returns a random six-digit code
+func generateLoginCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashToken is how one-time codes and tokens are stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprintDevice hashes what the client sent so raw device IDs are
+// never stored
+func fingerprintDevice(deviceID string) string {
+	sum := sha256.Sum256([]byte(deviceID))
+	return hex.EncodeToString(sum[:])
+}
+
+func describeLogin(location *models.LoginLocation, device *models.Device) string {
+	place := location.IPAddress
+	if location.Country != nil {
+		place = *location.Country
+		if location.City != nil && *location.City != "" {
+			place = *location.City + ", " + place
+		}
+		place += " (" + location.IPAddress + ")"
+	}
+	if device != nil {
+		place += " on " + device.Name
+	}
+	return place
+}
+
+func locationData(location *models.LoginLocation, device *models.Device) map[string]string {
+	data := map[string]string{
+		"login_location_id": location.ID.String(),
+		"ip_address":        location.IPAddress,
+	}
+	if location.CountryCode != nil {
+		data["country_code"] = *location.CountryCode
+	}
+	if location.Anomaly != nil {
+		data["anomaly"] = string(*location.Anomaly)
+	}
+	if device != nil {
+		data["device_id"] = device.ID.String()
+	}
+	return data
+}
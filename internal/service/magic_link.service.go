@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// magicLinkTokenBytes is the token's entropy before encoding
+const magicLinkTokenBytes = 32
+
+// MagicLinkPolicy decides where sign-in links point and how often they go out
+type MagicLinkPolicy struct {
+	// URL is where the link lands; the token is added as the token parameter
+	URL string
+	TTL time.Duration
+	// MaxPerWindow links are sent to one account per Window; further
+	// requests are dropped so the address can't be flooded
+	MaxPerWindow int
+	Window       time.Duration
+}
+
+type MagicLinkService interface {
+	// Send emails a sign-in link to the active account with the address.
+	// It reports nothing about whether one exists or was rate limited, so
+	// callers can't probe for accounts.
+	Send(ctx context.Context, email, ipAddress string) error
+	// Redeem uses up a link and returns the user it was sent to
+	Redeem(ctx context.Context, token string) (uuid.UUID, error)
+}
+
+type magicLinkService struct {
+	linkRepo repository.MagicLinkRepository
+	userRepo repository.UserRepository
+	notifier notification.Notifier
+	policy   MagicLinkPolicy
+}
+
+func NewMagicLinkService(linkRepo repository.MagicLinkRepository, userRepo repository.UserRepository, notifier notification.Notifier, policy MagicLinkPolicy) MagicLinkService {
+	return &magicLinkService{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+		notifier: notifier,
+		policy:   policy,
+	}
+}
+
+func (s *magicLinkService) Send(ctx context.Context, email, ipAddress string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil || user.Status != models.StatusActive {
+		return nil
+	}
+
+	if s.policy.MaxPerWindow > 0 {
+		sent, err := s.linkRepo.CountSince(ctx, user.ID, time.Now().Add(-s.policy.Window))
+		if err != nil {
+			return fmt.Errorf("error counting magic links: %w", err)
+		}
+		if sent >= s.policy.MaxPerWindow {
+			return nil
+		}
+	}
+
+	token, err := generateMagicLinkToken()
+	if err != nil {
+		return fmt.Errorf("error generating magic link token: %w", err)
+	}
+	link := &models.MagicLink{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.policy.TTL),
+	}
+	if ipAddress != "" {
+		link.IPAddress = &ipAddress
+	}
+	link, err = s.linkRepo.Create(ctx, link)
+	if err != nil {
+		return fmt.Errorf("error creating magic link: %w", err)
+	}
+
+	signInURL, err := s.linkURL(token)
+	if err != nil {
+		return err
+	}
+	if err := s.notifier.Notify(ctx, user.ID, notification.Notification{
+		Type:  "magic_link",
+		Title: "Your sign-in link",
+		Body:  fmt.Sprintf("Use this link to sign in: %s\n\nIt works once and expires in %d minutes. If you didn't ask for it, ignore this email.", signInURL, int(s.policy.TTL.Minutes())),
+		Data: map[string]string{
+			"magic_link_id": link.ID.String(),
+		},
+	}); err != nil {
+		return fmt.Errorf("error sending magic link: %w", err)
+	}
+
+	return nil
+}
+
+func (s *magicLinkService) linkURL(token string) (string, error) {
+	u, err := url.Parse(s.policy.URL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing magic link URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func (s *magicLinkService) Redeem(ctx context.Context, token string) (uuid.UUID, error) {
+	// Conditional, so two requests racing with the same link can't both pass
+	link, err := s.linkRepo.Redeem(ctx, hashToken(token))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error redeeming magic link: %w", err)
+	}
+	if link == nil {
+		return uuid.Nil, errors.New("magic link invalid, expired or already used")
+	}
+
+	return link.UserID, nil
+}
+
+func generateMagicLinkToken() (string, error) {
+	b := make([]byte, magicLinkTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/moderation"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type ModerationService interface {
+	Submit(ctx context.Context, subject models.ModerationSubject, subjectID, submittedBy uuid.UUID, content string) (*models.ModerationItem, error)
+	List(ctx context.Context, status *models.ModerationStatus, subject *models.ModerationSubject, page, limit int) ([]*models.ModerationItem, error)
+	Approve(ctx context.Context, id uuid.UUID, reviewerID *uuid.UUID) (*models.ModerationItem, error)
+	Reject(ctx context.Context, id uuid.UUID, reviewerID *uuid.UUID, reason string) (*models.ModerationItem, error)
+}
+
+type moderationService struct {
+	moderationRepo repository.ModerationRepository
+	userRepo       repository.UserRepository
+	screeners      []moderation.Screener
+	notifier       notification.Notifier
+}
+
+func NewModerationService(moderationRepo repository.ModerationRepository, userRepo repository.UserRepository, notifier notification.Notifier, screeners ...moderation.Screener) ModerationService {
+	return &moderationService{
+		moderationRepo: moderationRepo,
+		userRepo:       userRepo,
+		screeners:      screeners,
+		notifier:       notifier,
+	}
+}
+
+func (s *moderationService) Submit(ctx context.Context, subject models.ModerationSubject, subjectID, submittedBy uuid.UUID, content string) (*models.ModerationItem, error) {
+	item := &models.ModerationItem{
+		SubjectType: subject,
+		SubjectID:   subjectID,
+		SubmittedBy: submittedBy,
+		Content:     content,
+		Status:      models.ModerationPending,
+	}
+
+	// Run automated pre-screening; the first rejection wins
+	for _, screener := range s.screeners {
+		result, err := screener.Screen(ctx, subject, content)
+		if err != nil {
+			return nil, fmt.Errorf("error pre-screening content: %w", err)
+		}
+		if result.Reject {
+			item.Status = models.ModerationRejected
+			item.RejectionReason = &result.Reason
+			break
+		}
+	}
+
+	createdItem, err := s.moderationRepo.Create(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("error queueing content for moderation: %w", err)
+	}
+
+	if createdItem.Status == models.ModerationRejected {
+		s.notifyRejection(ctx, createdItem)
+	}
+
+	return createdItem, nil
+}
+
+func (s *moderationService) List(ctx context.Context, status *models.ModerationStatus, subject *models.ModerationSubject, page, limit int) ([]*models.ModerationItem, error) {
+	offset := (page - 1) * limit
+
+	items, err := s.moderationRepo.List(ctx, status, subject, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error listing moderation items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *moderationService) Approve(ctx context.Context, id uuid.UUID, reviewerID *uuid.UUID) (*models.ModerationItem, error) {
+	if err := s.checkLatest(ctx, id); err != nil {
+		return nil, err
+	}
+
+	item, err := s.review(ctx, id, models.ModerationApproved, nil, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, item); err != nil {
+		return nil, fmt.Errorf("error applying approved content: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *moderationService) Reject(ctx context.Context, id uuid.UUID, reviewerID *uuid.UUID, reason string) (*models.ModerationItem, error) {
+	item, err := s.review(ctx, id, models.ModerationRejected, &reason, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRejection(ctx, item)
+
+	return item, nil
+}
+
+func (s *moderationService) review(ctx context.Context, id uuid.UUID, status models.ModerationStatus, reason *string, reviewerID *uuid.UUID) (*models.ModerationItem, error) {
+	item, err := s.moderationRepo.Review(ctx, id, status, reason, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing moderation item: %w", err)
+	}
+	if item != nil {
+		return item, nil
+	}
+
+	// Distinguish a missing item from one another admin already decided
+	existing, err := s.moderationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting moderation item: %w", err)
+	}
+	if existing == nil {
+		return nil, errors.New("moderation item not found")
+	}
+	return nil, errors.New("moderation item already reviewed")
+}
+
+// checkLatest refuses to approve an avatar someone has since replaced,
+// which would overwrite the newer one once applied. It can still be
+// rejected.
+func (s *moderationService) checkLatest(ctx context.Context, id uuid.UUID) error {
+	item, err := s.moderationRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error getting moderation item: %w", err)
+	}
+	if item == nil || item.SubjectType != models.SubjectAvatar {
+		return nil
+	}
+
+	latest, err := s.moderationRepo.GetLatest(ctx, item.SubjectType, item.SubjectID)
+	if err != nil {
+		return fmt.Errorf("error getting latest submission: %w", err)
+	}
+	if latest != nil && latest.ID != item.ID {
+		return errors.New("moderation item superseded by a newer submission")
+	}
+	return nil
+}
+
+// apply makes approved content visible on its subject
+func (s *moderationService) apply(ctx context.Context, item *models.ModerationItem) error {
+	switch item.SubjectType {
+	case models.SubjectAvatar:
+		return s.userRepo.UpdateAvatar(ctx, item.SubjectID, &item.Content)
+	case models.SubjectSignup:
+		user, err := s.userRepo.GetByID(ctx, item.SubjectID)
+		if err != nil || user == nil {
+			return err
+		}
+		// An account suspended or deleted while waiting stays as it is
+		if user.Status != models.StatusInactive {
+			return nil
+		}
+		return s.userRepo.UpdateStatus(ctx, user.ID, models.StatusActive)
+	default:
+		return fmt.Errorf("unknown moderation subject %q", item.SubjectType)
+	}
+}
+
+func (s *moderationService) notifyRejection(ctx context.Context, item *models.ModerationItem) {
+	reason := ""
+	if item.RejectionReason != nil {
+		reason = *item.RejectionReason
+	}
+
+	// Delivery failures must not undo the moderation decision
+	_ = s.notifier.Notify(ctx, item.SubmittedBy, notification.Notification{
+		Type:  "moderation_rejected",
+		Title: fmt.Sprintf("Your %s was rejected", item.SubjectType),
+		Body:  reason,
+		Data: map[string]string{
+			"moderation_item_id": item.ID.String(),
+			"subject_type":       string(item.SubjectType),
+		},
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type NotificationService interface {
+	// RegisterPushToken registers an app install for push notifications.
+	// Past the limit, the user's least recently registered installs are
+	// forgotten.
+	RegisterPushToken(ctx context.Context, userID uuid.UUID, req *models.RegisterPushTokenRequest) (*models.PushToken, error)
+	ListPushTokens(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error)
+	DeletePushToken(ctx context.Context, userID, id uuid.UUID) error
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error)
+	// ListDeliveries returns the pushes sent to the user, newest first
+	ListDeliveries(ctx context.Context, userID uuid.UUID, page, limit int) ([]*models.PushDelivery, error)
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	tx               repository.Transactor
+	maxTokens        int
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository, userRepo repository.UserRepository, tx repository.Transactor, maxTokens int) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		tx:               tx,
+		maxTokens:        maxTokens,
+	}
+}
+
+func (s *notificationService) RegisterPushToken(ctx context.Context, userID uuid.UUID, req *models.RegisterPushTokenRequest) (*models.PushToken, error) {
+	var token *models.PushToken
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		token, err = s.notificationRepo.UpsertPushToken(ctx, &models.PushToken{
+			UserID:     userID,
+			Platform:   req.Platform,
+			Token:      req.Token,
+			DeviceName: req.DeviceName,
+		})
+		if err != nil {
+			return fmt.Errorf("error registering push token: %w", err)
+		}
+
+		if _, err := s.notificationRepo.TrimPushTokens(ctx, userID, s.maxTokens); err != nil {
+			return fmt.Errorf("error trimming push tokens: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *notificationService) ListPushTokens(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error) {
+	tokens, err := s.notificationRepo.ListPushTokens(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing push tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *notificationService) DeletePushToken(ctx context.Context, userID, id uuid.UUID) error {
+	deleted, err := s.notificationRepo.DeletePushToken(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting push token: %w", err)
+	}
+	if !deleted {
+		return errors.New("push token not found")
+	}
+
+	return nil
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	prefs, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting notification preferences: %w", err)
+	}
+	if prefs == nil {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+
+	return prefs, nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	prefs, err := s.notificationRepo.UpsertPreferences(ctx, &models.NotificationPreferences{
+		UserID: userID,
+		Push:   *req.Push,
+		Email:  *req.Email,
+		InApp:  *req.InApp,
+		SMS:    req.SMS != nil && *req.SMS,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (s *notificationService) ListDeliveries(ctx context.Context, userID uuid.UUID, page, limit int) ([]*models.PushDelivery, error) {
+	// Scoped to the request's tenant, so one store can't read another's
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	deliveries, err := s.notificationRepo.ListDeliveries(ctx, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing push deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,664 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// oauthTokenBytes is the entropy of client secrets, codes and tokens
+// before encoding
+const oauthTokenBytes = 32
+
+// OAuthPolicy decides how long what the authorization server issues lasts
+type OAuthPolicy struct {
+	CodeTTL         time.Duration
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// OAuthError is a token, introspection or revocation endpoint failure. Code
+// is the RFC 6749 error code, such as invalid_grant, reported to the client.
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// errRefreshTokenInvalid is a refresh token that can't be exchanged, which
+// may be a rotated one being replayed
+var errRefreshTokenInvalid = errors.New("refresh token invalid")
+
+type OAuthService interface {
+	// RegisterClient returns the client with its secret, which is shown
+	// only this once; public clients get none
+	RegisterClient(ctx context.Context, req *models.CreateOAuthClientRequest, actor models.Actor) (*models.RegisteredOAuthClient, error)
+	ListClients(ctx context.Context) ([]*models.OAuthClient, error)
+	DeleteClient(ctx context.Context, id uuid.UUID, actor models.Actor) error
+	// Authorize checks an authorization request for the signed-in user and
+	// returns the consent screen, or where to redirect when it can't go ahead
+	Authorize(ctx context.Context, userID uuid.UUID, req *models.OAuthAuthorizeRequest) (*models.OAuthAuthorization, error)
+	// Decide records the user's answer and returns where to redirect, with
+	// an authorization code when they approved
+	Decide(ctx context.Context, userID uuid.UUID, req *models.OAuthDecisionRequest) (*models.OAuthAuthorization, error)
+	// Exchange serves the token endpoint; failures are *OAuthError
+	Exchange(ctx context.Context, creds models.OAuthClientCredentials, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error)
+	// Introspect reports on a token issued to the calling client; any other
+	// token is inactive
+	Introspect(ctx context.Context, creds models.OAuthClientCredentials, token string) (*models.OAuthIntrospection, error)
+	// Revoke revokes a token issued to the calling client, and with a
+	// refresh token everything issued alongside it. Unknown tokens are
+	// ignored (RFC 7009).
+	Revoke(ctx context.Context, creds models.OAuthClientCredentials, token string) error
+	// VerifyAccessToken returns the live access token, or nil. It is
+	// checked on every request a third-party app makes.
+	VerifyAccessToken(ctx context.Context, token string) (*models.OAuthToken, error)
+	ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.OAuthGrant, error)
+	// RevokeGrant withdraws the user's approval and every token the client
+	// holds for them
+	RevokeGrant(ctx context.Context, userID, clientID uuid.UUID) error
+}
+
+type oauthService struct {
+	oauthRepo repository.OAuthRepository
+	auditRepo repository.AuditRepository
+	tx        repository.Transactor
+	policy    OAuthPolicy
+}
+
+func NewOAuthService(oauthRepo repository.OAuthRepository, auditRepo repository.AuditRepository, tx repository.Transactor, policy OAuthPolicy) OAuthService {
+	return &oauthService{
+		oauthRepo: oauthRepo,
+		auditRepo: auditRepo,
+		tx:        tx,
+		policy:    policy,
+	}
+}
+
+func (s *oauthService) RegisterClient(ctx context.Context, req *models.CreateOAuthClientRequest, actor models.Actor) (*models.RegisteredOAuthClient, error) {
+	for _, uri := range req.RedirectURIs {
+		if err := checkRedirectURI(uri); err != nil {
+			return nil, err
+		}
+	}
+
+	var secret string
+	var secretHash *string
+	if req.Confidential {
+		var err error
+		secret, err = generateOAuthToken()
+		if err != nil {
+			return nil, fmt.Errorf("error generating client secret: %w", err)
+		}
+		hash := hashToken(secret)
+		secretHash = &hash
+	}
+
+	var client *models.OAuthClient
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		client, err = s.oauthRepo.CreateClient(ctx, &models.OAuthClient{
+			Name:         req.Name,
+			SecretHash:   secretHash,
+			RedirectURIs: slices.Compact(slices.Sorted(slices.Values(req.RedirectURIs))),
+			Scopes:       slices.Compact(slices.Sorted(slices.Values(req.Scopes))),
+			CreatedBy:    actor.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating oauth client: %w", err)
+		}
+
+		return s.audit(ctx, actor, models.AuditOAuthClientCreated, client.ID, map[string]interface{}{
+			"name":          client.Name,
+			"confidential":  client.Confidential,
+			"redirect_uris": client.RedirectURIs,
+			"scopes":        joinScopes(client.Scopes),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RegisteredOAuthClient{OAuthClient: client, ClientSecret: secret}, nil
+}
+
+func (s *oauthService) ListClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	clients, err := s.oauthRepo.ListClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing oauth clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+func (s *oauthService) DeleteClient(ctx context.Context, id uuid.UUID, actor models.Actor) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		client, err := s.oauthRepo.GetClient(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error getting oauth client: %w", err)
+		}
+		if client == nil {
+			return errors.New("oauth client not found")
+		}
+
+		deleted, err := s.oauthRepo.DeleteClient(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error deleting oauth client: %w", err)
+		}
+		if !deleted {
+			return errors.New("oauth client not found")
+		}
+
+		return s.audit(ctx, actor, models.AuditOAuthClientDeleted, id, map[string]interface{}{
+			"name": client.Name,
+		})
+	})
+}
+
+func (s *oauthService) Authorize(ctx context.Context, userID uuid.UUID, req *models.OAuthAuthorizeRequest) (*models.OAuthAuthorization, error) {
+	client, scopes, rejection, err := s.checkAuthorization(ctx, req)
+	if err != nil || rejection != nil {
+		return rejection, err
+	}
+
+	grant, err := s.oauthRepo.GetGrant(ctx, userID, client.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth grant: %w", err)
+	}
+
+	consent := &models.OAuthAuthorization{
+		Client: &models.OAuthClientSummary{ID: client.ID, Name: client.Name},
+		Scopes: make([]models.OAuthScopeDescription, len(scopes)),
+	}
+	for i, scope := range scopes {
+		consent.Scopes[i] = models.OAuthScopeDescription{Name: scope, Description: models.OAuthScopes[scope]}
+	}
+	if grant != nil {
+		consent.Granted = true
+		for _, scope := range scopes {
+			if !slices.Contains(grant.Scopes, scope) {
+				consent.Granted = false
+				break
+			}
+		}
+	}
+
+	return consent, nil
+}
+
+func (s *oauthService) Decide(ctx context.Context, userID uuid.UUID, req *models.OAuthDecisionRequest) (*models.OAuthAuthorization, error) {
+	client, scopes, rejection, err := s.checkAuthorization(ctx, &req.OAuthAuthorizeRequest)
+	if err != nil || rejection != nil {
+		return rejection, err
+	}
+
+	if !*req.Approve {
+		return authorizationRedirect(req.RedirectURI, url.Values{
+			"error": {"access_denied"},
+			"state": {req.State},
+		})
+	}
+
+	code, err := generateOAuthToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating authorization code: %w", err)
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Approvals add up, so the next request for any of these is pre-approved
+		granted := scopes
+		grant, err := s.oauthRepo.GetGrant(ctx, userID, client.ID)
+		if err != nil {
+			return fmt.Errorf("error getting oauth grant: %w", err)
+		}
+		if grant != nil {
+			granted = slices.Compact(slices.Sorted(slices.Values(append(grant.Scopes, scopes...))))
+		}
+		if _, err := s.oauthRepo.SaveGrant(ctx, &models.OAuthGrant{UserID: userID, ClientID: client.ID, Scopes: granted}); err != nil {
+			return fmt.Errorf("error saving oauth grant: %w", err)
+		}
+
+		err = s.oauthRepo.CreateCode(ctx, &models.OAuthAuthorizationCode{
+			CodeHash:      hashToken(code),
+			ClientID:      client.ID,
+			UserID:        userID,
+			RedirectURI:   req.RedirectURI,
+			Scopes:        scopes,
+			CodeChallenge: req.CodeChallenge,
+			ExpiresAt:     time.Now().Add(s.policy.CodeTTL),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating authorization code: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return authorizationRedirect(req.RedirectURI, url.Values{
+		"code":  {code},
+		"state": {req.State},
+	})
+}
+
+// checkAuthorization validates an authorization request. The client and
+// redirect URI come first: until both check out, errors go to the user
+// rather than to a redirect URI that may not be the client's. Anything
+// wrong after that is a rejection to redirect to.
+func (s *oauthService) checkAuthorization(ctx context.Context, req *models.OAuthAuthorizeRequest) (*models.OAuthClient, []models.OAuthScope, *models.OAuthAuthorization, error) {
+	clientID, err := uuid.Parse(req.ClientID)
+	if err != nil {
+		return nil, nil, nil, errors.New("oauth client not found")
+	}
+	client, err := s.oauthRepo.GetClient(ctx, clientID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting oauth client: %w", err)
+	}
+	if client == nil {
+		return nil, nil, nil, errors.New("oauth client not found")
+	}
+	if !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		return nil, nil, nil, errors.New("invalid redirect URI: not registered for this client")
+	}
+
+	reject := func(code, description string) (*models.OAuthClient, []models.OAuthScope, *models.OAuthAuthorization, error) {
+		rejection, err := authorizationRedirect(req.RedirectURI, url.Values{
+			"error":             {code},
+			"error_description": {description},
+			"state":             {req.State},
+		})
+		return nil, nil, rejection, err
+	}
+
+	if req.ResponseType != "code" {
+		return reject("unsupported_response_type", "only the code response type is supported")
+	}
+	if req.CodeChallengeMethod != "S256" || len(req.CodeChallenge) < 43 || len(req.CodeChallenge) > 128 {
+		return reject("invalid_request", "a PKCE code_challenge with code_challenge_method S256 is required")
+	}
+	scopes, ok := parseScopes(req.Scope, client.Scopes)
+	if !ok {
+		return reject("invalid_scope", "the client may not request one or more of these scopes")
+	}
+
+	return client, scopes, nil, nil
+}
+
+func (s *oauthService) Exchange(ctx context.Context, creds models.OAuthClientCredentials, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	client, err := s.authenticateClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case "authorization_code":
+		return s.exchangeCode(ctx, client, req)
+	case "refresh_token":
+		return s.refresh(ctx, client, req)
+	default:
+		return nil, &OAuthError{Code: "unsupported_grant_type", Description: "grant_type must be authorization_code or refresh_token"}
+	}
+}
+
+func (s *oauthService) exchangeCode(ctx context.Context, client *models.OAuthClient, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, &OAuthError{Code: "invalid_request", Description: "code and code_verifier are required"}
+	}
+
+	// Redeemed before it is checked, so a code that fails a check is spent
+	code, err := s.oauthRepo.RedeemCode(ctx, hashToken(req.Code))
+	if err != nil {
+		return nil, fmt.Errorf("error redeeming authorization code: %w", err)
+	}
+	if code == nil || code.ClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		return nil, &OAuthError{Code: "invalid_grant", Description: "authorization code invalid, expired or already used"}
+	}
+	if subtle.ConstantTimeCompare([]byte(pkceChallenge(req.CodeVerifier)), []byte(code.CodeChallenge)) != 1 {
+		return nil, &OAuthError{Code: "invalid_grant", Description: "code_verifier does not match the code challenge"}
+	}
+
+	return s.issue(ctx, client.ID, code.UserID, code.Scopes)
+}
+
+func (s *oauthService) refresh(ctx context.Context, client *models.OAuthClient, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, &OAuthError{Code: "invalid_request", Description: "refresh_token is required"}
+	}
+	tokenHash := hashToken(req.RefreshToken)
+
+	var issued *models.OAuthTokenResponse
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		refresh, err := s.oauthRepo.RedeemRefreshToken(ctx, tokenHash)
+		if err != nil {
+			return fmt.Errorf("error redeeming refresh token: %w", err)
+		}
+		if refresh == nil {
+			return errRefreshTokenInvalid
+		}
+		if refresh.ClientID != client.ID {
+			return &OAuthError{Code: "invalid_grant", Description: "refresh token was not issued to this client"}
+		}
+
+		// A refresh may narrow the scopes but never widen them
+		scopes := refresh.Scopes
+		if req.Scope != "" {
+			var ok bool
+			if scopes, ok = parseScopes(req.Scope, refresh.Scopes); !ok {
+				return &OAuthError{Code: "invalid_scope", Description: "scope exceeds what the refresh token was granted"}
+			}
+		}
+
+		issued, err = s.issue(ctx, client.ID, refresh.UserID, scopes)
+		return err
+	})
+	if errors.Is(err, errRefreshTokenInvalid) {
+		if err := s.revokeOnReplay(ctx, client, tokenHash); err != nil {
+			return nil, err
+		}
+		return nil, &OAuthError{Code: "invalid_grant", Description: "refresh token invalid, expired or already used"}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+// revokeOnReplay revokes everything the client holds for the user when a
+// refresh token it already exchanged comes back: either the client or
+// whoever stole the token is replaying it, and there's no telling which
+func (s *oauthService) revokeOnReplay(ctx context.Context, client *models.OAuthClient, tokenHash string) error {
+	token, err := s.oauthRepo.GetToken(ctx, tokenHash)
+	if err != nil {
+		return fmt.Errorf("error getting refresh token: %w", err)
+	}
+	if token == nil || token.Kind != models.OAuthRefreshToken || token.RevokedAt == nil || token.ClientID != client.ID {
+		return nil
+	}
+
+	if err := s.oauthRepo.RevokeGrantTokens(ctx, token.UserID, token.ClientID); err != nil {
+		return fmt.Errorf("error revoking oauth tokens: %w", err)
+	}
+	return nil
+}
+
+// issue creates an access token and a refresh token for the grant
+func (s *oauthService) issue(ctx context.Context, clientID, userID uuid.UUID, scopes []models.OAuthScope) (*models.OAuthTokenResponse, error) {
+	accessToken, err := generateOAuthToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating access token: %w", err)
+	}
+	refreshToken, err := generateOAuthToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating refresh token: %w", err)
+	}
+
+	now := time.Now()
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, token := range []*models.OAuthToken{
+			{TokenHash: hashToken(accessToken), Kind: models.OAuthAccessToken, ExpiresAt: now.Add(s.policy.AccessTokenTTL)},
+			{TokenHash: hashToken(refreshToken), Kind: models.OAuthRefreshToken, ExpiresAt: now.Add(s.policy.RefreshTokenTTL)},
+		} {
+			token.ClientID = clientID
+			token.UserID = userID
+			token.Scopes = scopes
+			if _, err := s.oauthRepo.CreateToken(ctx, token); err != nil {
+				return fmt.Errorf("error creating oauth token: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OAuthTokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.policy.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+		Scope:        joinScopes(scopes),
+	}, nil
+}
+
+func (s *oauthService) Introspect(ctx context.Context, creds models.OAuthClientCredentials, token string) (*models.OAuthIntrospection, error) {
+	client, err := s.authenticateClient(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if !client.Confidential {
+		return nil, &OAuthError{Code: "invalid_client", Description: "only confidential clients may introspect tokens"}
+	}
+
+	found, err := s.oauthRepo.GetToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth token: %w", err)
+	}
+	if found == nil || found.ClientID != client.ID || !tokenLive(found) {
+		return &models.OAuthIntrospection{Active: false}, nil
+	}
+
+	introspection := &models.OAuthIntrospection{
+		Active:    true,
+		Scope:     joinScopes(found.Scopes),
+		ClientID:  found.ClientID.String(),
+		Subject:   found.UserID.String(),
+		ExpiresAt: found.ExpiresAt.Unix(),
+		IssuedAt:  found.CreatedAt.Unix(),
+	}
+	if found.Kind == models.OAuthAccessToken {
+		introspection.TokenType = "Bearer"
+	}
+	return introspection, nil
+}
+
+func (s *oauthService) Revoke(ctx context.Context, creds models.OAuthClientCredentials, token string) error {
+	client, err := s.authenticateClient(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.oauthRepo.GetToken(ctx, hashToken(token))
+	if err != nil {
+		return fmt.Errorf("error getting oauth token: %w", err)
+	}
+	if found == nil || found.ClientID != client.ID {
+		return nil
+	}
+
+	if found.Kind == models.OAuthRefreshToken {
+		err = s.oauthRepo.RevokeGrantTokens(ctx, found.UserID, found.ClientID)
+	} else {
+		err = s.oauthRepo.RevokeToken(ctx, found.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("error revoking oauth token: %w", err)
+	}
+	return nil
+}
+
+func (s *oauthService) VerifyAccessToken(ctx context.Context, token string) (*models.OAuthToken, error) {
+	found, err := s.oauthRepo.GetToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth token: %w", err)
+	}
+	if found == nil || found.Kind != models.OAuthAccessToken || !tokenLive(found) {
+		return nil, nil
+	}
+
+	return found, nil
+}
+
+func (s *oauthService) ListGrants(ctx context.Context, userID uuid.UUID) ([]*models.OAuthGrant, error) {
+	grants, err := s.oauthRepo.ListGrants(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing oauth grants: %w", err)
+	}
+
+	return grants, nil
+}
+
+func (s *oauthService) RevokeGrant(ctx context.Context, userID, clientID uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		deleted, err := s.oauthRepo.DeleteGrant(ctx, userID, clientID)
+		if err != nil {
+			return fmt.Errorf("error deleting oauth grant: %w", err)
+		}
+		if !deleted {
+			return errors.New("oauth grant not found")
+		}
+
+		if err := s.oauthRepo.RevokeGrantTokens(ctx, userID, clientID); err != nil {
+			return fmt.Errorf("error revoking oauth tokens: %w", err)
+		}
+		return nil
+	})
+}
+
+// authenticateClient identifies the client from its credentials. A
+// confidential client must present its secret; a public one has none.
+func (s *oauthService) authenticateClient(ctx context.Context, creds models.OAuthClientCredentials) (*models.OAuthClient, error) {
+	invalid := &OAuthError{Code: "invalid_client", Description: "client authentication failed"}
+
+	clientID, err := uuid.Parse(creds.ClientID)
+	if err != nil {
+		return nil, invalid
+	}
+	client, err := s.oauthRepo.GetClient(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth client: %w", err)
+	}
+	if client == nil {
+		return nil, invalid
+	}
+
+	if client.SecretHash == nil {
+		if creds.ClientSecret != "" {
+			return nil, invalid
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(creds.ClientSecret)), []byte(*client.SecretHash)) != 1 {
+		return nil, invalid
+	}
+	return client, nil
+}
+
+func (s *oauthService) audit(ctx context.Context, actor models.Actor, action string, clientID uuid.UUID, metadata map[string]interface{}) error {
+	entry := &models.AuditLog{
+		ActorID:    actor.UserID,
+		Action:     action,
+		TargetType: "oauth_client",
+		TargetID:   clientID,
+		Metadata:   metadata,
+	}
+	if actor.IPAddress != "" {
+		entry.IPAddress = &actor.IPAddress
+	}
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
+
+// checkRedirectURI applies RFC 6749 and RFC 8252: redirect URIs are
+// absolute with no fragment, and plain HTTP is only for loopback
+// addresses, where native apps listen
+func checkRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("invalid redirect URI: %s is not absolute", uri)
+	}
+	if u.Fragment != "" {
+		return fmt.Errorf("invalid redirect URI: %s has a fragment", uri)
+	}
+	if u.Scheme == "http" {
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("invalid redirect URI: %s must use https", uri)
+		}
+	}
+	return nil
+}
+
+// authorizationRedirect adds the response parameters to the client's
+// redirect URI, keeping any query it was registered with
+func authorizationRedirect(redirectURI string, params url.Values) (*models.OAuthAuthorization, error) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing redirect URI: %w", err)
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		if len(values) > 0 && values[0] != "" {
+			query[key] = values
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return &models.OAuthAuthorization{RedirectURI: u.String()}, nil
+}
+
+// parseScopes splits a space-delimited scope parameter, reporting false
+// when it asks for anything outside allowed. An empty parameter asks for
+// everything allowed.
+func parseScopes(scope string, allowed []models.OAuthScope) ([]models.OAuthScope, bool) {
+	fields := strings.Fields(scope)
+	if len(fields) == 0 {
+		return slices.Clone(allowed), true
+	}
+
+	scopes := make([]models.OAuthScope, 0, len(fields))
+	for _, field := range fields {
+		scope := models.OAuthScope(field)
+		if !slices.Contains(allowed, scope) {
+			return nil, false
+		}
+		scopes = append(scopes, scope)
+	}
+	return slices.Compact(slices.Sorted(slices.Values(scopes))), true
+}
+
+func joinScopes(scopes []models.OAuthScope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, " ")
+}
+
+func tokenLive(token *models.OAuthToken) bool {
+	return token.RevokedAt == nil && time.Now().Before(token.ExpiresAt)
+}
+
+// pkceChallenge is the S256 code challenge for verifier (RFC 7636)
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func generateOAuthToken() (string, error) {
+	b := make([]byte, oauthTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
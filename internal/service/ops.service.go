@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// OpsService is the operator tasks adminctl runs against the database
+// directly, for when there is no admin to run them through the API
+type OpsService interface {
+	// CreateSuperAdmin creates the store's first super admin. It refuses
+	// once the store has one; later admins are managed through the API.
+	CreateSuperAdmin(ctx context.Context, req *models.CreateUserRequest) (*models.User, error)
+	// SetPassword replaces the password of the user with email
+	SetPassword(ctx context.Context, email, password string) error
+	// ReplayOutbox has the relay deliver again the events that occurred
+	// since then, only those named eventName when it is set, and returns
+	// how many were queued
+	ReplayOutbox(ctx context.Context, since time.Time, eventName *string) (int64, error)
+}
+
+type opsService struct {
+	userRepo   repository.UserRepository
+	outboxRepo repository.OutboxRepository
+	auditRepo  repository.AuditRepository
+	tx         repository.Transactor
+	publisher  events.Publisher
+}
+
+func NewOpsService(userRepo repository.UserRepository, outboxRepo repository.OutboxRepository, auditRepo repository.AuditRepository, tx repository.Transactor, publisher events.Publisher) OpsService {
+	return &opsService{
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+		auditRepo:  auditRepo,
+		tx:         tx,
+		publisher:  publisher,
+	}
+}
+
+func (s *opsService) CreateSuperAdmin(ctx context.Context, req *models.CreateUserRequest) (*models.User, error) {
+	role := models.RoleSuperAdmin
+	existing, err := s.userRepo.List(ctx, &role, nil, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error listing super admins: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, errors.New("store already has a super admin")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	var user *models.User
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user, err = s.userRepo.Create(ctx, &models.User{
+			Email:        req.Email,
+			PasswordHash: string(hashedPassword),
+			FirstName:    req.FirstName,
+			LastName:     req.LastName,
+			Role:         role,
+			Status:       models.StatusActive,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return conflictError(err)
+			}
+			return fmt.Errorf("error creating user: %w", err)
+		}
+
+		if err := s.audit(ctx, models.AuditUserProvisioned, user, map[string]interface{}{"role": string(role)}); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.UserCreated{
+			UserID: user.ID,
+			Email:  user.Email,
+			Role:   user.Role,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *opsService) SetPassword(ctx context.Context, email, password string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+			return fmt.Errorf("error updating password: %w", err)
+		}
+		return s.audit(ctx, models.AuditUserPasswordReset, user, map[string]interface{}{})
+	})
+}
+
+func (s *opsService) ReplayOutbox(ctx context.Context, since time.Time, eventName *string) (int64, error) {
+	requeued, err := s.outboxRepo.Requeue(ctx, since, eventName)
+	if err != nil {
+		return 0, fmt.Errorf("error requeueing outbox messages: %w", err)
+	}
+	return requeued, nil
+}
+
+// audit records a change made from the command line; there is no acting
+// user
+func (s *opsService) audit(ctx context.Context, action string, user *models.User, metadata map[string]interface{}) error {
+	metadata["source"] = "adminctl"
+
+	entry := &models.AuditLog{
+		Action:     action,
+		TargetType: "user",
+		TargetID:   user.ID,
+		Metadata:   metadata,
+	}
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
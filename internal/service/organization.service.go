@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// OrganizationService manages publisher teams. Callers act as members:
+// organizations they don't belong to are reported as not found.
+type OrganizationService interface {
+	// Create makes the caller the organization's first owner
+	Create(ctx context.Context, req *models.CreateOrganizationRequest, actor models.Actor) (*models.Organization, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*models.Organization, error)
+	Get(ctx context.Context, userID, orgID uuid.UUID) (*models.Organization, error)
+	ListMembers(ctx context.Context, userID, orgID uuid.UUID) ([]*models.OrganizationMember, error)
+	// AddMember is open to owners, and to managers adding support members
+	AddMember(ctx context.Context, orgID uuid.UUID, req *models.AddOrganizationMemberRequest, actor models.Actor) (*models.OrganizationMember, error)
+	// UpdateMember changes a member's role; only owners may
+	UpdateMember(ctx context.Context, orgID, userID uuid.UUID, req *models.UpdateOrganizationMemberRequest, actor models.Actor) (*models.OrganizationMember, error)
+	// RemoveMember is open to owners, to managers removing support members,
+	// and to any member leaving
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID, actor models.Actor) error
+}
+
+type organizationService struct {
+	orgRepo   repository.OrganizationRepository
+	userRepo  repository.UserRepository
+	auditRepo repository.AuditRepository
+	tx        repository.Transactor
+}
+
+func NewOrganizationService(orgRepo repository.OrganizationRepository, userRepo repository.UserRepository, auditRepo repository.AuditRepository, tx repository.Transactor) OrganizationService {
+	return &organizationService{
+		orgRepo:   orgRepo,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		tx:        tx,
+	}
+}
+
+func (s *organizationService) Create(ctx context.Context, req *models.CreateOrganizationRequest, actor models.Actor) (*models.Organization, error) {
+	var org *models.Organization
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		org, err = s.orgRepo.Create(ctx, &models.Organization{Name: req.Name, CreatedBy: actor.UserID})
+		if err != nil {
+			return fmt.Errorf("error creating organization: %w", err)
+		}
+
+		_, err = s.orgRepo.AddMember(ctx, &models.OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         *actor.UserID,
+			Role:           models.OrgRoleOwner,
+			AddedBy:        actor.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("error adding organization owner: %w", err)
+		}
+
+		return s.audit(ctx, actor, models.AuditOrgCreated, org.ID, map[string]interface{}{
+			"name": org.Name,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	org.Role = models.OrgRoleOwner
+	return org, nil
+}
+
+func (s *organizationService) List(ctx context.Context, userID uuid.UUID) ([]*models.Organization, error) {
+	orgs, err := s.orgRepo.ListForMember(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+func (s *organizationService) Get(ctx context.Context, userID, orgID uuid.UUID) (*models.Organization, error) {
+	org, member, err := s.membership(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.Role = member.Role
+	return org, nil
+}
+
+func (s *organizationService) ListMembers(ctx context.Context, userID, orgID uuid.UUID) ([]*models.OrganizationMember, error) {
+	if _, _, err := s.membership(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing organization members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (s *organizationService) AddMember(ctx context.Context, orgID uuid.UUID, req *models.AddOrganizationMemberRequest, actor models.Actor) (*models.OrganizationMember, error) {
+	_, caller, err := s.membership(ctx, orgID, *actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(caller.Role, req.Role) {
+		return nil, errors.New("insufficient privileges to add this member")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	var member *models.OrganizationMember
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		member, err = s.orgRepo.AddMember(ctx, &models.OrganizationMember{
+			OrganizationID: orgID,
+			UserID:         user.ID,
+			Role:           req.Role,
+			AddedBy:        actor.UserID,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return fmt.Errorf("user is already a member of this organization: %w", err)
+			}
+			return fmt.Errorf("error adding organization member: %w", err)
+		}
+
+		return s.audit(ctx, actor, models.AuditOrgMemberAdded, orgID, map[string]interface{}{
+			"user_id": user.ID.String(),
+			"role":    string(req.Role),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *organizationService) UpdateMember(ctx context.Context, orgID, userID uuid.UUID, req *models.UpdateOrganizationMemberRequest, actor models.Actor) (*models.OrganizationMember, error) {
+	_, caller, err := s.membership(ctx, orgID, *actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if caller.Role != models.OrgRoleOwner {
+		return nil, errors.New("insufficient privileges to change member roles")
+	}
+
+	var member *models.OrganizationMember
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.lockedMember(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		if existing.Role == models.OrgRoleOwner && req.Role != models.OrgRoleOwner {
+			if err := s.keepOwner(ctx, orgID); err != nil {
+				return err
+			}
+		}
+
+		member, err = s.orgRepo.UpdateMemberRole(ctx, orgID, userID, req.Role)
+		if err != nil {
+			return fmt.Errorf("error updating organization member: %w", err)
+		}
+		if member == nil {
+			return errors.New("organization member not found")
+		}
+
+		return s.audit(ctx, actor, models.AuditOrgMemberRole, orgID, map[string]interface{}{
+			"user_id":       userID.String(),
+			"role":          string(req.Role),
+			"previous_role": string(existing.Role),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, orgID, userID uuid.UUID, actor models.Actor) error {
+	_, caller, err := s.membership(ctx, orgID, *actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.lockedMember(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		if userID != *actor.UserID && !canManage(caller.Role, existing.Role) {
+			return errors.New("insufficient privileges to remove this member")
+		}
+		if existing.Role == models.OrgRoleOwner {
+			if err := s.keepOwner(ctx, orgID); err != nil {
+				return err
+			}
+		}
+
+		removed, err := s.orgRepo.RemoveMember(ctx, orgID, userID)
+		if err != nil {
+			return fmt.Errorf("error removing organization member: %w", err)
+		}
+		if !removed {
+			return errors.New("organization member not found")
+		}
+
+		return s.audit(ctx, actor, models.AuditOrgMemberRemoved, orgID, map[string]interface{}{
+			"user_id": userID.String(),
+			"role":    string(existing.Role),
+		})
+	})
+}
+
+// membership returns the organization and the user's membership of it,
+// reporting the organization as not found to non-members
+func (s *organizationService) membership(ctx context.Context, orgID, userID uuid.UUID) (*models.Organization, *models.OrganizationMember, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting organization: %w", err)
+	}
+	if org == nil {
+		return nil, nil, errors.New("organization not found")
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting organization member: %w", err)
+	}
+	if member == nil {
+		return nil, nil, errors.New("organization not found")
+	}
+
+	return org, member, nil
+}
+
+// lockedMember locks the organization, so concurrent changes can't remove
+// its last owner between them, and returns the member
+func (s *organizationService) lockedMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	if err := s.orgRepo.Lock(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("error locking organization: %w", err)
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting organization member: %w", err)
+	}
+	if member == nil {
+		return nil, errors.New("organization member not found")
+	}
+	return member, nil
+}
+
+// keepOwner fails when removing one owner would leave the organization
+// with none
+func (s *organizationService) keepOwner(ctx context.Context, orgID uuid.UUID) error {
+	owners, err := s.orgRepo.CountOwners(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("error counting organization owners: %w", err)
+	}
+	if owners <= 1 {
+		return errors.New("organization must keep an owner")
+	}
+	return nil
+}
+
+func (s *organizationService) audit(ctx context.Context, actor models.Actor, action string, orgID uuid.UUID, metadata map[string]interface{}) error {
+	entry := &models.AuditLog{
+		ActorID:    actor.UserID,
+		Action:     action,
+		TargetType: "organization",
+		TargetID:   orgID,
+		Metadata:   metadata,
+	}
+	if actor.IPAddress != "" {
+		entry.IPAddress = &actor.IPAddress
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
+
+// canManage reports whether a member with role may add or remove a member
+// holding target
+func canManage(role, target models.OrganizationRole) bool {
+	switch role {
+	case models.OrgRoleOwner:
+		return true
+	case models.OrgRoleManager:
+		return target == models.OrgRoleSupport
+	default:
+		return false
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// defaultPasskeyName labels a passkey registered without a name
+const defaultPasskeyName = "Passkey"
+
+type PasskeyService interface {
+	// BeginRegistration starts adding a passkey to the user's account
+	BeginRegistration(ctx context.Context, userID uuid.UUID) (*models.WebAuthnBeginResponse, error)
+	FinishRegistration(ctx context.Context, userID uuid.UUID, req *models.FinishPasskeyRegistrationRequest) (*models.Passkey, error)
+	// BeginLogin starts a passkey login; the authenticator picks the account
+	BeginLogin(ctx context.Context) (*models.WebAuthnBeginResponse, error)
+	// FinishLogin verifies the assertion and returns the user it signed in
+	FinishLogin(ctx context.Context, req *models.FinishPasskeyLoginRequest) (uuid.UUID, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*models.Passkey, error)
+	Delete(ctx context.Context, userID, passkeyID uuid.UUID) error
+}
+
+type passkeyService struct {
+	passkeyRepo  repository.PasskeyRepository
+	userRepo     repository.UserRepository
+	relyingParty *webauthn.WebAuthn
+}
+
+func NewPasskeyService(passkeyRepo repository.PasskeyRepository, userRepo repository.UserRepository, relyingParty *webauthn.WebAuthn) PasskeyService {
+	return &passkeyService{
+		passkeyRepo:  passkeyRepo,
+		userRepo:     userRepo,
+		relyingParty: relyingParty,
+	}
+}
+
+// passkeyUser is a user as the WebAuthn library sees them. The user
+// handle is the account ID, which is what a passkey login hands back.
+type passkeyUser struct {
+	user        *models.User
+	credentials []webauthn.Credential
+}
+
+func (u *passkeyUser) WebAuthnID() []byte {
+	id := u.user.ID
+	return id[:]
+}
+
+func (u *passkeyUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *passkeyUser) WebAuthnDisplayName() string {
+	return strings.TrimSpace(u.user.FirstName + " " + u.user.LastName)
+}
+
+func (u *passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+func (s *passkeyService) loadUser(ctx context.Context, userID uuid.UUID) (*passkeyUser, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	passkeys, err := s.passkeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing passkeys: %w", err)
+	}
+	credentials := make([]webauthn.Credential, len(passkeys))
+	for i, passkey := range passkeys {
+		if err := json.Unmarshal(passkey.Credential, &credentials[i]); err != nil {
+			return nil, fmt.Errorf("error decoding passkey %s: %w", passkey.ID, err)
+		}
+	}
+
+	return &passkeyUser{user: user, credentials: credentials}, nil
+}
+
+func (s *passkeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (*models.WebAuthnBeginResponse, error) {
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discoverable credentials are what make a passkey usable without
+	// typing an email; excluding existing ones stops duplicates per device
+	creation, session, err := s.relyingParty.BeginRegistration(user,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExclusions(webauthn.Credentials(user.credentials).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error beginning passkey registration: %w", err)
+	}
+
+	return s.saveSession(ctx, &userID, models.WebAuthnRegistration, session, creation)
+}
+
+func (s *passkeyService) FinishRegistration(ctx context.Context, userID uuid.UUID, req *models.FinishPasskeyRegistrationRequest) (*models.Passkey, error) {
+	session, err := s.consumeSession(ctx, req.SessionID, models.WebAuthnRegistration)
+	if err != nil {
+		return nil, err
+	}
+	// A session only finishes for the account that began it
+	if session.UserID == nil || *session.UserID != userID {
+		return nil, errors.New("passkey session expired or already used")
+	}
+
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(req.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("passkey registration failed: %w", err)
+	}
+	credential, err := s.relyingParty.CreateCredential(user, *session.data, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("passkey registration failed: %w", err)
+	}
+
+	record, err := json.Marshal(credential)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding passkey: %w", err)
+	}
+	name := req.Name
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	passkey, err := s.passkeyRepo.Create(ctx, &models.Passkey{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credential.ID,
+		Credential:   record,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, fmt.Errorf("passkey already registered: %w", err)
+		}
+		return nil, fmt.Errorf("error creating passkey: %w", err)
+	}
+
+	return passkey, nil
+}
+
+func (s *passkeyService) BeginLogin(ctx context.Context) (*models.WebAuthnBeginResponse, error) {
+	assertion, session, err := s.relyingParty.BeginDiscoverableLogin()
+	if err != nil {
+		return nil, fmt.Errorf("error beginning passkey login: %w", err)
+	}
+
+	return s.saveSession(ctx, nil, models.WebAuthnLogin, session, assertion)
+}
+
+func (s *passkeyService) FinishLogin(ctx context.Context, req *models.FinishPasskeyLoginRequest) (uuid.UUID, error) {
+	session, err := s.consumeSession(ctx, req.SessionID, models.WebAuthnLogin)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(req.Credential)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("passkey login failed: %w", err)
+	}
+
+	// Lookup failures are kept apart so an outage isn't reported as a bad passkey
+	var lookupErr error
+	found, credential, err := s.relyingParty.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+		userID, err := uuid.FromBytes(userHandle)
+		if err != nil {
+			return nil, errors.New("unknown user handle")
+		}
+		user, err := s.loadUser(ctx, userID)
+		if err != nil && !strings.Contains(err.Error(), "user not found") {
+			lookupErr = err
+		}
+		return user, err
+	}, *session.data, parsed)
+	if lookupErr != nil {
+		return uuid.Nil, lookupErr
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("passkey login failed: %w", err)
+	}
+	// A counter that went backwards means the key was copied
+	if credential.Authenticator.CloneWarning {
+		return uuid.Nil, errors.New("passkey login failed: signature counter went backwards")
+	}
+
+	record, err := json.Marshal(credential)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error encoding passkey: %w", err)
+	}
+	if err := s.passkeyRepo.RecordUse(ctx, credential.ID, record); err != nil {
+		return uuid.Nil, fmt.Errorf("error recording passkey use: %w", err)
+	}
+
+	return found.(*passkeyUser).user.ID, nil
+}
+
+func (s *passkeyService) List(ctx context.Context, userID uuid.UUID) ([]*models.Passkey, error) {
+	passkeys, err := s.passkeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing passkeys: %w", err)
+	}
+
+	return passkeys, nil
+}
+
+func (s *passkeyService) Delete(ctx context.Context, userID, passkeyID uuid.UUID) error {
+	deleted, err := s.passkeyRepo.Delete(ctx, passkeyID, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting passkey: %w", err)
+	}
+	if !deleted {
+		return errors.New("passkey not found")
+	}
+
+	return nil
+}
+
+// ceremonySession is a consumed session with its library state decoded
+type ceremonySession struct {
+	*models.WebAuthnSession
+	data *webauthn.SessionData
+}
+
+func (s *passkeyService) saveSession(ctx context.Context, userID *uuid.UUID, ceremony models.WebAuthnCeremony, data *webauthn.SessionData, options interface{}) (*models.WebAuthnBeginResponse, error) {
+	// Abandoned ceremonies are swept here rather than by a worker
+	if err := s.passkeyRepo.DeleteExpiredSessions(ctx); err != nil {
+		return nil, fmt.Errorf("error deleting expired passkey sessions: %w", err)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding passkey session: %w", err)
+	}
+	session, err := s.passkeyRepo.CreateSession(ctx, &models.WebAuthnSession{
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Data:      encoded,
+		ExpiresAt: data.Expires,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating passkey session: %w", err)
+	}
+
+	return &models.WebAuthnBeginResponse{SessionID: session.ID, Options: options}, nil
+}
+
+func (s *passkeyService) consumeSession(ctx context.Context, id uuid.UUID, ceremony models.WebAuthnCeremony) (*ceremonySession, error) {
+	session, err := s.passkeyRepo.ConsumeSession(ctx, id, ceremony)
+	if err != nil {
+		return nil, fmt.Errorf("error getting passkey session: %w", err)
+	}
+	if session == nil {
+		return nil, errors.New("passkey session expired or already used")
+	}
+
+	var data webauthn.SessionData
+	if err := json.Unmarshal(session.Data, &data); err != nil {
+		return nil, fmt.Errorf("error decoding passkey session: %w", err)
+	}
+	return &ceremonySession{WebAuthnSession: session, data: &data}, nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type PermissionService interface {
+	List(ctx context.Context) ([]*models.PermissionDefinition, error)
+	// HasPermission reports whether the user holds permission through their
+	// role or a direct grant; super admins hold every permission
+	HasPermission(ctx context.Context, userID uuid.UUID, role models.UserRole, permission models.Permission) (bool, error)
+	GetForUser(ctx context.Context, userID uuid.UUID) (*models.UserPermissions, error)
+	Grant(ctx context.Context, userID uuid.UUID, permission models.Permission, actor models.Actor) (*models.UserPermissions, error)
+	Revoke(ctx context.Context, userID uuid.UUID, permission models.Permission, actor models.Actor) (*models.UserPermissions, error)
+}
+
+type permissionService struct {
+	permissionRepo repository.PermissionRepository
+	userRepo       repository.UserRepository
+	auditRepo      repository.AuditRepository
+	tx             repository.Transactor
+}
+
+func NewPermissionService(permissionRepo repository.PermissionRepository, userRepo repository.UserRepository, auditRepo repository.AuditRepository, tx repository.Transactor) PermissionService {
+	return &permissionService{
+		permissionRepo: permissionRepo,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		tx:             tx,
+	}
+}
+
+func (s *permissionService) List(ctx context.Context) ([]*models.PermissionDefinition, error) {
+	permissions, err := s.permissionRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (s *permissionService) HasPermission(ctx context.Context, userID uuid.UUID, role models.UserRole, permission models.Permission) (bool, error) {
+	if role == models.RoleSuperAdmin {
+		return true, nil
+	}
+
+	effective, err := s.permissionRepo.ListEffective(ctx, userID, role)
+	if err != nil {
+		return false, fmt.Errorf("error listing effective permissions: %w", err)
+	}
+
+	for _, held := range effective {
+		if held == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *permissionService) GetForUser(ctx context.Context, userID uuid.UUID) (*models.UserPermissions, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	return s.permissionsFor(ctx, user)
+}
+
+func (s *permissionService) Grant(ctx context.Context, userID uuid.UUID, permission models.Permission, actor models.Actor) (*models.UserPermissions, error) {
+	user, err := s.checkDelegation(ctx, userID, permission, actor)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.RoleAdmin && user.Role != models.RoleSuperAdmin {
+		return nil, errors.New("permissions can only be granted to admins")
+	}
+
+	// The grant and its audit entry commit together or not at all
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		granted, err := s.permissionRepo.Grant(ctx, userID, permission, actor.UserID)
+		if err != nil {
+			return fmt.Errorf("error granting permission: %w", err)
+		}
+
+		// Re-granting a held permission is a no-op and leaves no audit trail
+		if !granted {
+			return nil
+		}
+		return s.audit(ctx, actor, models.AuditPermissionGranted, userID, permission)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.permissionsFor(ctx, user)
+}
+
+func (s *permissionService) Revoke(ctx context.Context, userID uuid.UUID, permission models.Permission, actor models.Actor) (*models.UserPermissions, error) {
+	user, err := s.checkDelegation(ctx, userID, permission, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		revoked, err := s.permissionRepo.Revoke(ctx, userID, permission)
+		if err != nil {
+			return fmt.Errorf("error revoking permission: %w", err)
+		}
+		if !revoked {
+			return errors.New("permission grant not found")
+		}
+		return s.audit(ctx, actor, models.AuditPermissionRevoked, userID, permission)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.permissionsFor(ctx, user)
+}
+
+// checkDelegation enforces the rules shared by grant and revoke: admins may
+// only delegate permissions they hold themselves, never to their own account
+func (s *permissionService) checkDelegation(ctx context.Context, userID uuid.UUID, permission models.Permission, actor models.Actor) (*models.User, error) {
+	if actor.UserID != nil && *actor.UserID == userID {
+		return nil, errors.New("cannot change your own permissions")
+	}
+
+	definition, err := s.permissionRepo.Get(ctx, permission)
+	if err != nil {
+		return nil, fmt.Errorf("error getting permission: %w", err)
+	}
+	if definition == nil {
+		return nil, errors.New("permission not found")
+	}
+
+	if actor.UserID == nil {
+		return nil, errors.New("insufficient privileges to delegate this permission")
+	}
+	held, err := s.HasPermission(ctx, *actor.UserID, actor.Role, permission)
+	if err != nil {
+		return nil, err
+	}
+	if !held {
+		return nil, errors.New("insufficient privileges to delegate this permission")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.Role == models.RoleSuperAdmin && actor.Role != models.RoleSuperAdmin {
+		return nil, errors.New("insufficient privileges to change this user's permissions")
+	}
+
+	return user, nil
+}
+
+func (s *permissionService) permissionsFor(ctx context.Context, user *models.User) (*models.UserPermissions, error) {
+	var effective []models.Permission
+	if user.Role == models.RoleSuperAdmin {
+		all, err := s.permissionRepo.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing permissions: %w", err)
+		}
+		effective = make([]models.Permission, len(all))
+		for i, definition := range all {
+			effective[i] = definition.Name
+		}
+	} else {
+		var err error
+		effective, err = s.permissionRepo.ListEffective(ctx, user.ID, user.Role)
+		if err != nil {
+			return nil, fmt.Errorf("error listing effective permissions: %w", err)
+		}
+	}
+
+	grants, err := s.permissionRepo.ListGrants(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing permission grants: %w", err)
+	}
+
+	return &models.UserPermissions{
+		UserID:    user.ID,
+		Role:      user.Role,
+		Effective: effective,
+		Grants:    grants,
+	}, nil
+}
+
+func (s *permissionService) audit(ctx context.Context, actor models.Actor, action string, userID uuid.UUID, permission models.Permission) error {
+	entry := &models.AuditLog{
+		ActorID:    actor.UserID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		Metadata: map[string]interface{}{
+			"permission": string(permission),
+		},
+	}
+	if actor.IPAddress != "" {
+		entry.IPAddress = &actor.IPAddress
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
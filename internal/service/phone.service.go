@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/notification"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// PhonePolicy decides how long verification codes work and how often they
+// go out
+type PhonePolicy struct {
+	CodeTTL time.Duration
+	// MaxPerWindow codes are texted to one account per Window; further
+	// requests are refused, since every text costs money
+	MaxPerWindow int
+	Window       time.Duration
+}
+
+type PhoneService interface {
+	GetStatus(ctx context.Context, userID uuid.UUID) (*models.PhoneStatus, error)
+	// SendCode texts a code to the phone number on the user's account
+	SendCode(ctx context.Context, userID uuid.UUID) (*models.PhoneVerificationResponse, error)
+	// Confirm checks the code last texted and marks the number verified
+	Confirm(ctx context.Context, userID uuid.UUID, code string) (*models.PhoneStatus, error)
+}
+
+type phoneService struct {
+	phoneRepo repository.PhoneRepository
+	userRepo  repository.UserRepository
+	texter    *notification.Texter
+	tx        repository.Transactor
+	policy    PhonePolicy
+}
+
+func NewPhoneService(phoneRepo repository.PhoneRepository, userRepo repository.UserRepository, texter *notification.Texter, tx repository.Transactor, policy PhonePolicy) PhoneService {
+	return &phoneService{
+		phoneRepo: phoneRepo,
+		userRepo:  userRepo,
+		texter:    texter,
+		tx:        tx,
+		policy:    policy,
+	}
+}
+
+func (s *phoneService) GetStatus(ctx context.Context, userID uuid.UUID) (*models.PhoneStatus, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := s.phoneRepo.GetVerified(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting verified phone: %w", err)
+	}
+
+	status := &models.PhoneStatus{Phone: user.Phone}
+	if verified != nil {
+		status.Verified = true
+		status.VerifiedAt = &verified.VerifiedAt
+	}
+	return status, nil
+}
+
+func (s *phoneService) SendCode(ctx context.Context, userID uuid.UUID) (*models.PhoneVerificationResponse, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Phone == nil || *user.Phone == "" {
+		return nil, errors.New("phone number not set")
+	}
+	phone := *user.Phone
+
+	verified, err := s.phoneRepo.GetVerified(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting verified phone: %w", err)
+	}
+	if verified != nil {
+		return nil, errors.New("phone number already verified")
+	}
+	if !s.texter.Allowed(phone) {
+		return nil, errors.New("phone number not supported")
+	}
+
+	if s.policy.MaxPerWindow > 0 {
+		sent, err := s.phoneRepo.CountVerificationsSince(ctx, userID, time.Now().Add(-s.policy.Window))
+		if err != nil {
+			return nil, fmt.Errorf("error counting phone verifications: %w", err)
+		}
+		if sent >= s.policy.MaxPerWindow {
+			return nil, errors.New("too many verification codes")
+		}
+	}
+
+	code, err := generateLoginCode()
+	if err != nil {
+		return nil, fmt.Errorf("error generating verification code: %w", err)
+	}
+	verification, err := s.phoneRepo.CreateVerification(ctx, &models.PhoneVerification{
+		UserID:    userID,
+		Phone:     phone,
+		CodeHash:  hashToken(code),
+		ExpiresAt: time.Now().Add(s.policy.CodeTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating phone verification: %w", err)
+	}
+
+	body := fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(s.policy.CodeTTL.Minutes()))
+	if _, err := s.texter.Text(ctx, phone, body); err != nil {
+		return nil, fmt.Errorf("error sending verification code: %w", err)
+	}
+
+	return &models.PhoneVerificationResponse{
+		Phone:     phone,
+		ExpiresAt: verification.ExpiresAt,
+	}, nil
+}
+
+func (s *phoneService) Confirm(ctx context.Context, userID uuid.UUID, code string) (*models.PhoneStatus, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.phoneRepo.GetLatestVerification(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting phone verification: %w", err)
+	}
+	// A code sent before the number changed proves nothing about the new one
+	if verification == nil || user.Phone == nil || verification.Phone != *user.Phone ||
+		verification.VerifiedAt != nil || verification.Attempts >= maxChallengeAttempts || time.Now().After(verification.ExpiresAt) {
+		return nil, errors.New("phone verification expired or already used")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(code)), []byte(verification.CodeHash)) != 1 {
+		if _, err := s.phoneRepo.IncrementAttempts(ctx, verification.ID); err != nil {
+			return nil, fmt.Errorf("error recording phone verification attempt: %w", err)
+		}
+		return nil, errors.New("phone verification code is incorrect")
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Conditional, so two requests racing with the same code can't both pass
+		completed, err := s.phoneRepo.CompleteVerification(ctx, verification.ID, maxChallengeAttempts)
+		if err != nil {
+			return fmt.Errorf("error completing phone verification: %w", err)
+		}
+		if completed == nil {
+			return errors.New("phone verification expired or already used")
+		}
+		if err := s.phoneRepo.SetVerified(ctx, userID, completed.Phone); err != nil {
+			return fmt.Errorf("error saving verified phone: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetStatus(ctx, userID)
+}
+
+func (s *phoneService) getUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type ProfileService interface {
+	// GetPublic returns the privacy-filtered profile of an active user
+	GetPublic(ctx context.Context, username string) (*models.PublicProfile, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.ProfilePreferences, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req *models.UpdateProfilePreferencesRequest) (*models.ProfilePreferences, error)
+}
+
+type profileService struct {
+	profileRepo repository.ProfileRepository
+	userRepo    repository.UserRepository
+}
+
+func NewProfileService(profileRepo repository.ProfileRepository, userRepo repository.UserRepository) ProfileService {
+	return &profileService{
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *profileService) GetPublic(ctx context.Context, username string) (*models.PublicProfile, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	// Suspended and deactivated accounts have no public profile
+	if user == nil || user.Username == nil || user.Status != models.StatusActive {
+		return nil, errors.New("profile not found")
+	}
+
+	prefs, err := s.GetPreferences(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PublicProfile{
+		Username:    *user.Username,
+		DisplayName: displayName(user),
+		AvatarURL:   user.AvatarURL,
+		MemberSince: user.CreatedAt,
+		Visibility: models.ProfileVisibility{
+			Library:  prefs.ShowLibrary,
+			Wishlist: prefs.ShowWishlist,
+			Activity: prefs.ShowActivity,
+		},
+	}, nil
+}
+
+func (s *profileService) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.ProfilePreferences, error) {
+	prefs, err := s.profileRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting profile preferences: %w", err)
+	}
+	if prefs == nil {
+		return &models.ProfilePreferences{UserID: userID}, nil
+	}
+
+	return prefs, nil
+}
+
+func (s *profileService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *models.UpdateProfilePreferencesRequest) (*models.ProfilePreferences, error) {
+	prefs, err := s.profileRepo.UpsertPreferences(ctx, &models.ProfilePreferences{
+		UserID:       userID,
+		ShowLibrary:  *req.ShowLibrary,
+		ShowWishlist: *req.ShowWishlist,
+		ShowActivity: *req.ShowActivity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving profile preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// displayName shortens the last name to an initial so public profiles
+// don't reveal a user's full name
+func displayName(user *models.User) string {
+	last := strings.TrimSpace(user.LastName)
+	if last == "" {
+		return user.FirstName
+	}
+	initial, _ := utf8.DecodeRuneInString(last)
+	return fmt.Sprintf("%s %c.", user.FirstName, initial)
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/oidc"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+// ssoTokenBytes is the entropy of the state, nonce and PKCE verifier
+// before encoding
+const ssoTokenBytes = 32
+
+// ssoRoleRank orders the roles SSO can grant, so a user in several mapped
+// groups gets the most privileged
+var ssoRoleRank = map[models.UserRole]int{
+	models.RoleAdmin:      1,
+	models.RoleSuperAdmin: 2,
+}
+
+// SSOPolicy decides who single sign-on lets in and as what
+type SSOPolicy struct {
+	// GroupRoles maps the provider's groups to admin roles. Users in none
+	// of them are turned away.
+	GroupRoles map[string]models.UserRole
+	// Required stops admin accounts logging in any other way
+	Required bool
+	// StateTTL is how long the provider's callback is waited for
+	StateTTL time.Duration
+}
+
+type SSOService interface {
+	// Begin starts a sign-in and returns where to send the browser
+	Begin(ctx context.Context) (*models.SSOBeginResponse, error)
+	// Finish completes the provider's callback and returns the user it
+	// signed in. Users are created on their first sign-in, and their role
+	// follows their groups on every one.
+	Finish(ctx context.Context, req *models.FinishSSOLoginRequest) (uuid.UUID, error)
+	// LocalLoginAllowed reports whether an account with role may log in
+	// with a password, magic link or passkey
+	LocalLoginAllowed(role models.UserRole) bool
+}
+
+type ssoService struct {
+	provider  oidc.Provider
+	ssoRepo   repository.SSORepository
+	userRepo  repository.UserRepository
+	auditRepo repository.AuditRepository
+	tx        repository.Transactor
+	policy    SSOPolicy
+}
+
+func NewSSOService(provider oidc.Provider, ssoRepo repository.SSORepository, userRepo repository.UserRepository, auditRepo repository.AuditRepository, tx repository.Transactor, policy SSOPolicy) SSOService {
+	return &ssoService{
+		provider:  provider,
+		ssoRepo:   ssoRepo,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		tx:        tx,
+		policy:    policy,
+	}
+}
+
+func (s *ssoService) Begin(ctx context.Context) (*models.SSOBeginResponse, error) {
+	var values [3]string
+	for i := range values {
+		value, err := generateSSOToken()
+		if err != nil {
+			return nil, fmt.Errorf("error generating sso state: %w", err)
+		}
+		values[i] = value
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("error building sso authorization URL: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.policy.StateTTL)
+	err = s.ssoRepo.CreateState(ctx, &models.SSOLoginState{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating sso state: %w", err)
+	}
+
+	return &models.SSOBeginResponse{AuthorizationURL: authURL, ExpiresAt: expiresAt}, nil
+}
+
+func (s *ssoService) Finish(ctx context.Context, req *models.FinishSSOLoginRequest) (uuid.UUID, error) {
+	// Consumed first, so a callback replayed after a failure doesn't get a second try
+	state, err := s.ssoRepo.ConsumeState(ctx, hashToken(req.State))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error consuming sso state: %w", err)
+	}
+	if state == nil {
+		return uuid.Nil, errors.New("sso session expired or already used")
+	}
+
+	identity, err := s.provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		var rejected *oidc.RejectedError
+		if errors.As(err, &rejected) {
+			return uuid.Nil, fmt.Errorf("sso login failed: %w", err)
+		}
+		return uuid.Nil, fmt.Errorf("error exchanging sso code: %w", err)
+	}
+
+	role, ok := s.roleFor(identity.Groups)
+	if !ok {
+		return uuid.Nil, errors.New("sso account not authorized for the admin console")
+	}
+
+	var userID uuid.UUID
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.resolveUser(ctx, identity, role)
+		if err != nil {
+			return err
+		}
+		userID = user.ID
+
+		if user.Role != role {
+			if err := s.userRepo.UpdateRole(ctx, user.ID, role); err != nil {
+				return fmt.Errorf("error updating user role: %w", err)
+			}
+			return s.audit(ctx, models.AuditUserRoleChanged, user.ID, map[string]interface{}{
+				"from":   string(user.Role),
+				"to":     string(role),
+				"groups": identity.Groups,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+// resolveUser finds the user the provider account signs in as. An
+// unlinked account is linked to the user with its email, when the
+// provider vouches for the email, or else gets a new user.
+func (s *ssoService) resolveUser(ctx context.Context, identity *oidc.Identity, role models.UserRole) (*models.User, error) {
+	linked, err := s.ssoRepo.GetIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sso identity: %w", err)
+	}
+	if linked != nil {
+		user, err := s.userRepo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting user: %w", err)
+		}
+		if user == nil {
+			return nil, errors.New("user not found")
+		}
+		if err := s.ssoRepo.TouchIdentity(ctx, identity.Issuer, identity.Subject, identity.Email); err != nil {
+			return nil, fmt.Errorf("error updating sso identity: %w", err)
+		}
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errors.New("sso login failed: the provider did not send a verified email")
+	}
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		user, err = s.provision(ctx, identity, role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = s.ssoRepo.CreateIdentity(ctx, &models.SSOIdentity{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		UserID:  user.ID,
+		Email:   identity.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error linking sso identity: %w", err)
+	}
+	return user, nil
+}
+
+// provision creates the user for a first sign-in. They get no password,
+// so SSO is the only way in.
+func (s *ssoService) provision(ctx context.Context, identity *oidc.Identity, role models.UserRole) (*models.User, error) {
+	firstName := identity.GivenName
+	if firstName == "" {
+		firstName, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	user, err := s.userRepo.Create(ctx, &models.User{
+		Email:     identity.Email,
+		FirstName: firstName,
+		LastName:  identity.FamilyName,
+		Role:      role,
+		Status:    models.StatusActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	err = s.audit(ctx, models.AuditUserProvisioned, user.ID, map[string]interface{}{
+		"issuer": identity.Issuer,
+		"role":   string(role),
+		"groups": identity.Groups,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// roleFor is the most privileged role any of groups maps to
+func (s *ssoService) roleFor(groups []string) (models.UserRole, bool) {
+	var best models.UserRole
+	for _, group := range groups {
+		if role, ok := s.policy.GroupRoles[group]; ok && ssoRoleRank[role] > ssoRoleRank[best] {
+			best = role
+		}
+	}
+	return best, best != ""
+}
+
+func (s *ssoService) LocalLoginAllowed(role models.UserRole) bool {
+	_, admin := ssoRoleRank[role]
+	return !s.policy.Required || !admin
+}
+
+// audit records a change made by the provider's say-so; there is no
+// acting user
+func (s *ssoService) audit(ctx context.Context, action string, userID uuid.UUID, metadata map[string]interface{}) error {
+	metadata["source"] = "sso"
+
+	entry := &models.AuditLog{
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		Metadata:   metadata,
+	}
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
+
+func generateSSOToken() (string, error) {
+	b := make([]byte, ssoTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+)
+
+type SuspensionService interface {
+	Issue(ctx context.Context, userID uuid.UUID, req *models.IssueSuspensionRequest, actor models.Actor) (*models.Suspension, error)
+	Lift(ctx context.Context, userID uuid.UUID, actor models.Actor) error
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.Suspension, error)
+	GetActive(ctx context.Context, userID uuid.UUID) (*models.Suspension, error)
+	// BulkUpdateStatus moves every listed user to req.Status in one transaction.
+	// Users that cannot be changed are reported in their result and skipped.
+	BulkUpdateStatus(ctx context.Context, req *models.BulkStatusRequest, actor models.Actor) ([]models.BulkStatusResult, error)
+	// ReinstateExpired lifts up to batchSize expired suspensions and returns how many were lifted
+	ReinstateExpired(ctx context.Context, batchSize int) (int, error)
+}
+
+type suspensionService struct {
+	suspensionRepo repository.SuspensionRepository
+	userRepo       repository.UserRepository
+	auditRepo      repository.AuditRepository
+	tx             repository.Transactor
+	publisher      events.Publisher
+}
+
+func NewSuspensionService(suspensionRepo repository.SuspensionRepository, userRepo repository.UserRepository, auditRepo repository.AuditRepository, tx repository.Transactor, publisher events.Publisher) SuspensionService {
+	return &suspensionService{
+		suspensionRepo: suspensionRepo,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		tx:             tx,
+		publisher:      publisher,
+	}
+}
+
+func (s *suspensionService) Issue(ctx context.Context, userID uuid.UUID, req *models.IssueSuspensionRequest, actor models.Actor) (*models.Suspension, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	if actor.UserID != nil && *actor.UserID == userID {
+		return nil, errors.New("cannot suspend yourself")
+	}
+	if user.Role == models.RoleSuperAdmin && actor.Role != models.RoleSuperAdmin {
+		return nil, errors.New("insufficient privileges to suspend this user")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("invalid expiry: expires_at must be in the future")
+	}
+
+	var suspension *models.Suspension
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		suspension, err = s.suspend(ctx, userID, req.Reason, req.ExpiresAt, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return suspension, nil
+}
+
+func (s *suspensionService) Lift(ctx context.Context, userID uuid.UUID, actor models.Actor) error {
+	if err := s.requireUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		lifted, err := s.liftAll(ctx, userID, actor)
+		if err != nil {
+			return err
+		}
+		if lifted == 0 {
+			return errors.New("no active suspension for this user")
+		}
+		return nil
+	})
+}
+
+func (s *suspensionService) BulkUpdateStatus(ctx context.Context, req *models.BulkStatusRequest, actor models.Actor) ([]models.BulkStatusResult, error) {
+	if req.Status == models.StatusSuspended && req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("invalid expiry: expires_at must be in the future")
+	}
+
+	results := make([]models.BulkStatusResult, 0, len(req.UserIDs))
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, userID := range req.UserIDs {
+			result, err := s.changeStatus(ctx, userID, req, actor)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// changeStatus applies one user's part of a bulk status change. Rule
+// violations are reported in the result; only storage errors are returned,
+// rolling back the whole batch.
+func (s *suspensionService) changeStatus(ctx context.Context, userID uuid.UUID, req *models.BulkStatusRequest, actor models.Actor) (models.BulkStatusResult, error) {
+	result := models.BulkStatusResult{UserID: userID, Result: models.BulkStatusUpdated}
+	skip := func(err error) (models.BulkStatusResult, error) {
+		result.Result = models.BulkStatusFailed
+		result.Err = err
+		return result, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return result, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return skip(errors.New("user not found"))
+	}
+	if actor.UserID != nil && *actor.UserID == userID {
+		return skip(errors.New("cannot change your own status"))
+	}
+	if user.Role == models.RoleSuperAdmin && actor.Role != models.RoleSuperAdmin {
+		return skip(errors.New("insufficient privileges to change this user's status"))
+	}
+	if user.Status == req.Status {
+		result.Result = models.BulkStatusUnchanged
+		return result, nil
+	}
+
+	switch {
+	case req.Status == models.StatusSuspended:
+		// A bare status change is not enforced at login, so issue a real suspension
+		_, err = s.suspend(ctx, userID, req.Reason, req.ExpiresAt, actor)
+		return result, err
+	case req.Status == models.StatusActive && user.Status == models.StatusSuspended:
+		lifted, err := s.liftAll(ctx, userID, actor)
+		if err != nil || lifted > 0 {
+			return result, err
+		}
+		// Suspended with nothing left to lift: the reinstatement job has not run yet
+	}
+
+	if err := s.userRepo.UpdateStatus(ctx, userID, req.Status); err != nil {
+		return result, fmt.Errorf("error updating user status: %w", err)
+	}
+	err = s.audit(ctx, actor, models.AuditUserStatusChanged, userID, map[string]interface{}{
+		"from": string(user.Status),
+		"to":   string(req.Status),
+	})
+	return result, err
+}
+
+// suspend issues a suspension and marks the user suspended. Callers run it
+// inside a transaction.
+func (s *suspensionService) suspend(ctx context.Context, userID uuid.UUID, reason string, expiresAt *time.Time, actor models.Actor) (*models.Suspension, error) {
+	suspension, err := s.suspensionRepo.Create(ctx, userID, reason, actor.UserID, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("error creating suspension: %w", err)
+	}
+
+	if err := s.userRepo.UpdateStatus(ctx, userID, models.StatusSuspended); err != nil {
+		return nil, fmt.Errorf("error updating user status: %w", err)
+	}
+
+	metadata := map[string]interface{}{
+		"suspension_id": suspension.ID.String(),
+		"reason":        reason,
+	}
+	if expiresAt != nil {
+		metadata["expires_at"] = expiresAt.UTC().Format(time.RFC3339)
+	}
+	if err := s.audit(ctx, actor, models.AuditSuspensionIssued, userID, metadata); err != nil {
+		return nil, err
+	}
+
+	err = s.publisher.Publish(ctx, events.UserSuspended{
+		UserID:       userID,
+		SuspensionID: suspension.ID,
+		Reason:       reason,
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return suspension, nil
+}
+
+// liftAll lifts every open suspension on a user and reactivates them,
+// returning how many were lifted. Nothing is written when there were none.
+// Callers run it inside a transaction.
+func (s *suspensionService) liftAll(ctx context.Context, userID uuid.UUID, actor models.Actor) (int64, error) {
+	lifted, err := s.suspensionRepo.LiftAllForUser(ctx, userID, actor.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("error lifting suspensions: %w", err)
+	}
+	if lifted == 0 {
+		return 0, nil
+	}
+
+	if err := s.reactivate(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	err = s.audit(ctx, actor, models.AuditSuspensionLifted, userID, map[string]interface{}{
+		"lifted_count": lifted,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.publisher.Publish(ctx, events.UserReinstated{UserID: userID}); err != nil {
+		return 0, err
+	}
+	return lifted, nil
+}
+
+func (s *suspensionService) ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.Suspension, error) {
+	if err := s.requireUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	suspensions, err := s.suspensionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing suspensions: %w", err)
+	}
+
+	return suspensions, nil
+}
+
+func (s *suspensionService) GetActive(ctx context.Context, userID uuid.UUID) (*models.Suspension, error) {
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting active suspension: %w", err)
+	}
+
+	return suspension, nil
+}
+
+func (s *suspensionService) ReinstateExpired(ctx context.Context, batchSize int) (int, error) {
+	expired, err := s.suspensionRepo.ListExpired(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error listing expired suspensions: %w", err)
+	}
+
+	reinstated := 0
+	for _, suspension := range expired {
+		lifted, err := s.expire(ctx, suspension)
+		if err != nil {
+			return reinstated, err
+		}
+		if lifted {
+			reinstated++
+		}
+	}
+
+	return reinstated, nil
+}
+
+// expire lifts one expired suspension, reactivating the user when no other
+// suspension is in force. It reports false when the suspension was already lifted.
+func (s *suspensionService) expire(ctx context.Context, suspension *models.Suspension) (bool, error) {
+	lifted := false
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		lifted, err = s.suspensionRepo.Lift(ctx, suspension.ID, nil)
+		if err != nil {
+			return fmt.Errorf("error lifting suspension %s: %w", suspension.ID, err)
+		}
+		if !lifted {
+			// Lifted concurrently by an admin or another worker
+			return nil
+		}
+
+		// A longer or permanent ban may still be in force
+		active, err := s.suspensionRepo.GetActiveByUser(ctx, suspension.UserID)
+		if err != nil {
+			return fmt.Errorf("error checking remaining suspensions: %w", err)
+		}
+		if active == nil {
+			if err := s.reactivate(ctx, suspension.UserID); err != nil {
+				return err
+			}
+		}
+
+		err = s.audit(ctx, models.Actor{}, models.AuditSuspensionExpired, suspension.UserID, map[string]interface{}{
+			"suspension_id": suspension.ID.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if active == nil {
+			return s.publisher.Publish(ctx, events.UserReinstated{UserID: suspension.UserID, Expired: true})
+		}
+		return nil
+	})
+
+	return lifted && err == nil, err
+}
+
+// requireUser fails with "user not found" unless the user is in the
+// request's tenant, so one store's admins can't reach another's users
+func (s *suspensionService) requireUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// reactivate restores a suspended user to active; users deactivated for
+// other reasons keep their status
+func (s *suspensionService) reactivate(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil || user.Status != models.StatusSuspended {
+		return nil
+	}
+
+	if err := s.userRepo.UpdateStatus(ctx, userID, models.StatusActive); err != nil {
+		return fmt.Errorf("error updating user status: %w", err)
+	}
+	return nil
+}
+
+func (s *suspensionService) audit(ctx context.Context, actor models.Actor, action string, userID uuid.UUID, metadata map[string]interface{}) error {
+	entry := &models.AuditLog{
+		ActorID:    actor.UserID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		Metadata:   metadata,
+	}
+	if actor.IPAddress != "" {
+		entry.IPAddress = &actor.IPAddress
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
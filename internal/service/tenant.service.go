@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/tenancy"
+)
+
+type TenantService interface {
+	// Resolve finds the tenant a request is for: by slug when the client
+	// names one, which must exist, otherwise by host, falling back to the
+	// default tenant for hosts no tenant claims
+	Resolve(ctx context.Context, slug, host string) (*models.Tenant, error)
+	// Current returns the tenant in the context, or the default tenant
+	Current(ctx context.Context) (*models.Tenant, error)
+	// Update changes the branding, currency and fee of the tenant in the context
+	Update(ctx context.Context, req *models.UpdateTenantRequest, actor models.Actor) (*models.Tenant, error)
+}
+
+type tenantService struct {
+	tenantRepo repository.TenantRepository
+	auditRepo  repository.AuditRepository
+	tx         repository.Transactor
+	cacheTTL   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedTenant
+}
+
+// maxCachedTenants bounds the cache, which is keyed by client-supplied
+// hosts; it starts over once full
+const maxCachedTenants = 1024
+
+// cachedTenant is a lookup result; a nil tenant remembers a miss
+type cachedTenant struct {
+	tenant    *models.Tenant
+	expiresAt time.Time
+}
+
+func NewTenantService(tenantRepo repository.TenantRepository, auditRepo repository.AuditRepository, tx repository.Transactor, cacheTTL time.Duration) TenantService {
+	return &tenantService{
+		tenantRepo: tenantRepo,
+		auditRepo:  auditRepo,
+		tx:         tx,
+		cacheTTL:   cacheTTL,
+		cache:      make(map[string]cachedTenant),
+	}
+}
+
+func (s *tenantService) Resolve(ctx context.Context, slug, host string) (*models.Tenant, error) {
+	if slug != "" {
+		tenant, err := s.lookup("slug:"+slug, func() (*models.Tenant, error) {
+			return s.tenantRepo.GetBySlug(ctx, slug)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting tenant: %w", err)
+		}
+		if tenant == nil {
+			return nil, errors.New("tenant not found")
+		}
+		return tenant, nil
+	}
+
+	host = strings.ToLower(host)
+	tenant, err := s.lookup("host:"+host, func() (*models.Tenant, error) {
+		return s.tenantRepo.GetByHostname(ctx, host)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting tenant: %w", err)
+	}
+	if tenant != nil {
+		return tenant, nil
+	}
+	return s.defaultTenant(ctx)
+}
+
+func (s *tenantService) Current(ctx context.Context) (*models.Tenant, error) {
+	if tenant := tenancy.FromContext(ctx); tenant != nil {
+		return tenant, nil
+	}
+	return s.defaultTenant(ctx)
+}
+
+func (s *tenantService) Update(ctx context.Context, req *models.UpdateTenantRequest, actor models.Actor) (*models.Tenant, error) {
+	current, err := s.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *current
+	updated.Name = req.Name
+	updated.Branding = req.Branding
+	updated.Currency = req.Currency
+	updated.FeeBps = *req.FeeBps
+
+	var tenant *models.Tenant
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		tenant, err = s.tenantRepo.Update(ctx, &updated)
+		if err != nil {
+			return fmt.Errorf("error updating tenant: %w", err)
+		}
+		if tenant == nil {
+			return errors.New("tenant not found")
+		}
+
+		entry := &models.AuditLog{
+			ActorID:    actor.UserID,
+			Action:     models.AuditTenantUpdated,
+			TargetType: "tenant",
+			TargetID:   tenant.ID,
+			Metadata: map[string]interface{}{
+				"currency":          tenant.Currency,
+				"fee_bps":           tenant.FeeBps,
+				"previous_currency": current.Currency,
+				"previous_fee_bps":  current.FeeBps,
+			},
+		}
+		if actor.IPAddress != "" {
+			entry.IPAddress = &actor.IPAddress
+		}
+		if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+			return fmt.Errorf("error writing audit log: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Other instances pick the change up when their entries expire
+	s.mu.Lock()
+	clear(s.cache)
+	s.mu.Unlock()
+
+	return tenant, nil
+}
+
+func (s *tenantService) defaultTenant(ctx context.Context) (*models.Tenant, error) {
+	tenant, err := s.lookup("id:"+models.DefaultTenantID.String(), func() (*models.Tenant, error) {
+		return s.tenantRepo.GetByID(ctx, models.DefaultTenantID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting default tenant: %w", err)
+	}
+	if tenant == nil {
+		return nil, errors.New("default tenant is missing; run the tenants migration")
+	}
+	return tenant, nil
+}
+
+// lookup answers from the cache while the entry is fresh, misses included,
+// so every request does not cost a query
+func (s *tenantService) lookup(key string, load func() (*models.Tenant, error)) (*models.Tenant, error) {
+	if s.cacheTTL <= 0 {
+		return load()
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.tenant, nil
+	}
+
+	tenant, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(s.cache) >= maxCachedTenants {
+		clear(s.cache)
+	}
+	s.cache[key] = cachedTenant{tenant: tenant, expiresAt: now.Add(s.cacheTTL)}
+	s.mu.Unlock()
+	return tenant, nil
+}
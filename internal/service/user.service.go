@@ -4,41 +4,71 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
 	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/repository"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService interface {
-	CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error)
+	// CreateUser signs a user up from ipAddress. Signups flagged by fraud
+	// checks are refused, or created inactive and queued for review.
+	CreateUser(ctx context.Context, req *models.CreateUserRequest, ipAddress string) (*models.UserResponse, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.UserResponse, error)
+	// GetUsersByIDs looks ids up in one query, answering one result per ID
+	// in the order given, repeats included
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]models.BatchUserResult, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.UserResponse, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.PublicUserResponse, error)
+	// UsernameAvailability reports whether username could be registered right now
+	UsernameAvailability(ctx context.Context, username string) (*models.UsernameAvailability, error)
 	UpdateUser(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest) (*models.UserResponse, error)
 	UpdateUserStatus(ctx context.Context, id uuid.UUID, status models.UserStatus) error
 	ListUsers(ctx context.Context, role *models.UserRole, status *models.UserStatus, page, limit int) ([]*models.UserResponse, error)
+	// ListPublicUsers lists users as anyone may see them. It takes no role or
+	// status filter, since filtering would reveal the account state it hides.
+	ListPublicUsers(ctx context.Context, page, limit int) ([]*models.PublicUserResponse, error)
 	Login(ctx context.Context, req *models.LoginRequest) (*models.UserResponse, error)
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo          repository.UserRepository
+	moderationService ModerationService
+	fraudService      FraudService
+	tx                repository.Transactor
+	publisher         events.Publisher
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
+// NewUserService builds the user service; a nil fraudService skips fraud
+// checks at signup
+func NewUserService(userRepo repository.UserRepository, moderationService ModerationService, fraudService FraudService, tx repository.Transactor, publisher events.Publisher) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		moderationService: moderationService,
+		fraudService:      fraudService,
+		tx:                tx,
+		publisher:         publisher,
 	}
 }
 
-func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error) {
-	// Check if user already exists
-	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
-	if err != nil {
-		return nil, fmt.Errorf("error checking existing user: %w", err)
+func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequest, ipAddress string) (*models.UserResponse, error) {
+	var assessment *models.FraudAssessment
+	if s.fraudService != nil {
+		var err error
+		assessment, err = s.fraudService.AssessSignup(ctx, req.Email, ipAddress)
+		if err != nil {
+			return nil, err
+		}
 	}
-	if existingUser != nil {
-		return nil, errors.New("user with this email already exists")
+	if assessment != nil && assessment.Decision == models.FraudBlock {
+		// Recorded so repeated attempts keep counting towards velocity limits
+		if _, err := s.fraudService.Record(ctx, assessment); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("signup rejected by risk checks")
 	}
 
 	// Hash password
@@ -53,23 +83,75 @@ func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequ
 		PasswordHash: string(hashedPassword),
 		FirstName:    req.FirstName,
 		LastName:     req.LastName,
-		Role:         req.Role,
+		Role:         models.RoleGamer,
 		Status:       models.StatusActive,
 	}
+	if assessment != nil && assessment.Decision == models.FraudReview {
+		// Held until an admin approves the signup in the moderation queue
+		user.Status = models.StatusInactive
+	}
 
 	if req.Phone != "" {
 		user.Phone = &req.Phone
 	}
+	if req.Username != "" {
+		user.Username = &req.Username
+	}
+
+	// Create user in database, with its event in the same transaction
+	var createdUser *models.User
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		createdUser, err = s.userRepo.Create(ctx, user)
+		if err != nil {
+			// The unique indexes decide concurrent signups
+			if errors.Is(err, repository.ErrConflict) {
+				return conflictError(err)
+			}
+			return fmt.Errorf("error creating user: %w", err)
+		}
 
-	// Create user in database
-	createdUser, err := s.userRepo.Create(ctx, user)
+		if assessment != nil {
+			if err := s.holdForReview(ctx, createdUser, assessment); err != nil {
+				return err
+			}
+		}
+
+		return s.publisher.Publish(ctx, events.UserCreated{
+			UserID: createdUser.ID,
+			Email:  createdUser.Email,
+			Role:   createdUser.Role,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating user: %w", err)
+		return nil, err
 	}
 
 	return s.userToResponse(createdUser), nil
 }
 
+// holdForReview records a new user's fraud assessment and, when it asks
+// for review, queues the signup for an admin
+func (s *userService) holdForReview(ctx context.Context, user *models.User, assessment *models.FraudAssessment) error {
+	assessment.UserID = &user.ID
+	recorded, err := s.fraudService.Record(ctx, assessment)
+	if err != nil {
+		return err
+	}
+	if recorded.Decision != models.FraudReview {
+		return nil
+	}
+
+	rules := make([]string, len(recorded.Signals))
+	for i, signal := range recorded.Signals {
+		rules[i] = signal.Rule
+	}
+	summary := fmt.Sprintf("risk score %d (%s), assessment %s", recorded.Score, strings.Join(rules, ", "), recorded.ID)
+	if _, err := s.moderationService.Submit(ctx, models.SubjectSignup, user.ID, user.ID, summary); err != nil {
+		return fmt.Errorf("error queueing signup for review: %w", err)
+	}
+	return nil
+}
+
 func (s *userService) GetUserByID(ctx context.Context, id uuid.UUID) (*models.UserResponse, error) {
 	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
@@ -82,6 +164,25 @@ func (s *userService) GetUserByID(ctx context.Context, id uuid.UUID) (*models.Us
 	return s.userToResponse(user), nil
 }
 
+func (s *userService) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]models.BatchUserResult, error) {
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error getting users: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*models.PublicUserResponse, len(users))
+	for _, user := range users {
+		byID[user.ID] = publicUser(user)
+	}
+
+	results := make([]models.BatchUserResult, len(ids))
+	for i, id := range ids {
+		user, found := byID[id]
+		results[i] = models.BatchUserResult{PublicUserResponse: user, ID: id, Found: found}
+	}
+	return results, nil
+}
+
 func (s *userService) GetUserByEmail(ctx context.Context, email string) (*models.UserResponse, error) {
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
@@ -94,6 +195,40 @@ func (s *userService) GetUserByEmail(ctx context.Context, email string) (*models
 	return s.userToResponse(user), nil
 }
 
+func (s *userService) GetUserByUsername(ctx context.Context, username string) (*models.PublicUserResponse, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	return publicUser(user), nil
+}
+
+func (s *userService) UsernameAvailability(ctx context.Context, username string) (*models.UsernameAvailability, error) {
+	availability := &models.UsernameAvailability{Username: username}
+
+	switch {
+	case !models.ValidUsername(username):
+		availability.Reason = models.UsernameInvalid
+	case models.ReservedUsername(username):
+		availability.Reason = models.UsernameReserved
+	default:
+		existing, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("error checking username: %w", err)
+		}
+		if existing != nil {
+			availability.Reason = models.UsernameTaken
+		}
+	}
+
+	availability.Available = availability.Reason == ""
+	return availability, nil
+}
+
 func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest) (*models.UserResponse, error) {
 	// Get existing user
 	existingUser, err := s.userRepo.GetByID(ctx, id)
@@ -111,16 +246,26 @@ func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req *models.
 	if req.Phone != "" {
 		existingUser.Phone = &req.Phone
 	}
-	if req.AvatarURL != "" {
-		existingUser.AvatarURL = &req.AvatarURL
+	if req.Username != "" {
+		existingUser.Username = &req.Username
 	}
 
 	// Update user in database
 	updatedUser, err := s.userRepo.Update(ctx, existingUser)
 	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, conflictError(err)
+		}
 		return nil, fmt.Errorf("error updating user: %w", err)
 	}
 
+	// New avatars stay hidden until a moderator approves them
+	if req.AvatarURL != "" && (existingUser.AvatarURL == nil || *existingUser.AvatarURL != req.AvatarURL) {
+		if _, err := s.moderationService.Submit(ctx, models.SubjectAvatar, id, id, req.AvatarURL); err != nil {
+			return nil, fmt.Errorf("error submitting avatar: %w", err)
+		}
+	}
+
 	return s.userToResponse(updatedUser), nil
 }
 
@@ -153,6 +298,22 @@ func (s *userService) ListUsers(ctx context.Context, role *models.UserRole, stat
 	return responses, nil
 }
 
+func (s *userService) ListPublicUsers(ctx context.Context, page, limit int) ([]*models.PublicUserResponse, error) {
+	offset := (page - 1) * limit
+
+	users, err := s.userRepo.List(ctx, nil, nil, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+
+	responses := make([]*models.PublicUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = publicUser(user)
+	}
+
+	return responses, nil
+}
+
 func (s *userService) Login(ctx context.Context, req *models.LoginRequest) (*models.UserResponse, error) {
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
@@ -169,6 +330,9 @@ func (s *userService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	}
 
 	// Check if user is active
+	if user.Status == models.StatusSuspended {
+		return nil, errors.New("user account is suspended")
+	}
 	if user.Status != models.StatusActive {
 		return nil, errors.New("user account is inactive")
 	}
@@ -176,6 +340,14 @@ func (s *userService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	return s.userToResponse(user), nil
 }
 
+// conflictError names the unique field a write collided with
+func conflictError(err error) error {
+	if strings.Contains(err.Error(), "username") {
+		return fmt.Errorf("username is already taken: %w", err)
+	}
+	return fmt.Errorf("user with this email already exists: %w", err)
+}
+
 // Helper function to convert user model to response
 func (s *userService) userToResponse(user *models.User) *models.UserResponse {
 	return &models.UserResponse{
@@ -187,7 +359,19 @@ func (s *userService) userToResponse(user *models.User) *models.UserResponse {
 		Status:    user.Status,
 		AvatarURL: user.AvatarURL,
 		Phone:     user.Phone,
+		Username:  user.Username,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
 }
+
+// publicUser is the part of user that anyone may look up
+func publicUser(user *models.User) *models.PublicUserResponse {
+	return &models.PublicUserResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		DisplayName: displayName(user),
+		AvatarURL:   user.AvatarURL,
+		MemberSince: user.CreatedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+)
+
+// Handler processes one streamed event. Delivery is at least once, so
+// handlers must be idempotent; Envelope.ID identifies redeliveries.
+type Handler func(ctx context.Context, envelope *Envelope) error
+
+// Consumer reads events from a broker as part of a consumer group
+type Consumer interface {
+	// Consume delivers messages from topics to handler until ctx is cancelled
+	Consume(ctx context.Context, topics []string, handler Handler) error
+	Close() error
+}
+
+func NewConsumer(opts Options) (Consumer, error) {
+	if opts.Group == "" {
+		return nil, fmt.Errorf("a consumer group is required")
+	}
+
+	switch opts.Broker {
+	case BrokerNATS:
+		return newNATSConsumer(opts), nil
+	case BrokerKafka:
+		return newKafkaConsumer(opts), nil
+	default:
+		return nil, fmt.Errorf("unknown stream broker %q", opts.Broker)
+	}
+}
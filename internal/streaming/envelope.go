@@ -0,0 +1,55 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+)
+
+// schemaVersions is the current payload version of each event. Bump an
+// entry whenever a change to the event struct would break existing
+// consumers; additive fields don't need a bump.
+var schemaVersions = map[string]int{
+	events.NameUserCreated:    1,
+	events.NameUserSuspended:  1,
+	events.NameUserReinstated: 1,
+}
+
+// Envelope is the wire format of every streamed event. Consumers switch on
+// Schema, e.g. "user.created.v1", before decoding Data.
+type Envelope struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	Schema     string          `json:"schema"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEnvelope wraps event for streaming. id identifies this occurrence so
+// consumers can drop redeliveries.
+func NewEnvelope(id uuid.UUID, event events.Event, occurredAt time.Time) (*Envelope, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s: %w", event.EventName(), err)
+	}
+
+	return &Envelope{
+		ID:         id,
+		Type:       event.EventName(),
+		Schema:     SchemaOf(event.EventName()),
+		OccurredAt: occurredAt.UTC(),
+		Data:       data,
+	}, nil
+}
+
+// SchemaOf returns the versioned schema name for an event name
+func SchemaOf(name string) string {
+	version, ok := schemaVersions[name]
+	if !ok {
+		version = 1
+	}
+	return fmt.Sprintf("%s.v%d", name, version)
+}
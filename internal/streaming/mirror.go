@@ -0,0 +1,50 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+)
+
+// Mirror forwards domain events to a broker. The outbox relay calls Send
+// for each pending message, so delivery is at least once.
+type Mirror struct {
+	producer Producer
+	topics   Topics
+}
+
+func NewMirror(producer Producer, topics Topics) *Mirror {
+	return &Mirror{producer: producer, topics: topics}
+}
+
+// Send publishes one event occurrence to its topic. id must be stable
+// across retries so consumers and the broker can drop duplicates.
+func (m *Mirror) Send(ctx context.Context, id uuid.UUID, event events.Event, occurredAt time.Time) error {
+	envelope, err := NewEnvelope(id, event, occurredAt)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("error encoding envelope: %w", err)
+	}
+
+	msg := Message{
+		ID:    id.String(),
+		Topic: m.topics.For(event.EventName()),
+		Value: payload,
+		Headers: map[string]string{
+			"content-type": "application/json",
+			"schema":       envelope.Schema,
+		},
+	}
+	if keyed, ok := event.(events.Keyed); ok {
+		msg.Key = keyed.EventKey()
+	}
+
+	return m.producer.Publish(ctx, msg)
+}
@@ -0,0 +1,140 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// keyHeader carries Message.Key on NATS, which has no partition key
+const keyHeader = "Marketplace-Key"
+
+type natsProducer struct {
+	opts Options
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func newNATSProducer(opts Options) *natsProducer {
+	return &natsProducer{opts: opts}
+}
+
+// Connect dials NATS and makes sure the stream captures every topic
+func (p *natsProducer) Connect(ctx context.Context) error {
+	conn, js, err := connectJetStream(p.opts, "marketplace-producer")
+	if err != nil {
+		return err
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     p.opts.Stream,
+		Subjects: p.opts.Topics.All(),
+	})
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("error configuring stream %s: %w", p.opts.Stream, err)
+	}
+
+	p.conn, p.js = conn, js
+	return nil
+}
+
+func (p *natsProducer) Publish(ctx context.Context, msg Message) error {
+	if p.js == nil {
+		return fmt.Errorf("nats producer is not connected")
+	}
+
+	natsMsg := nats.NewMsg(msg.Topic)
+	natsMsg.Data = msg.Value
+	for name, value := range msg.Headers {
+		natsMsg.Header.Set(name, value)
+	}
+	if msg.Key != "" {
+		natsMsg.Header.Set(keyHeader, msg.Key)
+	}
+
+	// The message ID lets JetStream drop redeliveries within its dedupe window
+	_, err := p.js.PublishMsg(ctx, natsMsg, jetstream.WithMsgID(msg.ID))
+	return err
+}
+
+func (p *natsProducer) Close() error {
+	if p.conn != nil {
+		return p.conn.Drain()
+	}
+	return nil
+}
+
+type natsConsumer struct {
+	opts Options
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func newNATSConsumer(opts Options) *natsConsumer {
+	return &natsConsumer{opts: opts}
+}
+
+func (c *natsConsumer) Consume(ctx context.Context, topics []string, handler Handler) error {
+	conn, js, err := connectJetStream(c.opts, c.opts.Group)
+	if err != nil {
+		return err
+	}
+	c.conn, c.js = conn, js
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, c.opts.Stream, jetstream.ConsumerConfig{
+		Durable:        c.opts.Group,
+		FilterSubjects: topics,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating consumer %s: %w", c.opts.Group, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		var envelope Envelope
+		if err := json.Unmarshal(msg.Data(), &envelope); err != nil {
+			// Redelivering a malformed message can't help
+			msg.Term()
+			return
+		}
+		if err := handler(ctx, &envelope); err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	})
+	if err != nil {
+		return fmt.Errorf("error consuming: %w", err)
+	}
+	defer consumeCtx.Stop()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (c *natsConsumer) Close() error {
+	if c.conn != nil {
+		return c.conn.Drain()
+	}
+	return nil
+}
+
+func connectJetStream(opts Options, name string) (*nats.Conn, jetstream.JetStream, error) {
+	conn, err := nats.Connect(strings.Join(opts.URLs, ","), nats.Name(name), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("error opening jetstream: %w", err)
+	}
+
+	return conn, js, nil
+}
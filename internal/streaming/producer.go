@@ -0,0 +1,84 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/events"
+)
+
+// Supported brokers
+const (
+	BrokerNATS  = "nats"
+	BrokerKafka = "kafka"
+)
+
+// Message is one record sent to a broker. Key picks the partition (Kafka)
+// and is carried as a header on NATS; ID is used for broker-side dedupe.
+type Message struct {
+	ID      string
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Producer publishes messages to a broker. Connect is called once before
+// the first Publish; Publish returns only after the broker acknowledged.
+type Producer interface {
+	Connect(ctx context.Context) error
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Options is the broker connection shared by producers and consumers
+type Options struct {
+	Broker string
+	// URLs are NATS server URLs or Kafka bootstrap brokers
+	URLs   []string
+	Topics Topics
+	// Stream is the JetStream stream holding every topic; NATS only
+	Stream string
+	// Group is the consumer group (Kafka) or durable consumer name (NATS)
+	Group string
+}
+
+// NewProducer returns a producer for opts.Broker. It does not connect.
+func NewProducer(opts Options) (Producer, error) {
+	switch opts.Broker {
+	case BrokerNATS:
+		return newNATSProducer(opts), nil
+	case BrokerKafka:
+		return newKafkaProducer(opts), nil
+	default:
+		return nil, fmt.Errorf("unknown stream broker %q", opts.Broker)
+	}
+}
+
+// Topics maps event names to broker topics. Events without an override go
+// to Prefix followed by the event name, e.g. "marketplace.user.created".
+type Topics struct {
+	Prefix    string
+	Overrides map[string]string
+}
+
+func (t Topics) For(eventName string) string {
+	if topic, ok := t.Overrides[eventName]; ok {
+		return topic
+	}
+	return t.Prefix + eventName
+}
+
+// All returns the topic of every known event, without duplicates
+func (t Topics) All() []string {
+	seen := make(map[string]struct{})
+	var topics []string
+	for _, name := range events.Names() {
+		topic := t.For(name)
+		if _, ok := seen[topic]; !ok {
+			seen[topic] = struct{}{}
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
@@ -0,0 +1,41 @@
+// Package tenancy carries the tenant a request was resolved to, so
+// repositories can scope their queries without every caller passing it.
+package tenancy
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/internal/models"
+)
+
+type contextKey struct{}
+
+func WithTenant(ctx context.Context, tenant *models.Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// FromContext returns the request's tenant, or nil outside a request, as in
+// background jobs
+func FromContext(ctx context.Context) *models.Tenant {
+	tenant, _ := ctx.Value(contextKey{}).(*models.Tenant)
+	return tenant
+}
+
+// ID is the tenant new rows and lookups by email or username belong to:
+// the request's, or the default tenant outside a request
+func ID(ctx context.Context) uuid.UUID {
+	if tenant := FromContext(ctx); tenant != nil {
+		return tenant.ID
+	}
+	return models.DefaultTenantID
+}
+
+// ScopeID restricts lookups by ID to the request's tenant. It is nil
+// outside a request, so jobs acting on a stored ID see every tenant.
+func ScopeID(ctx context.Context) *uuid.UUID {
+	if tenant := FromContext(ctx); tenant != nil {
+		return &tenant.ID
+	}
+	return nil
+}
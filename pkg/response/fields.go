@@ -0,0 +1,144 @@
+package response
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ParseFields splits a ?fields= value into field names, dropping blanks
+// and repeats; nil means the client wants every field
+func ParseFields(value string) []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// FieldNames lists the top-level JSON fields of data, which is a struct,
+// a pointer to one or a slice of either; nil for anything else
+func FieldNames(data interface{}) []string {
+	t := reflect.TypeOf(data)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// A field declared on the struct itself shadows a promoted one
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range structFieldNames(t) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func structFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		// Untagged embedded structs have their fields promoted by encoding/json
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				names = append(names, structFieldNames(embedded)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// Project trims data to the named top-level fields, keeping each object's
+// fields in their usual order. Fields must come from FieldNames(data).
+func Project(data interface{}, fields []string) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(encoded, &items); err == nil {
+		projected := make([]json.RawMessage, len(items))
+		for i, item := range items {
+			if projected[i], err = projectObject(item, keep); err != nil {
+				return nil, err
+			}
+		}
+		return projected, nil
+	}
+	return projectObject(encoded, keep)
+}
+
+// projectObject drops the fields of one JSON object that aren't kept.
+// Decoding token by token preserves the field order of the struct.
+func projectObject(object json.RawMessage, keep map[string]bool) (json.RawMessage, error) {
+	if string(object) == "null" {
+		return object, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(object)))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		name, _ := token.(string)
+		if !keep[name] {
+			continue
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return json.RawMessage(b.String()), nil
+}
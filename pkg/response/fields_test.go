@@ -0,0 +1,94 @@
+package response
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type base struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type item struct {
+	base
+	Name    string `json:"display_name"`
+	Price   int    `json:"price,omitempty"`
+	Hidden  string `json:"-"`
+	Untaged string
+	secret  string
+}
+
+func TestParseFields(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"id", []string{"id"}},
+		{"id, name ,price", []string{"id", "name", "price"}},
+		{"id,,name,", []string{"id", "name"}},
+		{"id,name,id", []string{"id", "name"}},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			if got := ParseFields(tc.value); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ParseFields(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFieldNames(t *testing.T) {
+	want := []string{"id", "name", "display_name", "price", "Untaged"}
+
+	for _, tc := range []struct {
+		name string
+		data interface{}
+		want []string
+	}{
+		{"struct", item{}, want},
+		{"pointer", &item{}, want},
+		{"slice of pointers", []*item{}, want},
+		{"map", map[string]int{}, nil},
+		{"string", "id", nil},
+		{"nil", nil, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FieldNames(tc.data); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("FieldNames = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	one := &item{base: base{ID: 1, Name: "base"}, Name: "Ada", Price: 5}
+
+	for _, tc := range []struct {
+		name   string
+		data   interface{}
+		fields []string
+		want   string
+	}{
+		{"object", one, []string{"price", "id"}, `{"id":1,"price":5}`},
+		{"slice", []*item{one, {base: base{ID: 2}}}, []string{"id"}, `[{"id":1},{"id":2}]`},
+		{"omitted field", &item{base: base{ID: 3}}, []string{"id", "price"}, `{"id":3}`},
+		{"nil element", []*item{nil}, []string{"id"}, `[null]`},
+		{"no fields", one, nil, `{}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			projected, err := Project(tc.data, tc.fields)
+			if err != nil {
+				t.Fatalf("Project: %v", err)
+			}
+			got, err := json.Marshal(projected)
+			if err != nil {
+				t.Fatalf("encoding projection: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("Project = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
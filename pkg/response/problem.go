@@ -0,0 +1,58 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the RFC 7807 media type clients opt into via Accept
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error body. Type is a relative URI identifying the
+// error class; TraceID correlates with the access log's request_id.
+type Problem struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance,omitempty"`
+	TraceID  string      `json:"trace_id,omitempty"`
+	Errors   interface{} `json:"errors,omitempty"`
+}
+
+// NewProblem builds a problem for an i18n error key, e.g. "error.user_not_found"
+// becomes type "/problems/user_not_found"
+func NewProblem(statusCode int, key, detail string, r *http.Request, traceID string) Problem {
+	name := key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		name = key[i+1:]
+	}
+
+	return Problem{
+		Type:     "/problems/" + name,
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		TraceID:  traceID,
+	}
+}
+
+// WantsProblem reports whether the client asked for problem+json errors.
+// Clients that don't keep receiving the standard envelope.
+func WantsProblem(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(accept, ";", 2)[0])
+		if strings.EqualFold(mediaType, ProblemContentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func WriteProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -1,7 +1,9 @@
 package response
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -31,6 +33,17 @@ func JSON(w http.ResponseWriter, statusCode int, response interface{}) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// CSV writes rows as a downloadable CSV attachment
+func CSV(w http.ResponseWriter, filename string, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	writer.WriteAll(rows)
+}
+
 func Success(data interface{}) Response {
 	return Response{
 		Success: true,
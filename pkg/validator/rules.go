@@ -0,0 +1,148 @@
+package validator
+
+import (
+	_ "embed"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			passwords[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return passwords
+}()
+
+// E.164: leading +, country code without a leading zero, at most 15 digits
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// postalCodePatterns covers countries with a well-known postal code format;
+// other countries fall back to genericPostalCode
+var postalCodePatterns = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"CA": regexp.MustCompile(`^[A-Za-z]\d[A-Za-z] ?\d[A-Za-z]\d$`),
+	"GB": regexp.MustCompile(`^[A-Za-z]{1,2}\d[A-Za-z\d]? ?\d[A-Za-z]{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Za-z]{2}$`),
+	"ET": regexp.MustCompile(`^\d{4}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"IN": regexp.MustCompile(`^\d{6}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+}
+
+var genericPostalCode = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 -]{1,9}$`)
+
+// countriesWithoutPostalCodes accept an empty postal code
+var countriesWithoutPostalCodes = map[string]struct{}{
+	"AE": {}, "AG": {}, "AO": {}, "BS": {}, "BZ": {}, "HK": {}, "IE": {},
+	"QA": {}, "TZ": {}, "UG": {}, "ZW": {},
+}
+
+// minPasswordClasses is how many of lower, upper, digit and symbol a password must mix
+const minPasswordClasses = 3
+
+// Domain supplies what the user_role, user_status and username tags accept,
+// so the rules don't depend on the application's models. A zero Domain
+// accepts no role or status and any username.
+type Domain struct {
+	Roles    []string
+	Statuses []string
+	// Username reports whether a name may be registered
+	Username func(name string) bool
+}
+
+func registerCustomRules(validate *validator.Validate, domain Domain) {
+	validate.RegisterValidation("user_role", oneOf(domain.Roles))
+	validate.RegisterValidation("user_status", oneOf(domain.Statuses))
+	validate.RegisterValidation("password_strength", validatePasswordStrength)
+	validate.RegisterValidation("phone", validatePhone)
+	validate.RegisterValidation("postal_code", validatePostalCode)
+	validate.RegisterValidation("username", validateUsername(domain.Username))
+}
+
+// oneOf accepts exactly the allowed values
+func oneOf(allowed []string) validator.Func {
+	set := make(map[string]struct{}, len(allowed))
+	for _, value := range allowed {
+		set[value] = struct{}{}
+	}
+	return func(fl validator.FieldLevel) bool {
+		_, ok := set[fl.Field().String()]
+		return ok
+	}
+}
+
+func validatePasswordStrength(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+
+	if _, common := commonPasswords[strings.ToLower(password)]; common {
+		return false
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+
+	return classes >= minPasswordClasses
+}
+
+func validatePhone(fl validator.FieldLevel) bool {
+	return e164Pattern.MatchString(fl.Field().String())
+}
+
+func validateUsername(allowed func(name string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return allowed == nil || allowed(fl.Field().String())
+	}
+}
+
+// validatePostalCode checks the field against the format for the country
+// held in the struct field named by the tag param, e.g. postal_code=CountryCode
+func validatePostalCode(fl validator.FieldLevel) bool {
+	countryField, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found || kind != reflect.String {
+		return false
+	}
+	country := strings.ToUpper(countryField.String())
+	postalCode := strings.TrimSpace(fl.Field().String())
+
+	if postalCode == "" {
+		_, optional := countriesWithoutPostalCodes[country]
+		return optional
+	}
+	if pattern, ok := postalCodePatterns[country]; ok {
+		return pattern.MatchString(postalCode)
+	}
+	return genericPostalCode.MatchString(postalCode)
+}
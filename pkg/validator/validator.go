@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/johnmerga/realgaming-marketplace-backend/marketplace-backend/pkg/i18n"
 )
 
 type ValidationRule interface {
@@ -33,7 +35,9 @@ func (ve ValidationErrors) Error() string {
 	return fmt.Sprintf("validation failed with %d errors", len(ve.Errors))
 }
 
-func New() *Validator {
+// New validates against the built-in rules and the domain's roles,
+// statuses and usernames
+func New(domain Domain) *Validator {
 	validate := validator.New()
 
 	// Register custom tag name function to use json tags
@@ -45,16 +49,33 @@ func New() *Validator {
 		return name
 	})
 
+	// Register domain-specific validation tags
+	registerCustomRules(validate, domain)
+
 	return &Validator{validate: validate}
 }
 
 func (v *Validator) ValidateStruct(s interface{}) error {
+	return v.validateStruct(s, i18n.DefaultLanguage)
+}
+
+func (v *Validator) validateStruct(s interface{}, lang string) error {
 	if err := v.validate.Struct(s); err != nil {
-		return v.formatValidationErrors(err)
+		return v.formatValidationErrors(err, lang)
+	}
+	return nil
+}
+
+// ValidateVar validates a single value, e.g. a query parameter, against a tag
+func (v *Validator) ValidateVar(field interface{}, tag string) error {
+	if err := v.validate.Var(field, tag); err != nil {
+		return v.formatValidationErrors(err, i18n.DefaultLanguage)
 	}
 	return nil
 }
 
+// ValidateAndParseJSON decodes the body into schema and validates it, using
+// the language negotiated for the request for field messages
 func (v *Validator) ValidateAndParseJSON(r *http.Request, schema ValidationRule) error {
 	// Parse JSON body
 	if err := json.NewDecoder(r.Body).Decode(schema.GetSchema()); err != nil {
@@ -62,10 +83,10 @@ func (v *Validator) ValidateAndParseJSON(r *http.Request, schema ValidationRule)
 	}
 
 	// Validate struct
-	return v.ValidateStruct(schema.GetSchema())
+	return v.validateStruct(schema.GetSchema(), i18n.FromContext(r.Context()))
 }
 
-func (v *Validator) formatValidationErrors(err error) ValidationErrors {
+func (v *Validator) formatValidationErrors(err error, lang string) ValidationErrors {
 	var validationErrors []ValidationError
 
 	if validationErrs, ok := err.(validator.ValidationErrors); ok {
@@ -73,8 +94,8 @@ func (v *Validator) formatValidationErrors(err error) ValidationErrors {
 			validationErrors = append(validationErrors, ValidationError{
 				Field:   err.Field(),
 				Tag:     err.Tag(),
-				Value:   fmt.Sprintf("%v", err.Value()),
-				Message: v.getErrorMessage(err),
+				Value:   errorValue(err),
+				Message: v.getErrorMessage(err, lang),
 			})
 		}
 	}
@@ -82,25 +103,34 @@ func (v *Validator) formatValidationErrors(err error) ValidationErrors {
 	return ValidationErrors{Errors: validationErrors}
 }
 
-func (v *Validator) getErrorMessage(err validator.FieldError) string {
+// sensitiveFieldParts mark fields whose submitted value must not be echoed
+// back, where it could end up in logs or error trackers
+var sensitiveFieldParts = []string{"password", "secret", "token", "code", "credential"}
+
+// errorValue is the submitted value to report with err, blank when the
+// field is sensitive
+func errorValue(err validator.FieldError) string {
+	if err.Tag() == "password_strength" {
+		return ""
+	}
+	field := strings.ToLower(err.Field())
+	for _, part := range sensitiveFieldParts {
+		if strings.Contains(field, part) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%v", err.Value())
+}
+
+func (v *Validator) getErrorMessage(err validator.FieldError, lang string) string {
 	switch err.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", err.Field())
-	case "email":
-		return fmt.Sprintf("%s must be a valid email", err.Field())
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", err.Field(), err.Param())
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters", err.Field(), err.Param())
-	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", err.Field(), err.Param())
-	case "url":
-		return fmt.Sprintf("%s must be a valid URL", err.Field())
-	case "uuid":
-		return fmt.Sprintf("%s must be a valid UUID", err.Field())
-	case "oneof":
-		return fmt.Sprintf("%s must be one of: %s", err.Field(), err.Param())
+	case "required", "email", "url", "uuid", "user_role", "user_status", "phone", "iso3166_1_alpha2", "iso4217", "hexcolor", "postal_code", "username":
+		return i18n.T(lang, "validation."+err.Tag(), err.Field())
+	case "min", "max", "gt", "oneof":
+		return i18n.T(lang, "validation."+err.Tag(), err.Field(), err.Param())
+	case "password_strength":
+		return i18n.T(lang, "validation.password_strength", err.Field(), strconv.Itoa(minPasswordClasses))
 	default:
-		return fmt.Sprintf("%s is invalid", err.Field())
+		return i18n.T(lang, "validation.invalid", err.Field())
 	}
 }
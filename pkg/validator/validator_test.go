@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func testValidator() *Validator {
+	return New(Domain{
+		Roles:    []string{"gamer", "admin"},
+		Statuses: []string{"active"},
+		Username: func(name string) bool { return name != "admin" },
+	})
+}
+
+func TestRules(t *testing.T) {
+	v := testValidator()
+
+	for _, tc := range []struct {
+		name  string
+		value string
+		tag   string
+		valid bool
+	}{
+		{"strong password", "Corr3ct-Horse!", "password_strength", true},
+		{"three classes", "correct-horse9", "password_strength", true},
+		{"two classes", "correcthorse9", "password_strength", false},
+		{"common password", "Password123", "password_strength", false},
+		{"common password in another case", "PaSsWoRd123", "password_strength", false},
+		{"E.164 phone", "+447700900123", "phone", true},
+		{"phone without plus", "447700900123", "phone", false},
+		{"phone with leading zero country code", "+0447700900", "phone", false},
+		{"phone too long", "+1234567890123456", "phone", false},
+		{"phone with spaces", "+44 7700 900123", "phone", false},
+		{"allowed role", "admin", "user_role", true},
+		{"role outside the domain", "su-admin", "user_role", false},
+		{"allowed status", "active", "user_status", true},
+		{"status outside the domain", "suspended", "user_status", false},
+		{"allowed username", "johnd", "username", true},
+		{"username the domain refuses", "admin", "username", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.ValidateVar(tc.value, tc.tag)
+			if (err == nil) != tc.valid {
+				t.Fatalf("ValidateVar(%q, %q) = %v, want valid %v", tc.value, tc.tag, err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestZeroDomain(t *testing.T) {
+	v := New(Domain{})
+
+	if err := v.ValidateVar("gamer", "user_role"); err == nil {
+		t.Fatal("a zero Domain accepted a role")
+	}
+	if err := v.ValidateVar("anyone", "username"); err != nil {
+		t.Fatalf("a zero Domain refused a username: %v", err)
+	}
+}
+
+type signup struct {
+	Email       string `json:"email" validate:"email"`
+	Password    string `json:"password" validate:"password_strength"`
+	Code        string `json:"code" validate:"len=6"`
+	OldPassword string `json:"old_password" validate:"min=20"`
+}
+
+func TestValidationErrorsHideSensitiveValues(t *testing.T) {
+	err := testValidator().ValidateStruct(&signup{
+		Email:       "not-an-email",
+		Password:    "password",
+		Code:        "12345",
+		OldPassword: "hunter2",
+	})
+
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("ValidateStruct = %v, want ValidationErrors", err)
+	}
+	values := map[string]string{}
+	for _, fieldErr := range validationErrs.Errors {
+		values[fieldErr.Field] = fieldErr.Value
+	}
+	want := map[string]string{
+		"email":        "not-an-email",
+		"password":     "",
+		"code":         "",
+		"old_password": "",
+	}
+	for field, value := range want {
+		got, ok := values[field]
+		if !ok {
+			t.Fatalf("no error for %s in %+v", field, validationErrs.Errors)
+		}
+		if got != value {
+			t.Fatalf("%s value = %q, want %q", field, got, value)
+		}
+	}
+}